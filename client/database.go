@@ -0,0 +1,21 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+const (
+	routeDatabaseBackup = "database/backup"
+)
+
+// CreateDatabaseBackup writes a backup of the database to the backup directory of the node.
+func (api *GoShimmerAPI) CreateDatabaseBackup() (*jsonmodels.DatabaseBackupResponse, error) {
+	res := &jsonmodels.DatabaseBackupResponse{}
+	if err := api.do(http.MethodPost, routeDatabaseBackup, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -1,8 +1,16 @@
 package client
 
 import (
+	"bytes"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/drng"
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 )
 
@@ -10,8 +18,12 @@ const (
 	routeCollectiveBeacon = "drng/collectiveBeacon"
 	routeRandomness       = "drng/info/randomness"
 	routeCommittee        = "drng/info/committee"
+	routeHistory          = "drng/history/"
 )
 
+// ErrInvalidBeacon is returned by VerifyBeacon if a beacon was not produced by the committee.
+var ErrInvalidBeacon = errors.New("invalid beacon")
+
 // BroadcastCollectiveBeacon sends the given collective beacon (payload) by creating a message in the backend.
 func (api *GoShimmerAPI) BroadcastCollectiveBeacon(payload []byte) (string, error) {
 	res := &jsonmodels.CollectiveBeaconResponse{}
@@ -44,3 +56,59 @@ func (api *GoShimmerAPI) GetCommittee() (*jsonmodels.CommitteeResponse, error) {
 	}
 	return res, nil
 }
+
+// GetBeacon gets the verified beacon of the given dRNG instance and round from the history of the node.
+func (api *GoShimmerAPI) GetBeacon(instanceID uint32, round uint64) (*jsonmodels.BeaconResponse, error) {
+	res := &jsonmodels.BeaconResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s%d/%d", routeHistory, instanceID, round), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetBeacons gets the verified beacons of the given dRNG instance from round from to round to (both inclusive) from
+// the history of the node.
+func (api *GoShimmerAPI) GetBeacons(instanceID uint32, from, to uint64) (*jsonmodels.BeaconsResponse, error) {
+	query := url.Values{}
+	query.Set("from", strconv.FormatUint(from, 10))
+	query.Set("to", strconv.FormatUint(to, 10))
+
+	res := &jsonmodels.BeaconsResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s%d?%s", routeHistory, instanceID, query.Encode()), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetBeaconAt gets the verified beacon of the given dRNG instance whose randomness was the current one at the given
+// time, i.e. the randomness that FPC used at that time.
+func (api *GoShimmerAPI) GetBeaconAt(instanceID uint32, t time.Time) (*jsonmodels.BeaconResponse, error) {
+	query := url.Values{}
+	query.Set("time", t.Format(time.RFC3339))
+
+	res := &jsonmodels.BeaconsResponse{}
+	if err := api.do(http.MethodGet, fmt.Sprintf("%s%d?%s", routeHistory, instanceID, query.Encode()), nil, res); err != nil {
+		return nil, err
+	}
+	if len(res.Beacons) == 0 {
+		return nil, errors.Errorf("no beacon at %s: %w", t, ErrNotFound)
+	}
+	return &jsonmodels.BeaconResponse{Beacon: res.Beacons[0]}, nil
+}
+
+// VerifyBeacon checks offline that the given beacon was signed by the committee with the given distributed public key
+// and that its randomness was derived from the signature. The distributed public key of a committee can be retrieved
+// with GetCommittee, but it should be obtained from a trusted source.
+func VerifyBeacon(beacon *jsonmodels.Beacon, distributedPK []byte) error {
+	if err := drng.VerifyBeaconSignature(distributedPK, beacon.Round, beacon.PrevSignature, beacon.Signature); err != nil {
+		return errors.Errorf("round %d: %s: %w", beacon.Round, err, ErrInvalidBeacon)
+	}
+	randomness, err := drng.ExtractRandomness(beacon.Signature)
+	if err != nil {
+		return errors.Errorf("round %d: %s: %w", beacon.Round, err, ErrInvalidBeacon)
+	}
+	if !bytes.Equal(randomness, beacon.Randomness) {
+		return errors.Errorf("round %d: randomness does not match the signature: %w", beacon.Round, ErrInvalidBeacon)
+	}
+	return nil
+}
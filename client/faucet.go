@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/identity"
@@ -16,7 +17,8 @@ import (
 )
 
 const (
-	routeFaucet = "faucet"
+	routeFaucet              = "faucet"
+	routeFaucetRequestStatus = "faucet/"
 )
 
 var (
@@ -24,7 +26,8 @@ var (
 	powWorker        = pow.New(1)
 )
 
-// SendFaucetRequest requests funds from faucet nodes by sending a faucet request payload message.
+// SendFaucetRequest requests funds from faucet nodes by sending a faucet request payload message. If the node runs the
+// faucet and refuses the request, a *faucet.RefusalError with the reason of the refusal is returned.
 func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget int, pledgeIDs ...string) (*jsonmodels.FaucetResponse, error) {
 	var aManaPledgeID identity.ID
 	var cManaPledgeID identity.ID
@@ -39,7 +42,7 @@ func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget i
 		}
 	}
 
-	address, err := ledgerstate.AddressFromBase58EncodedString(base58EncodedAddr)
+	address, err := ledgerstate.AddressFromString(base58EncodedAddr)
 	if err != nil {
 		return nil, errors.Errorf("could not decode address from string: %w", err)
 	}
@@ -57,12 +60,27 @@ func (api *GoShimmerAPI) SendFaucetRequest(base58EncodedAddr string, powTarget i
 			ConsensusManaPledgeID: base58.Encode(cManaPledgeID.Bytes()),
 			Nonce:                 nonce,
 		}, res); err != nil {
+		if res.Refusal != "" {
+			if reason, reasonErr := faucet.RefusalReasonFromString(res.Refusal); reasonErr == nil {
+				return nil, faucet.NewRefusalError(reason, time.Duration(res.RetryAfter)*time.Second)
+			}
+		}
 		return nil, err
 	}
 
 	return res, nil
 }
 
+// GetFaucetRequestStatus gets the processing state of the funding request contained in the message with the given ID.
+// It is only available on the faucet node.
+func (api *GoShimmerAPI) GetFaucetRequestStatus(base58EncodedMessageID string) (*jsonmodels.FaucetRequestStatusResponse, error) {
+	res := &jsonmodels.FaucetRequestStatusResponse{}
+	if err := api.do(http.MethodGet, routeFaucetRequestStatus+base58EncodedMessageID, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func computeFaucetPoW(address ledgerstate.Address, aManaPledgeID, cManaPledgeID identity.ID, powTarget int) (nonce uint64, err error) {
 	if powTarget < 0 {
 		powTarget = defaultPOWTarget
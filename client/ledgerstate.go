@@ -2,6 +2,8 @@ package client
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
@@ -17,6 +19,7 @@ const (
 
 	// route path modifiers
 	pathUnspentOutputs = "/unspentOutputs"
+	pathHistory        = "/history"
 	pathChildren       = "/children"
 	pathConflicts      = "/conflicts"
 	pathConsumers      = "/consumers"
@@ -48,6 +51,30 @@ func (api *GoShimmerAPI) GetAddressUnspentOutputs(base58EncodedAddress string) (
 	return res, nil
 }
 
+// GetAddressHistory gets a page of the history of an address. The limit defines the maximum amount of returned entries
+// (0 uses the default of the node) and the cursor is the NextCursor of the previous page (empty for the first page).
+func (api *GoShimmerAPI) GetAddressHistory(base58EncodedAddress string, limit int, cursor string) (*jsonmodels.GetAddressHistoryResponse, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	res := &jsonmodels.GetAddressHistoryResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		route := strings.Join([]string{routeGetAddresses, base58EncodedAddress, pathHistory}, "")
+		if len(query) == 0 {
+			return route
+		}
+		return route + "?" + query.Encode()
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // PostAddressUnspentOutputs gets the unspent outputs of several addresses.
 func (api *GoShimmerAPI) PostAddressUnspentOutputs(base58EncodedAddresses []string) (*jsonmodels.PostAddressesUnspentOutputsResponse, error) {
 	res := &jsonmodels.PostAddressesUnspentOutputsResponse{}
@@ -28,6 +28,8 @@ var (
 	ErrUnknownError = errors.New("unknown error")
 	// ErrNotImplemented defines the "operation not implemented/supported/available" error.
 	ErrNotImplemented = errors.New("operation not implemented/supported/available")
+	// ErrTooManyRequests defines the "too many requests" error that is returned if the rate limit of the node was exceeded.
+	ErrTooManyRequests = errors.New("too many requests")
 )
 
 const (
@@ -57,6 +59,13 @@ func WithBasicAuth(username, password string) Option {
 	}
 }
 
+// WithToken sets the API token that is sent as a bearer token with every request.
+func WithToken(token string) Option {
+	return func(g *GoShimmerAPI) {
+		g.token = token
+	}
+}
+
 // WithHTTPClient sets the http Client.
 func WithHTTPClient(c http.Client) Option {
 	return func(g *GoShimmerAPI) {
@@ -90,6 +99,7 @@ type GoShimmerAPI struct {
 	baseURL    string
 	httpClient http.Client
 	basicAuth  BasicAuth
+	token      string
 }
 
 type errorresponse struct {
@@ -118,6 +128,10 @@ func interpretBody(res *http.Response, decodeTo interface{}) error {
 	if err := json.Unmarshal(resBody, errRes); err != nil {
 		return fmt.Errorf("unable to read error from response body: %w repsonseBody: %s", err, resBody)
 	}
+	// error responses may carry further details in the fields of the expected response
+	if decodeTo != nil && strings.HasPrefix(res.Header.Get(contentType), contentTypeJSON) {
+		_ = json.Unmarshal(resBody, decodeTo)
+	}
 
 	switch res.StatusCode {
 	case http.StatusInternalServerError:
@@ -130,6 +144,8 @@ func interpretBody(res *http.Response, decodeTo interface{}) error {
 		return fmt.Errorf("%w: %s", ErrUnauthorized, errRes.Error)
 	case http.StatusNotImplemented:
 		return fmt.Errorf("%w: %s", ErrNotImplemented, errRes.Error)
+	case http.StatusTooManyRequests:
+		return fmt.Errorf("%w: %s (retry after %ss)", ErrTooManyRequests, errRes.Error, res.Header.Get("Retry-After"))
 	}
 
 	return fmt.Errorf("%w: %s", ErrUnknownError, errRes.Error)
@@ -161,10 +177,13 @@ func (api *GoShimmerAPI) do(method string, route string, reqObj interface{}, res
 		req.Header.Set("Content-Type", contentTypeJSON)
 	}
 
-	// if enabled, add the basic-auth
+	// if enabled, add the basic-auth or the API token
 	if api.basicAuth.IsEnabled() {
 		req.SetBasicAuth(api.basicAuth.Credentials())
 	}
+	if api.token != "" {
+		req.Header.Set("Authorization", "Bearer "+api.token)
+	}
 
 	// make the request
 	res, err := api.httpClient.Do(req)
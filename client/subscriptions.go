@@ -0,0 +1,193 @@
+package client
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gorilla/websocket"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+const (
+	routeSubscriptions = "subscriptions"
+
+	// subscriptionRequestTimeout is the time to wait for the node to confirm a subscription request.
+	subscriptionRequestTimeout = 10 * time.Second
+)
+
+// Subscribe opens a WebSocket connection to the subscriptions endpoint of the node. The returned Subscriptions can be
+// used to subscribe to topics, whose events are delivered through Subscriptions.Events.
+func (api *GoShimmerAPI) Subscribe() (subscriptions *Subscriptions, err error) {
+	url := api.baseURL + "/" + routeSubscriptions
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		url = "wss://" + strings.TrimPrefix(url, "https://")
+	case strings.HasPrefix(url, "http://"):
+		url = "ws://" + strings.TrimPrefix(url, "http://")
+	}
+
+	header := http.Header{}
+	if api.basicAuth.IsEnabled() {
+		username, password := api.basicAuth.Credentials()
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+	}
+	if api.token != "" {
+		header.Set("Authorization", "Bearer "+api.token)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		return nil, errors.Errorf("failed to connect to %s: %w", url, err)
+	}
+
+	subscriptions = &Subscriptions{
+		conn:      conn,
+		events:    make(chan *jsonmodels.SubscriptionResponse, 1024),
+		responses: make(chan *jsonmodels.SubscriptionResponse, 1),
+		closed:    make(chan struct{}),
+	}
+	go subscriptions.readLoop()
+
+	return subscriptions, nil
+}
+
+// region Subscriptions ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Subscriptions represents an open connection to the subscriptions endpoint of a node.
+type Subscriptions struct {
+	conn         *websocket.Conn
+	events       chan *jsonmodels.SubscriptionResponse
+	responses    chan *jsonmodels.SubscriptionResponse
+	requestMutex sync.Mutex
+	closed       chan struct{}
+	closeOnce    sync.Once
+	err          error
+}
+
+// Events returns the channel that delivers the events of all subscriptions. The events carry the ID of the subscription
+// that they belong to. Responses of type jsonmodels.SubscriptionDropped indicate that the node dropped events because the
+// client did not keep up with reading them. The channel is closed when the connection is closed.
+func (s *Subscriptions) Events() <-chan *jsonmodels.SubscriptionResponse {
+	return s.events
+}
+
+// SubscribeMessages subscribes to new messages. If payloadType is given, only messages with that payload type are
+// delivered.
+func (s *Subscriptions) SubscribeMessages(payloadType ...uint32) (subscriptionID uint64, err error) {
+	request := &jsonmodels.SubscriptionRequest{Action: jsonmodels.SubscribeAction, Topic: jsonmodels.MessagesTopic}
+	if len(payloadType) > 0 {
+		request.PayloadType = &payloadType[0]
+	}
+
+	return s.subscribe(request)
+}
+
+// SubscribeTransactions subscribes to new transactions that consume or create outputs on one of the given base58
+// encoded addresses.
+func (s *Subscriptions) SubscribeTransactions(base58EncodedAddresses ...string) (subscriptionID uint64, err error) {
+	return s.subscribe(&jsonmodels.SubscriptionRequest{Action: jsonmodels.SubscribeAction, Topic: jsonmodels.TransactionsTopic, Addresses: base58EncodedAddresses})
+}
+
+// SubscribeInclusionState subscribes to the changes of the inclusion state of the given transaction. The current
+// inclusion state is delivered right away if the transaction is known to the node.
+func (s *Subscriptions) SubscribeInclusionState(base58EncodedTransactionID string) (subscriptionID uint64, err error) {
+	return s.subscribe(&jsonmodels.SubscriptionRequest{Action: jsonmodels.SubscribeAction, Topic: jsonmodels.InclusionStateTopic, TransactionID: base58EncodedTransactionID})
+}
+
+// SubscribeBranchConfirmation subscribes to the confirmation and rejection of branches. If a branch ID is given, only
+// the events of that branch are delivered.
+func (s *Subscriptions) SubscribeBranchConfirmation(base58EncodedBranchID ...string) (subscriptionID uint64, err error) {
+	request := &jsonmodels.SubscriptionRequest{Action: jsonmodels.SubscribeAction, Topic: jsonmodels.BranchConfirmationTopic}
+	if len(base58EncodedBranchID) > 0 {
+		request.BranchID = base58EncodedBranchID[0]
+	}
+
+	return s.subscribe(request)
+}
+
+// SubscribeTips subscribes to the tips that get added to or removed from the tip pool of the node.
+func (s *Subscriptions) SubscribeTips() (subscriptionID uint64, err error) {
+	return s.subscribe(&jsonmodels.SubscriptionRequest{Action: jsonmodels.SubscribeAction, Topic: jsonmodels.TipsTopic})
+}
+
+// Unsubscribe removes the subscription with the given ID.
+func (s *Subscriptions) Unsubscribe(subscriptionID uint64) (err error) {
+	_, err = s.request(&jsonmodels.SubscriptionRequest{Action: jsonmodels.UnsubscribeAction, SubscriptionID: subscriptionID})
+
+	return
+}
+
+// Err returns the error that caused the connection to be closed.
+func (s *Subscriptions) Err() error {
+	<-s.closed
+
+	return s.err
+}
+
+// Close closes the connection to the node.
+func (s *Subscriptions) Close() error {
+	return s.conn.Close()
+}
+
+// subscribe sends the subscribe request and returns the ID of the created subscription.
+func (s *Subscriptions) subscribe(request *jsonmodels.SubscriptionRequest) (subscriptionID uint64, err error) {
+	response, err := s.request(request)
+	if err != nil {
+		return 0, err
+	}
+
+	return response.SubscriptionID, nil
+}
+
+// request sends the request and waits for the node to confirm it.
+func (s *Subscriptions) request(request *jsonmodels.SubscriptionRequest) (response *jsonmodels.SubscriptionResponse, err error) {
+	s.requestMutex.Lock()
+	defer s.requestMutex.Unlock()
+
+	if err = s.conn.WriteJSON(request); err != nil {
+		return nil, errors.Errorf("failed to send request: %w", err)
+	}
+
+	select {
+	case response = <-s.responses:
+		if response.Type == jsonmodels.SubscriptionError {
+			return nil, errors.Errorf("%w: %s", ErrBadRequest, response.Error)
+		}
+		return response, nil
+	case <-s.closed:
+		return nil, errors.Errorf("connection closed: %w", s.err)
+	case <-time.After(subscriptionRequestTimeout):
+		return nil, errors.Errorf("timed out waiting for the node to confirm the request")
+	}
+}
+
+// readLoop reads the messages of the node and routes them to the events or the responses channel.
+func (s *Subscriptions) readLoop() {
+	defer close(s.events)
+	defer s.closeOnce.Do(func() { close(s.closed) })
+
+	for {
+		response := &jsonmodels.SubscriptionResponse{}
+		if s.err = s.conn.ReadJSON(response); s.err != nil {
+			return
+		}
+
+		switch response.Type {
+		case jsonmodels.SubscriptionSubscribed, jsonmodels.SubscriptionUnsubscribed, jsonmodels.SubscriptionError:
+			select {
+			case s.responses <- response:
+			default:
+				// nobody is waiting for the response (i.e. the request timed out)
+			}
+		default:
+			s.events <- response
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
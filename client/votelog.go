@@ -0,0 +1,26 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/vote/votelog"
+)
+
+const (
+	routeVoteLog = "votelog/"
+)
+
+// GetVoteTrace gets the logged trace of the vote on the given transaction or message ID.
+func (api *GoShimmerAPI) GetVoteTrace(id string) (*jsonmodels.VoteTrace, error) {
+	res := &jsonmodels.VoteTraceResponse{}
+	if err := api.do(http.MethodGet, routeVoteLog+id, nil, res); err != nil {
+		return nil, err
+	}
+	return res.Trace, nil
+}
+
+// ReplayVoteTrace re-executes the given logged vote and verifies that it leads to the logged decision.
+func ReplayVoteTrace(trace *jsonmodels.VoteTrace) error {
+	return votelog.Replay(trace.ToTrace())
+}
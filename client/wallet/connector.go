@@ -15,4 +15,5 @@ type Connector interface {
 	GetAllowedPledgeIDs() (pledgeIDMap map[mana.Type][]string, err error)
 	GetTransactionInclusionState(txID ledgerstate.TransactionID) (inc ledgerstate.InclusionState, err error)
 	GetUnspentAliasOutput(address *ledgerstate.AliasAddress) (output *ledgerstate.AliasOutput, err error)
+	AddressHistory(address address.Address) (history HistoryEntries, err error)
 }
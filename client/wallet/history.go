@@ -0,0 +1,54 @@
+package wallet
+
+import (
+	"sort"
+	"time"
+
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// region HistoryEntry /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HistoryEntry is a wallet specific representation of an entry in the history of an address. It records that an output
+// on the address was either created or consumed by a transaction.
+type HistoryEntry struct {
+	Address        address.Address
+	Type           ledgerstate.AddressHistoryEntryType
+	OutputID       ledgerstate.OutputID
+	TransactionID  ledgerstate.TransactionID
+	Timestamp      time.Time
+	InclusionState InclusionState
+}
+
+// String returns a human-readable representation of the HistoryEntry.
+func (h *HistoryEntry) String() string {
+	return stringify.Struct("HistoryEntry",
+		stringify.StructField("Address", h.Address),
+		stringify.StructField("Type", h.Type),
+		stringify.StructField("OutputID", h.OutputID),
+		stringify.StructField("TransactionID", h.TransactionID),
+		stringify.StructField("Timestamp", h.Timestamp),
+		stringify.StructField("InclusionState", h.InclusionState),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HistoryEntries ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// HistoryEntries is a collection of HistoryEntry objects.
+type HistoryEntries []*HistoryEntry
+
+// Sort sorts the HistoryEntries in chronological order.
+func (h HistoryEntries) Sort() HistoryEntries {
+	sort.SliceStable(h, func(i, j int) bool {
+		return h[i].Timestamp.Before(h[j].Timestamp)
+	})
+
+	return h
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
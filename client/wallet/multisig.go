@@ -0,0 +1,152 @@
+package wallet
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// region MultisigTransaction //////////////////////////////////////////////////////////////////////////////////////////
+
+// MultisigTransaction represents a partially signed transaction that spends the funds of a MultisigAddress. It contains
+// everything that is needed to add signatures offline and can therefore be passed around between the key holders
+// (i.e. as a file) until enough signatures were collected to submit it.
+type MultisigTransaction struct {
+	essence   *ledgerstate.TransactionEssence
+	inputs    ledgerstate.Outputs
+	signature *ledgerstate.MultisigSignature
+}
+
+// NewMultisigTransaction creates a new MultisigTransaction from the given essence, the consumed outputs (in the order of
+// the inputs of the essence) and the (partial) signature.
+func NewMultisigTransaction(essence *ledgerstate.TransactionEssence, inputs ledgerstate.Outputs, signature *ledgerstate.MultisigSignature) (multisigTransaction *MultisigTransaction, err error) {
+	if len(essence.Inputs()) != len(inputs) {
+		return nil, errors.Errorf("amount of consumed outputs (%d) does not match the amount of inputs (%d)", len(inputs), len(essence.Inputs()))
+	}
+
+	multisigAddress := signature.Address()
+	for i, input := range essence.Inputs() {
+		if input.(*ledgerstate.UTXOInput).ReferencedOutputID() != inputs[i].ID() {
+			return nil, errors.Errorf("consumed output %s does not match input %d", inputs[i].ID(), i)
+		}
+		if !inputs[i].Address().Equals(multisigAddress) {
+			return nil, errors.Errorf("consumed output %s does not belong to %s", inputs[i].ID(), multisigAddress.Base58())
+		}
+	}
+
+	return &MultisigTransaction{
+		essence:   essence,
+		inputs:    inputs,
+		signature: signature,
+	}, nil
+}
+
+// MultisigTransactionFromBytes unmarshals a MultisigTransaction from a sequence of bytes.
+func MultisigTransactionFromBytes(bytes []byte) (multisigTransaction *MultisigTransaction, err error) {
+	marshalUtil := marshalutil.New(bytes)
+
+	essenceLength, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse essence length: %w", err)
+	}
+	essenceBytes, err := marshalUtil.ReadBytes(int(essenceLength))
+	if err != nil {
+		return nil, errors.Errorf("failed to read essence bytes: %w", err)
+	}
+	essence, _, err := ledgerstate.TransactionEssenceFromBytes(essenceBytes)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse TransactionEssence: %w", err)
+	}
+
+	inputs := make(ledgerstate.Outputs, len(essence.Inputs()))
+	for i, input := range essence.Inputs() {
+		if inputs[i], err = ledgerstate.OutputFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse consumed output %d: %w", i, err)
+		}
+		inputs[i].SetID(input.(*ledgerstate.UTXOInput).ReferencedOutputID())
+	}
+
+	signature, err := ledgerstate.MultisigSignatureFromMarshalUtil(marshalUtil)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse MultisigSignature: %w", err)
+	}
+
+	if bytesLeft := len(bytes) - marshalUtil.ReadOffset(); bytesLeft != 0 {
+		return nil, errors.Errorf("unexpected %d trailing bytes", bytesLeft)
+	}
+
+	return NewMultisigTransaction(essence, inputs, signature)
+}
+
+// Essence returns the TransactionEssence that is signed by the key holders.
+func (m *MultisigTransaction) Essence() *ledgerstate.TransactionEssence {
+	return m.essence
+}
+
+// Inputs returns the consumed outputs in the order of the inputs of the essence.
+func (m *MultisigTransaction) Inputs() ledgerstate.Outputs {
+	return m.inputs
+}
+
+// Signature returns the MultisigSignature that collects the partial signatures.
+func (m *MultisigTransaction) Signature() *ledgerstate.MultisigSignature {
+	return m.signature
+}
+
+// Sign adds the signature of the given KeyPair to the MultisigTransaction.
+func (m *MultisigTransaction) Sign(keyPair ed25519.KeyPair) (err error) {
+	if err = m.signature.AddSignature(keyPair.PublicKey, keyPair.PrivateKey.Sign(m.essence.Bytes())); err != nil {
+		return errors.Errorf("failed to add signature: %w", err)
+	}
+
+	return
+}
+
+// Complete returns true if exactly threshold many valid signatures were collected to unlock the MultisigAddress.
+func (m *MultisigTransaction) Complete() bool {
+	return m.signature.SignatureValid(m.essence.Bytes())
+}
+
+// Transaction returns the final Transaction that can be submitted to the network. It returns an error if not enough
+// signatures were collected yet.
+func (m *MultisigTransaction) Transaction() (transaction *ledgerstate.Transaction, err error) {
+	if !m.Complete() {
+		return nil, errors.Errorf("not enough valid signatures (%d of %d)", len(m.signature.Signatures()), m.signature.Threshold())
+	}
+
+	// all inputs belong to the same MultisigAddress, so the first unlock block is referenced by all the others
+	unlockBlocks := make(ledgerstate.UnlockBlocks, len(m.inputs))
+	unlockBlocks[0] = ledgerstate.NewSignatureUnlockBlock(m.signature)
+	for i := 1; i < len(unlockBlocks); i++ {
+		unlockBlocks[i] = ledgerstate.NewReferenceUnlockBlock(0)
+	}
+
+	return ledgerstate.NewTransaction(m.essence, unlockBlocks), nil
+}
+
+// Bytes returns a marshaled version of the MultisigTransaction.
+func (m *MultisigTransaction) Bytes() []byte {
+	essenceBytes := m.essence.Bytes()
+	marshalUtil := marshalutil.New().
+		WriteUint32(uint32(len(essenceBytes))).
+		WriteBytes(essenceBytes)
+	for _, input := range m.inputs {
+		marshalUtil.WriteBytes(input.Bytes())
+	}
+
+	return marshalUtil.WriteBytes(m.signature.Bytes()).Bytes()
+}
+
+// String returns a human readable version of the MultisigTransaction.
+func (m *MultisigTransaction) String() string {
+	return stringify.Struct("MultisigTransaction",
+		stringify.StructField("essence", m.essence),
+		stringify.StructField("inputs", m.inputs),
+		stringify.StructField("signature", m.signature),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
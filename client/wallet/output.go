@@ -127,6 +127,31 @@ func (o OutputsByAddressAndOutputID) ConditionalOutputsOnly() OutputsByAddressAn
 	return result
 }
 
+// HashTimeLockedOutputsOnly filters out any non hash time locked outputs.
+func (o OutputsByAddressAndOutputID) HashTimeLockedOutputsOnly() OutputsByAddressAndOutputID {
+	return o.FilterHashTimeLocked(func(address.Address, *ledgerstate.HashTimeLockedOutput) bool {
+		return true
+	})
+}
+
+// FilterHashTimeLocked returns the HashTimeLockedOutputs that satisfy the given predicate.
+func (o OutputsByAddressAndOutputID) FilterHashTimeLocked(predicate func(addy address.Address, output *ledgerstate.HashTimeLockedOutput) bool) OutputsByAddressAndOutputID {
+	result := NewAddressToOutputs()
+	for addy, IDToOutputMap := range o {
+		for outputID, output := range IDToOutputMap {
+			casted, isHashTimeLocked := output.Object.(*ledgerstate.HashTimeLockedOutput)
+			if !isHashTimeLocked || !predicate(addy, casted) {
+				continue
+			}
+			if _, addressExists := result[addy]; !addressExists {
+				result[addy] = make(map[ledgerstate.OutputID]*Output)
+			}
+			result[addy][outputID] = output
+		}
+	}
+	return result
+}
+
 // AliasOutputsOnly filters out any non-alias outputs.
 func (o OutputsByAddressAndOutputID) AliasOutputsOnly() OutputsByAddressAndOutputID {
 	result := NewAddressToOutputs()
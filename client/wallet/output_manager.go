@@ -71,6 +71,11 @@ func (o *OutputManager) UnspentConditionalOutputs(includePending bool, addresses
 	return o.getOutputs(includePending, addresses...).ConditionalOutputsOnly()
 }
 
+// UnspentHashTimeLockedOutputs returns the HashTimeLockedOutputs that have not been spent, yet.
+func (o *OutputManager) UnspentHashTimeLockedOutputs(includePending bool, addresses ...address.Address) (unspentOutputs OutputsByAddressAndOutputID) {
+	return o.getOutputs(includePending, addresses...).HashTimeLockedOutputsOnly()
+}
+
 // UnspentAliasOutputs returns the alias type outputs that have not been spent, yet.
 func (o *OutputManager) UnspentAliasOutputs(includePending bool, addresses ...address.Address) (unspentOutputs OutputsByAddressAndOutputID) {
 	return o.getOutputs(includePending, addresses...).AliasOutputsOnly()
@@ -29,6 +29,11 @@ func (a Address) Base58() string {
 	return base58.Encode(a.AddressBytes[:])
 }
 
+// Bech32 returns the bech32 encoded address that uses the human-readable part of the network.
+func (a Address) Bech32() string {
+	return a.Address().Bech32()
+}
+
 func (a Address) String() string {
 	return stringify.Struct("Address",
 		stringify.StructField("Address", a.Address()),
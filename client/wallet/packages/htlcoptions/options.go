@@ -0,0 +1,73 @@
+package htlcoptions
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// HashTimeLockedFundsOption is a function that provides options.
+type HashTimeLockedFundsOption func(options *HashTimeLockedFundsOptions) error
+
+// Preimage is an option for the ClaimHashTimeLockedFunds call that defines the secret that unlocks the hash lock of the
+// outputs that are supposed to be claimed.
+func Preimage(preimage []byte) HashTimeLockedFundsOption {
+	return func(options *HashTimeLockedFundsOptions) error {
+		if len(preimage) == 0 {
+			return errors.New("empty preimage provided")
+		}
+		if len(preimage) > ledgerstate.MaxHashLockPreimageSize {
+			return errors.Errorf("preimage size (%d bytes) is bigger than maximum allowed (%d bytes)", len(preimage), ledgerstate.MaxHashLockPreimageSize)
+		}
+		options.Preimage = preimage
+		return nil
+	}
+}
+
+// WaitForConfirmation is an optional parameter to define if the call should wait for confirmation before it returns.
+func WaitForConfirmation(wait bool) HashTimeLockedFundsOption {
+	return func(options *HashTimeLockedFundsOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
+// AccessManaPledgeID is an option that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) HashTimeLockedFundsOption {
+	return func(options *HashTimeLockedFundsOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) HashTimeLockedFundsOption {
+	return func(options *HashTimeLockedFundsOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// HashTimeLockedFundsOptions is a struct that is used to aggregate the optional parameters in the
+// ClaimHashTimeLockedFunds and RefundHashTimeLockedFunds calls.
+type HashTimeLockedFundsOptions struct {
+	Preimage              []byte
+	AccessManaPledgeID    string
+	ConsensusManaPledgeID string
+	WaitForConfirmation   bool
+}
+
+// Build builds the options.
+func Build(options ...HashTimeLockedFundsOption) (result *HashTimeLockedFundsOptions, err error) {
+	// create options to collect the arguments provided
+	result = &HashTimeLockedFundsOptions{}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -124,6 +124,28 @@ func Fallback(addy ledgerstate.Address, deadline time.Time) SendFundsOption {
 	}
 }
 
+// HashTimeLock defines the parameters for sending funds that are locked by a hash lock: the recipient can only claim
+// them before the timeout by revealing the preimage of the hash lock, afterwards they can be refunded to the refund
+// address.
+func HashTimeLock(hashLock ledgerstate.HashLock, timeout time.Time, refundAddress ledgerstate.Address) SendFundsOption {
+	return func(options *SendFundsOptions) error {
+		if refundAddress == nil {
+			return errors.Errorf("empty refund address provided")
+		}
+		if timeout.Before(time.Now()) {
+			return errors.Errorf("invalid hash lock timeout: %s is in the past", timeout.String())
+		}
+		if timeout.After(constants.MaxRepresentableTime) {
+			return errors.Errorf("invalid hash lock timeout: %s is later, than max representable time %s",
+				timeout.String(), constants.MaxRepresentableTime.String())
+		}
+		options.HashLock = hashLock
+		options.HashLockTimeout = timeout
+		options.RefundAddress = refundAddress
+		return nil
+	}
+}
+
 // SendFundsOptions is a struct that is used to aggregate the optional parameters provided in the SendFunds call.
 type SendFundsOptions struct {
 	Destinations          map[address.Address]map[ledgerstate.Color]uint64
@@ -131,6 +153,9 @@ type SendFundsOptions struct {
 	LockUntil             time.Time
 	FallbackAddress       ledgerstate.Address
 	FallbackDeadline      time.Time
+	HashLock              ledgerstate.HashLock
+	HashLockTimeout       time.Time
+	RefundAddress         ledgerstate.Address
 	AccessManaPledgeID    string
 	ConsensusManaPledgeID string
 	WaitForConfirmation   bool
@@ -171,6 +196,11 @@ func Build(options ...SendFundsOption) (result *SendFundsOptions, err error) {
 
 		return
 	}
+	if result.RefundAddress != nil && (!result.LockUntil.IsZero() || result.FallbackAddress != nil) {
+		err = errors.New("a hash time lock can not be combined with a timelock or fallback options")
+
+		return
+	}
 
 	return
 }
@@ -48,7 +48,7 @@ func Alias(aliasID string) SweepNFTOwnedNFTsOption {
 // ToAddress specifies the optional receiving address.
 func ToAddress(address string) SweepNFTOwnedNFTsOption {
 	return func(options *SweepNFTOwnedNFTsOptions) error {
-		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		parsed, err := ledgerstate.AddressFromString(address)
 		if err != nil {
 			return err
 		}
@@ -48,7 +48,7 @@ func Alias(aliasID string) SweepNFTOwnedFundsOption {
 // ToAddress specifies the optional receiving address.
 func ToAddress(address string) SweepNFTOwnedFundsOption {
 	return func(options *SweepNFTOwnedFundsOptions) error {
-		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		parsed, err := ledgerstate.AddressFromString(address)
 		if err != nil {
 			return err
 		}
@@ -48,7 +48,7 @@ func Alias(aliasID string) TransferNFTOption {
 // ToAddress specifies the new governor of the alias.
 func ToAddress(address string) TransferNFTOption {
 	return func(options *TransferNFTOptions) error {
-		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		parsed, err := ledgerstate.AddressFromString(address)
 		if err != nil {
 			return err
 		}
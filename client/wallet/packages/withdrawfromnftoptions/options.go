@@ -40,7 +40,7 @@ func Alias(aliasID string) WithdrawFundsFromNFTOption {
 // ToAddress specifies the new governor of the alias.
 func ToAddress(address string) WithdrawFundsFromNFTOption {
 	return func(options *WithdrawFundsFromNFTOptions) error {
-		parsed, err := ledgerstate.AddressFromBase58EncodedString(address)
+		parsed, err := ledgerstate.AddressFromString(address)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,175 @@
+package wallet
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// region UnsignedTransaction //////////////////////////////////////////////////////////////////////////////////////////
+
+// UnsignedTransaction represents a transaction of the wallet that was prepared without being signed. It contains the
+// essence together with the consumed outputs and the wallet addresses that own them, so it can be passed (i.e. as a
+// file) to an air-gapped machine that only knows the seed and creates the unlock blocks.
+type UnsignedTransaction struct {
+	essence        *ledgerstate.TransactionEssence
+	inputs         ledgerstate.Outputs
+	inputAddresses []address.Address
+}
+
+// NewUnsignedTransaction creates a new UnsignedTransaction from the given essence, the consumed outputs and the wallet
+// addresses that own them (both in the order of the inputs of the essence).
+func NewUnsignedTransaction(essence *ledgerstate.TransactionEssence, inputs ledgerstate.Outputs, inputAddresses []address.Address) (unsignedTransaction *UnsignedTransaction, err error) {
+	if len(essence.Inputs()) != len(inputs) {
+		return nil, errors.Errorf("amount of consumed outputs (%d) does not match the amount of inputs (%d)", len(inputs), len(essence.Inputs()))
+	}
+	if len(inputs) != len(inputAddresses) {
+		return nil, errors.Errorf("amount of addresses (%d) does not match the amount of inputs (%d)", len(inputAddresses), len(inputs))
+	}
+
+	for i, input := range essence.Inputs() {
+		if input.(*ledgerstate.UTXOInput).ReferencedOutputID() != inputs[i].ID() {
+			return nil, errors.Errorf("consumed output %s does not match input %d", inputs[i].ID(), i)
+		}
+	}
+
+	return &UnsignedTransaction{
+		essence:        essence,
+		inputs:         inputs,
+		inputAddresses: inputAddresses,
+	}, nil
+}
+
+// UnsignedTransactionFromBytes unmarshals an UnsignedTransaction from a sequence of bytes.
+func UnsignedTransactionFromBytes(bytes []byte) (unsignedTransaction *UnsignedTransaction, err error) {
+	marshalUtil := marshalutil.New(bytes)
+
+	essenceLength, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse essence length: %w", err)
+	}
+	essenceBytes, err := marshalUtil.ReadBytes(int(essenceLength))
+	if err != nil {
+		return nil, errors.Errorf("failed to read essence bytes: %w", err)
+	}
+	essence, _, err := ledgerstate.TransactionEssenceFromBytes(essenceBytes)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse TransactionEssence: %w", err)
+	}
+
+	inputs := make(ledgerstate.Outputs, len(essence.Inputs()))
+	inputAddresses := make([]address.Address, len(essence.Inputs()))
+	for i, input := range essence.Inputs() {
+		if inputs[i], err = ledgerstate.OutputFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse consumed output %d: %w", i, err)
+		}
+		inputs[i].SetID(input.(*ledgerstate.UTXOInput).ReferencedOutputID())
+
+		addressBytes, addressErr := marshalUtil.ReadBytes(ledgerstate.AddressLength)
+		if addressErr != nil {
+			return nil, errors.Errorf("failed to parse address of input %d: %w", i, addressErr)
+		}
+		copy(inputAddresses[i].AddressBytes[:], addressBytes)
+		if inputAddresses[i].Index, err = marshalUtil.ReadUint64(); err != nil {
+			return nil, errors.Errorf("failed to parse address index of input %d: %w", i, err)
+		}
+	}
+
+	if bytesLeft := len(bytes) - marshalUtil.ReadOffset(); bytesLeft != 0 {
+		return nil, errors.Errorf("unexpected %d trailing bytes", bytesLeft)
+	}
+
+	return NewUnsignedTransaction(essence, inputs, inputAddresses)
+}
+
+// Essence returns the TransactionEssence that needs to be signed.
+func (u *UnsignedTransaction) Essence() *ledgerstate.TransactionEssence {
+	return u.essence
+}
+
+// Inputs returns the consumed outputs in the order of the inputs of the essence.
+func (u *UnsignedTransaction) Inputs() ledgerstate.Outputs {
+	return u.inputs
+}
+
+// InputAddresses returns the wallet addresses that own the consumed outputs in the order of the inputs of the essence.
+func (u *UnsignedTransaction) InputAddresses() []address.Address {
+	return u.inputAddresses
+}
+
+// Sign creates the unlock blocks for the UnsignedTransaction with the keys that are derived from the given seed. It
+// only requires the seed, so it can be executed on a machine without network access.
+func (u *UnsignedTransaction) Sign(walletSeed *seed.Seed) (unlockBlocks ledgerstate.UnlockBlocks, err error) {
+	for i, inputAddress := range u.inputAddresses {
+		if walletSeed.Address(inputAddress.Index) != inputAddress {
+			return nil, errors.Errorf("address %s of input %d is not derived from the seed", inputAddress.Base58(), i)
+		}
+	}
+
+	return signInputs(walletSeed, u.inputAddresses, u.essence), nil
+}
+
+// Transaction assembles the final Transaction from the UnsignedTransaction and the given unlock blocks.
+func (u *UnsignedTransaction) Transaction(unlockBlocks ledgerstate.UnlockBlocks) (transaction *ledgerstate.Transaction, err error) {
+	if len(unlockBlocks) != len(u.inputs) {
+		return nil, errors.Errorf("amount of unlock blocks (%d) does not match the amount of inputs (%d)", len(unlockBlocks), len(u.inputs))
+	}
+
+	return ledgerstate.NewTransaction(u.essence, unlockBlocks), nil
+}
+
+// Bytes returns a marshaled version of the UnsignedTransaction.
+func (u *UnsignedTransaction) Bytes() []byte {
+	essenceBytes := u.essence.Bytes()
+	marshalUtil := marshalutil.New().
+		WriteUint32(uint32(len(essenceBytes))).
+		WriteBytes(essenceBytes)
+	for i, input := range u.inputs {
+		marshalUtil.
+			WriteBytes(input.Bytes()).
+			WriteBytes(u.inputAddresses[i].AddressBytes[:]).
+			WriteUint64(u.inputAddresses[i].Index)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the UnsignedTransaction.
+func (u *UnsignedTransaction) String() string {
+	return stringify.Struct("UnsignedTransaction",
+		stringify.StructField("essence", u.essence),
+		stringify.StructField("inputs", u.inputs),
+		stringify.StructField("inputAddresses", u.inputAddresses),
+	)
+}
+
+// signInputs creates the unlock blocks for the given essence by signing it with the keys of the addresses that own the
+// consumed outputs. Inputs of an address that was already signed for reference the existing unlock block. If a preimage
+// is provided, the signatures are wrapped in HashTimeLockUnlockBlocks that reveal the preimage.
+func signInputs(walletSeed *seed.Seed, inputAddresses []address.Address, essence *ledgerstate.TransactionEssence, optionalPreimage ...[]byte) (unlocks ledgerstate.UnlockBlocks) {
+	unlocks = make(ledgerstate.UnlockBlocks, len(inputAddresses))
+	existingUnlockBlocks := make(map[address.Address]uint16)
+	for inputIndex, inputAddress := range inputAddresses {
+		if unlockBlockIndex, unlockBlockExists := existingUnlockBlocks[inputAddress]; unlockBlockExists {
+			unlocks[inputIndex] = ledgerstate.NewReferenceUnlockBlock(unlockBlockIndex)
+			continue
+		}
+
+		keyPair := walletSeed.KeyPair(inputAddress.Index)
+		signature := ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essence.Bytes()))
+		if len(optionalPreimage) > 0 && len(optionalPreimage[0]) > 0 {
+			unlocks[inputIndex] = ledgerstate.NewHashTimeLockUnlockBlock(optionalPreimage[0], signature)
+		} else {
+			unlocks[inputIndex] = ledgerstate.NewSignatureUnlockBlock(signature)
+		}
+		existingUnlockBlocks[inputAddress] = uint16(inputIndex)
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
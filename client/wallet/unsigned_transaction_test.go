@@ -0,0 +1,130 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+// newTestUnsignedTransaction returns an UnsignedTransaction that consumes two outputs of the first address and one
+// output of the second address of the given seed.
+func newTestUnsignedTransaction(t *testing.T, walletSeed *seed.Seed) *UnsignedTransaction {
+	inputAddresses := []address.Address{walletSeed.Address(0), walletSeed.Address(0), walletSeed.Address(1)}
+	consumedOutputs := make(map[ledgerstate.OutputID]ledgerstate.Output)
+	ownerAddresses := make(map[ledgerstate.OutputID]address.Address)
+	inputs := make([]ledgerstate.Input, 0, len(inputAddresses))
+	for i, inputAddress := range inputAddresses {
+		output := ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100}), inputAddress.Address())
+		output.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, uint16(i)))
+		consumedOutputs[output.ID()] = output
+		ownerAddresses[output.ID()] = inputAddress
+		inputs = append(inputs, ledgerstate.NewUTXOInput(output.ID()))
+	}
+
+	nodeID := identity.GenerateIdentity().ID()
+	essence := ledgerstate.NewTransactionEssence(0, time.Now(), nodeID, nodeID, ledgerstate.NewInputs(inputs...), ledgerstate.NewOutputs(
+		ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 300}), walletSeed.Address(2).Address()),
+	))
+
+	// the inputs of the essence are sorted, so the consumed outputs have to follow their order
+	orderedOutputs := make(ledgerstate.Outputs, 0, len(inputAddresses))
+	orderedAddresses := make([]address.Address, 0, len(inputAddresses))
+	for _, input := range essence.Inputs() {
+		outputID := input.(*ledgerstate.UTXOInput).ReferencedOutputID()
+		orderedOutputs = append(orderedOutputs, consumedOutputs[outputID])
+		orderedAddresses = append(orderedAddresses, ownerAddresses[outputID])
+	}
+
+	unsignedTransaction, err := NewUnsignedTransaction(essence, orderedOutputs, orderedAddresses)
+	require.NoError(t, err)
+
+	return unsignedTransaction
+}
+
+func TestUnsignedTransaction_Bytes(t *testing.T) {
+	unsignedTransaction := newTestUnsignedTransaction(t, seed.NewSeed())
+
+	restoredTransaction, err := UnsignedTransactionFromBytes(unsignedTransaction.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, unsignedTransaction.Bytes(), restoredTransaction.Bytes())
+	assert.Equal(t, unsignedTransaction.Essence().Bytes(), restoredTransaction.Essence().Bytes())
+	assert.Equal(t, unsignedTransaction.InputAddresses(), restoredTransaction.InputAddresses())
+	require.Len(t, restoredTransaction.Inputs(), len(unsignedTransaction.Inputs()))
+	for i, input := range unsignedTransaction.Inputs() {
+		assert.Equal(t, input.ID(), restoredTransaction.Inputs()[i].ID())
+		assert.Equal(t, input.Bytes(), restoredTransaction.Inputs()[i].Bytes())
+	}
+}
+
+func TestUnsignedTransaction_Sign(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	unsignedTransaction := newTestUnsignedTransaction(t, walletSeed)
+
+	unlockBlocks, err := unsignedTransaction.Sign(walletSeed)
+	require.NoError(t, err)
+	require.Len(t, unlockBlocks, len(unsignedTransaction.Inputs()))
+
+	// every address signs once, further inputs of the same address reference its signature
+	signedAddresses := make(map[address.Address]int)
+	for i, unlockBlock := range unlockBlocks {
+		inputAddress := unsignedTransaction.InputAddresses()[i]
+		if signatureIndex, signed := signedAddresses[inputAddress]; signed {
+			require.Equal(t, ledgerstate.ReferenceUnlockBlockType, unlockBlock.Type())
+			assert.Equal(t, uint16(signatureIndex), unlockBlock.(*ledgerstate.ReferenceUnlockBlock).ReferencedIndex())
+			continue
+		}
+
+		require.Equal(t, ledgerstate.SignatureUnlockBlockType, unlockBlock.Type())
+		signature := unlockBlock.(*ledgerstate.SignatureUnlockBlock).Signature()
+		assert.True(t, signature.AddressSignatureValid(inputAddress.Address(), unsignedTransaction.Essence().Bytes()))
+		signedAddresses[inputAddress] = i
+	}
+	assert.Len(t, signedAddresses, 2)
+
+	transaction, err := unsignedTransaction.Transaction(unlockBlocks)
+	require.NoError(t, err)
+	valid, err := checkBalancesAndUnlocks(unsignedTransaction.Inputs(), transaction)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// a different seed can't sign the transaction
+	_, err = unsignedTransaction.Sign(seed.NewSeed())
+	assert.Error(t, err)
+
+	// the amount of unlock blocks has to match
+	_, err = unsignedTransaction.Transaction(unlockBlocks[:1])
+	assert.Error(t, err)
+}
+
+func TestUnsignedTransactionFromBytes_Malformed(t *testing.T) {
+	unsignedTransaction := newTestUnsignedTransaction(t, seed.NewSeed())
+	unsignedTransactionBytes := unsignedTransaction.Bytes()
+
+	_, err := UnsignedTransactionFromBytes(nil)
+	assert.Error(t, err)
+
+	// truncated essence, outputs and addresses
+	for _, length := range []int{3, 20, len(unsignedTransactionBytes) / 2, len(unsignedTransactionBytes) - 1} {
+		_, err = UnsignedTransactionFromBytes(unsignedTransactionBytes[:length])
+		assert.Error(t, err, "truncated to %d bytes", length)
+	}
+
+	_, err = UnsignedTransactionFromBytes(append(append([]byte{}, unsignedTransactionBytes...), 0))
+	assert.Error(t, err)
+
+	// the consumed outputs have to match the inputs of the essence
+	inputs := unsignedTransaction.Inputs()
+	_, err = NewUnsignedTransaction(unsignedTransaction.Essence(), inputs[:len(inputs)-1], unsignedTransaction.InputAddresses())
+	assert.Error(t, err)
+	_, err = NewUnsignedTransaction(unsignedTransaction.Essence(), ledgerstate.Outputs{inputs[1], inputs[0], inputs[2]}, unsignedTransaction.InputAddresses())
+	assert.Error(t, err)
+	_, err = NewUnsignedTransaction(unsignedTransaction.Essence(), inputs, unsignedTransaction.InputAddresses()[1:])
+	assert.Error(t, err)
+}
@@ -1,12 +1,15 @@
 package wallet
 
 import (
+	"bytes"
 	"reflect"
+	"sort"
 	"time"
 	"unsafe"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/bitmask"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"golang.org/x/crypto/blake2b"
@@ -18,6 +21,7 @@ import (
 	"github.com/iotaledger/goshimmer/client/wallet/packages/delegateoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/deposittonftoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/destroynftoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/htlcoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/reclaimoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
@@ -331,6 +335,248 @@ func (wallet *Wallet) ClaimConditionalFunds(options ...claimconditionaloptions.C
 
 // endregion //////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region ClaimHashTimeLockedFunds /////////////////////////////////////////////////////////////////////////////////////
+
+// ClaimHashTimeLockedFunds claims all hash time locked outputs of the wallet that can be unlocked by the given preimage
+// and consolidates them into one output.
+func (wallet *Wallet) ClaimHashTimeLockedFunds(options ...htlcoptions.HashTimeLockedFundsOption) (tx *ledgerstate.Transaction, err error) {
+	htlcOptions, err := htlcoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	if len(htlcOptions.Preimage) == 0 {
+		err = errors.Errorf("a preimage is required to claim hash time locked funds")
+		return
+	}
+
+	_ = wallet.outputManager.Refresh()
+	now := time.Now()
+	hashLock := ledgerstate.NewHashLock(htlcOptions.Preimage)
+	consumedOutputs := wallet.outputManager.UnspentHashTimeLockedOutputs(false, wallet.addressManager.Addresses()...).
+		FilterHashTimeLocked(func(addy address.Address, output *ledgerstate.HashTimeLockedOutput) bool {
+			return output.HashLock() == hashLock && !output.ExpiredNow(now) && addy.Address().Equals(output.Address())
+		})
+	if len(consumedOutputs) == 0 {
+		err = errors.Errorf("failed to find claimable hash time locked outputs for hash lock %s in wallet", hashLock.Base58())
+		return
+	}
+
+	return wallet.sweepHashTimeLockedOutputs(consumedOutputs, htlcOptions, now)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RefundHashTimeLockedFunds ////////////////////////////////////////////////////////////////////////////////////
+
+// RefundHashTimeLockedFunds refunds all expired hash time locked outputs that were sent by the wallet and consolidates
+// them into one output.
+func (wallet *Wallet) RefundHashTimeLockedFunds(options ...htlcoptions.HashTimeLockedFundsOption) (tx *ledgerstate.Transaction, err error) {
+	htlcOptions, err := htlcoptions.Build(options...)
+	if err != nil {
+		return
+	}
+
+	_ = wallet.outputManager.Refresh()
+	now := time.Now()
+	consumedOutputs := wallet.outputManager.UnspentHashTimeLockedOutputs(false, wallet.addressManager.Addresses()...).
+		FilterHashTimeLocked(func(addy address.Address, output *ledgerstate.HashTimeLockedOutput) bool {
+			return output.ExpiredNow(now) && addy.Address().Equals(output.RefundAddress())
+		})
+	if len(consumedOutputs) == 0 {
+		err = errors.Errorf("failed to find refundable hash time locked outputs in wallet")
+		return
+	}
+
+	return wallet.sweepHashTimeLockedOutputs(consumedOutputs, htlcOptions, now)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Multisig /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MultisigPublicKey returns the public key that the wallet contributes to a MultisigAddress. It belongs to the first
+// address of the seed, so it stays the same while the wallet moves on to new addresses.
+func (wallet *Wallet) MultisigPublicKey() ed25519.PublicKey {
+	return wallet.Seed().KeyPair(0).PublicKey
+}
+
+// PrepareMultisigTransfer creates a MultisigTransaction that spends the funds of the MultisigAddress that is defined by
+// the given threshold and public keys. The transaction needs to be signed by threshold many key holders before it can
+// be submitted. Remaining funds are sent back to the MultisigAddress unless a remainder address is provided.
+func (wallet *Wallet) PrepareMultisigTransfer(threshold uint8, publicKeys []ed25519.PublicKey, options ...sendoptions.SendFundsOption) (multisigTransaction *MultisigTransaction, err error) {
+	sendOptions, err := sendoptions.Build(options...)
+	if err != nil {
+		return
+	}
+
+	signature, err := ledgerstate.NewMultisigSignature(threshold, publicKeys...)
+	if err != nil {
+		return
+	}
+	multisigAddress := address.Address{AddressBytes: signature.Address().Array()}
+
+	consumedOutputs, err := wallet.collectMultisigOutputsForFunding(multisigAddress, sendOptions.RequiredFunds())
+	if err != nil {
+		return
+	}
+
+	// determine pledgeIDs
+	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(sendOptions.AccessManaPledgeID, sendOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+
+	remainderAddress := multisigAddress
+	if sendOptions.RemainderAddress != address.AddressEmpty {
+		remainderAddress = sendOptions.RemainderAddress
+	}
+
+	inputs := wallet.buildInputs(consumedOutputs)
+	outputs := wallet.buildOutputs(sendOptions, consumedOutputs.TotalFundsInOutputs(), remainderAddress)
+	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), aPledgeID, cPledgeID, inputs, outputs)
+
+	outputsByID := consumedOutputs.OutputsByID()
+	inputsInOrder := make(ledgerstate.Outputs, len(inputs))
+	for i, input := range inputs {
+		inputsInOrder[i] = outputsByID[input.(*ledgerstate.UTXOInput).ReferencedOutputID()].Object
+	}
+
+	return NewMultisigTransaction(txEssence, inputsInOrder, signature)
+}
+
+// SignMultisigTransaction adds the signature of the wallet to the given MultisigTransaction.
+func (wallet *Wallet) SignMultisigTransaction(multisigTransaction *MultisigTransaction) (err error) {
+	for _, addy := range wallet.addressManager.Addresses() {
+		keyPair := wallet.Seed().KeyPair(addy.Index)
+		for _, publicKey := range multisigTransaction.Signature().PublicKeys() {
+			if publicKey == keyPair.PublicKey {
+				return multisigTransaction.Sign(*keyPair)
+			}
+		}
+	}
+
+	return errors.Errorf("wallet does not own any of the public keys of %s", multisigTransaction.Signature().Address().Base58())
+}
+
+// SubmitMultisigTransaction submits a MultisigTransaction that collected enough signatures to the network.
+func (wallet *Wallet) SubmitMultisigTransaction(multisigTransaction *MultisigTransaction, waitForConfirmation ...bool) (tx *ledgerstate.Transaction, err error) {
+	if tx, err = multisigTransaction.Transaction(); err != nil {
+		return
+	}
+
+	// check syntactical validity by marshaling an unmarshaling
+	tx, _, err = ledgerstate.TransactionFromBytes(tx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// check tx validity (balances, unlock blocks)
+	ok, err := checkBalancesAndUnlocks(multisigTransaction.Inputs(), tx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("created transaction is invalid: %s", tx.String())
+	}
+
+	if err = wallet.connector.SendTransaction(tx); err != nil {
+		return nil, err
+	}
+	if len(waitForConfirmation) > 0 && waitForConfirmation[0] {
+		err = wallet.WaitForTxConfirmation(tx.ID())
+	}
+
+	return tx, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region OfflineSigning ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// PrepareTransfer creates an UnsignedTransaction for a transfer that is described by the given options (the same options
+// that are used by SendFunds). The transaction can be signed on an air-gapped machine and is afterwards submitted with
+// SubmitSignedTransaction. The consumed outputs are only marked as spent once the transaction is submitted.
+func (wallet *Wallet) PrepareTransfer(options ...sendoptions.SendFundsOption) (unsignedTransaction *UnsignedTransaction, err error) {
+	sendOptions, err := sendoptions.Build(options...)
+	if err != nil {
+		return
+	}
+
+	consumedOutputs, err := wallet.collectOutputsForFunding(sendOptions.RequiredFunds())
+	if err != nil {
+		if errors.Is(err, ErrTooManyOutputs) {
+			err = errors.Errorf("consolidate funds and try again: %w", err)
+		}
+		return
+	}
+
+	// determine pledgeIDs
+	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(sendOptions.AccessManaPledgeID, sendOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+
+	inputs := wallet.buildInputs(consumedOutputs)
+	remainderAddress := wallet.chooseRemainderAddress(consumedOutputs, sendOptions.RemainderAddress)
+	outputs := wallet.buildOutputs(sendOptions, consumedOutputs.TotalFundsInOutputs(), remainderAddress)
+	txEssence := ledgerstate.NewTransactionEssence(0, time.Now(), aPledgeID, cPledgeID, inputs, outputs)
+
+	outputsByID := consumedOutputs.OutputsByID()
+	inputsInOrder := make(ledgerstate.Outputs, len(inputs))
+	inputAddresses := make([]address.Address, len(inputs))
+	for i, input := range inputs {
+		output := outputsByID[input.(*ledgerstate.UTXOInput).ReferencedOutputID()]
+		inputsInOrder[i] = output.Object
+		inputAddresses[i] = output.Address
+	}
+
+	return NewUnsignedTransaction(txEssence, inputsInOrder, inputAddresses)
+}
+
+// SubmitSignedTransaction assembles the Transaction from the UnsignedTransaction and the unlock blocks that were created
+// by the signer and submits it to the network.
+func (wallet *Wallet) SubmitSignedTransaction(unsignedTransaction *UnsignedTransaction, unlockBlocks ledgerstate.UnlockBlocks, waitForConfirmation ...bool) (tx *ledgerstate.Transaction, err error) {
+	if tx, err = unsignedTransaction.Transaction(unlockBlocks); err != nil {
+		return
+	}
+
+	// check syntactical validity by marshaling an unmarshaling
+	tx, _, err = ledgerstate.TransactionFromBytes(tx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// check tx validity (balances, unlock blocks)
+	ok, err := checkBalancesAndUnlocks(unsignedTransaction.Inputs(), tx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("signed transaction is invalid: %s", tx.String())
+	}
+
+	consumedOutputs := NewAddressToOutputs()
+	for i, input := range unsignedTransaction.Inputs() {
+		inputAddress := unsignedTransaction.InputAddresses()[i]
+		if _, exists := consumedOutputs[inputAddress]; !exists {
+			consumedOutputs[inputAddress] = make(map[ledgerstate.OutputID]*Output)
+		}
+		consumedOutputs[inputAddress][input.ID()] = &Output{Address: inputAddress, Object: input}
+	}
+	wallet.markOutputsAndAddressesSpent(consumedOutputs)
+
+	if err = wallet.connector.SendTransaction(tx); err != nil {
+		return nil, err
+	}
+	if len(waitForConfirmation) > 0 && waitForConfirmation[0] {
+		err = wallet.WaitForTxConfirmation(tx.ID())
+	}
+
+	return tx, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region CreateAsset //////////////////////////////////////////////////////////////////////////////////////////////////
 
 // CreateAsset creates a new colored token with the given details.
@@ -1432,6 +1678,24 @@ func (wallet *Wallet) UnspentOutputs() map[address.Address]map[ledgerstate.Outpu
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region TransactionHistory ///////////////////////////////////////////////////////////////////////////////////////////
+
+// TransactionHistory returns the outputs that were created and consumed on the addresses of the wallet in chronological
+// order. It requires the connected node to maintain the history of the addresses.
+func (wallet *Wallet) TransactionHistory() (history HistoryEntries, err error) {
+	for _, addr := range wallet.addressManager.Addresses() {
+		addressHistory, err := wallet.connector.AddressHistory(addr)
+		if err != nil {
+			return nil, errors.Errorf("failed to retrieve history of address %s: %w", addr.Address().Base58(), err)
+		}
+		history = append(history, addressHistory...)
+	}
+
+	return history.Sort(), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region UnspentValueOutputs //////////////////////////////////////////////////////////////////////////////////////////
 
 // UnspentValueOutputs returns the unspent value type outputs that are available for spending.
@@ -1748,6 +2012,59 @@ func (wallet *Wallet) ConditionalBalances(refresh ...bool) (confirmed, pending T
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region HashTimeLockedBalances ///////////////////////////////////////////////////////////////////////////////////////
+
+// HashTimeLockedBalances returns all confirmed and pending balances of hash time locked outputs that can currently be
+// claimed (before the timeout) or refunded (after the timeout) by the wallet. These funds are not part of the Balance,
+// as they can only be moved by ClaimHashTimeLockedFunds or RefundHashTimeLockedFunds.
+func (wallet *Wallet) HashTimeLockedBalances(refresh ...bool) (confirmed, pending TimedBalanceSlice, err error) {
+	shouldRefresh := true
+	if len(refresh) > 0 {
+		shouldRefresh = refresh[0]
+	}
+	if shouldRefresh {
+		err = wallet.outputManager.Refresh()
+		if err != nil {
+			return
+		}
+	}
+
+	confirmed = make(TimedBalanceSlice, 0)
+	pending = make(TimedBalanceSlice, 0)
+	now := time.Now()
+
+	// iterate through the unspent outputs
+	for addy, outputsOnAddress := range wallet.outputManager.UnspentOutputs(true) {
+		for _, output := range outputsOnAddress {
+			// skip if the output was rejected or spent already
+			if output.InclusionState.Spent || output.InclusionState.Rejected {
+				continue
+			}
+			if output.Object.Type() != ledgerstate.HashTimeLockedOutputType {
+				continue
+			}
+			casted := output.Object.(*ledgerstate.HashTimeLockedOutput)
+			if !addy.Address().Equals(casted.UnlockAddressNow(now)) {
+				continue
+			}
+			// we are either the recipient or the output expired and we can refund it
+			hBal := &TimedBalance{
+				Balance: casted.Balances().Map(),
+				Time:    casted.Timeout(),
+			}
+			if output.InclusionState.Confirmed {
+				confirmed = append(confirmed, hBal)
+			} else {
+				pending = append(pending, hBal)
+			}
+		}
+	}
+
+	return confirmed, pending, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region AliasBalance /////////////////////////////////////////////////////////////////////////////////////////////////
 
 // AliasBalance returns the aliases held by this wallet
@@ -2150,6 +2467,68 @@ func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[ledgerstate.Co
 	)
 }
 
+// collectMultisigOutputsForFunding collects confirmed outputs of the given MultisigAddress until the fundingBalance is
+// reached. The outputs are queried directly from the network, as they do not belong to the seed of the wallet.
+func (wallet *Wallet) collectMultisigOutputsForFunding(multisigAddress address.Address, fundingBalance map[ledgerstate.Color]uint64) (OutputsByAddressAndOutputID, error) {
+	if fundingBalance == nil {
+		return nil, errors.Errorf("can't collect fund: empty fundingBalance provided")
+	}
+
+	unspentOutputs, err := wallet.connector.UnspentOutputs(multisigAddress)
+	if err != nil {
+		return nil, errors.Errorf("failed to retrieve unspent outputs of %s: %w", multisigAddress.Base58(), err)
+	}
+
+	// sort the outputs to collect them deterministically
+	outputs := make([]*Output, 0, len(unspentOutputs[multisigAddress]))
+	for _, output := range unspentOutputs[multisigAddress] {
+		outputs = append(outputs, output)
+	}
+	sort.Slice(outputs, func(i, j int) bool {
+		return bytes.Compare(outputs[i].Object.ID().Bytes(), outputs[j].Object.ID().Bytes()) < 0
+	})
+
+	collected := make(map[ledgerstate.Color]uint64)
+	outputsToConsume := NewAddressToOutputs()
+	for _, output := range outputs {
+		if !output.InclusionState.Confirmed {
+			continue
+		}
+		// only plain signature locked outputs can be unlocked by a MultisigSignature without further conditions
+		if outputType := output.Object.Type(); outputType != ledgerstate.SigLockedSingleOutputType && outputType != ledgerstate.SigLockedColoredOutputType {
+			continue
+		}
+
+		contributingOutput := false
+		output.Object.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			if _, has := fundingBalance[color]; has {
+				collected[color] += balance
+				contributingOutput = true
+			}
+			return true
+		})
+		if !contributingOutput {
+			continue
+		}
+
+		if _, addressEntryExists := outputsToConsume[multisigAddress]; !addressEntryExists {
+			outputsToConsume[multisigAddress] = make(map[ledgerstate.OutputID]*Output)
+		}
+		outputsToConsume[multisigAddress][output.Object.ID()] = output
+		if len(outputsToConsume[multisigAddress]) > ledgerstate.MaxInputCount {
+			return nil, errors.Errorf("failed to collect outputs: %w", ErrTooManyOutputs)
+		}
+		if enoughCollected(collected, fundingBalance) {
+			return outputsToConsume, nil
+		}
+	}
+
+	return nil, errors.Errorf("failed to gather initial funds \n %s, there are only \n %s funds available",
+		ledgerstate.NewColoredBalances(fundingBalance).String(),
+		ledgerstate.NewColoredBalances(collected).String(),
+	)
+}
+
 // enoughCollected checks if collected has at least target funds
 func enoughCollected(collected, target map[ledgerstate.Color]uint64) bool {
 	for color, balance := range target {
@@ -2212,7 +2591,9 @@ func (wallet *Wallet) buildOutputs(
 	for addr, outputBalanceMap := range outputsByColor {
 		coloredBalances := ledgerstate.NewColoredBalances(outputBalanceMap)
 		var output ledgerstate.Output
-		if !sendOptions.LockUntil.IsZero() || !sendOptions.FallbackDeadline.IsZero() || sendOptions.FallbackAddress != nil {
+		if sendOptions.RefundAddress != nil {
+			output = ledgerstate.NewHashTimeLockedOutput(outputBalanceMap, addr.Address(), sendOptions.HashLock, sendOptions.HashLockTimeout, sendOptions.RefundAddress)
+		} else if !sendOptions.LockUntil.IsZero() || !sendOptions.FallbackDeadline.IsZero() || sendOptions.FallbackAddress != nil {
 			extended := ledgerstate.NewExtendedLockedOutput(outputBalanceMap, addr.Address())
 			if !sendOptions.LockUntil.IsZero() {
 				extended = extended.WithTimeLock(sendOptions.LockUntil)
@@ -2232,22 +2613,65 @@ func (wallet *Wallet) buildOutputs(
 	return
 }
 
-// buildUnlockBlocks constructs the unlock blocks for a transaction.
-func (wallet *Wallet) buildUnlockBlocks(inputs ledgerstate.Inputs, consumedOutputsByID OutputsByID, essence *ledgerstate.TransactionEssence) (unlocks ledgerstate.UnlockBlocks, inputsInOrder ledgerstate.Outputs) {
-	unlocks = make([]ledgerstate.UnlockBlock, len(inputs))
-	existingUnlockBlocks := make(map[address.Address]uint16)
-	for outputIndex, input := range inputs {
+// buildUnlockBlocks constructs the unlock blocks for a transaction. If a preimage is provided, the signatures are wrapped
+// in HashTimeLockUnlockBlocks that reveal the preimage.
+func (wallet *Wallet) buildUnlockBlocks(inputs ledgerstate.Inputs, consumedOutputsByID OutputsByID, essence *ledgerstate.TransactionEssence, optionalPreimage ...[]byte) (unlocks ledgerstate.UnlockBlocks, inputsInOrder ledgerstate.Outputs) {
+	inputAddresses := make([]address.Address, len(inputs))
+	for i, input := range inputs {
 		output := consumedOutputsByID[input.(*ledgerstate.UTXOInput).ReferencedOutputID()]
 		inputsInOrder = append(inputsInOrder, output.Object)
-		if unlockBlockIndex, unlockBlockExists := existingUnlockBlocks[output.Address]; unlockBlockExists {
-			unlocks[outputIndex] = ledgerstate.NewReferenceUnlockBlock(unlockBlockIndex)
-			continue
-		}
+		inputAddresses[i] = output.Address
+	}
+
+	return signInputs(wallet.Seed(), inputAddresses, essence, optionalPreimage...), inputsInOrder
+}
+
+// sweepHashTimeLockedOutputs consolidates the given hash time locked outputs into one output of the wallet. If a
+// preimage is provided in the options, the outputs are claimed by the recipient, otherwise they are refunded.
+func (wallet *Wallet) sweepHashTimeLockedOutputs(consumedOutputs OutputsByAddressAndOutputID, htlcOptions *htlcoptions.HashTimeLockedFundsOptions, now time.Time) (tx *ledgerstate.Transaction, err error) {
+	// build inputs from consumed outputs
+	inputs := wallet.buildInputs(consumedOutputs)
+	// aggregate all the funds we consume from inputs
+	totalConsumedFunds := consumedOutputs.TotalFundsInOutputs()
+	toAddress := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty) // no optional toAddress from options
+	outputs := ledgerstate.NewOutputs(ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(totalConsumedFunds), toAddress.Address()))
+
+	// determine pledgeIDs
+	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(htlcOptions.AccessManaPledgeID, htlcOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+
+	txEssence := ledgerstate.NewTransactionEssence(0, now, aPledgeID, cPledgeID, inputs, outputs)
+	outputsByID := consumedOutputs.OutputsByID()
+
+	unlockBlocks, inputsAsOutputsInOrder := wallet.buildUnlockBlocks(inputs, outputsByID, txEssence, htlcOptions.Preimage)
 
-		keyPair := wallet.Seed().KeyPair(output.Address.Index)
-		unlockBlock := ledgerstate.NewSignatureUnlockBlock(ledgerstate.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essence.Bytes())))
-		unlocks[outputIndex] = unlockBlock
-		existingUnlockBlocks[output.Address] = uint16(outputIndex)
+	tx = ledgerstate.NewTransaction(txEssence, unlockBlocks)
+
+	// check syntactical validity by marshaling an unmarshaling
+	tx, _, err = ledgerstate.TransactionFromBytes(tx.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// check tx validity (balances, unlock blocks)
+	ok, err := checkBalancesAndUnlocks(inputsAsOutputsInOrder, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("created transaction is invalid: %s", tx.String())
+	}
+
+	wallet.markOutputsAndAddressesSpent(consumedOutputs)
+
+	err = wallet.connector.SendTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	if htlcOptions.WaitForConfirmation {
+		err = wallet.WaitForTxConfirmation(tx.ID())
 	}
 	return
 }
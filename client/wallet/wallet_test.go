@@ -0,0 +1,129 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/bitmask"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/mana"
+)
+
+// region mockConnector ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// mockConnector is a Connector that serves a fixed set of outputs.
+type mockConnector struct {
+	outputs map[address.Address]map[ledgerstate.OutputID]*Output
+}
+
+func newMockConnector(outputs ...*Output) (connector *mockConnector) {
+	connector = &mockConnector{
+		outputs: make(map[address.Address]map[ledgerstate.OutputID]*Output),
+	}
+
+	for _, output := range outputs {
+		if _, addressExists := connector.outputs[output.Address]; !addressExists {
+			connector.outputs[output.Address] = make(map[ledgerstate.OutputID]*Output)
+		}
+
+		connector.outputs[output.Address][output.Object.ID()] = output
+	}
+
+	return
+}
+
+func (connector *mockConnector) UnspentOutputs(addresses ...address.Address) (outputs OutputsByAddressAndOutputID, err error) {
+	outputs = NewAddressToOutputs()
+	for _, addr := range addresses {
+		if outputsOnAddress, exists := connector.outputs[addr]; exists {
+			outputs[addr] = outputsOnAddress
+		}
+	}
+
+	return
+}
+
+func (connector *mockConnector) SendTransaction(*ledgerstate.Transaction) (err error) {
+	return
+}
+
+func (connector *mockConnector) RequestFaucetFunds(address.Address, int) (err error) {
+	return
+}
+
+func (connector *mockConnector) GetAllowedPledgeIDs() (pledgeIDMap map[mana.Type][]string, err error) {
+	return
+}
+
+func (connector *mockConnector) GetTransactionInclusionState(ledgerstate.TransactionID) (inc ledgerstate.InclusionState, err error) {
+	return
+}
+
+func (connector *mockConnector) GetUnspentAliasOutput(*ledgerstate.AliasAddress) (output *ledgerstate.AliasOutput, err error) {
+	return
+}
+
+func (connector *mockConnector) AddressHistory(address.Address) (history HistoryEntries, err error) {
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+func newTestOutput(addr address.Address, index uint16, object ledgerstate.Output, confirmed bool) *Output {
+	object.SetID(ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, index))
+
+	return &Output{
+		Address: addr,
+		Object:  object,
+		InclusionState: InclusionState{
+			Liked:     true,
+			Confirmed: confirmed,
+		},
+	}
+}
+
+func TestWallet_HashTimeLockedBalances(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	walletAddress := walletSeed.Address(0)
+	otherAddress := ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	hashLock := ledgerstate.NewHashLock([]byte("secret"))
+	claimTimeout := time.Now().Add(time.Hour)
+	refundTimeout := time.Now().Add(-time.Hour)
+
+	outputs := []*Output{
+		newTestOutput(walletAddress, 0, ledgerstate.NewSigLockedColoredOutput(ledgerstate.NewColoredBalances(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100}), walletAddress.Address()), true),
+		// claimable by the wallet
+		newTestOutput(walletAddress, 1, ledgerstate.NewHashTimeLockedOutput(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 50}, walletAddress.Address(), hashLock, claimTimeout, otherAddress), true),
+		// refundable by the wallet
+		newTestOutput(walletAddress, 2, ledgerstate.NewHashTimeLockedOutput(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 20}, otherAddress, hashLock, refundTimeout, walletAddress.Address()), false),
+		// only claimable by the other address until the timeout
+		newTestOutput(walletAddress, 3, ledgerstate.NewHashTimeLockedOutput(map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 30}, otherAddress, hashLock, claimTimeout, walletAddress.Address()), true),
+	}
+
+	wallet := New(Import(walletSeed, 1, []bitmask.BitMask{}, NewAssetRegistry("test")), GenericConnector(newMockConnector(outputs...)))
+
+	// the hash time locked outputs can't be spent by regular transactions, so they are not part of the balance
+	confirmedBalance, pendingBalance, err := wallet.Balance()
+	require.NoError(t, err)
+	assert.Equal(t, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100}, confirmedBalance)
+	assert.Empty(t, pendingBalance)
+	confirmedBalance, pendingBalance, err = wallet.AvailableBalance()
+	require.NoError(t, err)
+	assert.Equal(t, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 100}, confirmedBalance)
+	assert.Empty(t, pendingBalance)
+
+	confirmed, pending, err := wallet.HashTimeLockedBalances()
+	require.NoError(t, err)
+	require.Len(t, confirmed, 1)
+	assert.Equal(t, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 50}, confirmed[0].Balance)
+	assert.True(t, claimTimeout.Equal(confirmed[0].Time))
+	require.Len(t, pending, 1)
+	assert.Equal(t, map[ledgerstate.Color]uint64{ledgerstate.ColorIOTA: 20}, pending[0].Balance)
+	assert.True(t, refundTimeout.Equal(pending[0].Time))
+}
@@ -1,10 +1,13 @@
 package wallet
 
 import (
+	"time"
+
 	"github.com/cockroachdb/errors"
 
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
 )
@@ -102,6 +105,30 @@ func (webConnector WebConnector) UnspentOutputs(addresses ...address.Address) (u
 	return
 }
 
+// AddressHistory returns the history of the given address by fetching all pages of the history endpoint of the node.
+func (webConnector WebConnector) AddressHistory(addr address.Address) (history HistoryEntries, err error) {
+	cursor := ""
+	for {
+		response, err := webConnector.client.GetAddressHistory(addr.Address().Base58(), 0, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range response.Entries {
+			historyEntry, err := historyEntryFromJSONModel(addr, entry)
+			if err != nil {
+				return nil, err
+			}
+			history = append(history, historyEntry)
+		}
+
+		if response.NextCursor == "" {
+			return history, nil
+		}
+		cursor = response.NextCursor
+	}
+}
+
 // SendTransaction sends a new transaction to the network.
 func (webConnector WebConnector) SendTransaction(tx *ledgerstate.Transaction) (err error) {
 	_, err = webConnector.client.PostTransaction(tx.Bytes())
@@ -188,3 +215,33 @@ func colorFromString(colorStr string) (color ledgerstate.Color) {
 
 // Interface contract: make compiler warn if the interface is not implemented correctly.
 var _ Connector = &WebConnector{}
+
+// historyEntryFromJSONModel converts an entry of the history endpoint into a HistoryEntry of the given address.
+func historyEntryFromJSONModel(addr address.Address, entry *jsonmodels.AddressHistoryEntry) (historyEntry *HistoryEntry, err error) {
+	historyEntry = &HistoryEntry{
+		Address:   addr,
+		Timestamp: time.Unix(entry.Timestamp, 0),
+		InclusionState: InclusionState{
+			Confirmed: entry.Confirmed,
+			Rejected:  entry.Rejected,
+			Spent:     entry.Type == ledgerstate.ConsumedAddressHistoryEntryType.String(),
+		},
+	}
+
+	switch entry.Type {
+	case ledgerstate.CreatedAddressHistoryEntryType.String():
+		historyEntry.Type = ledgerstate.CreatedAddressHistoryEntryType
+	case ledgerstate.ConsumedAddressHistoryEntryType.String():
+		historyEntry.Type = ledgerstate.ConsumedAddressHistoryEntryType
+	default:
+		return nil, errors.Errorf("unknown history entry type '%s'", entry.Type)
+	}
+	if historyEntry.OutputID, err = ledgerstate.OutputIDFromBase58(entry.OutputID.Base58); err != nil {
+		return nil, errors.Errorf("failed to parse output ID %s: %w", entry.OutputID.Base58, err)
+	}
+	if historyEntry.TransactionID, err = ledgerstate.TransactionIDFromBase58(entry.TransactionID); err != nil {
+		return nil, errors.Errorf("failed to parse transaction ID %s: %w", entry.TransactionID, err)
+	}
+
+	return historyEntry, nil
+}
@@ -0,0 +1,199 @@
+// Package bech32 implements the bech32 encoding as specified in BIP-173. A bech32 string consists of a human-readable
+// part (HRP), the separator "1" and a data part that ends with a 6 character checksum, which detects typos reliably.
+package bech32
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// charset is the alphabet that is used to encode the 5-bit groups of the data part.
+	charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// separator separates the human-readable part from the data part.
+	separator = '1'
+
+	// checksumLength is the amount of characters of the checksum.
+	checksumLength = 6
+
+	// maxLength is the maximum length of a bech32 string.
+	maxLength = 90
+)
+
+var (
+	// ErrInvalidLength is returned if the length of a bech32 string or its parts is invalid.
+	ErrInvalidLength = errors.New("invalid length")
+
+	// ErrInvalidCharacter is returned if a bech32 string contains invalid characters.
+	ErrInvalidCharacter = errors.New("invalid character")
+
+	// ErrMixedCase is returned if a bech32 string contains both lower and upper case characters.
+	ErrMixedCase = errors.New("mixed case")
+
+	// ErrInvalidChecksum is returned if the checksum of a bech32 string does not match its content.
+	ErrInvalidChecksum = errors.New("invalid checksum")
+
+	// ErrInvalidPadding is returned if the data part can not be converted back to bytes.
+	ErrInvalidPadding = errors.New("invalid padding")
+)
+
+// charsetReverse maps the characters of the charset to their 5-bit value (or -1 if they are not part of the charset).
+var charsetReverse = func() (reverse [128]int8) {
+	for i := range reverse {
+		reverse[i] = -1
+	}
+	for i, c := range charset {
+		reverse[c] = int8(i)
+	}
+
+	return
+}()
+
+// Encode encodes the data with the given human-readable part into a bech32 string.
+func Encode(hrp string, data []byte) (string, error) {
+	if len(hrp) == 0 {
+		return "", errors.Errorf("human-readable part must not be empty: %w", ErrInvalidLength)
+	}
+	for _, c := range hrp {
+		if c < 33 || c > 126 {
+			return "", errors.Errorf("human-readable part contains '%c': %w", c, ErrInvalidCharacter)
+		}
+	}
+	if strings.ToLower(hrp) != hrp && strings.ToUpper(hrp) != hrp {
+		return "", errors.Errorf("human-readable part %s: %w", hrp, ErrMixedCase)
+	}
+	hrp = strings.ToLower(hrp)
+
+	values := convertBits(data, 8, 5, true)
+	if len(hrp)+1+len(values)+checksumLength > maxLength {
+		return "", errors.Errorf("encoded string would exceed %d characters: %w", maxLength, ErrInvalidLength)
+	}
+
+	var builder strings.Builder
+	builder.Grow(len(hrp) + 1 + len(values) + checksumLength)
+	builder.WriteString(hrp)
+	builder.WriteByte(separator)
+	for _, value := range append(values, createChecksum(hrp, values)...) {
+		builder.WriteByte(charset[value])
+	}
+
+	return builder.String(), nil
+}
+
+// Decode decodes a bech32 string into its human-readable part and its data. The human-readable part is returned in
+// lower case.
+func Decode(bech32String string) (hrp string, data []byte, err error) {
+	if len(bech32String) > maxLength {
+		return "", nil, errors.Errorf("string exceeds %d characters: %w", maxLength, ErrInvalidLength)
+	}
+	if strings.ToLower(bech32String) != bech32String && strings.ToUpper(bech32String) != bech32String {
+		return "", nil, errors.Errorf("%s: %w", bech32String, ErrMixedCase)
+	}
+	bech32String = strings.ToLower(bech32String)
+
+	separatorIndex := strings.LastIndexByte(bech32String, separator)
+	if separatorIndex < 1 || separatorIndex+checksumLength+1 > len(bech32String) {
+		return "", nil, errors.Errorf("missing human-readable part or checksum: %w", ErrInvalidLength)
+	}
+
+	hrp = bech32String[:separatorIndex]
+	for _, c := range hrp {
+		if c < 33 || c > 126 {
+			return "", nil, errors.Errorf("human-readable part contains '%c': %w", c, ErrInvalidCharacter)
+		}
+	}
+
+	values := make([]byte, 0, len(bech32String)-separatorIndex-1)
+	for _, c := range bech32String[separatorIndex+1:] {
+		if c >= 128 || charsetReverse[c] == -1 {
+			return "", nil, errors.Errorf("data part contains '%c': %w", c, ErrInvalidCharacter)
+		}
+		values = append(values, byte(charsetReverse[c]))
+	}
+
+	if polymod(append(expandHRP(hrp), values...)) != 1 {
+		return "", nil, errors.Errorf("%s: %w", bech32String, ErrInvalidChecksum)
+	}
+
+	if data = convertBits(values[:len(values)-checksumLength], 5, 8, false); data == nil {
+		return "", nil, errors.Errorf("%s: %w", bech32String, ErrInvalidPadding)
+	}
+
+	return hrp, data, nil
+}
+
+// polymod computes the BCH checksum of the given 5-bit values.
+func polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+
+	checksum := uint32(1)
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				checksum ^= generator[i]
+			}
+		}
+	}
+
+	return checksum
+}
+
+// expandHRP expands the human-readable part into 5-bit values for the checksum computation.
+func expandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+
+	return expanded
+}
+
+// createChecksum computes the 6 checksum values of the given human-readable part and 5-bit values.
+func createChecksum(hrp string, values []byte) []byte {
+	checksumInput := append(expandHRP(hrp), values...)
+	checksumInput = append(checksumInput, make([]byte, checksumLength)...)
+	mod := polymod(checksumInput) ^ 1
+
+	checksum := make([]byte, checksumLength)
+	for i := range checksum {
+		checksum[i] = byte((mod >> uint(5*(5-i))) & 31)
+	}
+
+	return checksum
+}
+
+// convertBits regroups the bits of the given values from groups of fromBits to groups of toBits. It returns nil if pad
+// is false and the input can not be converted without padding.
+func convertBits(values []byte, fromBits, toBits uint, pad bool) []byte {
+	var accumulator uint32
+	var bits uint
+	maxValue := uint32(1<<toBits) - 1
+
+	result := make([]byte, 0, len(values)*int(fromBits)/int(toBits)+1)
+	for _, value := range values {
+		accumulator = accumulator<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(accumulator>>bits&maxValue))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(accumulator<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || accumulator<<(toBits-bits)&maxValue != 0 {
+		return nil
+	}
+
+	return result
+}
@@ -0,0 +1,80 @@
+package bech32
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_Valid(t *testing.T) {
+	// test vectors of BIP-173
+	validStrings := []string{
+		"A12UEL5L",
+		"a12uel5l",
+		"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs",
+		"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw",
+		"11qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqc8247j",
+		"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w",
+		"?1ezyfcl",
+	}
+
+	for _, validString := range validStrings {
+		hrp, data, err := Decode(validString)
+		require.NoError(t, err, validString)
+
+		encoded, err := Encode(hrp, data)
+		require.NoError(t, err, validString)
+		assert.Equal(t, strings.ToLower(validString), encoded)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	invalidStrings := map[string]error{
+		"pzry9x0s0muk":  ErrInvalidLength,
+		"1pzry9x0s0muk": ErrInvalidLength,
+		"x1b4n0q5v":     ErrInvalidCharacter,
+		"li1dgmt3":      ErrInvalidLength,
+		"A1G7SGD8":      ErrInvalidChecksum,
+		"10a06t8":       ErrInvalidLength,
+		"1qzzfhee":      ErrInvalidLength,
+		"a12UEL5L":      ErrMixedCase,
+		"a12uel5m":      ErrInvalidChecksum,
+		"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx": ErrInvalidLength,
+	}
+
+	for invalidString, expectedErr := range invalidStrings {
+		_, _, err := Decode(invalidString)
+		assert.True(t, errors.Is(err, expectedErr), "%s: %v", invalidString, err)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	data := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32}
+
+	encoded, err := Encode("atoi", data)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "atoi1"))
+
+	hrp, decoded, err := Decode(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, "atoi", hrp)
+	assert.Equal(t, data, decoded)
+
+	// a single typo is detected by the checksum
+	typo := []byte(encoded)
+	if typo[10] == 'q' {
+		typo[10] = 'p'
+	} else {
+		typo[10] = 'q'
+	}
+	_, _, err = Decode(string(typo))
+	assert.True(t, errors.Is(err, ErrInvalidChecksum))
+
+	_, err = Encode("", data)
+	assert.True(t, errors.Is(err, ErrInvalidLength))
+	_, err = Encode("AtOi", data)
+	assert.True(t, errors.Is(err, ErrMixedCase))
+}
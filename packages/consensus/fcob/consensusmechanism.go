@@ -67,6 +67,12 @@ func (f *ConsensusMechanism) Setup() {
 
 	f.tangle.Booker.Events.MessageBooked.Attach(events.NewClosure(f.Evaluate))
 	f.tangle.Booker.Events.MessageBooked.Attach(events.NewClosure(f.EvaluateTimestamp))
+
+	// the opinions about transactions are part of the ledger state and are kept, only the message related data is pruned
+	f.tangle.Pruner.Events.MessagePruned.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		f.Storage.DeleteTimestampOpinion(messageID)
+		f.Storage.DeleteMessageMetadata(messageID)
+	}))
 }
 
 // TransactionLiked returns a boolean value indicating whether the given Transaction is liked.
@@ -103,6 +109,11 @@ func (f *ConsensusMechanism) Shutdown() {
 	f.Storage.Shutdown()
 }
 
+// Flush persists the state of the ConsensusMechanism without shutting it down.
+func (f *ConsensusMechanism) Flush() {
+	f.Storage.Flush()
+}
+
 // Evaluate evaluates the opinion of the given messageID.
 func (f *ConsensusMechanism) Evaluate(messageID tangle.MessageID) {
 	f.Storage.StoreMessageMetadata(NewMessageMetadata(messageID))
@@ -136,6 +147,12 @@ func (f *ConsensusMechanism) EvaluateTimestamp(messageID tangle.MessageID) {
 
 // ProcessVote allows an external voter to hand in the results of the voting process.
 func (f *ConsensusMechanism) ProcessVote(ev *vote.OpinionEvent) {
+	f.tangle.ProcessingGate.Run(func() {
+		f.processVote(ev)
+	})
+}
+
+func (f *ConsensusMechanism) processVote(ev *vote.OpinionEvent) {
 	if ev.Ctx.Type == vote.ConflictType {
 		transactionID, err := ledgerstate.TransactionIDFromBase58(ev.ID)
 		if err != nil {
@@ -249,58 +266,62 @@ func (f *ConsensusMechanism) onTransactionBooked(transactionID ledgerstate.Trans
 
 	// Wait LikedThreshold
 	f.likedThresholdExecutor.ExecuteAt(func() {
-		runLocallyFinalizedExecutor := true
-		if !f.Storage.Opinion(transactionID).Consume(func(opinion *Opinion) {
-			opinion.SetFCOBTime1(time.Now())
-
-			if f.tangle.LedgerState.TransactionConflicting(transactionID) {
-				runLocallyFinalizedExecutor = false
-				// if the previous conflicts have been finalized with all dislikes,
-				// and no other conflicts arrived within LikedThreshold seconds,
-				// start voting with local like
-				conflictSet := ConflictSet(f.OpinionsEssence(transactionID, f.tangle.LedgerState.ConflictSet(transactionID)))
-				if conflictSet.finalizedAsDisliked(opinion.OpinionEssence) {
-					opinion.SetLiked(true)
+		f.tangle.ProcessingGate.Run(func() {
+			runLocallyFinalizedExecutor := true
+			if !f.Storage.Opinion(transactionID).Consume(func(opinion *Opinion) {
+				opinion.SetFCOBTime1(time.Now())
+
+				if f.tangle.LedgerState.TransactionConflicting(transactionID) {
+					runLocallyFinalizedExecutor = false
+					// if the previous conflicts have been finalized with all dislikes,
+					// and no other conflicts arrived within LikedThreshold seconds,
+					// start voting with local like
+					conflictSet := ConflictSet(f.OpinionsEssence(transactionID, f.tangle.LedgerState.ConflictSet(transactionID)))
+					if conflictSet.finalizedAsDisliked(opinion.OpinionEssence) {
+						opinion.SetLiked(true)
+						opinion.SetLevelOfKnowledge(One)
+						// trigger voting for this transactionID
+						f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
+						return
+					}
 					opinion.SetLevelOfKnowledge(One)
+					opinion.SetLiked(false)
 					// trigger voting for this transactionID
-					f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
+					f.Events.Vote.Trigger(transactionID.Base58(), voter.Dislike)
 					return
 				}
 				opinion.SetLevelOfKnowledge(One)
-				opinion.SetLiked(false)
-				// trigger voting for this transactionID
-				f.Events.Vote.Trigger(transactionID.Base58(), voter.Dislike)
-				return
+				opinion.SetLiked(true)
+			}) {
+				panic(fmt.Sprintf("could not load opinion of transaction %s", transactionID))
 			}
-			opinion.SetLevelOfKnowledge(One)
-			opinion.SetLiked(true)
-		}) {
-			panic(fmt.Sprintf("could not load opinion of transaction %s", transactionID))
-		}
-
-		if runLocallyFinalizedExecutor {
-			// Wait LocallyFinalizedThreshold
-			f.locallyFinalizedExecutor.ExecuteAt(func() {
-				if !f.Storage.Opinion(transactionID).Consume(func(opinion *Opinion) {
-					opinion.SetFCOBTime2(time.Now())
 
-					opinion.SetLiked(true)
-					if f.tangle.LedgerState.TransactionConflicting(transactionID) {
-						// trigger voting for this transactionID
-						f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
-						return
-					}
-					opinion.SetLevelOfKnowledge(Two)
-					// trigger OpinionPayloadFormed
-					messageIDs := f.tangle.Storage.AttachmentMessageIDs(transactionID)
-					for _, messageID := range messageIDs {
-						f.onPayloadOpinionFormed(messageID, opinion.liked)
-					}
-				}) {
-					panic(fmt.Sprintf("could not load opinion of transaction %s", transactionID))
-				}
-			}, timestamp.Add(LocallyFinalizedThreshold))
-		}
+			if runLocallyFinalizedExecutor {
+				// Wait LocallyFinalizedThreshold
+				f.locallyFinalizedExecutor.ExecuteAt(func() {
+					f.tangle.ProcessingGate.Run(func() {
+						if !f.Storage.Opinion(transactionID).Consume(func(opinion *Opinion) {
+							opinion.SetFCOBTime2(time.Now())
+
+							opinion.SetLiked(true)
+							if f.tangle.LedgerState.TransactionConflicting(transactionID) {
+								// trigger voting for this transactionID
+								f.Events.Vote.Trigger(transactionID.Base58(), voter.Like)
+								return
+							}
+							opinion.SetLevelOfKnowledge(Two)
+							// trigger OpinionPayloadFormed
+							messageIDs := f.tangle.Storage.AttachmentMessageIDs(transactionID)
+							for _, messageID := range messageIDs {
+								f.onPayloadOpinionFormed(messageID, opinion.liked)
+							}
+						}) {
+							panic(fmt.Sprintf("could not load opinion of transaction %s", transactionID))
+						}
+					})
+				}, timestamp.Add(LocallyFinalizedThreshold))
+			}
+		})
 	}, timestamp.Add(LikedThreshold))
 }
 
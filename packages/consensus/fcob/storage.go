@@ -99,6 +99,23 @@ func (s *Storage) StoreMessageMetadata(messageMetadata *MessageMetadata) (stored
 	return
 }
 
+// DeleteTimestampOpinion deletes the TimestampOpinion associated with given MessageID.
+func (s *Storage) DeleteTimestampOpinion(messageID tangle.MessageID) {
+	s.timestampOpinionStorage.Delete(messageID.Bytes())
+}
+
+// DeleteMessageMetadata deletes the MessageMetadata associated with given MessageID.
+func (s *Storage) DeleteMessageMetadata(messageID tangle.MessageID) {
+	s.messageMetadataStorage.Delete(messageID.Bytes())
+}
+
+// Flush persists the cached content of the Storage to the disk without shutting it down.
+func (s *Storage) Flush() {
+	s.opinionStorage.Flush()
+	s.timestampOpinionStorage.Flush()
+	s.messageMetadataStorage.Flush()
+}
+
 // Shutdown shuts down the Storage and causes its content to be persisted to the disk.
 func (s *Storage) Shutdown() {
 	s.opinionStorage.Shutdown()
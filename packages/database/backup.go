@@ -0,0 +1,325 @@
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// BackupFormatVersion is the version of the backup format that is written by WriteBackup.
+	BackupFormatVersion uint8 = 1
+
+	// BackupChecksumLength contains the amount of bytes of the checksum of a backup.
+	BackupChecksumLength = blake2b.Size256
+)
+
+const (
+	// objectBackupEntry is the type of the entries that contain a stored object.
+	objectBackupEntry uint8 = iota + 1
+
+	// endBackupEntry is the type of the entry that terminates the objects of a backup.
+	endBackupEntry
+)
+
+var (
+	// ErrBackupChecksumMismatch is returned if the checksum of a backup does not match its content.
+	ErrBackupChecksumMismatch = errors.New("backup checksum mismatch")
+
+	// ErrUnsupportedBackupFormat is returned if a backup was written in an unknown format.
+	ErrUnsupportedBackupFormat = errors.New("unsupported backup format")
+
+	// ErrDatabaseNotEmpty is returned if a backup is restored into a database that already contains objects.
+	ErrDatabaseNotEmpty = errors.New("database not empty")
+)
+
+// backupMagic is the prefix of every backup.
+var backupMagic = [4]byte{'G', 'S', 'D', 'B'}
+
+// region BackupInfo ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// BackupInfo contains information about a backup of the database.
+type BackupInfo struct {
+	// FormatVersion is the version of the backup format.
+	FormatVersion uint8
+
+	// DatabaseVersion is the schema version of the database at the time of the backup.
+	DatabaseVersion uint8
+
+	// Time is the time at which the backup was taken.
+	Time time.Time
+
+	// Objects contains the amount of objects per storage prefix.
+	Objects map[byte]int
+
+	// Checksum is the checksum over the content of the backup.
+	Checksum [BackupChecksumLength]byte
+}
+
+// TotalObjects returns the amount of objects in the backup.
+func (b *BackupInfo) TotalObjects() (total int) {
+	for _, objects := range b.Objects {
+		total += objects
+	}
+
+	return total
+}
+
+// String returns a human readable version of the BackupInfo.
+func (b *BackupInfo) String() string {
+	return stringify.Struct("BackupInfo",
+		stringify.StructField("formatVersion", b.FormatVersion),
+		stringify.StructField("databaseVersion", b.DatabaseVersion),
+		stringify.StructField("time", b.Time),
+		stringify.StructField("objects", b.TotalObjects()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region WriteBackup //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// WriteBackup writes a gzip compressed copy of all objects of the store (except the keys starting with one of the
+// excludedPrefixes) to the writer. The objects are read with a single iteration over the store, which sees the state of
+// all realms at the same point in time (RocksDB iterators operate on an implicit snapshot and the in-memory database is
+// read locked while it is iterated). Objects that are only cached in memory (i.e. by an objectstorage) are not part of
+// the backup, so the caller needs to persist them first. The backup is terminated by a checksum over its content.
+func WriteBackup(store kvstore.KVStore, writer io.Writer, excludedPrefixes ...kvstore.KeyPrefix) (info *BackupInfo, err error) {
+	version, _, err := NewMigrator(store, nil).Version()
+	if err != nil {
+		return nil, errors.Errorf("failed to read database version: %w", err)
+	}
+
+	checksum, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to create backup checksum: %w", err)
+	}
+
+	info = &BackupInfo{
+		FormatVersion:   BackupFormatVersion,
+		DatabaseVersion: version,
+		Time:            time.Now(),
+		Objects:         make(map[byte]int),
+	}
+
+	gzipWriter := gzip.NewWriter(writer)
+	backupWriter := &backupWriter{buffer: bufio.NewWriter(gzipWriter), checksum: checksum}
+
+	backupWriter.write(backupMagic[:])
+	backupWriter.write(marshalutil.New(2*marshalutil.Uint8Size + marshalutil.TimeSize).
+		WriteUint8(info.FormatVersion).
+		WriteUint8(info.DatabaseVersion).
+		WriteTime(info.Time).
+		Bytes(),
+	)
+
+	if iterateErr := store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		for _, excludedPrefix := range excludedPrefixes {
+			if bytes.HasPrefix(key, excludedPrefix) {
+				return true
+			}
+		}
+
+		backupWriter.write(marshalutil.New(marshalutil.Uint8Size + 2*marshalutil.Uint32Size).
+			WriteUint8(objectBackupEntry).
+			WriteUint32(uint32(len(key))).
+			WriteUint32(uint32(len(value))).
+			Bytes(),
+		)
+		backupWriter.write(key)
+		backupWriter.write(value)
+		info.Objects[key[0]]++
+
+		return backupWriter.err == nil
+	}); iterateErr != nil {
+		return nil, errors.Errorf("failed to iterate database: %w", iterateErr)
+	}
+	backupWriter.write([]byte{endBackupEntry})
+	if backupWriter.err != nil {
+		return nil, errors.Errorf("failed to write backup: %w", backupWriter.err)
+	}
+
+	copy(info.Checksum[:], checksum.Sum(nil))
+	if _, err = backupWriter.buffer.Write(info.Checksum[:]); err != nil {
+		return nil, errors.Errorf("failed to write backup checksum: %w", err)
+	}
+	if err = backupWriter.buffer.Flush(); err != nil {
+		return nil, errors.Errorf("failed to flush backup: %w", err)
+	}
+	if err = gzipWriter.Close(); err != nil {
+		return nil, errors.Errorf("failed to close backup: %w", err)
+	}
+
+	return info, nil
+}
+
+// backupWriter writes to a buffer while it updates the checksum and remembers the first error.
+type backupWriter struct {
+	buffer   *bufio.Writer
+	checksum hash.Hash
+	err      error
+}
+
+func (b *backupWriter) write(data []byte) {
+	if b.err != nil {
+		return
+	}
+	if _, b.err = b.buffer.Write(data); b.err == nil {
+		b.checksum.Write(data)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ReadBackup ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// VerifyBackup reads the complete backup and verifies its format and checksum without modifying any store.
+func VerifyBackup(reader io.Reader) (info *BackupInfo, err error) {
+	return readBackup(reader, func(key kvstore.Key, value kvstore.Value) error { return nil })
+}
+
+// RestoreBackup writes the objects of the backup to the store, which needs to be empty. If the backup turns out to be
+// invalid, the objects that were already written are removed again.
+func RestoreBackup(store kvstore.KVStore, reader io.Reader) (info *BackupInfo, err error) {
+	if err = assertEmpty(store); err != nil {
+		return nil, errors.Errorf("failed to restore backup: %w", err)
+	}
+
+	writer := newBatchWriter(store, copyBatchSize)
+	if info, err = readBackup(reader, writer.Set); err == nil {
+		err = writer.Commit()
+	} else {
+		writer.Cancel()
+	}
+
+	if err != nil {
+		if clearErr := store.Clear(); clearErr != nil {
+			return nil, errors.Errorf("failed to remove partially restored backup (%v) after error: %w", clearErr, err)
+		}
+
+		return nil, errors.Errorf("failed to restore backup: %w", err)
+	}
+
+	return info, nil
+}
+
+// readBackup reads a backup and passes its objects to the consumer.
+func readBackup(reader io.Reader, consumer func(key kvstore.Key, value kvstore.Value) error) (info *BackupInfo, err error) {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, errors.Errorf("failed to decompress backup (%v): %w", err, ErrUnsupportedBackupFormat)
+	}
+	defer gzipReader.Close()
+
+	checksum, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to create backup checksum: %w", err)
+	}
+	backupReader := &backupReader{buffer: bufio.NewReader(gzipReader), checksum: checksum}
+
+	magic, err := backupReader.read(len(backupMagic))
+	if err != nil {
+		return nil, errors.Errorf("failed to read backup prefix: %w", err)
+	}
+	if !bytes.Equal(magic, backupMagic[:]) {
+		return nil, errors.Errorf("failed to read backup prefix: %w", ErrUnsupportedBackupFormat)
+	}
+
+	headerBytes, err := backupReader.read(2*marshalutil.Uint8Size + marshalutil.TimeSize)
+	if err != nil {
+		return nil, errors.Errorf("failed to read backup header: %w", err)
+	}
+	header := marshalutil.New(headerBytes)
+	info = &BackupInfo{Objects: make(map[byte]int)}
+	info.FormatVersion, _ = header.ReadUint8()
+	info.DatabaseVersion, _ = header.ReadUint8()
+	info.Time, _ = header.ReadTime()
+	if info.FormatVersion != BackupFormatVersion {
+		return nil, errors.Errorf("backup format version %d: %w", info.FormatVersion, ErrUnsupportedBackupFormat)
+	}
+
+	for {
+		entryType, readErr := backupReader.read(marshalutil.Uint8Size)
+		if readErr != nil {
+			return nil, errors.Errorf("failed to read backup entry: %w", readErr)
+		}
+		if entryType[0] == endBackupEntry {
+			break
+		}
+		if entryType[0] != objectBackupEntry {
+			return nil, errors.Errorf("unknown backup entry type %d: %w", entryType[0], ErrUnsupportedBackupFormat)
+		}
+
+		lengthBytes, readErr := backupReader.read(2 * marshalutil.Uint32Size)
+		if readErr != nil {
+			return nil, errors.Errorf("failed to read backup entry: %w", readErr)
+		}
+		lengths := marshalutil.New(lengthBytes)
+		keyLength, _ := lengths.ReadUint32()
+		valueLength, _ := lengths.ReadUint32()
+		if keyLength == 0 {
+			return nil, errors.Errorf("backup entry with empty key: %w", ErrUnsupportedBackupFormat)
+		}
+
+		key, readErr := backupReader.read(int(keyLength))
+		if readErr != nil {
+			return nil, errors.Errorf("failed to read backup entry key: %w", readErr)
+		}
+		value, readErr := backupReader.read(int(valueLength))
+		if readErr != nil {
+			return nil, errors.Errorf("failed to read backup entry value: %w", readErr)
+		}
+
+		if err = consumer(key, value); err != nil {
+			return nil, errors.Errorf("failed to process object with key %x: %w", key, err)
+		}
+		info.Objects[key[0]]++
+	}
+
+	var expectedChecksum [BackupChecksumLength]byte
+	copy(expectedChecksum[:], checksum.Sum(nil))
+	if _, err = io.ReadFull(backupReader.buffer, info.Checksum[:]); err != nil {
+		return nil, errors.Errorf("failed to read backup checksum: %w", io.ErrUnexpectedEOF)
+	}
+	if info.Checksum != expectedChecksum {
+		return nil, errors.Errorf("backup checksum %x does not match content %x: %w", info.Checksum, expectedChecksum, ErrBackupChecksumMismatch)
+	}
+
+	// reading until the end also verifies the integrity of the compressed stream
+	if trailingBytes, readErr := io.Copy(io.Discard, backupReader.buffer); readErr != nil || trailingBytes != 0 {
+		return nil, errors.Errorf("failed to read end of backup (%d trailing bytes, %v): %w", trailingBytes, readErr, ErrUnsupportedBackupFormat)
+	}
+
+	return info, nil
+}
+
+// backupReader reads from a buffer while it updates the checksum.
+type backupReader struct {
+	buffer   *bufio.Reader
+	checksum hash.Hash
+}
+
+func (b *backupReader) read(length int) (data []byte, err error) {
+	data = make([]byte, length)
+	if _, err = io.ReadFull(b.buffer, data); err != nil {
+		// the end of a backup is marked explicitly, so running out of data always means that it is truncated
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	b.checksum.Write(data)
+
+	return data, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
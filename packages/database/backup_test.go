@@ -0,0 +1,93 @@
+package database
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackup(t *testing.T) {
+	store := mapdb.NewMapDB()
+	require.NoError(t, NewMigrator(store, nil).SetVersion(38))
+	require.NoError(t, store.WithRealm([]byte{PrefixTangle}).Set([]byte("message"), []byte{1, 2, 3}))
+	require.NoError(t, store.WithRealm([]byte{PrefixMarkers}).Set([]byte("marker"), []byte{4}))
+	require.NoError(t, store.WithRealm([]byte{PrefixLedgerState}).Set([]byte("output"), []byte{}))
+	require.NoError(t, store.WithRealm([]byte{PrefixHealth}).Set([]byte("db_health"), []byte{}))
+
+	var backup bytes.Buffer
+	info, err := WriteBackup(store, &backup, []byte{PrefixHealth, 'd', 'b', '_', 'h'})
+	require.NoError(t, err)
+	assert.Equal(t, uint8(38), info.DatabaseVersion)
+	assert.Equal(t, map[byte]int{PrefixHealth: 1, PrefixTangle: 1, PrefixMarkers: 1, PrefixLedgerState: 1}, info.Objects)
+
+	verifiedInfo, err := VerifyBackup(bytes.NewReader(backup.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, info.Checksum, verifiedInfo.Checksum)
+	assert.Equal(t, info.Objects, verifiedInfo.Objects)
+	assert.True(t, info.Time.Equal(verifiedInfo.Time))
+
+	restoredStore := mapdb.NewMapDB()
+	_, err = RestoreBackup(restoredStore, bytes.NewReader(backup.Bytes()))
+	require.NoError(t, err)
+	version, _, err := NewMigrator(restoredStore, nil).Version()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(38), version)
+	value, err := restoredStore.WithRealm([]byte{PrefixTangle}).Get([]byte("message"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, value)
+	has, err := restoredStore.WithRealm([]byte{PrefixLedgerState}).Has([]byte("output"))
+	require.NoError(t, err)
+	assert.True(t, has)
+	has, err = restoredStore.WithRealm([]byte{PrefixHealth}).Has([]byte("db_health"))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// a backup can only be restored into an empty database
+	_, err = RestoreBackup(restoredStore, bytes.NewReader(backup.Bytes()))
+	assert.True(t, errors.Is(err, ErrDatabaseNotEmpty))
+}
+
+func TestBackup_Corrupted(t *testing.T) {
+	store := mapdb.NewMapDB()
+	require.NoError(t, store.WithRealm([]byte{PrefixTangle}).Set([]byte("message"), []byte{1, 2, 3}))
+
+	var backup bytes.Buffer
+	_, err := WriteBackup(store, &backup)
+	require.NoError(t, err)
+
+	// truncated backups are rejected
+	_, err = VerifyBackup(bytes.NewReader(backup.Bytes()[:backup.Len()-10]))
+	assert.Error(t, err)
+
+	// backups whose content does not match the checksum are rejected and nothing is restored
+	gzipReader, err := gzip.NewReader(bytes.NewReader(backup.Bytes()))
+	require.NoError(t, err)
+	content, err := io.ReadAll(gzipReader)
+	require.NoError(t, err)
+	content = bytes.Replace(content, []byte("message\x01\x02\x03"), []byte("message\x01\x02\x04"), 1)
+	var tamperedBackup bytes.Buffer
+	gzipWriter := gzip.NewWriter(&tamperedBackup)
+	_, err = gzipWriter.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+
+	restoredStore := mapdb.NewMapDB()
+	_, err = RestoreBackup(restoredStore, &tamperedBackup)
+	assert.True(t, errors.Is(err, ErrBackupChecksumMismatch))
+	empty := true
+	require.NoError(t, restoredStore.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		empty = false
+		return false
+	}))
+	assert.True(t, empty)
+
+	_, err = VerifyBackup(bytes.NewReader([]byte("not a backup")))
+	assert.True(t, errors.Is(err, ErrUnsupportedBackupFormat))
+}
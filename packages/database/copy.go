@@ -0,0 +1,124 @@
+package database
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+// copyBatchSize defines how many objects are written at once when objects are copied or restored to a store.
+const copyBatchSize = 10000
+
+// CopyStore copies all objects of the source to the target store, which needs to be empty (i.e. to convert a database
+// to a different Engine). If the copy fails, the objects that were already written are removed again.
+func CopyStore(source, target kvstore.KVStore) (copiedObjects int, err error) {
+	if err = assertEmpty(target); err != nil {
+		return 0, errors.Errorf("failed to copy store: %w", err)
+	}
+
+	writer := newBatchWriter(target, copyBatchSize)
+	if err = source.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		err = writer.Set(key, value)
+		return err == nil
+	}); err == nil {
+		err = writer.Commit()
+	}
+
+	if err != nil {
+		writer.Cancel()
+		if clearErr := target.Clear(); clearErr != nil {
+			return 0, errors.Errorf("failed to remove partially copied objects (%v) after error: %w", clearErr, err)
+		}
+
+		return 0, errors.Errorf("failed to copy store: %w", err)
+	}
+
+	return writer.WrittenObjects(), nil
+}
+
+// assertEmpty returns ErrDatabaseNotEmpty if the store contains any objects.
+func assertEmpty(store kvstore.KVStore) (err error) {
+	empty := true
+	if err = store.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		empty = false
+		return false
+	}); err != nil {
+		return errors.Errorf("failed to check database: %w", err)
+	}
+	if !empty {
+		return ErrDatabaseNotEmpty
+	}
+
+	return nil
+}
+
+// region batchWriter //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// batchWriter writes objects to a store in batches of a fixed size.
+type batchWriter struct {
+	store          kvstore.KVStore
+	batch          kvstore.BatchedMutations
+	batchSize      int
+	maxBatchSize   int
+	writtenObjects int
+}
+
+// newBatchWriter creates a batchWriter for the given store that commits its batches once they contain maxBatchSize
+// mutations.
+func newBatchWriter(store kvstore.KVStore, maxBatchSize int) *batchWriter {
+	return &batchWriter{
+		store:        store,
+		batch:        store.Batched(),
+		maxBatchSize: maxBatchSize,
+	}
+}
+
+// Set adds the object to the current batch and commits the batch once it is full.
+func (b *batchWriter) Set(key kvstore.Key, value kvstore.Value) (err error) {
+	if err = b.batch.Set(key, value); err != nil {
+		return err
+	}
+
+	return b.mutated()
+}
+
+// Delete adds the deletion of the object to the current batch and commits the batch once it is full.
+func (b *batchWriter) Delete(key kvstore.Key) (err error) {
+	if err = b.batch.Delete(key); err != nil {
+		return err
+	}
+
+	return b.mutated()
+}
+
+// mutated counts a mutation of the current batch and commits the batch once it is full.
+func (b *batchWriter) mutated() (err error) {
+	if b.batchSize++; b.batchSize < b.maxBatchSize {
+		return nil
+	}
+
+	return b.Commit()
+}
+
+// Commit writes the current batch to the store.
+func (b *batchWriter) Commit() (err error) {
+	if err = b.batch.Commit(); err != nil {
+		return err
+	}
+	b.writtenObjects += b.batchSize
+	b.batch, b.batchSize = b.store.Batched(), 0
+
+	return nil
+}
+
+// Cancel discards the objects of the current batch.
+func (b *batchWriter) Cancel() {
+	b.batch.Cancel()
+	b.batchSize = 0
+}
+
+// WrittenObjects returns the number of mutations that were committed to the store.
+func (b *batchWriter) WrittenObjects() int {
+	return b.writtenObjects
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
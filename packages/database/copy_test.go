@@ -0,0 +1,32 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyStore(t *testing.T) {
+	source := mapdb.NewMapDB()
+	for i := 0; i < copyBatchSize+1; i++ {
+		require.NoError(t, source.WithRealm([]byte{PrefixTangle}).Set([]byte{byte(i >> 8), byte(i)}, []byte{byte(i)}))
+	}
+	require.NoError(t, source.WithRealm([]byte{PrefixLedgerState}).Set([]byte("output"), []byte{7}))
+
+	db, err := NewPebbleDB(t.TempDir())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, db.Close()) }()
+	target := db.NewStore()
+
+	copiedObjects, err := CopyStore(source, target)
+	require.NoError(t, err)
+	assert.Equal(t, copyBatchSize+2, copiedObjects)
+	assert.Equal(t, iterate(t, source, nil), iterate(t, target, nil))
+
+	// objects can only be copied to an empty store
+	_, err = CopyStore(source, target)
+	assert.True(t, errors.Is(err, ErrDatabaseNotEmpty))
+}
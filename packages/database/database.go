@@ -1,6 +1,12 @@
 package database
 
 import (
+	"os"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/vfs"
 	"github.com/iotaledger/hive.go/kvstore"
 )
 
@@ -16,3 +22,64 @@ type DB interface {
 	// GC runs the garbage collection to clean deleted database items.
 	GC() error
 }
+
+// region Engine ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// EngineRocksDB is the Engine of a DB that is backed by RocksDB (requires cgo and the rocksdb build tag).
+	EngineRocksDB Engine = "rocksdb"
+
+	// EnginePebble is the Engine of a DB that is backed by Pebble (pure Go).
+	EnginePebble Engine = "pebble"
+)
+
+// ErrUnknownEngine is returned when a DB is opened with an Engine that is not supported.
+var ErrUnknownEngine = errors.New("unknown database engine")
+
+// Engine represents the storage engine that backs a persisting DB.
+type Engine string
+
+// NewDBWithEngine returns a new persisting DB object that is backed by the given Engine.
+func NewDBWithEngine(engine Engine, dirname string) (DB, error) {
+	switch engine {
+	case EngineRocksDB:
+		return NewDB(dirname)
+	case EnginePebble:
+		return NewPebbleDB(dirname)
+	default:
+		return nil, errors.Errorf("failed to open database with engine '%s': %w", engine, ErrUnknownEngine)
+	}
+}
+
+// DetectEngine returns the Engine that last wrote to the database in the given directory. It returns false if the
+// directory does not contain a database.
+func DetectEngine(dirname string) (engine Engine, exists bool, err error) {
+	if _, err = os.Stat(dirname); err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, errors.Errorf("failed to access database directory: %w", err)
+	}
+
+	// both engines persist their options in an OPTIONS file that contains the version of the engine
+	version, err := pebble.GetVersion(dirname, vfs.Default)
+	if err != nil {
+		return "", false, errors.Errorf("failed to read database options: %w", err)
+	}
+
+	switch {
+	case version == "":
+		return "", false, nil
+	case strings.HasPrefix(version, string(EngineRocksDB)):
+		return EngineRocksDB, true, nil
+	default:
+		return EnginePebble, true, nil
+	}
+}
+
+// String returns a human readable version of the Engine.
+func (e Engine) String() string {
+	return string(e)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDB(t *testing.T) {
+	for name, newDB := range map[string]func(t *testing.T) DB{
+		"memdb": func(t *testing.T) DB {
+			db, err := NewMemDB()
+			require.NoError(t, err)
+			return db
+		},
+		"pebble": func(t *testing.T) DB {
+			db, err := NewPebbleDB(t.TempDir())
+			require.NoError(t, err)
+			return db
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			db := newDB(t)
+			defer func() { assert.NoError(t, db.Close()) }()
+
+			testStore(t, db.NewStore())
+		})
+	}
+}
+
+func TestPebbleDB_Reopen(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "db")
+
+	engine, exists, err := DetectEngine(dir)
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	db, err := NewDBWithEngine(EnginePebble, dir)
+	require.NoError(t, err)
+	require.NoError(t, db.NewStore().WithRealm([]byte{PrefixTangle}).Set([]byte("message"), []byte{1, 2, 3}))
+	require.NoError(t, db.Close())
+
+	engine, exists, err = DetectEngine(dir)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, EnginePebble, engine)
+
+	db, err = NewDBWithEngine(EnginePebble, dir)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, db.Close()) }()
+	value, err := db.NewStore().WithRealm([]byte{PrefixTangle}).Get([]byte("message"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, value)
+
+	_, err = NewDBWithEngine("leveldb", dir)
+	assert.True(t, errors.Is(err, ErrUnknownEngine))
+}
+
+// testStore checks the behavior of a KVStore that the storage layer relies on.
+func testStore(t *testing.T, store kvstore.KVStore) {
+	tangleStore := store.WithRealm([]byte{PrefixTangle})
+	markersStore := store.WithRealm([]byte{PrefixMarkers})
+
+	// get, set, has and delete
+	_, err := tangleStore.Get([]byte("a"))
+	assert.True(t, errors.Is(err, kvstore.ErrKeyNotFound))
+	require.NoError(t, tangleStore.Set([]byte("a"), []byte{1}))
+	require.NoError(t, tangleStore.Set([]byte("a"), []byte{2}))
+	require.NoError(t, markersStore.Set([]byte("a"), []byte{3}))
+	value, err := tangleStore.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{2}, value)
+	has, err := markersStore.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.True(t, has)
+	require.NoError(t, markersStore.Delete([]byte("a")))
+	has, err = markersStore.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// batched mutations are only applied on commit
+	batch := tangleStore.Batched()
+	require.NoError(t, batch.Set([]byte("ab"), []byte{4}))
+	require.NoError(t, batch.Set([]byte("b"), []byte{5}))
+	require.NoError(t, batch.Delete([]byte("a")))
+	has, err = tangleStore.Has([]byte("ab"))
+	require.NoError(t, err)
+	assert.False(t, has)
+	require.NoError(t, batch.Commit())
+	has, err = tangleStore.Has([]byte("a"))
+	require.NoError(t, err)
+	assert.False(t, has)
+	require.NoError(t, markersStore.Set([]byte("a"), []byte{6}))
+
+	// iteration is limited to the realm and the prefix
+	assert.Equal(t, map[string][]byte{"ab": {4}}, iterate(t, tangleStore, []byte("a")))
+	assert.Equal(t, map[string][]byte{"ab": {4}, "b": {5}}, iterate(t, tangleStore, kvstore.EmptyPrefix))
+	assert.Equal(t, []string{"a"}, iterateKeys(t, markersStore, kvstore.EmptyPrefix))
+	assert.Equal(t, []string{string([]byte{PrefixTangle}) + "ab", string([]byte{PrefixTangle}) + "b", string([]byte{PrefixMarkers}) + "a"}, iterateKeys(t, store, kvstore.EmptyPrefix))
+
+	// deletion of prefixes is limited to the realm
+	require.NoError(t, tangleStore.DeletePrefix([]byte("a")))
+	assert.Equal(t, []string{"b"}, iterateKeys(t, tangleStore, kvstore.EmptyPrefix))
+	require.NoError(t, tangleStore.Clear())
+	assert.Empty(t, iterateKeys(t, tangleStore, kvstore.EmptyPrefix))
+	assert.Equal(t, []string{"a"}, iterateKeys(t, markersStore, kvstore.EmptyPrefix))
+	require.NoError(t, store.Clear())
+	assert.Empty(t, iterateKeys(t, store, kvstore.EmptyPrefix))
+}
+
+func iterate(t *testing.T, store kvstore.KVStore, prefix kvstore.KeyPrefix) (objects map[string][]byte) {
+	objects = make(map[string][]byte)
+	require.NoError(t, store.Iterate(prefix, func(key kvstore.Key, value kvstore.Value) bool {
+		objects[string(key)] = value
+		return true
+	}))
+
+	return objects
+}
+
+func iterateKeys(t *testing.T, store kvstore.KVStore, prefix kvstore.KeyPrefix) (keys []string) {
+	require.NoError(t, store.IterateKeys(prefix, func(key kvstore.Key) bool {
+		keys = append(keys, string(key))
+		return true
+	}))
+	sort.Strings(keys)
+
+	return keys
+}
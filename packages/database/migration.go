@@ -0,0 +1,515 @@
+package database
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+var (
+	// ErrMigrationMissing is returned if the database can not be migrated because a migration step is missing.
+	ErrMigrationMissing = errors.New("migration missing")
+
+	// ErrMigrationInterrupted is returned if a previous migration was interrupted and no backup exists to recover from.
+	ErrMigrationInterrupted = errors.New("migration interrupted")
+
+	// ErrNoBackup is returned if a rollback was requested but no backup exists.
+	ErrNoBackup = errors.New("no backup")
+)
+
+// region Migration ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Migration represents a step that rewrites the stored objects of a single storage prefix from Version to Version+1.
+type Migration struct {
+	// Version is the database version that the Migration migrates from.
+	Version uint8
+
+	// Prefix is the storage prefix (i.e. PrefixTangle) whose objects are rewritten.
+	Prefix byte
+
+	// Description is a human readable description of the changes.
+	Description string
+
+	// Migrate rewrites the objects in the given store (which is scoped to the Prefix) and reports its progress.
+	Migrate func(store kvstore.KVStore, progress ProgressFunc) error
+}
+
+// ProgressFunc is the type of the function that is used by Migrations to report their progress.
+type ProgressFunc func(migratedObjects, totalObjects int)
+
+var (
+	registeredMigrations      []*Migration
+	registeredMigrationsMutex sync.RWMutex
+)
+
+// RegisterMigration registers a Migration that is executed when a database with an older version is opened. The
+// packages that own a storage prefix register their migrations in their init function.
+func RegisterMigration(migration *Migration) {
+	registeredMigrationsMutex.Lock()
+	defer registeredMigrationsMutex.Unlock()
+
+	registeredMigrations = append(registeredMigrations, migration)
+}
+
+// RegisteredMigrations returns all registered Migrations.
+func RegisteredMigrations() []*Migration {
+	registeredMigrationsMutex.RLock()
+	defer registeredMigrationsMutex.RUnlock()
+
+	return append([]*Migration{}, registeredMigrations...)
+}
+
+// rewriteBatchSize defines how many objects are written at once by RewriteObjects.
+const rewriteBatchSize = 10000
+
+// RewriteObjects is a helper for Migrations that rewrites all objects of the store whose keys start with the given
+// prefix. The rewrite function returns the new key and value of the object, where a nil key deletes the object. The
+// rewritten objects are committed in batches of rewriteBatchSize objects while the store is iterated, which relies on
+// the iterators of the storage engines operating on a snapshot that does not contain the rewritten objects.
+func RewriteObjects(store kvstore.KVStore, keyPrefix kvstore.KeyPrefix, rewrite func(key kvstore.Key, value kvstore.Value) (newKey kvstore.Key, newValue kvstore.Value, err error), progress ProgressFunc) (err error) {
+	totalObjects := 0
+	if err = store.IterateKeys(keyPrefix, func(kvstore.Key) bool {
+		totalObjects++
+		return true
+	}); err != nil {
+		return errors.Errorf("failed to count objects: %w", err)
+	}
+
+	writer := newBatchWriter(store, rewriteBatchSize)
+	rewrittenObjects := 0
+	if iterateErr := store.Iterate(keyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		key = append([]byte{}, key...)
+		newKey, newValue, rewriteErr := rewrite(key, append([]byte{}, value...))
+		if rewriteErr != nil {
+			err = errors.Errorf("failed to rewrite object with key %x: %w", key, rewriteErr)
+			return false
+		}
+
+		if newKey == nil || !bytes.Equal(key, newKey) {
+			if err = writer.Delete(key); err != nil {
+				err = errors.Errorf("failed to delete object with key %x: %w", key, err)
+				return false
+			}
+		}
+		if newKey != nil {
+			if err = writer.Set(newKey, newValue); err != nil {
+				err = errors.Errorf("failed to write object with key %x: %w", newKey, err)
+				return false
+			}
+		}
+
+		if rewrittenObjects++; progress != nil && rewrittenObjects%rewriteBatchSize == 0 {
+			progress(rewrittenObjects, totalObjects)
+		}
+
+		return true
+	}); iterateErr != nil {
+		writer.Cancel()
+		return errors.Errorf("failed to iterate objects: %w", iterateErr)
+	}
+	if err != nil {
+		writer.Cancel()
+		return err
+	}
+
+	if err = writer.Commit(); err != nil {
+		return errors.Errorf("failed to commit rewritten objects: %w", err)
+	}
+	if progress != nil && rewrittenObjects%rewriteBatchSize != 0 {
+		progress(rewrittenObjects, totalObjects)
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Migrator /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// versionKey is the key under which the version of the database is stored in the PrefixHealth realm.
+	versionKey = []byte{0}
+
+	// migrationInProgressKey is the key that marks a migration as running in the PrefixHealth realm.
+	migrationInProgressKey = []byte("db_migration_in_progress")
+
+	// migrationBackupKey is the key under which the version and the prefixes of the backup are stored in the
+	// PrefixHealth realm.
+	migrationBackupKey = []byte("db_migration_backup")
+)
+
+// Migrator migrates the database to newer versions by executing the registered Migrations in order.
+type Migrator struct {
+	// Events contains the events that are triggered while the Migrations are executed.
+	Events *MigratorEvents
+
+	store         kvstore.KVStore
+	metadataStore kvstore.KVStore
+	migrations    []*Migration
+	options       *MigratorOptions
+}
+
+// NewMigrator creates a new Migrator for the given store that executes the given Migrations.
+func NewMigrator(store kvstore.KVStore, migrations []*Migration, options ...MigratorOption) (migrator *Migrator) {
+	migrator = &Migrator{
+		Events: &MigratorEvents{
+			MigrationStarted:  events.NewEvent(migrationEventCaller),
+			MigrationProgress: events.NewEvent(migrationEventCaller),
+			MigrationFinished: events.NewEvent(migrationEventCaller),
+		},
+		store:         store,
+		metadataStore: store.WithRealm([]byte{PrefixHealth}),
+		migrations:    append([]*Migration{}, migrations...),
+		options:       &MigratorOptions{},
+	}
+	for _, option := range options {
+		option(migrator.options)
+	}
+
+	sort.SliceStable(migrator.migrations, func(i, j int) bool {
+		return migrator.migrations[i].Version < migrator.migrations[j].Version
+	})
+
+	return migrator
+}
+
+// Version returns the version of the database. It returns false if the database does not have a version yet.
+func (m *Migrator) Version() (version uint8, exists bool, err error) {
+	versionBytes, err := m.metadataStore.Get(versionKey)
+	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, errors.Errorf("failed to read database version: %w", err)
+	}
+	if len(versionBytes) == 0 {
+		return 0, false, errors.New("no database version was persisted")
+	}
+
+	return versionBytes[0], true, nil
+}
+
+// SetVersion sets the version of the database.
+func (m *Migrator) SetVersion(version uint8) error {
+	if err := m.metadataStore.Set(versionKey, []byte{version}); err != nil {
+		return errors.Errorf("failed to write database version: %w", err)
+	}
+
+	return nil
+}
+
+// Plan returns the Migrations that migrate the database from the given version to the target version.
+func (m *Migrator) Plan(fromVersion, targetVersion uint8) (plan []*Migration, err error) {
+	for version := fromVersion; version < targetVersion; version++ {
+		stepFound := false
+		for _, migration := range m.migrations {
+			if migration.Version == version {
+				plan = append(plan, migration)
+				stepFound = true
+			}
+		}
+		if !stepFound {
+			return nil, errors.Errorf("no migration from version %d to %d: %w", version, version+1, ErrMigrationMissing)
+		}
+	}
+
+	return plan, nil
+}
+
+// Migrate migrates the database from its current version to the target version. If backups are enabled, the affected
+// prefixes are copied before the first Migration is executed and are restored if a Migration fails. The backup is kept
+// after a successful migration, so the previous version can be restored with Rollback.
+func (m *Migrator) Migrate(targetVersion uint8) (err error) {
+	if err = m.recoverInterruptedMigration(); err != nil {
+		return err
+	}
+
+	version, _, err := m.Version()
+	if err != nil {
+		return err
+	}
+	plan, err := m.Plan(version, targetVersion)
+	if err != nil {
+		return err
+	}
+	if len(plan) == 0 {
+		return nil
+	}
+
+	if m.options.Backup {
+		if err = m.createBackup(version, affectedPrefixes(plan)); err != nil {
+			return errors.Errorf("failed to create backup: %w", err)
+		}
+	}
+
+	if err = m.metadataStore.Set(migrationInProgressKey, []byte{version}); err != nil {
+		return errors.Errorf("failed to mark migration as in progress: %w", err)
+	}
+
+	for _, migration := range plan {
+		if err = m.execute(migration, m.store.WithRealm([]byte{migration.Prefix})); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = m.finishMigration(targetVersion)
+	}
+	if err != nil {
+		if !m.options.Backup {
+			return errors.Errorf("%v (the database is left in an inconsistent state): %w", err, ErrMigrationInterrupted)
+		}
+		if rollbackErr := m.Rollback(); rollbackErr != nil {
+			return errors.Errorf("failed to roll back migration (%v) after error: %w", rollbackErr, err)
+		}
+
+		return errors.Errorf("migration was rolled back to version %d after error: %w", version, err)
+	}
+
+	return nil
+}
+
+// DryRun executes the Migrations from the current version to the target version on an in-memory copy of the affected
+// prefixes, so the database remains unchanged. The affected prefixes need to fit into memory.
+func (m *Migrator) DryRun(targetVersion uint8) (plan []*Migration, err error) {
+	version, _, err := m.Version()
+	if err != nil {
+		return nil, err
+	}
+	if plan, err = m.Plan(version, targetVersion); err != nil {
+		return nil, err
+	}
+
+	copiedStore := mapdb.NewMapDB()
+	for _, prefix := range affectedPrefixes(plan) {
+		if err = copyRealm(m.store.WithRealm([]byte{prefix}), copiedStore.WithRealm([]byte{prefix})); err != nil {
+			return nil, errors.Errorf("failed to copy prefix %d: %w", prefix, err)
+		}
+	}
+
+	for _, migration := range plan {
+		if err = m.execute(migration, copiedStore.WithRealm([]byte{migration.Prefix})); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, nil
+}
+
+// BackupVersion returns the version of the database that is stored in the backup.
+func (m *Migrator) BackupVersion() (version uint8, exists bool, err error) {
+	backupBytes, err := m.metadataStore.Get(migrationBackupKey)
+	if errors.Is(err, kvstore.ErrKeyNotFound) || (err == nil && len(backupBytes) == 0) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, errors.Errorf("failed to read backup: %w", err)
+	}
+
+	return backupBytes[0], true, nil
+}
+
+// Rollback restores the prefixes and the version of the database from the backup that was created by the last
+// migration and deletes the backup.
+func (m *Migrator) Rollback() (err error) {
+	backupBytes, err := m.metadataStore.Get(migrationBackupKey)
+	if errors.Is(err, kvstore.ErrKeyNotFound) || (err == nil && len(backupBytes) == 0) {
+		return errors.Errorf("failed to roll back database: %w", ErrNoBackup)
+	}
+	if err != nil {
+		return errors.Errorf("failed to read backup: %w", err)
+	}
+
+	version, prefixes := backupBytes[0], backupBytes[1:]
+	for _, prefix := range prefixes {
+		if err = m.store.WithRealm([]byte{prefix}).Clear(); err != nil {
+			return errors.Errorf("failed to clear prefix %d: %w", prefix, err)
+		}
+		if err = copyRealm(m.backupStore(prefix), m.store.WithRealm([]byte{prefix})); err != nil {
+			return errors.Errorf("failed to restore prefix %d: %w", prefix, err)
+		}
+	}
+	if err = m.finishMigration(version); err != nil {
+		return err
+	}
+
+	return m.deleteBackup()
+}
+
+// finishMigration atomically sets the version of the database and removes the mark of the running migration.
+func (m *Migrator) finishMigration(version uint8) (err error) {
+	batch := m.metadataStore.Batched()
+	if err = batch.Set(versionKey, []byte{version}); err != nil {
+		batch.Cancel()
+		return errors.Errorf("failed to write database version: %w", err)
+	}
+	if err = batch.Delete(migrationInProgressKey); err != nil {
+		batch.Cancel()
+		return errors.Errorf("failed to mark migration as done: %w", err)
+	}
+	if err = batch.Commit(); err != nil {
+		return errors.Errorf("failed to finish migration: %w", err)
+	}
+
+	return nil
+}
+
+// execute executes a single Migration on the given store and triggers the corresponding events.
+func (m *Migrator) execute(migration *Migration, store kvstore.KVStore) (err error) {
+	startTime := time.Now()
+	m.Events.MigrationStarted.Trigger(&MigrationEvent{Migration: migration})
+
+	if err = migration.Migrate(store, func(migratedObjects, totalObjects int) {
+		m.Events.MigrationProgress.Trigger(&MigrationEvent{
+			Migration:       migration,
+			MigratedObjects: migratedObjects,
+			TotalObjects:    totalObjects,
+			Duration:        time.Since(startTime),
+		})
+	}); err != nil {
+		return errors.Errorf("failed to migrate prefix %d from version %d (%s): %w", migration.Prefix, migration.Version, migration.Description, err)
+	}
+
+	m.Events.MigrationFinished.Trigger(&MigrationEvent{Migration: migration, Duration: time.Since(startTime)})
+
+	return nil
+}
+
+// recoverInterruptedMigration restores the backup if a previous migration was interrupted (i.e. by a crash).
+func (m *Migrator) recoverInterruptedMigration() error {
+	interrupted, err := m.metadataStore.Has(migrationInProgressKey)
+	if err != nil {
+		return errors.Errorf("failed to check for interrupted migration: %w", err)
+	}
+	if !interrupted {
+		return nil
+	}
+
+	if _, backupExists, backupErr := m.BackupVersion(); backupErr != nil || !backupExists {
+		return errors.Errorf("a previous migration was interrupted and no backup exists: %w", ErrMigrationInterrupted)
+	}
+
+	return m.Rollback()
+}
+
+// createBackup copies the given prefixes to the backup realm and replaces any older backup.
+func (m *Migrator) createBackup(version uint8, prefixes []byte) (err error) {
+	if err = m.deleteBackup(); err != nil {
+		return err
+	}
+
+	for _, prefix := range prefixes {
+		if err = copyRealm(m.store.WithRealm([]byte{prefix}), m.backupStore(prefix)); err != nil {
+			return errors.Errorf("failed to back up prefix %d: %w", prefix, err)
+		}
+	}
+
+	if err = m.metadataStore.Set(migrationBackupKey, append([]byte{version}, prefixes...)); err != nil {
+		return errors.Errorf("failed to write backup metadata: %w", err)
+	}
+
+	return nil
+}
+
+// deleteBackup deletes the backup of the last migration (if it exists).
+func (m *Migrator) deleteBackup() (err error) {
+	if err = m.store.WithRealm([]byte{PrefixMigrationBackup}).Clear(); err != nil {
+		return errors.Errorf("failed to delete backup: %w", err)
+	}
+	if err = m.metadataStore.Delete(migrationBackupKey); err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		return errors.Errorf("failed to delete backup metadata: %w", err)
+	}
+
+	return nil
+}
+
+// backupStore returns the realm that contains the backup of the given prefix.
+func (m *Migrator) backupStore(prefix byte) kvstore.KVStore {
+	return m.store.WithRealm([]byte{PrefixMigrationBackup, prefix})
+}
+
+// affectedPrefixes returns the distinct prefixes that are rewritten by the given Migrations.
+func affectedPrefixes(migrations []*Migration) (prefixes []byte) {
+	seenPrefixes := make(map[byte]bool)
+	for _, migration := range migrations {
+		if !seenPrefixes[migration.Prefix] {
+			seenPrefixes[migration.Prefix] = true
+			prefixes = append(prefixes, migration.Prefix)
+		}
+	}
+
+	return prefixes
+}
+
+// copyRealm copies all objects from the source to the target store in batches of copyBatchSize objects.
+func copyRealm(source, target kvstore.KVStore) (err error) {
+	writer := newBatchWriter(target, copyBatchSize)
+	if iterateErr := source.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		err = writer.Set(append([]byte{}, key...), append([]byte{}, value...))
+		return err == nil
+	}); iterateErr != nil {
+		writer.Cancel()
+		return iterateErr
+	}
+	if err != nil {
+		writer.Cancel()
+		return err
+	}
+
+	return writer.Commit()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MigratorOptions //////////////////////////////////////////////////////////////////////////////////////////////
+
+// MigratorOptions defines the options of a Migrator.
+type MigratorOptions struct {
+	// Backup defines whether the affected prefixes are backed up before they are migrated.
+	Backup bool
+}
+
+// MigratorOption is a function which sets the given option.
+type MigratorOption func(*MigratorOptions)
+
+// WithBackup enables or disables the backup of the affected prefixes before they are migrated.
+func WithBackup(backup bool) MigratorOption {
+	return func(options *MigratorOptions) {
+		options.Backup = backup
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MigratorEvents ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// MigratorEvents represents events happening while the Migrator executes Migrations.
+type MigratorEvents struct {
+	// MigrationStarted is triggered before a Migration is executed.
+	MigrationStarted *events.Event
+
+	// MigrationProgress is triggered when a Migration reports its progress.
+	MigrationProgress *events.Event
+
+	// MigrationFinished is triggered after a Migration was executed successfully.
+	MigrationFinished *events.Event
+}
+
+// MigrationEvent contains information about the Migration that is being executed.
+type MigrationEvent struct {
+	Migration       *Migration
+	MigratedObjects int
+	TotalObjects    int
+	Duration        time.Duration
+}
+
+func migrationEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*MigrationEvent))(params[0].(*MigrationEvent))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,194 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendByteMigration returns a Migration that appends the given byte to the values of all objects of the prefix.
+func appendByteMigration(version uint8, prefix byte, suffix byte) *Migration {
+	return &Migration{
+		Version:     version,
+		Prefix:      prefix,
+		Description: "append byte",
+		Migrate: func(store kvstore.KVStore, progress ProgressFunc) error {
+			return RewriteObjects(store, kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) (kvstore.Key, kvstore.Value, error) {
+				return key, append(value, suffix), nil
+			}, progress)
+		},
+	}
+}
+
+func newMigrationTestStore(t *testing.T, version uint8) kvstore.KVStore {
+	store := mapdb.NewMapDB()
+	require.NoError(t, store.WithRealm([]byte{PrefixTangle}).Set([]byte("message"), []byte{1}))
+	require.NoError(t, store.WithRealm([]byte{PrefixLedgerState}).Set([]byte("output"), []byte{2}))
+	require.NoError(t, NewMigrator(store, nil).SetVersion(version))
+
+	return store
+}
+
+func storedValue(t *testing.T, store kvstore.KVStore, prefix byte, key string) []byte {
+	result, err := store.WithRealm([]byte{prefix}).Get([]byte(key))
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	store := newMigrationTestStore(t, 1)
+	migrator := NewMigrator(store, []*Migration{
+		appendByteMigration(2, PrefixLedgerState, 4),
+		appendByteMigration(1, PrefixTangle, 3),
+		appendByteMigration(1, PrefixLedgerState, 3),
+	})
+
+	var startedMigrations []*Migration
+	migrator.Events.MigrationStarted.Attach(events.NewClosure(func(event *MigrationEvent) {
+		startedMigrations = append(startedMigrations, event.Migration)
+	}))
+
+	require.NoError(t, migrator.Migrate(3))
+	version, exists, err := migrator.Version()
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, uint8(3), version)
+	assert.Equal(t, []byte{1, 3}, storedValue(t, store, PrefixTangle, "message"))
+	assert.Equal(t, []byte{2, 3, 4}, storedValue(t, store, PrefixLedgerState, "output"))
+
+	require.Len(t, startedMigrations, 3)
+	assert.Equal(t, uint8(1), startedMigrations[0].Version)
+	assert.Equal(t, uint8(1), startedMigrations[1].Version)
+	assert.Equal(t, uint8(2), startedMigrations[2].Version)
+
+	// migrating to the current version is a no-op
+	require.NoError(t, migrator.Migrate(3))
+
+	// missing steps are reported
+	assert.True(t, errors.Is(migrator.Migrate(4), ErrMigrationMissing))
+}
+
+func TestMigrator_DryRun(t *testing.T) {
+	store := newMigrationTestStore(t, 1)
+	migrator := NewMigrator(store, []*Migration{
+		appendByteMigration(1, PrefixTangle, 3),
+		appendByteMigration(2, PrefixTangle, 4),
+	})
+
+	plan, err := migrator.DryRun(3)
+	require.NoError(t, err)
+	assert.Len(t, plan, 2)
+
+	version, _, err := migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), version)
+	assert.Equal(t, []byte{1}, storedValue(t, store, PrefixTangle, "message"))
+}
+
+func TestMigrator_Rollback(t *testing.T) {
+	store := newMigrationTestStore(t, 1)
+
+	failingMigration := &Migration{
+		Version: 2,
+		Prefix:  PrefixTangle,
+		Migrate: func(store kvstore.KVStore, progress ProgressFunc) error {
+			return errors.New("broken object")
+		},
+	}
+
+	// a failing migration restores the backup
+	migrator := NewMigrator(store, []*Migration{appendByteMigration(1, PrefixTangle, 3), failingMigration}, WithBackup(true))
+	assert.Error(t, migrator.Migrate(3))
+	version, _, err := migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), version)
+	assert.Equal(t, []byte{1}, storedValue(t, store, PrefixTangle, "message"))
+	_, backupExists, err := migrator.BackupVersion()
+	require.NoError(t, err)
+	assert.False(t, backupExists)
+
+	// a successful migration keeps the backup until it is rolled back
+	migrator = NewMigrator(store, []*Migration{appendByteMigration(1, PrefixTangle, 3)}, WithBackup(true))
+	require.NoError(t, migrator.Migrate(2))
+	assert.Equal(t, []byte{1, 3}, storedValue(t, store, PrefixTangle, "message"))
+	backupVersion, backupExists, err := migrator.BackupVersion()
+	require.NoError(t, err)
+	assert.True(t, backupExists)
+	assert.Equal(t, uint8(1), backupVersion)
+
+	require.NoError(t, migrator.Rollback())
+	version, _, err = migrator.Version()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), version)
+	assert.Equal(t, []byte{1}, storedValue(t, store, PrefixTangle, "message"))
+	assert.True(t, errors.Is(migrator.Rollback(), ErrNoBackup))
+}
+
+func TestMigrator_Interrupted(t *testing.T) {
+	store := newMigrationTestStore(t, 1)
+
+	failingMigration := &Migration{
+		Version: 1,
+		Prefix:  PrefixTangle,
+		Migrate: func(store kvstore.KVStore, progress ProgressFunc) error {
+			return errors.New("broken object")
+		},
+	}
+
+	// without a backup, a failed migration leaves the database unusable
+	migrator := NewMigrator(store, []*Migration{failingMigration})
+	assert.True(t, errors.Is(migrator.Migrate(2), ErrMigrationInterrupted))
+	assert.True(t, errors.Is(NewMigrator(store, []*Migration{appendByteMigration(1, PrefixTangle, 3)}).Migrate(2), ErrMigrationInterrupted))
+}
+
+func TestRewriteObjects(t *testing.T) {
+	store := mapdb.NewMapDB()
+	require.NoError(t, store.Set([]byte("a1"), []byte{1}))
+	require.NoError(t, store.Set([]byte("a2"), []byte{2}))
+	require.NoError(t, store.Set([]byte("b1"), []byte{3}))
+
+	require.NoError(t, RewriteObjects(store, []byte("a"), func(key kvstore.Key, value kvstore.Value) (kvstore.Key, kvstore.Value, error) {
+		if value[0] == 2 {
+			return nil, nil, nil
+		}
+
+		return append([]byte("c"), key[1:]...), value, nil
+	}, nil))
+
+	for key, exists := range map[string]bool{"a1": false, "a2": false, "b1": true, "c1": true} {
+		has, err := store.Has([]byte(key))
+		require.NoError(t, err)
+		assert.Equal(t, exists, has, key)
+	}
+}
+
+func TestRewriteObjects_Batches(t *testing.T) {
+	store := mapdb.NewMapDB()
+	objectCount := rewriteBatchSize + 1
+	for i := 0; i < objectCount; i++ {
+		require.NoError(t, store.Set([]byte{byte(i >> 16), byte(i >> 8), byte(i)}, []byte{1}))
+	}
+
+	var reportedProgress []int
+	require.NoError(t, RewriteObjects(store, kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) (kvstore.Key, kvstore.Value, error) {
+		return key, append(value, 2), nil
+	}, func(migratedObjects, totalObjects int) {
+		assert.Equal(t, objectCount, totalObjects)
+		reportedProgress = append(reportedProgress, migratedObjects)
+	}))
+	assert.Equal(t, []int{rewriteBatchSize, objectCount}, reportedProgress)
+
+	rewrittenObjects := 0
+	require.NoError(t, store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		assert.Equal(t, []byte{1, 2}, value)
+		rewrittenObjects++
+		return true
+	}))
+	assert.Equal(t, objectCount, rewrittenObjects)
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"runtime"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/iotaledger/hive.go/kvstore"
+	pebblestore "github.com/iotaledger/hive.go/kvstore/pebble"
+)
+
+type pebbleDB struct {
+	*pebble.DB
+}
+
+// NewPebbleDB returns a new persisting DB object that is backed by Pebble, a pure Go key-value store.
+func NewPebbleDB(dirname string) (DB, error) {
+	db, err := pebblestore.CreateDB(dirname)
+	return &pebbleDB{DB: db}, err
+}
+
+func (db *pebbleDB) NewStore() kvstore.KVStore {
+	return pebblestore.New(db.DB)
+}
+
+// Close closes a DB. It flushes the memtables, so all the pending updates make their way to disk.
+func (db *pebbleDB) Close() error {
+	if err := db.DB.Flush(); err != nil {
+		return err
+	}
+	return db.DB.Close()
+}
+
+func (db *pebbleDB) RequiresGC() bool {
+	return true
+}
+
+func (db *pebbleDB) GC() error {
+	// trigger the go garbage collector to release the used memory
+	runtime.GC()
+	return nil
+}
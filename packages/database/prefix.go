@@ -24,4 +24,19 @@ const (
 
 	// PrefixEpochs defines the storage prefix for the epochs package.
 	PrefixEpochs
+
+	// PrefixMigrationBackup defines the storage prefix for the backups that are created before the database is migrated.
+	PrefixMigrationBackup
+
+	// PrefixManualPeering defines the storage prefix for the known peers of the manualpeering package.
+	PrefixManualPeering
+
+	// PrefixDRNG defines the storage prefix for the beacon history of the drng package.
+	PrefixDRNG
+
+	// PrefixVoteLog defines the storage prefix for the vote traces of the votelog package.
+	PrefixVoteLog
+
+	// PrefixFaucet defines the storage prefix for the request queue of the faucet.
+	PrefixFaucet
 )
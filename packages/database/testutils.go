@@ -0,0 +1,30 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+// ForEachStore runs the test once for every DB implementation that can be created without external dependencies, so
+// that the tests of the storage layers cover the behavior of the different engines (i.e. the iteration order).
+func ForEachStore(t *testing.T, test func(t *testing.T, store kvstore.KVStore)) {
+	for name, newDB := range map[string]func(t *testing.T) (DB, error){
+		"memdb":  func(t *testing.T) (DB, error) { return NewMemDB() },
+		"pebble": func(t *testing.T) (DB, error) { return NewPebbleDB(t.TempDir()) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			db, err := newDB(t)
+			if err != nil {
+				t.Fatalf("failed to create database: %v", err)
+			}
+			defer func() {
+				if err := db.Close(); err != nil {
+					t.Errorf("failed to close database: %v", err)
+				}
+			}()
+
+			test(t, db.NewStore())
+		})
+	}
+}
@@ -98,14 +98,19 @@ func verifyIssuer(state *State, issuer ed25519.PublicKey) error {
 
 // verifySignature checks the current signature against the distributed public key.
 func verifySignature(cb *CollectiveBeaconEvent) error {
+	return VerifyBeaconSignature(cb.Dpk, cb.Round, cb.PrevSignature, cb.Signature)
+}
+
+// VerifyBeaconSignature checks the signature of the given round against the distributed public key of the committee.
+func VerifyBeaconSignature(distributedPK []byte, round uint64, prevSignature, signature []byte) error {
 	dpk := key.KeyGroup.Point()
-	if err := dpk.UnmarshalBinary(cb.Dpk); err != nil {
+	if err := dpk.UnmarshalBinary(distributedPK); err != nil {
 		return err
 	}
 
-	msg := chain.Message(cb.Round, cb.PrevSignature)
+	msg := chain.Message(round, prevSignature)
 
-	if err := key.Scheme.VerifyRecovered(dpk, msg, cb.Signature); err != nil {
+	if err := key.Scheme.VerifyRecovered(dpk, msg, signature); err != nil {
 		return err
 	}
 
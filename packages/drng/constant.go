@@ -4,6 +4,10 @@ const (
 	// SignatureSize defines the BLS Signature size in bytes.
 	SignatureSize = 96
 
+	// PartialSignatureSize defines the size of a partial BLS Signature in bytes, i.e. the signature prefixed with the
+	// index of the key share.
+	PartialSignatureSize = SignatureSize + 2
+
 	// PublicKeySize defines the BLS Public Key size in bytes.
 	PublicKeySize = 48
 )
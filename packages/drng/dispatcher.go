@@ -43,11 +43,41 @@ func (d *DRNG) Dispatch(issuer ed25519.PublicKey, timestamp time.Time, payload *
 			d.State[cbEvent.InstanceID].UpdateDPK(cbEvent.Dpk)
 		}
 
-		// trigger RandomnessEvent
+		// trigger VerifiedCollectiveBeacon and RandomnessEvent
+		d.Events.VerifiedCollectiveBeacon.Trigger(cbEvent)
 		d.Events.Randomness.Trigger(d.State[cbEvent.InstanceID])
 
 		return nil
 
+	case TypePartialBeacon:
+		// parse as PartialBeaconType
+		marshalUtil := marshalutil.New(payload.Bytes())
+		parsedPayload, err := PartialBeaconPayloadFromMarshalUtil(marshalUtil)
+		if err != nil {
+			return err
+		}
+
+		// only the committee members are allowed to issue partial beacons
+		state, ok := d.State[parsedPayload.Header.InstanceID]
+		if !ok {
+			return ErrInstanceIDMismatch
+		}
+		if err := verifyIssuer(state, issuer); err != nil {
+			return err
+		}
+
+		// trigger PartialBeacon Event
+		d.Events.PartialBeacon.Trigger(&PartialBeaconEvent{
+			IssuerPublicKey:  issuer,
+			Timestamp:        timestamp,
+			InstanceID:       parsedPayload.Header.InstanceID,
+			Round:            parsedPayload.Round,
+			PrevSignature:    parsedPayload.PrevSignature,
+			PartialSignature: parsedPayload.PartialSignature,
+		})
+
+		return nil
+
 	default:
 		return errors.New("subtype not implemented")
 	}
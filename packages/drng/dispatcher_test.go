@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -81,3 +82,35 @@ func TestEmptyState(t *testing.T) {
 		assert.Equal(t, ErrInstanceIDMismatch, err)
 	}
 }
+
+func TestDispatcher_PartialBeacon(t *testing.T) {
+	keyShares, err := NewKeyShares(3, 2)
+	require.NoError(t, err)
+	producer, err := NewProducer(1, 2, 3, keyShares[0])
+	require.NoError(t, err)
+	partial, err := producer.Sign(1)
+	require.NoError(t, err)
+
+	marshalUtil := marshalutil.New(partial.Bytes())
+	parsedPayload, err := PayloadFromMarshalUtil(marshalUtil)
+	require.NoError(t, err)
+	config := make(map[uint32][]Option)
+	config[1] = []Option{SetCommittee(committeeTest)}
+
+	drng := New(config)
+	var received *PartialBeaconEvent
+	drng.Events.PartialBeacon.Attach(events.NewClosure(func(event *PartialBeaconEvent) {
+		received = event
+	}))
+
+	// partial beacons of issuers that are not part of the committee are ignored
+	err = drng.Dispatch(ed25519.GenerateKeyPair().PublicKey, timestampTest, parsedPayload)
+	assert.ErrorIs(t, err, ErrInvalidIssuer)
+	assert.Nil(t, received)
+
+	require.NoError(t, drng.Dispatch(issuerPK, timestampTest, parsedPayload))
+	require.NotNil(t, received)
+	assert.Equal(t, partial.Round, received.Round)
+	assert.Equal(t, partial.PrevSignature, received.PrevSignature)
+	assert.Equal(t, partial.PartialSignature, received.PartialSignature)
+}
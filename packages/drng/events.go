@@ -30,18 +30,46 @@ func CollectiveBeaconReceived(handler interface{}, params ...interface{}) {
 	handler.(func(*CollectiveBeaconEvent))(params[0].(*CollectiveBeaconEvent))
 }
 
+// PartialBeaconEvent holds data about a partial beacon event.
+type PartialBeaconEvent struct {
+	// Public key of the issuer.
+	IssuerPublicKey ed25519.PublicKey
+	// Timestamp when the partial beacon was issued.
+	Timestamp time.Time
+	// InstanceID of the beacon.
+	InstanceID uint32
+	// Round of the beacon.
+	Round uint64
+	// Collective signature of the previous beacon.
+	PrevSignature []byte
+	// Partial signature of the beacon.
+	PartialSignature []byte
+}
+
+// PartialBeaconReceived returns the data of a partial beacon event.
+func PartialBeaconReceived(handler interface{}, params ...interface{}) {
+	handler.(func(*PartialBeaconEvent))(params[0].(*PartialBeaconEvent))
+}
+
 // Event holds the different events triggered by a DRNG instance.
 type Event struct {
 	// Collective Beacon is triggered each time we receive a new CollectiveBeacon message.
 	CollectiveBeacon *events.Event
 	// Randomness is triggered each time we receive a new and valid CollectiveBeacon message.
 	Randomness *events.Event
+	// VerifiedCollectiveBeacon is triggered with the CollectiveBeaconEvent each time we receive a new and valid
+	// CollectiveBeacon message, right before the Randomness event.
+	VerifiedCollectiveBeacon *events.Event
+	// PartialBeacon is triggered each time we receive a PartialBeacon message of a committee member.
+	PartialBeacon *events.Event
 }
 
 func newEvent() *Event {
 	return &Event{
-		CollectiveBeacon: events.NewEvent(CollectiveBeaconReceived),
-		Randomness:       events.NewEvent(randomnessReceived),
+		CollectiveBeacon:         events.NewEvent(CollectiveBeaconReceived),
+		Randomness:               events.NewEvent(randomnessReceived),
+		PartialBeacon:            events.NewEvent(PartialBeaconReceived),
+		VerifiedCollectiveBeacon: events.NewEvent(CollectiveBeaconReceived),
 	}
 }
 
@@ -10,6 +10,8 @@ type Type = byte
 const (
 	// TypeCollectiveBeacon defines a CollectiveBeacon payload type
 	TypeCollectiveBeacon Type = 1
+	// TypePartialBeacon defines a PartialBeacon payload type
+	TypePartialBeacon Type = 2
 )
 
 // HeaderLength defines the length of a DRNG header
@@ -0,0 +1,193 @@
+package drng
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+// ErrBeaconNotFound is returned if a beacon is not part of the history.
+var ErrBeaconNotFound = errors.New("beacon not found")
+
+const (
+	beaconKeyLength = marshalutil.Uint32Size + marshalutil.Uint64Size
+
+	// beaconAtMaxProbes defines how many rounds BeaconAt checks before it gives up (i.e. if the node was offline).
+	beaconAtMaxProbes = 100
+)
+
+// Beacon is a verified collective beacon as it is persisted in the history.
+type Beacon struct {
+	// InstanceID of the beacon.
+	InstanceID uint32
+	// Round of the beacon.
+	Round uint64
+	// Timestamp when the beacon was issued.
+	Timestamp time.Time
+	// Collective signature of the previous beacon.
+	PrevSignature []byte
+	// Collective signature of the beacon.
+	Signature []byte
+}
+
+// NewBeacon creates the beacon of the given CollectiveBeaconEvent.
+func NewBeacon(cb *CollectiveBeaconEvent) *Beacon {
+	return &Beacon{
+		InstanceID:    cb.InstanceID,
+		Round:         cb.Round,
+		Timestamp:     cb.Timestamp,
+		PrevSignature: cb.PrevSignature,
+		Signature:     cb.Signature,
+	}
+}
+
+// Randomness returns the randomness of the beacon.
+func (b *Beacon) Randomness() ([]byte, error) {
+	return ExtractRandomness(b.Signature)
+}
+
+// Verify checks the signature of the beacon against the given distributed public key.
+func (b *Beacon) Verify(distributedPK []byte) error {
+	return VerifyBeaconSignature(distributedPK, b.Round, b.PrevSignature, b.Signature)
+}
+
+// key returns the storage key of the beacon. The key is ordered by instance and round.
+func (b *Beacon) key() []byte {
+	return beaconKey(b.InstanceID, b.Round)
+}
+
+// value returns the marshaled fields of the beacon that are not part of its key.
+func (b *Beacon) value() []byte {
+	return marshalutil.New(marshalutil.TimeSize + SignatureSize*2).
+		WriteTime(b.Timestamp).
+		WriteBytes(b.PrevSignature).
+		WriteBytes(b.Signature).
+		Bytes()
+}
+
+func beaconKey(instanceID uint32, round uint64) []byte {
+	key := make([]byte, beaconKeyLength)
+	binary.BigEndian.PutUint32(key, instanceID)
+	binary.BigEndian.PutUint64(key[marshalutil.Uint32Size:], round)
+	return key
+}
+
+// beaconFromStorage unmarshals a beacon that was persisted with key and value.
+func beaconFromStorage(key, value []byte) (b *Beacon, err error) {
+	if len(key) != beaconKeyLength {
+		return nil, errors.Errorf("invalid key length %d of stored beacon", len(key))
+	}
+
+	b = &Beacon{
+		InstanceID: binary.BigEndian.Uint32(key),
+		Round:      binary.BigEndian.Uint64(key[marshalutil.Uint32Size:]),
+	}
+	marshalUtil := marshalutil.New(value)
+	if b.Timestamp, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse timestamp of stored beacon %d", b.Round)
+	}
+	if b.PrevSignature, err = marshalUtil.ReadBytes(SignatureSize); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse prevSignature of stored beacon %d", b.Round)
+	}
+	if b.Signature, err = marshalUtil.ReadBytes(SignatureSize); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse signature of stored beacon %d", b.Round)
+	}
+	return b, nil
+}
+
+// History persists the verified beacons of all instances, so that it can be replayed which randomness was used at a
+// given time.
+type History struct {
+	store          kvstore.KVStore
+	beaconInterval time.Duration
+}
+
+// NewHistory creates a history that persists the beacons in the given store. The beaconInterval is the time between
+// two rounds, which are numbered by the time at which they are issued (round = unix time / interval).
+func NewHistory(store kvstore.KVStore, beaconInterval time.Duration) *History {
+	return &History{
+		store:          store,
+		beaconInterval: beaconInterval,
+	}
+}
+
+// Store persists the given beacon.
+func (h *History) Store(b *Beacon) error {
+	if err := h.store.Set(b.key(), b.value()); err != nil {
+		return errors.Wrapf(err, "failed to store beacon %d of instance %d", b.Round, b.InstanceID)
+	}
+	return nil
+}
+
+// Beacon returns the beacon of the given instance and round.
+func (h *History) Beacon(instanceID uint32, round uint64) (*Beacon, error) {
+	key := beaconKey(instanceID, round)
+	value, err := h.store.Get(key)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errors.Errorf("round %d of instance %d: %w", round, instanceID, ErrBeaconNotFound)
+		}
+		return nil, errors.Wrapf(err, "failed to load beacon %d of instance %d", round, instanceID)
+	}
+	return beaconFromStorage(key, value)
+}
+
+// Beacons returns the beacons of the given instance from round from to round to (both inclusive), ordered by round. At
+// most limit beacons are returned. Rounds that are not part of the history are skipped.
+func (h *History) Beacons(instanceID uint32, from, to uint64, limit int) (beacons []*Beacon, err error) {
+	if to < from {
+		return nil, nil
+	}
+	if limit > 0 && to-from >= uint64(limit) {
+		to = from + uint64(limit) - 1
+	}
+
+	for round := from; round <= to; round++ {
+		b, err := h.Beacon(instanceID, round)
+		if err == nil {
+			beacons = append(beacons, b)
+		} else if !errors.Is(err, ErrBeaconNotFound) {
+			return nil, err
+		}
+
+		// prevent an overflow if to is the last round
+		if round == to {
+			break
+		}
+	}
+	return beacons, nil
+}
+
+// BeaconAt returns the latest beacon of the given instance that was issued at or before the given time, i.e. the
+// beacon whose randomness was the current one at that time. The round is derived from the time and the earlier rounds
+// are checked, if the beacon of that round is missing or was issued after the given time.
+func (h *History) BeaconAt(instanceID uint32, t time.Time) (*Beacon, error) {
+	if t.Unix() < 0 {
+		return nil, errors.Errorf("instance %d at %s: %w", instanceID, t, ErrBeaconNotFound)
+	}
+
+	intervalSeconds := uint64(h.beaconInterval / time.Second)
+	if intervalSeconds == 0 {
+		intervalSeconds = 1
+	}
+
+	round := uint64(t.Unix()) / intervalSeconds
+	for probes := 0; probes < beaconAtMaxProbes; probes++ {
+		b, err := h.Beacon(instanceID, round)
+		if err == nil && !b.Timestamp.After(t) {
+			return b, nil
+		}
+		if err != nil && !errors.Is(err, ErrBeaconNotFound) {
+			return nil, err
+		}
+
+		if round == 0 {
+			break
+		}
+		round--
+	}
+	return nil, errors.Errorf("instance %d at %s: %w", instanceID, t, ErrBeaconNotFound)
+}
@@ -0,0 +1,92 @@
+package drng
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	history := NewHistory(mapdb.NewMapDB(), 10*time.Second)
+
+	// the rounds are numbered by the time at which they are issued, the beacons arrive a second later
+	start := time.Unix(1600000000, 0)
+	firstRound := uint64(start.Unix() / 10)
+	roundTime := func(round uint64) time.Time {
+		return time.Unix(int64(round)*10, 0)
+	}
+	for _, round := range []uint64{firstRound + 1, firstRound + 2, firstRound + 4, firstRound + 5} {
+		require.NoError(t, history.Store(&Beacon{
+			InstanceID:    1,
+			Round:         round,
+			Timestamp:     roundTime(round).Add(time.Second),
+			PrevSignature: prevSignatureTest,
+			Signature:     signatureTest,
+		}))
+	}
+	require.NoError(t, history.Store(&Beacon{
+		InstanceID:    2,
+		Round:         firstRound + 3,
+		Timestamp:     roundTime(firstRound + 3),
+		PrevSignature: prevSignatureTest,
+		Signature:     signatureTest,
+	}))
+
+	beacon, err := history.Beacon(1, firstRound+2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, beacon.InstanceID)
+	assert.EqualValues(t, firstRound+2, beacon.Round)
+	assert.True(t, roundTime(firstRound+2).Add(time.Second).Equal(beacon.Timestamp))
+	assert.Equal(t, prevSignatureTest, beacon.PrevSignature)
+	assert.Equal(t, signatureTest, beacon.Signature)
+
+	_, err = history.Beacon(1, firstRound+3)
+	assert.ErrorIs(t, err, ErrBeaconNotFound)
+
+	// missing rounds are skipped
+	beacons, err := history.Beacons(1, firstRound+2, firstRound+4, 0)
+	require.NoError(t, err)
+	require.Len(t, beacons, 2)
+	assert.EqualValues(t, firstRound+2, beacons[0].Round)
+	assert.EqualValues(t, firstRound+4, beacons[1].Round)
+
+	// the limit bounds the range of rounds
+	beacons, err = history.Beacons(1, firstRound+1, firstRound+5, 3)
+	require.NoError(t, err)
+	require.Len(t, beacons, 2)
+	assert.EqualValues(t, firstRound+1, beacons[0].Round)
+	assert.EqualValues(t, firstRound+2, beacons[1].Round)
+
+	beacons, err = history.Beacons(1, firstRound+4, firstRound+2, 0)
+	require.NoError(t, err)
+	assert.Empty(t, beacons)
+
+	beacon, err = history.BeaconAt(1, roundTime(firstRound+4).Add(5*time.Second))
+	require.NoError(t, err)
+	assert.EqualValues(t, firstRound+4, beacon.Round)
+
+	// the beacon of the round was not issued yet and the previous round is missing
+	beacon, err = history.BeaconAt(1, roundTime(firstRound+4).Add(500*time.Millisecond))
+	require.NoError(t, err)
+	assert.EqualValues(t, firstRound+2, beacon.Round)
+
+	_, err = history.BeaconAt(1, start)
+	assert.ErrorIs(t, err, ErrBeaconNotFound)
+	_, err = history.BeaconAt(1, roundTime(firstRound+5+beaconAtMaxProbes))
+	assert.ErrorIs(t, err, ErrBeaconNotFound)
+}
+
+func TestBeacon_Verify(t *testing.T) {
+	beacon := NewBeacon(eventTest)
+	require.NoError(t, beacon.Verify(dpkTest))
+
+	randomness, err := beacon.Randomness()
+	require.NoError(t, err)
+	assert.Equal(t, randomnessTest.Randomness, randomness)
+
+	beacon.Round++
+	assert.Error(t, beacon.Verify(dpkTest))
+}
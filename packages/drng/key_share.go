@@ -0,0 +1,110 @@
+package drng
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/drand/drand/key"
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/util/random"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+// ErrInvalidKeyShare is returned if a key share cannot be parsed.
+var ErrInvalidKeyShare = errors.New("invalid key share")
+
+// KeyShare is the threshold BLS key share of a committee member that produces beacons.
+type KeyShare struct {
+	// PriShare is the private share of the committee member.
+	PriShare *share.PriShare
+	// Commits are the commitments of the public polynomial that is shared by all committee members.
+	Commits []kyber.Point
+}
+
+// NewKeyShares deals the key shares of a committee with n members, of which threshold members are needed to sign a
+// beacon. The shares are created by a trusted dealer and are therefore only meant for private networks.
+func NewKeyShares(n, threshold int) ([]*KeyShare, error) {
+	if threshold < 1 || threshold > n {
+		return nil, errors.Errorf("threshold %d must be between 1 and %d: %w", threshold, n, ErrInvalidKeyShare)
+	}
+
+	priPoly := share.NewPriPoly(key.KeyGroup, threshold, key.KeyGroup.Scalar().Pick(random.New()), random.New())
+	_, commits := priPoly.Commit(key.KeyGroup.Point().Base()).Info()
+
+	keyShares := make([]*KeyShare, n)
+	for i, priShare := range priPoly.Shares(n) {
+		keyShares[i] = &KeyShare{
+			PriShare: priShare,
+			Commits:  commits,
+		}
+	}
+	return keyShares, nil
+}
+
+// KeyShareFromBytes parses a key share from the marshaled private share and the marshaled commitments.
+func KeyShareFromBytes(priShareBytes []byte, commitsBytes [][]byte) (*KeyShare, error) {
+	marshalUtil := marshalutil.New(priShareBytes)
+	index, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse index of private share: %w", ErrInvalidKeyShare)
+	}
+	value := key.KeyGroup.Scalar()
+	if err := value.UnmarshalBinary(priShareBytes[marshalutil.Uint16Size:]); err != nil {
+		return nil, errors.Errorf("failed to parse value of private share: %s: %w", err, ErrInvalidKeyShare)
+	}
+
+	if len(commitsBytes) == 0 {
+		return nil, errors.Errorf("no commitments: %w", ErrInvalidKeyShare)
+	}
+	commits := make([]kyber.Point, len(commitsBytes))
+	for i, commitBytes := range commitsBytes {
+		commits[i] = key.KeyGroup.Point()
+		if err := commits[i].UnmarshalBinary(commitBytes); err != nil {
+			return nil, errors.Errorf("failed to parse commitment %d: %s: %w", i, err, ErrInvalidKeyShare)
+		}
+	}
+
+	return &KeyShare{
+		PriShare: &share.PriShare{I: int(index), V: value},
+		Commits:  commits,
+	}, nil
+}
+
+// PriShareBytes returns the private share marshaled as its index followed by its value.
+func (k *KeyShare) PriShareBytes() ([]byte, error) {
+	value, err := k.PriShare.V.MarshalBinary()
+	if err != nil {
+		return nil, errors.Errorf("failed to marshal private share: %w", err)
+	}
+
+	return marshalutil.New(marshalutil.Uint16Size + len(value)).
+		WriteUint16(uint16(k.PriShare.I)).
+		WriteBytes(value).
+		Bytes(), nil
+}
+
+// CommitsBytes returns the marshaled commitments of the public polynomial.
+func (k *KeyShare) CommitsBytes() ([][]byte, error) {
+	commitsBytes := make([][]byte, len(k.Commits))
+	for i, commit := range k.Commits {
+		bytes, err := commit.MarshalBinary()
+		if err != nil {
+			return nil, errors.Errorf("failed to marshal commitment %d: %w", i, err)
+		}
+		commitsBytes[i] = bytes
+	}
+	return commitsBytes, nil
+}
+
+// PubPoly returns the public polynomial of the committee.
+func (k *KeyShare) PubPoly() *share.PubPoly {
+	return share.NewPubPoly(key.KeyGroup, key.KeyGroup.Point().Base(), k.Commits)
+}
+
+// DistributedPK returns the marshaled distributed public key of the committee.
+func (k *KeyShare) DistributedPK() ([]byte, error) {
+	dpk, err := k.PubPoly().Commit().MarshalBinary()
+	if err != nil {
+		return nil, errors.Errorf("failed to marshal distributed public key: %w", err)
+	}
+	return dpk, nil
+}
@@ -0,0 +1,160 @@
+package drng
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// PartialBeaconPayload is the partial signature of a beacon that is issued by a single committee member.
+type PartialBeaconPayload struct {
+	Header
+
+	// Round of the beacon
+	Round uint64
+	// Collective signature of the previous beacon
+	PrevSignature []byte
+	// Partial signature of the beacon, prefixed with the index of the key share
+	PartialSignature []byte
+
+	bytes      []byte
+	bytesMutex sync.RWMutex
+}
+
+// NewPartialBeaconPayload creates a new partial beacon payload.
+func NewPartialBeaconPayload(instanceID uint32, round uint64, prevSignature, partialSignature []byte) *PartialBeaconPayload {
+	return &PartialBeaconPayload{
+		Header:           NewHeader(TypePartialBeacon, instanceID),
+		Round:            round,
+		PrevSignature:    prevSignature,
+		PartialSignature: partialSignature,
+	}
+}
+
+// PartialBeaconPayloadFromMarshalUtil is a wrapper for simplified unmarshaling in a byte stream using the marshalUtil package.
+func PartialBeaconPayloadFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (*PartialBeaconPayload, error) {
+	unmarshalledPayload, err := marshalUtil.Parse(func(data []byte) (interface{}, int, error) { return PartialBeaconPayloadFromBytes(data) })
+	if err != nil {
+		err = fmt.Errorf("failed to parse partial beacon payload: %w", err)
+		return nil, err
+	}
+	_payload := unmarshalledPayload.(*PartialBeaconPayload)
+
+	return _payload, nil
+}
+
+// PartialBeaconPayloadFromBytes parses the marshaled version of a Payload into an object.
+func PartialBeaconPayloadFromBytes(bytes []byte) (result *PartialBeaconPayload, consumedBytes int, err error) {
+	// initialize helper
+	marshalUtil := marshalutil.New(bytes)
+
+	// read information that are required to identify the payload from the outside
+	if _, err = marshalUtil.ReadUint32(); err != nil {
+		err = fmt.Errorf("failed to parse payload size of partial beacon payload: %w", err)
+		return
+	}
+	if _, err = marshalUtil.ReadUint32(); err != nil {
+		err = fmt.Errorf("failed to parse payload type of partial beacon payload: %w", err)
+		return
+	}
+
+	// parse header
+	result = &PartialBeaconPayload{}
+	if result.Header, err = HeaderFromMarshalUtil(marshalUtil); err != nil {
+		err = fmt.Errorf("failed to parse header of partial beacon payload: %w", err)
+		return
+	}
+
+	// parse round
+	if result.Round, err = marshalUtil.ReadUint64(); err != nil {
+		err = fmt.Errorf("failed to parse round of partial beacon payload: %w", err)
+		return
+	}
+
+	// parse prevSignature
+	if result.PrevSignature, err = marshalUtil.ReadBytes(SignatureSize); err != nil {
+		err = fmt.Errorf("failed to parse prevSignature of partial beacon payload: %w", err)
+		return
+	}
+
+	// parse partial signature
+	if result.PartialSignature, err = marshalUtil.ReadBytes(PartialSignatureSize); err != nil {
+		err = fmt.Errorf("failed to parse partial signature of partial beacon payload: %w", err)
+		return
+	}
+
+	// return the number of bytes we processed
+	consumedBytes = marshalUtil.ReadOffset()
+
+	// store bytes, so we don't have to marshal manually
+	result.bytes = bytes[:consumedBytes]
+
+	return
+}
+
+// Bytes returns the partial beacon payload bytes.
+func (p *PartialBeaconPayload) Bytes() (bytes []byte) {
+	// acquire lock for reading bytes
+	p.bytesMutex.RLock()
+
+	// return if bytes have been determined already
+	if bytes = p.bytes; bytes != nil {
+		p.bytesMutex.RUnlock()
+		return
+	}
+
+	// switch to write lock
+	p.bytesMutex.RUnlock()
+	p.bytesMutex.Lock()
+	defer p.bytesMutex.Unlock()
+
+	// return if bytes have been determined in the mean time
+	if bytes = p.bytes; bytes != nil {
+		return
+	}
+
+	// marshal fields
+	payloadLength := HeaderLength + marshalutil.Uint64Size + SignatureSize + PartialSignatureSize
+	marshalUtil := marshalutil.New(marshalutil.Uint32Size + marshalutil.Uint32Size + payloadLength)
+	marshalUtil.WriteUint32(payload.TypeLength + uint32(payloadLength))
+	marshalUtil.WriteBytes(PayloadType.Bytes())
+	marshalUtil.WriteBytes(p.Header.Bytes())
+	marshalUtil.WriteUint64(p.Round)
+	marshalUtil.WriteBytes(p.PrevSignature)
+	marshalUtil.WriteBytes(p.PartialSignature)
+
+	bytes = marshalUtil.Bytes()
+
+	// store result
+	p.bytes = bytes
+
+	return
+}
+
+func (p *PartialBeaconPayload) String() string {
+	return stringify.Struct("PartialBeaconPayload",
+		stringify.StructField("type", uint64(p.Header.PayloadType)),
+		stringify.StructField("instance", uint64(p.Header.InstanceID)),
+		stringify.StructField("round", p.Round),
+		stringify.StructField("prevSignature", p.PrevSignature),
+		stringify.StructField("partialSignature", p.PartialSignature),
+	)
+}
+
+// region Payload implementation ///////////////////////////////////////////////////////////////////////////////////////
+
+// Type returns the partial beacon payload type.
+func (p *PartialBeaconPayload) Type() payload.Type {
+	return PayloadType
+}
+
+// Marshal marshals the partial beacon payload into bytes.
+func (p *PartialBeaconPayload) Marshal() (bytes []byte, err error) {
+	return p.Bytes(), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
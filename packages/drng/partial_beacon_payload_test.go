@@ -0,0 +1,27 @@
+package drng
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartialBeaconPayload(t *testing.T) {
+	payload := NewPartialBeaconPayload(1, 2,
+		[]byte("AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"),         // prevSignature
+		[]byte("\x00\x01BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB")) // partialSignature
+
+	marshalUtil := marshalutil.New(payload.Bytes())
+	parsedPayload, err := PartialBeaconPayloadFromMarshalUtil(marshalUtil)
+	require.NoError(t, err)
+
+	require.Equal(t, TypePartialBeacon, parsedPayload.Header.PayloadType)
+	require.Equal(t, payload.Header.InstanceID, parsedPayload.Header.InstanceID)
+	require.Equal(t, payload.Round, parsedPayload.Round)
+	require.Equal(t, payload.PrevSignature, parsedPayload.PrevSignature)
+	require.Equal(t, payload.PartialSignature, parsedPayload.PartialSignature)
+	require.Equal(t, payload.Bytes(), parsedPayload.Bytes())
+
+	_ = payload.String()
+}
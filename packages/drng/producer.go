@@ -0,0 +1,169 @@
+package drng
+
+import (
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/key"
+	"github.com/drand/kyber/share"
+)
+
+// ErrInvalidPartialSignature is returned if a partial signature does not belong to the committee or the round.
+var ErrInvalidPartialSignature = errors.New("invalid partial signature")
+
+// Producer produces the collective beacons of a committee whose members are nodes of the network itself, e.g. in a
+// private network. Every member signs each round with its key share and issues the partial signature as a
+// PartialBeaconPayload. As soon as a member received the partial signatures of threshold members, it recovers the
+// collective signature and issues it as a CollectiveBeaconPayload. As every member that recovers the signature issues
+// it, the same round can be issued more than once, but only the first beacon of a round updates the state.
+type Producer struct {
+	instanceID    uint32
+	threshold     int
+	committeeSize int
+	keyShare      *KeyShare
+	pubPoly       *share.PubPoly
+	dpk           []byte
+
+	lastRound     uint64
+	lastSignature []byte
+	partials      map[uint64]*roundPartials
+	mutex         sync.Mutex
+}
+
+// roundPartials holds the partial signatures of a round, grouped by the previous signature they were signed with.
+type roundPartials struct {
+	signatures map[string]map[int][]byte
+	recovered  bool
+}
+
+// NewProducer creates a producer for the committee of the given instance, that consists of committeeSize members of
+// which threshold members are needed to sign a beacon.
+func NewProducer(instanceID uint32, threshold, committeeSize int, keyShare *KeyShare) (*Producer, error) {
+	dpk, err := keyShare.DistributedPK()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Producer{
+		instanceID:    instanceID,
+		threshold:     threshold,
+		committeeSize: committeeSize,
+		keyShare:      keyShare,
+		pubPoly:       keyShare.PubPoly(),
+		dpk:           dpk,
+		lastSignature: make([]byte, SignatureSize),
+		partials:      make(map[uint64]*roundPartials),
+	}, nil
+}
+
+// DistributedPK returns the distributed public key of the committee.
+func (p *Producer) DistributedPK() []byte {
+	return p.dpk
+}
+
+// Sign signs the given round with the key share of the member. The round is chained to the last collective beacon of
+// the committee that is known to the producer.
+func (p *Producer) Sign(round uint64) (*PartialBeaconPayload, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if round <= p.lastRound {
+		return nil, ErrInvalidRound
+	}
+
+	// the partial signatures of older rounds can no longer be used
+	for r := range p.partials {
+		if r+1 < round {
+			delete(p.partials, r)
+		}
+	}
+
+	partialSignature, err := key.Scheme.Sign(p.keyShare.PriShare, chain.Message(round, p.lastSignature))
+	if err != nil {
+		return nil, errors.Errorf("failed to sign round %d: %w", round, err)
+	}
+	return NewPartialBeaconPayload(p.instanceID, round, p.lastSignature, partialSignature), nil
+}
+
+// ProcessPartialBeacon adds the partial signature of a committee member. It returns the collective beacon of the round
+// as soon as enough partial signatures have been received, otherwise nil.
+func (p *Producer) ProcessPartialBeacon(event *PartialBeaconEvent) (*CollectiveBeaconPayload, error) {
+	if event.InstanceID != p.instanceID {
+		return nil, ErrInstanceIDMismatch
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if event.Round <= p.lastRound {
+		return nil, ErrInvalidRound
+	}
+
+	msg := chain.Message(event.Round, event.PrevSignature)
+	if err := key.Scheme.VerifyPartial(p.pubPoly, msg, event.PartialSignature); err != nil {
+		return nil, errors.Errorf("round %d: %s: %w", event.Round, err, ErrInvalidPartialSignature)
+	}
+	index, err := key.Scheme.IndexOf(event.PartialSignature)
+	if err != nil {
+		return nil, errors.Errorf("round %d: %s: %w", event.Round, err, ErrInvalidPartialSignature)
+	}
+
+	partials, ok := p.partials[event.Round]
+	if !ok {
+		partials = &roundPartials{signatures: make(map[string]map[int][]byte)}
+		p.partials[event.Round] = partials
+	}
+	if partials.recovered {
+		return nil, nil
+	}
+	signatures, ok := partials.signatures[string(event.PrevSignature)]
+	if !ok {
+		signatures = make(map[int][]byte)
+		partials.signatures[string(event.PrevSignature)] = signatures
+	}
+	signatures[index] = event.PartialSignature
+	if len(signatures) < p.threshold {
+		return nil, nil
+	}
+
+	sigs := make([][]byte, 0, len(signatures))
+	for _, sig := range signatures {
+		sigs = append(sigs, sig)
+	}
+	signature, err := key.Scheme.Recover(p.pubPoly, msg, sigs, p.threshold, p.committeeSize)
+	if err != nil {
+		return nil, errors.Errorf("failed to recover the signature of round %d: %w", event.Round, err)
+	}
+	partials.recovered = true
+
+	return NewCollectiveBeaconPayload(p.instanceID, event.Round, event.PrevSignature, signature, p.dpk), nil
+}
+
+// ProcessBeacon updates the last collective beacon of the committee, to which the next rounds are chained.
+func (p *Producer) ProcessBeacon(event *CollectiveBeaconEvent) error {
+	if event.InstanceID != p.instanceID {
+		return ErrInstanceIDMismatch
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if event.Round <= p.lastRound {
+		return ErrInvalidRound
+	}
+	msg := chain.Message(event.Round, event.PrevSignature)
+	if err := key.Scheme.VerifyRecovered(p.pubPoly.Commit(), msg, event.Signature); err != nil {
+		return errors.Errorf("invalid signature of round %d: %w", event.Round, err)
+	}
+
+	p.lastRound = event.Round
+	p.lastSignature = event.Signature
+	for r := range p.partials {
+		if r <= event.Round {
+			delete(p.partials, r)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package drng
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProducer(t *testing.T) {
+	const n, threshold = 5, 3
+
+	keyShares, err := NewKeyShares(n, threshold)
+	require.NoError(t, err)
+	producers := make([]*Producer, n)
+	for i := range producers {
+		producers[i], err = NewProducer(1, threshold, n, keyShares[i])
+		require.NoError(t, err)
+	}
+
+	issuer := ed25519.GenerateKeyPair().PublicKey
+	state := NewState(SetCommittee(&Committee{
+		InstanceID:    1,
+		Threshold:     threshold,
+		Identities:    []ed25519.PublicKey{issuer},
+		DistributedPK: producers[0].DistributedPK(),
+	}))
+
+	for round := uint64(1); round <= 2; round++ {
+		var beacon *CollectiveBeaconPayload
+		for i := 0; i < threshold; i++ {
+			partial, err := producers[i].Sign(round)
+			require.NoError(t, err)
+
+			beacon, err = producers[0].ProcessPartialBeacon(partialBeaconEvent(issuer, partial))
+			require.NoError(t, err)
+			if i < threshold-1 {
+				assert.Nil(t, beacon)
+			}
+		}
+		require.NotNil(t, beacon)
+
+		// further partial signatures of the round do not recover the beacon again
+		partial, err := producers[threshold].Sign(round)
+		require.NoError(t, err)
+		duplicate, err := producers[0].ProcessPartialBeacon(partialBeaconEvent(issuer, partial))
+		require.NoError(t, err)
+		assert.Nil(t, duplicate)
+
+		event := collectiveBeaconEvent(issuer, beacon)
+		require.NoError(t, ProcessBeacon(state, event))
+		assert.Equal(t, round, state.Randomness().Round)
+		for _, producer := range producers {
+			require.NoError(t, producer.ProcessBeacon(event))
+		}
+	}
+
+	// rounds that have a beacon already can not be signed again
+	_, err = producers[0].Sign(2)
+	assert.ErrorIs(t, err, ErrInvalidRound)
+}
+
+func TestProducer_InvalidPartialSignature(t *testing.T) {
+	keyShares, err := NewKeyShares(3, 2)
+	require.NoError(t, err)
+	otherKeyShares, err := NewKeyShares(3, 2)
+	require.NoError(t, err)
+
+	producer, err := NewProducer(1, 2, 3, keyShares[0])
+	require.NoError(t, err)
+	otherProducer, err := NewProducer(1, 2, 3, otherKeyShares[1])
+	require.NoError(t, err)
+
+	partial, err := otherProducer.Sign(1)
+	require.NoError(t, err)
+	_, err = producer.ProcessPartialBeacon(partialBeaconEvent(ed25519.PublicKey{}, partial))
+	assert.ErrorIs(t, err, ErrInvalidPartialSignature)
+}
+
+func TestKeyShareFromBytes(t *testing.T) {
+	keyShares, err := NewKeyShares(3, 2)
+	require.NoError(t, err)
+
+	priShareBytes, err := keyShares[2].PriShareBytes()
+	require.NoError(t, err)
+	commitsBytes, err := keyShares[2].CommitsBytes()
+	require.NoError(t, err)
+
+	keyShare, err := KeyShareFromBytes(priShareBytes, commitsBytes)
+	require.NoError(t, err)
+	assert.Equal(t, keyShares[2].PriShare.I, keyShare.PriShare.I)
+	assert.True(t, keyShares[2].PriShare.V.Equal(keyShare.PriShare.V))
+	assert.True(t, keyShares[2].PubPoly().Equal(keyShare.PubPoly()))
+
+	_, err = KeyShareFromBytes(priShareBytes[:1], commitsBytes)
+	assert.ErrorIs(t, err, ErrInvalidKeyShare)
+}
+
+func partialBeaconEvent(issuer ed25519.PublicKey, partial *PartialBeaconPayload) *PartialBeaconEvent {
+	return &PartialBeaconEvent{
+		IssuerPublicKey:  issuer,
+		InstanceID:       partial.InstanceID,
+		Round:            partial.Round,
+		PrevSignature:    partial.PrevSignature,
+		PartialSignature: partial.PartialSignature,
+	}
+}
+
+func collectiveBeaconEvent(issuer ed25519.PublicKey, beacon *CollectiveBeaconPayload) *CollectiveBeaconEvent {
+	return &CollectiveBeaconEvent{
+		IssuerPublicKey: issuer,
+		InstanceID:      beacon.InstanceID,
+		Round:           beacon.Round,
+		PrevSignature:   beacon.PrevSignature,
+		Signature:       beacon.Signature,
+		Dpk:             beacon.Dpk,
+	}
+}
@@ -0,0 +1,700 @@
+package faucet
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+const (
+	// DefaultQueueCapacity is the default number of requests the queue holds.
+	DefaultQueueCapacity = 1000
+	// DefaultAddressQuota is the default number of times an address is funded within the DefaultAddressQuotaWindow.
+	DefaultAddressQuota = 1
+	// DefaultAddressQuotaWindow is the default sliding window of the address quota.
+	DefaultAddressQuotaWindow = 24 * time.Hour
+	// DefaultNodeQuota is the default number of requests a mana pledge node can make within the DefaultNodeQuotaWindow.
+	DefaultNodeQuota = 10
+	// DefaultNodeQuotaWindow is the default sliding window of the node quota.
+	DefaultNodeQuotaWindow = time.Hour
+	// DefaultRefusalCapacity is the default number of refused requests whose state is kept.
+	DefaultRefusalCapacity = 1000
+)
+
+// ErrRequestNotFound is returned if the faucet didn't receive a request.
+var ErrRequestNotFound = errors.New("faucet request not found")
+
+// region RequestState /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RequestState is the processing state of a funding request.
+type RequestState uint8
+
+const (
+	// RequestQueued is the state of requests that wait in the queue.
+	RequestQueued RequestState = iota
+	// RequestProcessing is the state of requests that are currently being funded.
+	RequestProcessing
+	// RequestFulfilled is the state of requests that were funded.
+	RequestFulfilled
+	// RequestFailed is the state of requests that couldn't be funded.
+	RequestFailed
+	// RequestRefused is the state of requests that were refused by the faucet.
+	RequestRefused
+)
+
+// String returns the name of the RequestState, which is used in the web API.
+func (s RequestState) String() string {
+	switch s {
+	case RequestQueued:
+		return "queued"
+	case RequestProcessing:
+		return "processing"
+	case RequestFulfilled:
+		return "fulfilled"
+	case RequestFailed:
+		return "failed"
+	case RequestRefused:
+		return "refused"
+	default:
+		return "unknown"
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region QueuedRequest ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// QueuedRequest is a funding request received by the faucet together with its processing state.
+type QueuedRequest struct {
+	// MessageID is the ID of the message that contains the request.
+	MessageID tangle.MessageID
+	// Address is the address to fund.
+	Address ledgerstate.Address
+	// IssuerID is the node that issued the message containing the request.
+	IssuerID identity.ID
+	// AccessManaPledgeID is the node that the access mana of the funds is pledged to.
+	AccessManaPledgeID identity.ID
+	// ConsensusManaPledgeID is the node that the consensus mana of the funds is pledged to.
+	ConsensusManaPledgeID identity.ID
+	// Prioritized is true if the request is funded before the requests that are not prioritized.
+	Prioritized bool
+	// State is the processing state of the request.
+	State RequestState
+	// Refusal is the reason of the refusal, if the request was refused.
+	Refusal RefusalReason
+	// TransactionID is the ID of the funding transaction, if the request was fulfilled.
+	TransactionID ledgerstate.TransactionID
+	// Received is the time the faucet received the request.
+	Received time.Time
+	// Updated is the time the state of the request changed last.
+	Updated time.Time
+}
+
+// NewQueuedRequest returns a new QueuedRequest for the given faucet request. The mana pledge IDs of the request default
+// to the issuer of the message.
+func NewQueuedRequest(message *tangle.Message, received time.Time) *QueuedRequest {
+	request := message.Payload().(*Request)
+	issuerID := identity.NewID(message.IssuerPublicKey())
+	accessManaPledgeID, consensusManaPledgeID := request.ManaPledgeIDs(issuerID)
+	return &QueuedRequest{
+		MessageID:             message.ID(),
+		Address:               request.Address(),
+		IssuerID:              issuerID,
+		AccessManaPledgeID:    accessManaPledgeID,
+		ConsensusManaPledgeID: consensusManaPledgeID,
+		Received:              received,
+		Updated:               received,
+	}
+}
+
+// QueuedRequestFromBytes parses the marshaled version of a QueuedRequest.
+func QueuedRequestFromBytes(bytes []byte) (request *QueuedRequest, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	request = &QueuedRequest{}
+	if request.MessageID, err = tangle.MessageIDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse message ID: %w", err)
+	}
+	if request.Address, err = ledgerstate.AddressFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse address: %w", err)
+	}
+	if request.IssuerID, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse issuer ID (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if request.AccessManaPledgeID, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse access mana pledge ID (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if request.ConsensusManaPledgeID, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse consensus mana pledge ID (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if request.Prioritized, err = marshalUtil.ReadBool(); err != nil {
+		return nil, errors.Errorf("failed to parse priority (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	state, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse state (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	request.State = RequestState(state)
+	refusal, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse refusal reason (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	request.Refusal = RefusalReason(refusal)
+	if request.TransactionID, err = ledgerstate.TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse transaction ID: %w", err)
+	}
+	if request.Received, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse received time (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if request.Updated, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse updated time (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	return request, nil
+}
+
+// Bytes returns the marshaled version of the QueuedRequest.
+func (r *QueuedRequest) Bytes() []byte {
+	return marshalutil.New().
+		Write(r.MessageID).
+		Write(r.Address).
+		Write(r.IssuerID).
+		Write(r.AccessManaPledgeID).
+		Write(r.ConsensusManaPledgeID).
+		WriteBool(r.Prioritized).
+		WriteUint8(uint8(r.State)).
+		WriteUint8(uint8(r.Refusal)).
+		Write(r.TransactionID).
+		WriteTime(r.Received).
+		WriteTime(r.Updated).
+		Bytes()
+}
+
+// String returns a human readable version of the QueuedRequest.
+func (r *QueuedRequest) String() string {
+	return stringify.Struct("QueuedRequest",
+		stringify.StructField("messageID", r.MessageID),
+		stringify.StructField("address", r.Address.Base58()),
+		stringify.StructField("issuerID", r.IssuerID),
+		stringify.StructField("accessManaPledgeID", r.AccessManaPledgeID),
+		stringify.StructField("consensusManaPledgeID", r.ConsensusManaPledgeID),
+		stringify.StructField("prioritized", r.Prioritized),
+		stringify.StructField("state", r.State),
+		stringify.StructField("refusal", r.Refusal),
+		stringify.StructField("transactionID", r.TransactionID),
+		stringify.StructField("received", r.Received),
+		stringify.StructField("updated", r.Updated),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Queue ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Queue is the persistent queue of the funding requests of a faucet. It refuses requests whose address, issuer or mana
+// pledge nodes exceed their quota over a sliding window, funds prioritized requests first and serves the issuers in a
+// round robin fashion, so that a single node can't delay the requests of all the others.
+type Queue struct {
+	store        kvstore.KVStore
+	config       *queueConfig
+	queued       map[tangle.MessageID]*QueuedRequest
+	refused      []tangle.MessageID
+	prioritized  *roundRobin
+	regular      *roundRobin
+	addressQuota *quota
+	nodeQuota    *quota
+	ready        chan struct{}
+	mutex        sync.Mutex
+}
+
+// NewQueue returns a new Queue that persists the requests in the given store. Requests that were queued before are
+// restored, while requests that were being processed are marked as failed, as their funding can't be verified.
+func NewQueue(store kvstore.KVStore, opts ...QueueOption) (*Queue, error) {
+	config := buildQueueConfig(opts)
+	q := &Queue{
+		store:        store,
+		config:       config,
+		queued:       make(map[tangle.MessageID]*QueuedRequest),
+		prioritized:  newRoundRobin(),
+		regular:      newRoundRobin(),
+		addressQuota: newQuota(config.addressQuota, config.addressQuotaWindow),
+		nodeQuota:    newQuota(config.nodeQuota, config.nodeQuotaWindow),
+		ready:        make(chan struct{}, 1),
+	}
+
+	var requests []*QueuedRequest
+	if err := store.Iterate(kvstore.EmptyPrefix, func(_ kvstore.Key, value kvstore.Value) bool {
+		request, err := QueuedRequestFromBytes(value)
+		if err != nil {
+			return true
+		}
+		requests = append(requests, request)
+		return true
+	}); err != nil {
+		return nil, errors.Errorf("failed to load faucet requests: %w", err)
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return earlier(requests[i], requests[j])
+	})
+	for _, request := range requests {
+		switch request.State {
+		case RequestQueued:
+			q.push(request)
+		case RequestProcessing:
+			request.State = RequestFailed
+			request.Updated = time.Now()
+			if err := q.persist(request); err != nil {
+				return nil, err
+			}
+			continue
+		case RequestFulfilled:
+		case RequestRefused:
+			q.refused = append(q.refused, request.MessageID)
+			continue
+		default:
+			continue
+		}
+		q.addToQuotas(request)
+	}
+	if err := q.trimRefused(); err != nil {
+		return nil, err
+	}
+	if len(q.queued) > 0 {
+		q.signal()
+	}
+
+	return q, nil
+}
+
+// Check returns a RefusalError if a request for the given address, issued by the given node and pledging to the given
+// mana pledge nodes would be refused at the given time. It doesn't change the state of the queue.
+func (q *Queue) Check(address ledgerstate.Address, issuerID, accessManaPledgeID, consensusManaPledgeID identity.ID, now time.Time) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.check(&QueuedRequest{
+		Address:               address,
+		IssuerID:              issuerID,
+		AccessManaPledgeID:    accessManaPledgeID,
+		ConsensusManaPledgeID: consensusManaPledgeID,
+	}, now)
+}
+
+// Enqueue adds the request to the queue. If the request is refused, it is stored with its refusal reason and a
+// RefusalError is returned. Only the state of the most recent refusals is kept.
+func (q *Queue) Enqueue(request *QueuedRequest) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if has, err := q.store.Has(request.MessageID.Bytes()); err != nil {
+		return errors.Errorf("failed to look up request %s: %w", request.MessageID, err)
+	} else if has {
+		return NewRefusalError(RefusalAlreadyQueued, 0)
+	}
+
+	if err := q.check(request, request.Received); err != nil {
+		var refusalErr *RefusalError
+		if errors.As(err, &refusalErr) {
+			return q.refuse(request, refusalErr)
+		}
+		return err
+	}
+
+	request.State = RequestQueued
+	if err := q.persist(request); err != nil {
+		return err
+	}
+	q.push(request)
+	q.addToQuotas(request)
+	q.signal()
+
+	return nil
+}
+
+// Dequeue removes the next request from the queue and marks it as being processed. It returns false if the queue is
+// empty.
+func (q *Queue) Dequeue() (*QueuedRequest, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	request, ok := q.prioritized.pop()
+	if !ok {
+		if request, ok = q.regular.pop(); !ok {
+			return nil, false
+		}
+	}
+	delete(q.queued, request.MessageID)
+	request.State = RequestProcessing
+	request.Updated = time.Now()
+	// the request is processed even if it can't be persisted, it's only marked as failed after a restart
+	_ = q.persist(request)
+
+	return request, true
+}
+
+// Ready returns a channel that receives a signal when a request was added to an empty queue.
+func (q *Queue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// Fulfilled marks the request as funded by the transaction with the given ID.
+func (q *Queue) Fulfilled(request *QueuedRequest, transactionID ledgerstate.TransactionID) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	request.State = RequestFulfilled
+	request.TransactionID = transactionID
+	request.Updated = time.Now()
+	return q.persist(request)
+}
+
+// Failed marks the request as failed. It doesn't count towards the quotas anymore, so that it can be requested again.
+func (q *Queue) Failed(request *QueuedRequest) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	request.State = RequestFailed
+	request.Updated = time.Now()
+	q.addressQuota.remove(request.Address.Base58(), request.Received)
+	for _, key := range q.nodeKeys(request) {
+		q.nodeQuota.remove(key, request.Received)
+	}
+	return q.persist(request)
+}
+
+// Request returns the request contained in the message with the given ID together with its position in the queue. The
+// position is 0 for the request that is funded next and -1 if the request isn't queued.
+func (q *Queue) Request(messageID tangle.MessageID) (request *QueuedRequest, position int, err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	value, err := q.store.Get(messageID.Bytes())
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, -1, errors.Errorf("%w: %s", ErrRequestNotFound, messageID)
+		}
+		return nil, -1, errors.Errorf("failed to load request %s: %w", messageID, err)
+	}
+	if request, err = QueuedRequestFromBytes(value); err != nil {
+		return nil, -1, err
+	}
+
+	position = -1
+	if _, queued := q.queued[messageID]; queued {
+		for i, queuedRequest := range q.order() {
+			if queuedRequest.MessageID == messageID {
+				position = i
+				break
+			}
+		}
+	}
+	return request, position, nil
+}
+
+// Size returns the number of queued requests.
+func (q *Queue) Size() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.queued)
+}
+
+// Prune deletes all requests that are no longer queued or processed and were received before the given time.
+func (q *Queue) Prune(before time.Time) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	var keys []kvstore.Key
+	if err := q.store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		request, err := QueuedRequestFromBytes(value)
+		if err != nil || (request.State != RequestQueued && request.State != RequestProcessing && request.Received.Before(before)) {
+			keys = append(keys, key)
+		}
+		return true
+	}); err != nil {
+		return errors.Errorf("failed to iterate faucet requests: %w", err)
+	}
+	deleted := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if err := q.store.Delete(key); err != nil {
+			return errors.Errorf("failed to delete faucet request: %w", err)
+		}
+		deleted[string(key)] = struct{}{}
+	}
+
+	refused := q.refused[:0]
+	for _, messageID := range q.refused {
+		if _, exists := deleted[string(messageID.Bytes())]; !exists {
+			refused = append(refused, messageID)
+		}
+	}
+	q.refused = refused
+
+	now := time.Now()
+	q.addressQuota.prune(now)
+	q.nodeQuota.prune(now)
+	return nil
+}
+
+// check returns a RefusalError if the request exceeds the capacity of the queue or one of the quotas.
+func (q *Queue) check(request *QueuedRequest, now time.Time) error {
+	if len(q.queued) >= q.config.capacity {
+		return NewRefusalError(RefusalQueueFull, 0)
+	}
+	if allowed, retryAfter := q.addressQuota.check(request.Address.Base58(), now); !allowed {
+		return NewRefusalError(RefusalAddressQuotaExceeded, retryAfter)
+	}
+	for _, key := range q.nodeKeys(request) {
+		if allowed, retryAfter := q.nodeQuota.check(key, now); !allowed {
+			return NewRefusalError(RefusalNodeQuotaExceeded, retryAfter)
+		}
+	}
+	return nil
+}
+
+// refuse stores the refused request and deletes the oldest refused requests that exceed the refusal capacity.
+func (q *Queue) refuse(request *QueuedRequest, refusalErr *RefusalError) error {
+	request.State = RequestRefused
+	request.Refusal = refusalErr.Reason
+	request.Updated = time.Now()
+	if err := q.persist(request); err != nil {
+		return err
+	}
+	q.refused = append(q.refused, request.MessageID)
+	if err := q.trimRefused(); err != nil {
+		return err
+	}
+	return refusalErr
+}
+
+// trimRefused deletes the oldest refused requests that exceed the refusal capacity.
+func (q *Queue) trimRefused() error {
+	for len(q.refused) > q.config.refusalCapacity {
+		if err := q.store.Delete(q.refused[0].Bytes()); err != nil {
+			return errors.Errorf("failed to delete refused request %s: %w", q.refused[0], err)
+		}
+		q.refused = q.refused[1:]
+	}
+	return nil
+}
+
+func (q *Queue) addToQuotas(request *QueuedRequest) {
+	q.addressQuota.add(request.Address.Base58(), request.Received)
+	for _, key := range q.nodeKeys(request) {
+		q.nodeQuota.add(key, request.Received)
+	}
+}
+
+// nodeKeys returns the keys of the distinct issuer and mana pledge nodes of the request in the node quota. Exempt nodes
+// are skipped.
+func (q *Queue) nodeKeys(request *QueuedRequest) (keys []string) {
+	seen := make(map[identity.ID]struct{}, 3)
+	for _, nodeID := range []identity.ID{request.IssuerID, request.AccessManaPledgeID, request.ConsensusManaPledgeID} {
+		if _, exempt := q.config.exemptNodes[nodeID]; exempt {
+			continue
+		}
+		if _, exists := seen[nodeID]; exists {
+			continue
+		}
+		seen[nodeID] = struct{}{}
+		keys = append(keys, base58.Encode(nodeID.Bytes()))
+	}
+	return keys
+}
+
+// turnID returns the node whose turn the request waits for in the round robin. This is the issuer, unless it is exempt,
+// e.g. because the faucet issued the request on behalf of a web API client, in which case it's the access mana pledge
+// node.
+func (q *Queue) turnID(request *QueuedRequest) identity.ID {
+	if _, exempt := q.config.exemptNodes[request.IssuerID]; exempt {
+		return request.AccessManaPledgeID
+	}
+	return request.IssuerID
+}
+
+// push adds the request to the queue.
+func (q *Queue) push(request *QueuedRequest) {
+	q.queued[request.MessageID] = request
+	if request.Prioritized {
+		q.prioritized.push(q.turnID(request), request)
+		return
+	}
+	q.regular.push(q.turnID(request), request)
+}
+
+// order returns the queued requests in the order they are funded.
+func (q *Queue) order() []*QueuedRequest {
+	return append(q.prioritized.order(), q.regular.order()...)
+}
+
+func (q *Queue) persist(request *QueuedRequest) error {
+	if err := q.store.Set(request.MessageID.Bytes(), request.Bytes()); err != nil {
+		return errors.Errorf("failed to store request %s: %w", request.MessageID, err)
+	}
+	return nil
+}
+
+// signal notifies a waiting consumer without blocking.
+func (q *Queue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// earlier returns true if request a was received before request b.
+func earlier(a, b *QueuedRequest) bool {
+	if !a.Received.Equal(b.Received) {
+		return a.Received.Before(b.Received)
+	}
+	return a.MessageID.String() < b.MessageID.String()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region roundRobin ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// roundRobin holds the requests of every node in the order they were received and serves the nodes in turns, so that
+// the requests of a node wait for at most one request of every other node.
+type roundRobin struct {
+	// the nodes with queued requests in the order they are served next.
+	nodes    []identity.ID
+	requests map[identity.ID][]*QueuedRequest
+}
+
+func newRoundRobin() *roundRobin {
+	return &roundRobin{
+		requests: make(map[identity.ID][]*QueuedRequest),
+	}
+}
+
+func (r *roundRobin) push(nodeID identity.ID, request *QueuedRequest) {
+	if _, exists := r.requests[nodeID]; !exists {
+		r.nodes = append(r.nodes, nodeID)
+	}
+	r.requests[nodeID] = append(r.requests[nodeID], request)
+}
+
+// pop removes and returns the oldest request of the next node, which then moves to the end of the turns.
+func (r *roundRobin) pop() (*QueuedRequest, bool) {
+	if len(r.nodes) == 0 {
+		return nil, false
+	}
+
+	nodeID := r.nodes[0]
+	r.nodes = r.nodes[1:]
+	requests := r.requests[nodeID]
+	request := requests[0]
+	if len(requests) == 1 {
+		delete(r.requests, nodeID)
+	} else {
+		r.requests[nodeID] = requests[1:]
+		r.nodes = append(r.nodes, nodeID)
+	}
+	return request, true
+}
+
+// order returns the requests in the order pop would return them.
+func (r *roundRobin) order() []*QueuedRequest {
+	var result []*QueuedRequest
+	for turn := 0; ; turn++ {
+		served := false
+		for _, nodeID := range r.nodes {
+			if requests := r.requests[nodeID]; turn < len(requests) {
+				result = append(result, requests[turn])
+				served = true
+			}
+		}
+		if !served {
+			return result
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region QueueOption //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// QueueOption defines an option for the Queue.
+type QueueOption func(conf *queueConfig)
+
+type queueConfig struct {
+	capacity           int
+	addressQuota       int
+	addressQuotaWindow time.Duration
+	nodeQuota          int
+	nodeQuotaWindow    time.Duration
+	exemptNodes        map[identity.ID]struct{}
+	refusalCapacity    int
+}
+
+func buildQueueConfig(opts []QueueOption) *queueConfig {
+	conf := &queueConfig{
+		capacity:           DefaultQueueCapacity,
+		addressQuota:       DefaultAddressQuota,
+		addressQuotaWindow: DefaultAddressQuotaWindow,
+		nodeQuota:          DefaultNodeQuota,
+		nodeQuotaWindow:    DefaultNodeQuotaWindow,
+		exemptNodes:        make(map[identity.ID]struct{}),
+		refusalCapacity:    DefaultRefusalCapacity,
+	}
+	for _, o := range opts {
+		o(conf)
+	}
+	return conf
+}
+
+// WithCapacity returns a QueueOption that defines the maximum number of queued requests.
+func WithCapacity(capacity int) QueueOption {
+	return func(conf *queueConfig) {
+		conf.capacity = capacity
+	}
+}
+
+// WithAddressQuota returns a QueueOption that allows limit requests per address within the sliding window. A limit of 0
+// disables the quota.
+func WithAddressQuota(limit int, window time.Duration) QueueOption {
+	return func(conf *queueConfig) {
+		conf.addressQuota = limit
+		conf.addressQuotaWindow = window
+	}
+}
+
+// WithNodeQuota returns a QueueOption that allows limit requests per issuer or mana pledge node within the sliding
+// window. A limit of 0 disables the quota.
+func WithNodeQuota(limit int, window time.Duration) QueueOption {
+	return func(conf *queueConfig) {
+		conf.nodeQuota = limit
+		conf.nodeQuotaWindow = window
+	}
+}
+
+// WithExemptNodes returns a QueueOption that exempts the given nodes from the node quota, e.g. the faucet itself, which
+// issues the requests of its web API clients.
+func WithExemptNodes(nodeIDs ...identity.ID) QueueOption {
+	return func(conf *queueConfig) {
+		for _, nodeID := range nodeIDs {
+			conf.exemptNodes[nodeID] = struct{}{}
+		}
+	}
+}
+
+// WithRefusalCapacity returns a QueueOption that defines how many refused requests are kept, so that their refusal
+// reason can be looked up.
+func WithRefusalCapacity(capacity int) QueueOption {
+	return func(conf *queueConfig) {
+		conf.refusalCapacity = capacity
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
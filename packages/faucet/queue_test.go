@@ -0,0 +1,261 @@
+package faucet
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// newTestRequest returns a request issued by the given node, which also receives the mana of the funds.
+func newTestRequest(t *testing.T, nodeID identity.ID, received time.Time) *QueuedRequest {
+	var messageID tangle.MessageID
+	_, err := rand.Read(messageID[:])
+	require.NoError(t, err)
+
+	return &QueuedRequest{
+		MessageID:             messageID,
+		Address:               ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey),
+		IssuerID:              nodeID,
+		AccessManaPledgeID:    nodeID,
+		ConsensusManaPledgeID: nodeID,
+		Received:              received,
+		Updated:               received,
+	}
+}
+
+func requireRefusal(t *testing.T, err error, reason RefusalReason) *RefusalError {
+	var refusalErr *RefusalError
+	require.True(t, errors.As(err, &refusalErr), "expected refusal %s, got %v", reason, err)
+	require.Equal(t, reason, refusalErr.Reason)
+	assert.True(t, errors.Is(err, ErrRequestRefused))
+	return refusalErr
+}
+
+func TestQueue_Quotas(t *testing.T) {
+	queue, err := NewQueue(mapdb.NewMapDB(), WithAddressQuota(1, time.Hour), WithNodeQuota(2, time.Hour))
+	require.NoError(t, err)
+
+	now := time.Now()
+	node := identity.GenerateIdentity().ID()
+	first := newTestRequest(t, node, now)
+	require.NoError(t, queue.Enqueue(first))
+
+	// the same message is only queued once
+	requireRefusal(t, queue.Enqueue(first), RefusalAlreadyQueued)
+
+	// the same address is refused until the window has passed
+	sameAddress := newTestRequest(t, identity.GenerateIdentity().ID(), now.Add(10*time.Minute))
+	sameAddress.Address = first.Address
+	refusalErr := requireRefusal(t, queue.Enqueue(sameAddress), RefusalAddressQuotaExceeded)
+	assert.Equal(t, 50*time.Minute, refusalErr.RetryAfter)
+
+	refused, position, err := queue.Request(sameAddress.MessageID)
+	require.NoError(t, err)
+	assert.Equal(t, RequestRefused, refused.State)
+	assert.Equal(t, RefusalAddressQuotaExceeded, refused.Refusal)
+	assert.Equal(t, -1, position)
+
+	// the node is refused after two requests
+	require.NoError(t, queue.Enqueue(newTestRequest(t, node, now.Add(time.Minute))))
+	requireRefusal(t, queue.Enqueue(newTestRequest(t, node, now.Add(2*time.Minute))), RefusalNodeQuotaExceeded)
+	requireRefusal(t, queue.Check(first.Address, identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), now), RefusalAddressQuotaExceeded)
+
+	// a different consensus mana pledge node doesn't circumvent the quota
+	other := newTestRequest(t, identity.GenerateIdentity().ID(), now.Add(2*time.Minute))
+	other.ConsensusManaPledgeID = node
+	requireRefusal(t, queue.Enqueue(other), RefusalNodeQuotaExceeded)
+
+	// neither do different mana pledge nodes of the same issuer
+	otherPledge := newTestRequest(t, identity.GenerateIdentity().ID(), now.Add(2*time.Minute))
+	otherPledge.IssuerID = node
+	requireRefusal(t, queue.Enqueue(otherPledge), RefusalNodeQuotaExceeded)
+
+	// failed requests don't count towards the quotas
+	dequeued, ok := queue.Dequeue()
+	require.True(t, ok)
+	require.Equal(t, first.MessageID, dequeued.MessageID)
+	require.NoError(t, queue.Failed(dequeued))
+	assert.NoError(t, queue.Check(first.Address, node, node, node, now.Add(2*time.Minute)))
+
+	// after the window, the node is allowed again
+	assert.NoError(t, queue.Enqueue(newTestRequest(t, node, now.Add(61*time.Minute))))
+}
+
+func TestQueue_Order(t *testing.T) {
+	queue, err := NewQueue(mapdb.NewMapDB(), WithNodeQuota(0, 0))
+	require.NoError(t, err)
+
+	now := time.Now()
+	spammer := identity.GenerateIdentity().ID()
+	var expected []tangle.MessageID
+	var spammed []*QueuedRequest
+	for i := 0; i < 3; i++ {
+		request := newTestRequest(t, spammer, now.Add(time.Duration(i)*time.Second))
+		require.NoError(t, queue.Enqueue(request))
+		spammed = append(spammed, request)
+	}
+	late := newTestRequest(t, identity.GenerateIdentity().ID(), now.Add(10*time.Second))
+	require.NoError(t, queue.Enqueue(late))
+	prioritized := newTestRequest(t, identity.GenerateIdentity().ID(), now.Add(20*time.Second))
+	prioritized.Prioritized = true
+	require.NoError(t, queue.Enqueue(prioritized))
+
+	// prioritized first, then one request per node
+	expected = append(expected, prioritized.MessageID, spammed[0].MessageID, late.MessageID, spammed[1].MessageID, spammed[2].MessageID)
+
+	for i, messageID := range expected {
+		_, position, err := queue.Request(messageID)
+		require.NoError(t, err)
+		assert.Equal(t, i, position)
+	}
+	assert.Equal(t, len(expected), queue.Size())
+
+	for _, messageID := range expected {
+		request, ok := queue.Dequeue()
+		require.True(t, ok)
+		assert.Equal(t, messageID, request.MessageID)
+		assert.Equal(t, RequestProcessing, request.State)
+	}
+	_, ok := queue.Dequeue()
+	assert.False(t, ok)
+}
+
+func TestQueue_Persistence(t *testing.T) {
+	store := mapdb.NewMapDB()
+	queue, err := NewQueue(store)
+	require.NoError(t, err)
+
+	now := time.Now()
+	fulfilled := newTestRequest(t, identity.GenerateIdentity().ID(), now)
+	processing := newTestRequest(t, identity.GenerateIdentity().ID(), now.Add(time.Second))
+	queued := newTestRequest(t, identity.GenerateIdentity().ID(), now.Add(2*time.Second))
+	for _, request := range []*QueuedRequest{fulfilled, processing, queued} {
+		require.NoError(t, queue.Enqueue(request))
+	}
+	request, ok := queue.Dequeue()
+	require.True(t, ok)
+	transactionID := ledgerstate.TransactionID{1}
+	require.NoError(t, queue.Fulfilled(request, transactionID))
+	_, ok = queue.Dequeue()
+	require.True(t, ok)
+
+	restored, err := NewQueue(store)
+	require.NoError(t, err)
+	assert.Equal(t, 1, restored.Size())
+	select {
+	case <-restored.Ready():
+	default:
+		assert.Fail(t, "restored queue is not ready")
+	}
+
+	request, _, err = restored.Request(fulfilled.MessageID)
+	require.NoError(t, err)
+	assert.Equal(t, RequestFulfilled, request.State)
+	assert.Equal(t, transactionID, request.TransactionID)
+	assert.Equal(t, fulfilled.Address, request.Address)
+	assert.True(t, fulfilled.Received.Equal(request.Received))
+
+	// the outcome of a request that was processed during the shutdown is unknown
+	request, _, err = restored.Request(processing.MessageID)
+	require.NoError(t, err)
+	assert.Equal(t, RequestFailed, request.State)
+
+	// the quotas are restored
+	requireRefusal(t, restored.Check(fulfilled.Address, identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), identity.GenerateIdentity().ID(), now), RefusalAddressQuotaExceeded)
+	assert.NoError(t, restored.Check(processing.Address, processing.IssuerID, processing.AccessManaPledgeID, processing.ConsensusManaPledgeID, now))
+
+	require.NoError(t, restored.Prune(now.Add(time.Minute)))
+	_, _, err = restored.Request(fulfilled.MessageID)
+	assert.True(t, errors.Is(err, ErrRequestNotFound))
+	_, position, err := restored.Request(queued.MessageID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, position)
+}
+
+func TestQueue_ExemptNodes(t *testing.T) {
+	faucetID := identity.GenerateIdentity().ID()
+	queue, err := NewQueue(mapdb.NewMapDB(), WithNodeQuota(1, time.Hour), WithExemptNodes(faucetID))
+	require.NoError(t, err)
+
+	// the requests issued by the faucet for its web API clients don't share a quota
+	now := time.Now()
+	first := newTestRequest(t, faucetID, now)
+	require.NoError(t, queue.Enqueue(first))
+	second := newTestRequest(t, faucetID, now.Add(time.Second))
+	require.NoError(t, queue.Enqueue(second))
+
+	// but the quota of their mana pledge nodes still applies
+	client := identity.GenerateIdentity().ID()
+	pledging := newTestRequest(t, faucetID, now.Add(2*time.Second))
+	pledging.AccessManaPledgeID = client
+	require.NoError(t, queue.Enqueue(pledging))
+	pledgingAgain := newTestRequest(t, faucetID, now.Add(3*time.Second))
+	pledgingAgain.ConsensusManaPledgeID = client
+	requireRefusal(t, queue.Enqueue(pledgingAgain), RefusalNodeQuotaExceeded)
+
+	// the requests of the faucet take turns by their access mana pledge node
+	for _, expected := range []*QueuedRequest{first, pledging, second} {
+		request, ok := queue.Dequeue()
+		require.True(t, ok)
+		assert.Equal(t, expected.MessageID, request.MessageID)
+	}
+}
+
+func TestQueue_RefusalCapacity(t *testing.T) {
+	store := mapdb.NewMapDB()
+	queue, err := NewQueue(store, WithCapacity(0), WithRefusalCapacity(2))
+	require.NoError(t, err)
+
+	now := time.Now()
+	var refused []*QueuedRequest
+	for i := 0; i < 3; i++ {
+		request := newTestRequest(t, identity.GenerateIdentity().ID(), now.Add(time.Duration(i)*time.Second))
+		requireRefusal(t, queue.Enqueue(request), RefusalQueueFull)
+		refused = append(refused, request)
+	}
+
+	// only the most recent refusals are kept
+	_, _, err = queue.Request(refused[0].MessageID)
+	assert.True(t, errors.Is(err, ErrRequestNotFound))
+	for _, request := range refused[1:] {
+		stored, _, err := queue.Request(request.MessageID)
+		require.NoError(t, err)
+		assert.Equal(t, RefusalQueueFull, stored.Refusal)
+	}
+
+	// the capacity also applies to the refusals that are restored
+	restored, err := NewQueue(store, WithCapacity(0), WithRefusalCapacity(1))
+	require.NoError(t, err)
+	_, _, err = restored.Request(refused[1].MessageID)
+	assert.True(t, errors.Is(err, ErrRequestNotFound))
+	_, _, err = restored.Request(refused[2].MessageID)
+	assert.NoError(t, err)
+}
+
+func TestQueue_Capacity(t *testing.T) {
+	queue, err := NewQueue(mapdb.NewMapDB(), WithCapacity(1))
+	require.NoError(t, err)
+
+	require.NoError(t, queue.Enqueue(newTestRequest(t, identity.GenerateIdentity().ID(), time.Now())))
+	requireRefusal(t, queue.Enqueue(newTestRequest(t, identity.GenerateIdentity().ID(), time.Now())), RefusalQueueFull)
+}
+
+func TestRefusalReason(t *testing.T) {
+	for reason := RefusalInsufficientPoW; reason <= RefusalAlreadyQueued; reason++ {
+		parsed, err := RefusalReasonFromString(reason.String())
+		require.NoError(t, err)
+		assert.Equal(t, reason, parsed)
+	}
+	_, err := RefusalReasonFromString("unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,84 @@
+package faucet
+
+import (
+	"sort"
+	"time"
+)
+
+// quota limits the number of requests per key within a sliding time window. It is not thread safe.
+type quota struct {
+	limit  int
+	window time.Duration
+	// the sorted times of the requests of every key within the window.
+	requests map[string][]time.Time
+}
+
+// newQuota returns a quota that allows limit requests per key within the window. A limit <= 0 disables the quota.
+func newQuota(limit int, window time.Duration) *quota {
+	return &quota{
+		limit:    limit,
+		window:   window,
+		requests: make(map[string][]time.Time),
+	}
+}
+
+// check returns whether a request of the given key is allowed at the given time. If not, it also returns the duration
+// after which the next request would be allowed.
+func (q *quota) check(key string, now time.Time) (allowed bool, retryAfter time.Duration) {
+	if q.limit <= 0 {
+		return true, 0
+	}
+
+	q.expire(key, now)
+	requests := q.requests[key]
+	if len(requests) < q.limit {
+		return true, 0
+	}
+	return false, requests[len(requests)-q.limit].Add(q.window).Sub(now)
+}
+
+// add records a request of the given key at the given time.
+func (q *quota) add(key string, t time.Time) {
+	if q.limit <= 0 {
+		return
+	}
+
+	requests := q.requests[key]
+	i := sort.Search(len(requests), func(i int) bool { return requests[i].After(t) })
+	requests = append(requests, time.Time{})
+	copy(requests[i+1:], requests[i:])
+	requests[i] = t
+	q.requests[key] = requests
+}
+
+// remove removes a request of the given key at the given time, so that it doesn't count towards the quota anymore.
+func (q *quota) remove(key string, t time.Time) {
+	requests := q.requests[key]
+	for i := range requests {
+		if requests[i].Equal(t) {
+			q.requests[key] = append(requests[:i], requests[i+1:]...)
+			break
+		}
+	}
+	if len(q.requests[key]) == 0 {
+		delete(q.requests, key)
+	}
+}
+
+// prune removes the requests of all keys that are outside of the window at the given time.
+func (q *quota) prune(now time.Time) {
+	for key := range q.requests {
+		q.expire(key, now)
+	}
+}
+
+func (q *quota) expire(key string, now time.Time) {
+	requests := q.requests[key]
+	cutoff := now.Add(-q.window)
+	i := sort.Search(len(requests), func(i int) bool { return requests[i].After(cutoff) })
+	if i == len(requests) {
+		delete(q.requests, key)
+		return
+	}
+	q.requests[key] = requests[i:]
+}
@@ -0,0 +1,93 @@
+package faucet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ErrRequestRefused is returned if the faucet refuses to fund a request.
+var ErrRequestRefused = errors.New("faucet request refused")
+
+// region RefusalReason ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RefusalReason is the reason why the faucet refused to fund a request.
+type RefusalReason uint8
+
+const (
+	// NotRefused is the RefusalReason of requests that were not refused.
+	NotRefused RefusalReason = iota
+	// RefusalInsufficientPoW is the RefusalReason of requests that don't fulfill the PoW difficulty of the faucet.
+	RefusalInsufficientPoW
+	// RefusalAddressQuotaExceeded is the RefusalReason of requests whose address was funded too often recently.
+	RefusalAddressQuotaExceeded
+	// RefusalNodeQuotaExceeded is the RefusalReason of requests whose mana pledge node requested too often recently.
+	RefusalNodeQuotaExceeded
+	// RefusalQueueFull is the RefusalReason of requests that arrived while the request queue of the faucet was full.
+	RefusalQueueFull
+	// RefusalAlreadyQueued is the RefusalReason of requests that were already received by the faucet.
+	RefusalAlreadyQueued
+)
+
+var refusalReasonNames = map[RefusalReason]string{
+	NotRefused:                  "",
+	RefusalInsufficientPoW:      "insufficientPoW",
+	RefusalAddressQuotaExceeded: "addressQuotaExceeded",
+	RefusalNodeQuotaExceeded:    "nodeQuotaExceeded",
+	RefusalQueueFull:            "queueFull",
+	RefusalAlreadyQueued:        "alreadyQueued",
+}
+
+// RefusalReasonFromString returns the RefusalReason with the given name.
+func RefusalReasonFromString(name string) (RefusalReason, error) {
+	for reason, reasonName := range refusalReasonNames {
+		if reasonName == name {
+			return reason, nil
+		}
+	}
+	return NotRefused, errors.Errorf("unknown refusal reason %s", name)
+}
+
+// String returns the name of the RefusalReason, which is used in the web API.
+func (r RefusalReason) String() string {
+	if name, ok := refusalReasonNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("RefusalReason(%d)", uint8(r))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RefusalError /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RefusalError is the error returned if the faucet refuses a request. It matches ErrRequestRefused.
+type RefusalError struct {
+	// Reason is the reason of the refusal.
+	Reason RefusalReason
+	// RetryAfter is the time after which the same request would be accepted again, or zero if it's unknown.
+	RetryAfter time.Duration
+}
+
+// NewRefusalError returns a new RefusalError with the given reason.
+func NewRefusalError(reason RefusalReason, retryAfter time.Duration) *RefusalError {
+	return &RefusalError{
+		Reason:     reason,
+		RetryAfter: retryAfter,
+	}
+}
+
+// Error returns a human readable version of the RefusalError.
+func (e *RefusalError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s: %s (retry after %v)", ErrRequestRefused, e.Reason, e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("%s: %s", ErrRequestRefused, e.Reason)
+}
+
+// Is returns true if the target is ErrRequestRefused.
+func (e *RefusalError) Is(target error) bool {
+	return target == ErrRequestRefused
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
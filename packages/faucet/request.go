@@ -111,6 +111,19 @@ func (p *Request) ConsensusManaPledgeID() identity.ID {
 	return p.consensusManaPledgeID
 }
 
+// ManaPledgeIDs returns the IDs of the nodes that the access and consensus mana of the funding is pledged to. Both
+// default to the given issuer of the request.
+func (p *Request) ManaPledgeIDs(issuerID identity.ID) (accessManaPledgeID, consensusManaPledgeID identity.ID) {
+	accessManaPledgeID, consensusManaPledgeID = issuerID, issuerID
+	if p.accessManaPledgeID != (identity.ID{}) {
+		accessManaPledgeID = p.accessManaPledgeID
+	}
+	if p.consensusManaPledgeID != (identity.ID{}) {
+		consensusManaPledgeID = p.consensusManaPledgeID
+	}
+	return
+}
+
 // Bytes marshals the faucet Request payload into a sequence of bytes.
 func (p *Request) Bytes() []byte {
 	// initialize helper
@@ -0,0 +1,70 @@
+package gossip
+
+import (
+	"bytes"
+	"compress/flate"
+	"io"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
+)
+
+// SupportedCompressions contains the compression algorithms that can be negotiated for gossip connections.
+var SupportedCompressions = []server.Compression{server.CompressionDeflate}
+
+// ErrUnsupportedCompression is returned when a packet is compressed with an unsupported algorithm.
+var ErrUnsupportedCompression = errors.New("unsupported compression")
+
+// deflateWriterPool reuses the (large) state of the DEFLATE compressors.
+var deflateWriterPool = sync.Pool{
+	New: func() interface{} {
+		writer, err := flate.NewWriter(nil, flate.BestSpeed)
+		if err != nil {
+			panic(err)
+		}
+		return writer
+	},
+}
+
+// compress compresses the data with the given algorithm.
+func compress(compression server.Compression, data []byte) ([]byte, error) {
+	switch compression {
+	case server.CompressionDeflate:
+		var buffer bytes.Buffer
+		writer := deflateWriterPool.Get().(*flate.Writer)
+		defer deflateWriterPool.Put(writer)
+
+		writer.Reset(&buffer)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	default:
+		return nil, errors.Errorf("failed to compress with '%s': %w", compression, ErrUnsupportedCompression)
+	}
+}
+
+// decompress decompresses the data with the given algorithm. It fails if the result exceeds maxPacketSize.
+func decompress(compression server.Compression, data []byte) ([]byte, error) {
+	switch compression {
+	case server.CompressionDeflate:
+		reader := flate.NewReader(bytes.NewReader(data))
+		defer reader.Close()
+
+		decompressed, err := io.ReadAll(io.LimitReader(reader, maxPacketSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(decompressed) > maxPacketSize {
+			return nil, errors.Errorf("decompressed packet exceeds %d bytes: %w", maxPacketSize, ErrInvalidPacket)
+		}
+		return decompressed, nil
+	default:
+		return nil, errors.Errorf("failed to decompress with '%s': %w", compression, ErrUnsupportedCompression)
+	}
+}
@@ -13,6 +13,8 @@ var (
 	ErrDuplicateNeighbor = errors.New("already connected")
 	// ErrInvalidPacket is returned when the gossip manager receives an invalid packet.
 	ErrInvalidPacket = errors.New("invalid packet")
+	// ErrNeighborBanned is returned when a peer is added as a neighbor while it is banned.
+	ErrNeighborBanned = errors.New("neighbor banned")
 	// ErrNeighborQueueFull is returned when the send queue is already full.
 	ErrNeighborQueueFull = errors.New("send queue is full")
 )
@@ -19,6 +19,8 @@ type NeighborsEvents struct {
 	NeighborAdded *events.Event
 	// Fired when a neighbor has been removed.
 	NeighborRemoved *events.Event
+	// Fired when a neighbor is banned, because its score fell below the threshold.
+	NeighborBanned *events.Event
 }
 
 // NewNeighborsEvents returns a new instance of NeighborsEvents.
@@ -27,6 +29,7 @@ func NewNeighborsEvents() NeighborsEvents {
 		ConnectionFailed: events.NewEvent(peerAndErrorCaller),
 		NeighborAdded:    events.NewEvent(neighborCaller),
 		NeighborRemoved:  events.NewEvent(neighborCaller),
+		NeighborBanned:   events.NewEvent(neighborCaller),
 	}
 }
 
@@ -3,9 +3,9 @@ package gossip
 import (
 	"context"
 	"fmt"
-	"net"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/autopeering/peer"
@@ -43,6 +43,7 @@ type Manager struct {
 	log             *logger.Logger
 	events          Events
 	neighborsEvents map[NeighborsGroup]NeighborsEvents
+	config          *managerConfig
 
 	server      *server.TCP
 	serverMutex sync.RWMutex
@@ -50,6 +51,10 @@ type Manager struct {
 	neighbors      map[identity.ID]*Neighbor
 	neighborsMutex sync.RWMutex
 
+	bannedPeers    map[identity.ID]time.Time
+	neverDropPeers map[identity.ID]struct{}
+	scoringMutex   sync.Mutex
+
 	// messageWorkerPool defines a worker pool where all incoming messages are processed.
 	messageWorkerPool *workerpool.NonBlockingQueuedWorkerPool
 
@@ -57,7 +62,7 @@ type Manager struct {
 }
 
 // NewManager creates a new Manager.
-func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger) *Manager {
+func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger, opts ...ManagerOption) *Manager {
 	m := &Manager{
 		local:           local,
 		loadMessageFunc: f,
@@ -69,8 +74,11 @@ func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger) *Manag
 			NeighborsGroupAuto:   NewNeighborsEvents(),
 			NeighborsGroupManual: NewNeighborsEvents(),
 		},
-		neighbors: map[identity.ID]*Neighbor{},
-		server:    nil,
+		config:         buildManagerConfig(opts),
+		neighbors:      map[identity.ID]*Neighbor{},
+		bannedPeers:    map[identity.ID]time.Time{},
+		neverDropPeers: map[identity.ID]struct{}{},
+		server:         nil,
 	}
 
 	m.messageWorkerPool = workerpool.NewNonBlockingQueuedWorkerPool(func(task workerpool.Task) {
@@ -80,7 +88,12 @@ func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger) *Manag
 	}, workerpool.WorkerCount(messageWorkerCount), workerpool.QueueSize(messageWorkerQueueSize))
 
 	m.messageRequestWorkerPool = workerpool.NewNonBlockingQueuedWorkerPool(func(task workerpool.Task) {
-		m.processMessageRequest(task.Param(0).([]byte), task.Param(1).(*Neighbor))
+		data, nbr := task.Param(0).([]byte), task.Param(1).(*Neighbor)
+		if pb.PacketType(data[0]) == pb.PacketMessageRequestBatch {
+			m.processMessageRequestBatch(data, nbr)
+		} else {
+			m.processMessageRequest(data, nbr)
+		}
 
 		task.Return(nil)
 	}, workerpool.WorkerCount(messageRequestWorkerCount), workerpool.QueueSize(messageRequestWorkerQueueSize))
@@ -148,6 +161,74 @@ func (m *Manager) DropNeighbor(id identity.ID, group NeighborsGroup) error {
 	return nbr.Close()
 }
 
+// Penalize lowers the score of the neighbor with the given ID by the given penalty. If scoring is enabled and the score
+// falls below the threshold, the neighbor is dropped and banned for the configured duration, unless it was exempted
+// with SetNeverDrop.
+func (m *Manager) Penalize(id identity.ID, penalty float64) {
+	if !m.config.scoring {
+		return
+	}
+
+	m.neighborsMutex.RLock()
+	nbr, ok := m.neighbors[id]
+	m.neighborsMutex.RUnlock()
+	if !ok {
+		return
+	}
+
+	if score := nbr.score.Add(-penalty); score < m.config.scoreThreshold {
+		m.banNeighbor(nbr)
+	}
+}
+
+// SetNeverDrop sets whether the peer with the given ID is exempt from being dropped and banned when its score falls
+// below the threshold.
+func (m *Manager) SetNeverDrop(id identity.ID, neverDrop bool) {
+	m.scoringMutex.Lock()
+	defer m.scoringMutex.Unlock()
+
+	if neverDrop {
+		m.neverDropPeers[id] = struct{}{}
+		return
+	}
+	delete(m.neverDropPeers, id)
+}
+
+// banNeighbor bans the peer of the given neighbor and drops the connection.
+func (m *Manager) banNeighbor(nbr *Neighbor) {
+	m.scoringMutex.Lock()
+	if _, neverDrop := m.neverDropPeers[nbr.ID()]; neverDrop {
+		m.scoringMutex.Unlock()
+		return
+	}
+	if bannedUntil, banned := m.bannedPeers[nbr.ID()]; banned && time.Now().Before(bannedUntil) {
+		m.scoringMutex.Unlock()
+		return
+	}
+	m.bannedPeers[nbr.ID()] = time.Now().Add(m.config.banDuration)
+	m.scoringMutex.Unlock()
+
+	m.neighborsEvents[nbr.Group].NeighborBanned.Trigger(nbr)
+
+	// the neighbor might be penalized while one of its packets is handled, so it must not be closed synchronously
+	go func() {
+		_ = nbr.Close()
+	}()
+}
+
+// isBanned returns true if the peer with the given ID is currently banned.
+func (m *Manager) isBanned(id identity.ID) bool {
+	m.scoringMutex.Lock()
+	defer m.scoringMutex.Unlock()
+
+	bannedUntil, banned := m.bannedPeers[id]
+	if banned && !time.Now().Before(bannedUntil) {
+		delete(m.bannedPeers, id)
+		return false
+	}
+	return banned
+}
+
 // getNeighbor returns neighbor by ID and group.
 func (m *Manager) getNeighbor(id identity.ID, group NeighborsGroup) (*Neighbor, error) {
 	m.neighborsMutex.RLock()
@@ -160,10 +241,40 @@ func (m *Manager) getNeighbor(id identity.ID, group NeighborsGroup) (*Neighbor,
 }
 
 // RequestMessage requests the message with the given id from the neighbors.
-// If no peer is provided, all neighbors are queried.
+// If no peer is provided, all neighbors are queried. If the requests are scheduled, the request is queued and sent
+// together with the other requests to the neighbor.
 func (m *Manager) RequestMessage(messageID []byte, to ...identity.ID) {
-	msgReq := &pb.MessageRequest{Id: messageID}
-	m.send(marshal(msgReq), to...)
+	if m.config.requestInterval == 0 {
+		msgReq := &pb.MessageRequest{Id: messageID}
+		m.send(marshal(msgReq), to...)
+		return
+	}
+
+	msgID, _, err := tangle.MessageIDFromBytes(messageID)
+	if err != nil {
+		m.log.Debugw("invalid message id:", "err", err)
+		return
+	}
+	for _, nbr := range m.targetNeighbors(to) {
+		nbr.requests.Add(msgID)
+	}
+}
+
+// StopMessageRequest removes the scheduled requests for the message with the given id, e.g. because the message was
+// received. The neighbors that were requested to send the message can then be sent the next requests.
+func (m *Manager) StopMessageRequest(messageID []byte) {
+	if m.config.requestInterval == 0 {
+		return
+	}
+
+	msgID, _, err := tangle.MessageIDFromBytes(messageID)
+	if err != nil {
+		m.log.Debugw("invalid message id:", "err", err)
+		return
+	}
+	for _, nbr := range m.AllNeighbors() {
+		nbr.requests.Remove(msgID)
+	}
 }
 
 // SendMessage adds the given message the send queue of the neighbors.
@@ -200,13 +311,17 @@ func (m *Manager) getNeighborsByID(ids []identity.ID) []*Neighbor {
 	return result
 }
 
-func (m *Manager) send(b []byte, to ...identity.ID) {
+// targetNeighbors returns the neighbors with the given IDs or all neighbors, if none of them is connected.
+func (m *Manager) targetNeighbors(to []identity.ID) []*Neighbor {
 	neighbors := m.getNeighborsByID(to)
 	if len(neighbors) == 0 {
 		neighbors = m.AllNeighbors()
 	}
+	return neighbors
+}
 
-	for _, nbr := range neighbors {
+func (m *Manager) send(b []byte, to ...identity.ID) {
+	for _, nbr := range m.targetNeighbors(to) {
 		if _, err := nbr.Write(b); err != nil {
 			m.log.Warnw("send error", "peer-id", nbr.ID(), "err", err)
 		}
@@ -214,7 +329,7 @@ func (m *Manager) send(b []byte, to ...identity.ID) {
 }
 
 func (m *Manager) addNeighbor(ctx context.Context, p *peer.Peer, group NeighborsGroup,
-	connectorFunc func(context.Context, *peer.Peer, ...server.ConnectPeerOption) (net.Conn, error),
+	connectorFunc func(context.Context, *peer.Peer, ...server.ConnectPeerOption) (*server.Conn, error),
 	connectOpts []server.ConnectPeerOption,
 ) error {
 	if p.ID() == m.local.ID() {
@@ -229,6 +344,10 @@ func (m *Manager) addNeighbor(ctx context.Context, p *peer.Peer, group Neighbors
 		m.neighborsEvents[group].ConnectionFailed.Trigger(p, ErrDuplicateNeighbor)
 		return ErrDuplicateNeighbor
 	}
+	if m.isBanned(p.ID()) {
+		m.neighborsEvents[group].ConnectionFailed.Trigger(p, ErrNeighborBanned)
+		return ErrNeighborBanned
+	}
 
 	conn, err := connectorFunc(ctx, p, connectOpts...)
 	if err != nil {
@@ -237,7 +356,20 @@ func (m *Manager) addNeighbor(ctx context.Context, p *peer.Peer, group Neighbors
 	}
 
 	// create and add the neighbor
-	nbr := NewNeighbor(p, group, conn, m.log)
+	neighborOpts := []NeighborOption{
+		WithNeighborCompression(conn.Compression),
+		WithNeighborScoreHalfLife(m.config.scoreHalfLife),
+	}
+	if conn.Batching && m.config.batchFlushInterval > 0 {
+		neighborOpts = append(neighborOpts, WithNeighborBatching(m.config.batchFlushInterval, m.config.batchMaxSize))
+	}
+	if m.config.requestInterval > 0 {
+		neighborOpts = append(neighborOpts, WithNeighborRequestScheduling(m.config.requestInterval, m.config.requestMaxQueued, m.config.requestMaxInFlight, m.config.requestTimeout))
+		if conn.RequestBatching {
+			neighborOpts = append(neighborOpts, WithNeighborRequestBatching())
+		}
+	}
+	nbr := NewNeighbor(p, group, conn, m.log, neighborOpts...)
 	if err := m.setNeighbor(nbr); err != nil {
 		_ = conn.Close()
 		m.neighborsEvents[group].ConnectionFailed.Trigger(p, err)
@@ -290,12 +422,55 @@ func (m *Manager) handlePacket(data []byte, nbr *Neighbor) error {
 		return nil
 	}
 
+	// compressed packets contain a single packet or a batch
+	if pb.PacketType(data[0]) == pb.PacketCompressed {
+		if nbr.Compression() == server.CompressionNone {
+			return fmt.Errorf("compression was not negotiated: %w", ErrInvalidPacket)
+		}
+		packet := new(pb.Compressed)
+		if err := proto.Unmarshal(data[1:], packet); err != nil {
+			return fmt.Errorf("%s: %w", err, ErrInvalidPacket)
+		}
+		decompressed, err := decompress(nbr.Compression(), packet.GetData())
+		if err != nil {
+			return err
+		}
+		if len(decompressed) == 0 || pb.PacketType(decompressed[0]) == pb.PacketCompressed {
+			return ErrInvalidPacket
+		}
+		data = decompressed
+	}
+
+	if pb.PacketType(data[0]) == pb.PacketBatch {
+		batch := new(pb.Batch)
+		if err := proto.Unmarshal(data[1:], batch); err != nil {
+			return fmt.Errorf("%s: %w", err, ErrInvalidPacket)
+		}
+		// handle all packets of the batch, even if some of them fail
+		var err error
+		for _, packet := range batch.GetPackets() {
+			if packetErr := m.handleSinglePacket(packet, nbr); packetErr != nil && err == nil {
+				err = packetErr
+			}
+		}
+		return err
+	}
+
+	return m.handleSinglePacket(data, nbr)
+}
+
+func (m *Manager) handleSinglePacket(data []byte, nbr *Neighbor) error {
+	// ignore empty packages
+	if len(data) == 0 {
+		return nil
+	}
+
 	switch pb.PacketType(data[0]) {
 	case pb.PacketMessage:
 		if _, added := m.messageWorkerPool.TrySubmit(data, nbr); !added {
 			return fmt.Errorf("messageWorkerPool full: packet message discarded")
 		}
-	case pb.PacketMessageRequest:
+	case pb.PacketMessageRequest, pb.PacketMessageRequestBatch:
 		if _, added := m.messageRequestWorkerPool.TrySubmit(data, nbr); !added {
 			return fmt.Errorf("messageRequestWorkerPool full: message request discarded")
 		}
@@ -346,7 +521,28 @@ func (m *Manager) processMessageRequest(data []byte, nbr *Neighbor) {
 		return
 	}
 
-	msgID, _, err := tangle.MessageIDFromBytes(packet.GetId())
+	m.sendRequestedMessage(packet.GetId(), nbr)
+}
+
+func (m *Manager) processMessageRequestBatch(data []byte, nbr *Neighbor) {
+	packet := new(pb.MessageRequestBatch)
+	if err := proto.Unmarshal(data[1:], packet); err != nil {
+		m.log.Debugw("invalid packet", "err", err)
+		return
+	}
+	if len(packet.GetIds()) > maxRequestBatchSize {
+		m.log.Debugw("invalid packet", "err", "too many message requests", "size", len(packet.GetIds()))
+		return
+	}
+
+	for _, id := range packet.GetIds() {
+		m.sendRequestedMessage(id, nbr)
+	}
+}
+
+// sendRequestedMessage sends the message with the given id to the neighbor that requested it.
+func (m *Manager) sendRequestedMessage(id []byte, nbr *Neighbor) {
+	msgID, _, err := tangle.MessageIDFromBytes(id)
 	if err != nil {
 		m.log.Debugw("invalid message id:", "err", err)
 		return
@@ -361,3 +557,64 @@ func (m *Manager) processMessageRequest(data []byte, nbr *Neighbor) {
 	// send the loaded message directly to the neighbor
 	_, _ = nbr.Write(marshal(&pb.Message{Data: msgBytes}))
 }
+
+// region ManagerOption ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ManagerOption defines an option for the Manager.
+type ManagerOption func(conf *managerConfig)
+
+type managerConfig struct {
+	batchFlushInterval time.Duration
+	batchMaxSize       int
+	scoring            bool
+	scoreHalfLife      time.Duration
+	scoreThreshold     float64
+	banDuration        time.Duration
+	requestInterval    time.Duration
+	requestMaxQueued   int
+	requestMaxInFlight int
+	requestTimeout     time.Duration
+}
+
+func buildManagerConfig(opts []ManagerOption) *managerConfig {
+	conf := &managerConfig{}
+	for _, o := range opts {
+		o(conf)
+	}
+	return conf
+}
+
+// WithBatching returns a ManagerOption that batches the packets sent to the neighbors that accept batched packets.
+// The packets are collected for the flush interval and sent as a single packet of at most maxSize bytes.
+func WithBatching(flushInterval time.Duration, maxSize int) ManagerOption {
+	return func(conf *managerConfig) {
+		conf.batchFlushInterval = flushInterval
+		conf.batchMaxSize = maxSize
+	}
+}
+
+// WithScoring returns a ManagerOption that evicts misbehaving neighbors. The penalties of the neighbors decay with the
+// given half-life and a neighbor whose score falls below the threshold is dropped and banned for the ban duration.
+func WithScoring(halfLife time.Duration, threshold float64, banDuration time.Duration) ManagerOption {
+	return func(conf *managerConfig) {
+		conf.scoring = true
+		conf.scoreHalfLife = halfLife
+		conf.scoreThreshold = threshold
+		conf.banDuration = banDuration
+	}
+}
+
+// WithRequestScheduling returns a ManagerOption that queues up to maxQueued message requests per neighbor instead of
+// sending them immediately. The queued requests are sent every interval (as a single batch to the neighbors that accept batched
+// requests), so that at most maxInFlight requests of a neighbor are unanswered at any time. A request that is not
+// answered within the timeout no longer counts towards that limit.
+func WithRequestScheduling(interval time.Duration, maxQueued int, maxInFlight int, timeout time.Duration) ManagerOption {
+	return func(conf *managerConfig) {
+		conf.requestInterval = interval
+		conf.requestMaxQueued = maxQueued
+		conf.requestMaxInFlight = maxInFlight
+		conf.requestTimeout = timeout
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -125,6 +125,66 @@ func TestP2PSend(t *testing.T) {
 	mgrB.AssertExpectations(t)
 }
 
+func TestP2PSendBatchedAndCompressed(t *testing.T) {
+	srvOpts := []server.Option{server.WithCompression(SupportedCompressions...)}
+	mgrA, closeA, peerA := newTestManager(t, "A", srvOpts, WithBatching(graceTime, maxPacketSize))
+	mgrB, closeB, peerB := newTestManager(t, "B", srvOpts, WithBatching(graceTime, maxPacketSize))
+	mockedA, mockedB := mockManager(t, mgrA), mockManager(t, mgrB)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// connect in the following way
+	// B -> A
+	mockedA.On("neighborAdded", mock.Anything).Once()
+	mockedB.On("neighborAdded", mock.Anything).Once()
+
+	go func() {
+		defer wg.Done()
+		err := mgrA.AddInbound(context.Background(), peerB, NeighborsGroupAuto)
+		assert.NoError(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		err := mgrB.AddOutbound(context.Background(), peerA, NeighborsGroupAuto)
+		assert.NoError(t, err)
+	}()
+
+	// wait for the connections to establish
+	wg.Wait()
+
+	neighborA := mgrA.AllNeighbors()[0]
+	assert.Equal(t, server.CompressionDeflate, neighborA.Compression())
+	assert.True(t, neighborA.Batching())
+
+	const messageCount = 10
+	mockedB.On("messageReceived", &MessageReceivedEvent{
+		Data: testMessageData,
+		Peer: peerA,
+	}).Times(messageCount)
+
+	for i := 0; i < messageCount; i++ {
+		mgrA.SendMessage(testMessageData)
+	}
+	time.Sleep(4 * graceTime)
+
+	// the messages were sent as a single compressed batch
+	assert.Equal(t, uint64(messageCount*(packetHeaderSize+len(marshal(&pb.Message{Data: testMessageData})))), neighborA.PacketBytesWritten())
+	assert.Less(t, neighborA.BytesWritten(), neighborA.PacketBytesWritten())
+	assert.Equal(t, neighborA.PacketBytesWritten()-neighborA.BytesWritten(), neighborA.SavedBytes())
+
+	mockedA.On("neighborRemoved", mock.Anything).Once()
+	mockedB.On("neighborRemoved", mock.Anything).Once()
+
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mockedA.AssertExpectations(t)
+	mockedB.AssertExpectations(t)
+}
+
 func TestP2PSendTwice(t *testing.T) {
 	mgrA, closeA, peerA := newMockedManager(t, "A")
 	mgrB, closeB, peerB := newMockedManager(t, "B")
@@ -359,10 +419,74 @@ func TestMessageRequest(t *testing.T) {
 	mgrB.AssertExpectations(t)
 }
 
+func TestScheduledMessageRequests(t *testing.T) {
+	mgrA, closeA, peerA := newTestManager(t, "A", nil, WithRequestScheduling(graceTime/10, 10, 2, time.Hour))
+	mockedA := mockManager(t, mgrA)
+	mgrB, closeB, peerB := newTestManager(t, "B", nil, WithRequestScheduling(graceTime/10, 10, 2, time.Hour))
+	mockedB := mockManager(t, mgrB)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// connect in the following way
+	// B -> A
+	mockedA.On("neighborAdded", mock.Anything).Once()
+	mockedB.On("neighborAdded", mock.Anything).Once()
+
+	go func() {
+		defer wg.Done()
+		err := mgrA.AddInbound(context.Background(), peerB, NeighborsGroupAuto)
+		assert.NoError(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		err := mgrB.AddOutbound(context.Background(), peerA, NeighborsGroupAuto)
+		assert.NoError(t, err)
+	}()
+
+	// wait for the connections to establish
+	wg.Wait()
+
+	nbrB, err := mgrA.getNeighbor(peerB.ID(), NeighborsGroupAuto)
+	require.NoError(t, err)
+	assert.True(t, nbrB.config.requestBatching)
+
+	// only two of the three requests are sent, as the requests are not answered in the view of the manager
+	mockedA.On("messageReceived", &MessageReceivedEvent{Data: testMessageData, Peer: peerB}).Twice()
+	ids := tangle.MessageIDs{{1}, {2}, {3}}
+	for _, id := range ids {
+		mgrA.RequestMessage(id.Bytes(), peerB.ID())
+	}
+	time.Sleep(graceTime)
+	queued, inFlight := nbrB.RequestQueueSize()
+	assert.Equal(t, 1, queued)
+	assert.Equal(t, 2, inFlight)
+	mockedA.AssertExpectations(t)
+
+	// the remaining request is sent as soon as one of the messages was received
+	mockedA.On("messageReceived", &MessageReceivedEvent{Data: testMessageData, Peer: peerB}).Once()
+	mgrA.StopMessageRequest(ids[0].Bytes())
+	time.Sleep(graceTime)
+	queued, inFlight = nbrB.RequestQueueSize()
+	assert.Equal(t, 0, queued)
+	assert.Equal(t, 2, inFlight)
+
+	mockedA.On("neighborRemoved", mock.Anything).Once()
+	mockedB.On("neighborRemoved", mock.Anything).Once()
+
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mockedA.AssertExpectations(t)
+	mockedB.AssertExpectations(t)
+}
+
 func TestDropNeighbor(t *testing.T) {
-	mgrA, closeA, peerA := newTestManager(t, "A")
+	mgrA, closeA, peerA := newTestManager(t, "A", nil)
 	defer closeA()
-	mgrB, closeB, peerB := newTestManager(t, "B")
+	mgrB, closeB, peerB := newTestManager(t, "B", nil)
 	defer closeB()
 
 	// establish connection
@@ -420,9 +544,9 @@ func TestDropNeighbor(t *testing.T) {
 }
 
 func TestDropNeighborDifferentGroup(t *testing.T) {
-	mgrA, closeA, peerA := newTestManager(t, "A")
+	mgrA, closeA, peerA := newTestManager(t, "A", nil)
 	defer closeA()
-	mgrB, closeB, peerB := newTestManager(t, "B")
+	mgrB, closeB, peerB := newTestManager(t, "B", nil)
 	defer closeB()
 
 	// establish connection
@@ -468,13 +592,54 @@ func TestDropNeighborDifferentGroup(t *testing.T) {
 	}
 }
 
+func TestPenalizeAndBan(t *testing.T) {
+	mgrA, closeA, peerA := newTestManager(t, "A", nil, WithScoring(time.Hour, -10, time.Hour))
+	defer closeA()
+	mgrB, closeB, peerB := newTestManager(t, "B", nil)
+	defer closeB()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	signal := events.NewClosure(func(_ *Neighbor) { wg.Done() })
+	mgrA.NeighborsEvents(NeighborsGroupManual).NeighborAdded.Attach(signal)
+	mgrB.NeighborsEvents(NeighborsGroupManual).NeighborAdded.Attach(signal)
+	go func() { assert.NoError(t, mgrA.AddInbound(context.Background(), peerB, NeighborsGroupManual)) }()
+	go func() { assert.NoError(t, mgrB.AddOutbound(context.Background(), peerA, NeighborsGroupManual)) }()
+	wg.Wait()
+	mgrA.NeighborsEvents(NeighborsGroupManual).NeighborAdded.Detach(signal)
+	mgrB.NeighborsEvents(NeighborsGroupManual).NeighborAdded.Detach(signal)
+
+	// penalties above the threshold only lower the score
+	mgrA.Penalize(peerB.ID(), 5)
+	nbr, err := mgrA.getNeighbor(peerB.ID(), NeighborsGroupManual)
+	require.NoError(t, err)
+	assert.InDelta(t, -5, nbr.Score(), 0.01)
+
+	banned := make(chan *Neighbor, 1)
+	mgrA.NeighborsEvents(NeighborsGroupManual).NeighborBanned.Attach(events.NewClosure(func(n *Neighbor) { banned <- n }))
+
+	// falling below the threshold drops and bans the neighbor
+	mgrA.Penalize(peerB.ID(), 6)
+	select {
+	case n := <-banned:
+		assert.Equal(t, peerB.ID(), n.ID())
+	case <-time.After(time.Second):
+		t.Fatal("neighbor was not banned")
+	}
+	assert.Eventually(t, func() bool { return len(mgrA.AllNeighbors()) == 0 }, time.Second, graceTime)
+	assert.Eventually(t, func() bool { return len(mgrB.AllNeighbors()) == 0 }, time.Second, graceTime)
+
+	// the banned peer can not be added again
+	assert.ErrorIs(t, mgrA.AddInbound(context.Background(), peerB, NeighborsGroupAuto), ErrNeighborBanned)
+}
+
 func newTestDB(t require.TestingT) *peer.DB {
 	db, err := peer.NewDB(mapdb.NewMapDB())
 	require.NoError(t, err)
 	return db
 }
 
-func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Peer) {
+func newTestManager(t require.TestingT, name string, srvOpts []server.Option, opts ...ManagerOption) (*Manager, func(), *peer.Peer) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -489,10 +654,10 @@ func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Pe
 	local, err := peer.NewLocal(lis.Addr().(*net.TCPAddr).IP, services, newTestDB(t))
 	require.NoError(t, err)
 
-	srv := server.ServeTCP(local, lis, l)
+	srv := server.ServeTCP(local, lis, l, srvOpts...)
 
 	// start the actual gossipping
-	mgr := NewManager(local, loadTestMessage, l)
+	mgr := NewManager(local, loadTestMessage, l, opts...)
 	mgr.Start(srv)
 
 	detach := func() {
@@ -504,7 +669,7 @@ func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Pe
 }
 
 func newMockedManager(t *testing.T, name string) (*mockedManager, func(), *peer.Peer) {
-	mgr, detach, p := newTestManager(t, name)
+	mgr, detach, p := newTestManager(t, name, nil)
 	return mockManager(t, mgr), detach, p
 }
 
@@ -12,12 +12,20 @@ import (
 	"github.com/iotaledger/hive.go/netutil"
 	"github.com/iotaledger/hive.go/netutil/buffconn"
 	"go.uber.org/atomic"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	pb "github.com/iotaledger/goshimmer/packages/gossip/proto"
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
+	"github.com/iotaledger/goshimmer/packages/tangle"
 )
 
 const (
 	neighborQueueSize        = 5000
 	maxNumReadErrors         = 10
 	droppedMessagesThreshold = 1000
+
+	// packetHeaderSize is the size of the header that is written in front of every packet.
+	packetHeaderSize = 4
 )
 
 // NeighborsGroup is an enum type for various neighbors groups like auto/manual.
@@ -35,10 +43,14 @@ type Neighbor struct {
 	*peer.Peer
 	*buffconn.BufferedConnection
 
-	Group           NeighborsGroup
-	log             *logger.Logger
-	queue           chan []byte
-	messagesDropped atomic.Int32
+	Group              NeighborsGroup
+	log                *logger.Logger
+	config             *neighborConfig
+	queue              chan []byte
+	messagesDropped    atomic.Int32
+	packetBytesWritten atomic.Uint64
+	score              *decayingScore
+	requests           *requestQueue
 
 	wg             sync.WaitGroup
 	closing        chan struct{}
@@ -48,7 +60,7 @@ type Neighbor struct {
 }
 
 // NewNeighbor creates a new neighbor from the provided peer and connection.
-func NewNeighbor(p *peer.Peer, group NeighborsGroup, conn net.Conn, log *logger.Logger) *Neighbor {
+func NewNeighbor(p *peer.Peer, group NeighborsGroup, conn net.Conn, log *logger.Logger, opts ...NeighborOption) *Neighbor {
 	if !IsSupported(p) {
 		panic("peer does not support gossip")
 	}
@@ -60,12 +72,22 @@ func NewNeighbor(p *peer.Peer, group NeighborsGroup, conn net.Conn, log *logger.
 		"addr", conn.RemoteAddr().String(),
 	)
 
+	config := buildNeighborConfig(opts)
+
+	var requests *requestQueue
+	if config.requestInterval > 0 {
+		requests = newRequestQueue(config.requestMaxQueued, config.requestMaxInFlight, config.requestTimeout)
+	}
+
 	return &Neighbor{
 		Peer:                  p,
 		Group:                 group,
 		BufferedConnection:    buffconn.NewBufferedConnection(conn, maxPacketSize),
 		log:                   log,
+		config:                config,
 		queue:                 make(chan []byte, neighborQueueSize),
+		score:                 newDecayingScore(config.scoreHalfLife),
+		requests:              requests,
 		closing:               make(chan struct{}),
 		connectionEstablished: time.Now(),
 	}
@@ -81,6 +103,10 @@ func (n *Neighbor) Listen() {
 	n.wg.Add(2)
 	go n.readLoop()
 	go n.writeLoop()
+	if n.requests != nil {
+		n.wg.Add(1)
+		go n.requestLoop()
+	}
 
 	n.log.Info("Connection established")
 }
@@ -94,6 +120,46 @@ func (n *Neighbor) Close() error {
 	return err
 }
 
+// Compression returns the algorithm that is used to compress the packets sent to the neighbor.
+func (n *Neighbor) Compression() server.Compression {
+	return n.config.compression
+}
+
+// Batching returns true if the packets sent to the neighbor are batched.
+func (n *Neighbor) Batching() bool {
+	return n.config.batchFlushInterval > 0
+}
+
+// PacketBytesWritten returns the total number of bytes of the packets that were sent to the neighbor, as if they were
+// written without batching and compression.
+func (n *Neighbor) PacketBytesWritten() uint64 {
+	return n.packetBytesWritten.Load()
+}
+
+// SavedBytes returns the total number of bytes that were saved by batching and compressing the packets.
+func (n *Neighbor) SavedBytes() uint64 {
+	packetBytesWritten, bytesWritten := n.PacketBytesWritten(), n.BytesWritten()
+	if bytesWritten >= packetBytesWritten {
+		return 0
+	}
+	return packetBytesWritten - bytesWritten
+}
+
+// RequestQueueSize returns the amount of message requests that are queued for the neighbor and the amount of requests
+// that were sent to the neighbor but have not been answered yet.
+func (n *Neighbor) RequestQueueSize() (queued int, inFlight int) {
+	if n.requests == nil {
+		return 0, 0
+	}
+	return n.requests.Size()
+}
+
+// Score returns the current score of the neighbor. The score starts at 0, is lowered by the penalties of the neighbor
+// and recovers towards 0 over time.
+func (n *Neighbor) Score() float64 {
+	return n.score.Value()
+}
+
 // IsOutbound returns true if the neighbor is an outbound neighbor.
 func (n *Neighbor) IsOutbound() bool {
 	return GetAddress(n.Peer) == n.RemoteAddr().String()
@@ -111,23 +177,139 @@ func (n *Neighbor) disconnect() (err error) {
 func (n *Neighbor) writeLoop() {
 	defer n.wg.Done()
 
+	var batch [][]byte
+	var batchSize int
+	var flushTimer <-chan time.Time
+	flush := func() error {
+		packets := batch
+		batch, batchSize, flushTimer = nil, 0, nil
+		if len(packets) == 0 {
+			return nil
+		}
+		return n.write(packets)
+	}
+
 	for {
+		var err error
 		select {
 		case msg := <-n.queue:
 			if len(msg) == 0 {
 				continue
 			}
-			if _, err := n.BufferedConnection.Write(msg); err != nil {
-				n.log.Warnw("Write error", "err", err)
-				_ = n.disconnect()
-				return
+			if !n.Batching() {
+				err = n.write([][]byte{msg})
+				break
+			}
+
+			// the size of the packet inside of a marshaled batch
+			size := protowire.SizeTag(1) + protowire.SizeBytes(len(msg))
+			if batchSize+size > n.config.batchMaxSize {
+				if err = flush(); err != nil {
+					break
+				}
+			}
+			// packets that are too large to be batched are written directly
+			if batchSize+size > n.config.batchMaxSize {
+				err = n.write([][]byte{msg})
+				break
+			}
+
+			if len(batch) == 0 {
+				batchSize = 1 // packet type of the batch
+				flushTimer = time.After(n.config.batchFlushInterval)
 			}
+			batch = append(batch, msg)
+			batchSize += size
+		case <-flushTimer:
+			err = flush()
+		case <-n.closing:
+			return
+		}
+
+		if err != nil {
+			n.log.Warnw("Write error", "err", err)
+			_ = n.disconnect()
+			return
+		}
+	}
+}
+
+// write writes the packets to the connection. Multiple packets are sent as a single batch and the result is compressed
+// if the neighbor supports it and if it saves bytes.
+func (n *Neighbor) write(packets [][]byte) error {
+	packetBytes := 0
+	for _, packet := range packets {
+		packetBytes += packetHeaderSize + len(packet)
+	}
+
+	data := packets[0]
+	if len(packets) > 1 {
+		data = marshal(&pb.Batch{Packets: packets})
+	}
+	if n.config.compression != server.CompressionNone {
+		compressed, err := compress(n.config.compression, data)
+		if err != nil {
+			return err
+		}
+		if compressedPacket := marshal(&pb.Compressed{Data: compressed}); len(compressedPacket) < len(data) {
+			data = compressedPacket
+		}
+	}
+
+	if _, err := n.BufferedConnection.Write(data); err != nil {
+		return err
+	}
+	n.packetBytesWritten.Add(uint64(packetBytes))
+
+	return nil
+}
+
+// requestLoop regularly sends the queued message requests, as long as the neighbor does not have too many unanswered
+// requests.
+func (n *Neighbor) requestLoop() {
+	defer n.wg.Done()
+
+	ticker := time.NewTicker(n.config.requestInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			n.sendRequests(n.requests.Next())
 		case <-n.closing:
 			return
 		}
 	}
 }
 
+// sendRequests sends the requests for the given messages, as batches if the neighbor accepts them.
+func (n *Neighbor) sendRequests(messageIDs tangle.MessageIDs) {
+	var packets [][]byte
+	if n.config.requestBatching {
+		for len(messageIDs) > 0 {
+			size := len(messageIDs)
+			if size > maxRequestBatchSize {
+				size = maxRequestBatchSize
+			}
+			batch := &pb.MessageRequestBatch{Ids: make([][]byte, size)}
+			for i, messageID := range messageIDs[:size] {
+				batch.Ids[i] = messageID.Bytes()
+			}
+			packets = append(packets, marshal(batch))
+			messageIDs = messageIDs[size:]
+		}
+	} else {
+		for _, messageID := range messageIDs {
+			packets = append(packets, marshal(&pb.MessageRequest{Id: messageID.Bytes()}))
+		}
+	}
+
+	for _, packet := range packets {
+		if _, err := n.Write(packet); err != nil {
+			n.log.Warnw("send error", "err", err)
+		}
+	}
+}
+
 func (n *Neighbor) readLoop() {
 	defer n.wg.Done()
 
@@ -176,3 +358,80 @@ func (n *Neighbor) Write(b []byte) (int, error) {
 		return 0, nil
 	}
 }
+
+// region NeighborOption ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// NeighborOption defines an option for a Neighbor.
+type NeighborOption func(conf *neighborConfig)
+
+type neighborConfig struct {
+	compression        server.Compression
+	batchFlushInterval time.Duration
+	batchMaxSize       int
+	scoreHalfLife      time.Duration
+	requestInterval    time.Duration
+	requestMaxQueued   int
+	requestMaxInFlight int
+	requestTimeout     time.Duration
+	requestBatching    bool
+}
+
+func buildNeighborConfig(opts []NeighborOption) *neighborConfig {
+	conf := &neighborConfig{
+		compression: server.CompressionNone,
+	}
+	for _, o := range opts {
+		o(conf)
+	}
+	return conf
+}
+
+// WithNeighborCompression returns a NeighborOption that compresses the packets with the given algorithm, which needs to
+// be negotiated with the neighbor.
+func WithNeighborCompression(compression server.Compression) NeighborOption {
+	return func(conf *neighborConfig) {
+		conf.compression = compression
+	}
+}
+
+// WithNeighborBatching returns a NeighborOption that collects the packets for the flush interval and sends them as a
+// single batch of at most maxSize bytes. The neighbor needs to accept batched packets.
+func WithNeighborBatching(flushInterval time.Duration, maxSize int) NeighborOption {
+	return func(conf *neighborConfig) {
+		if maxSize > maxPacketSize {
+			maxSize = maxPacketSize
+		}
+		conf.batchFlushInterval = flushInterval
+		conf.batchMaxSize = maxSize
+	}
+}
+
+// WithNeighborScoreHalfLife returns a NeighborOption that lets the penalties of the neighbor decay with the given
+// half-life.
+func WithNeighborScoreHalfLife(halfLife time.Duration) NeighborOption {
+	return func(conf *neighborConfig) {
+		conf.scoreHalfLife = halfLife
+	}
+}
+
+// WithNeighborRequestScheduling returns a NeighborOption that queues up to maxQueued message requests for the neighbor
+// and sends them every interval, so that at most maxInFlight requests are unanswered at any time. A request that is not
+// answered within the timeout no longer counts towards that limit.
+func WithNeighborRequestScheduling(interval time.Duration, maxQueued int, maxInFlight int, timeout time.Duration) NeighborOption {
+	return func(conf *neighborConfig) {
+		conf.requestInterval = interval
+		conf.requestMaxQueued = maxQueued
+		conf.requestMaxInFlight = maxInFlight
+		conf.requestTimeout = timeout
+	}
+}
+
+// WithNeighborRequestBatching returns a NeighborOption that sends the scheduled message requests as batches. The
+// neighbor needs to accept batched message requests.
+func WithNeighborRequestBatching() NeighborOption {
+	return func(conf *neighborConfig) {
+		conf.requestBatching = true
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1,6 +1,7 @@
 package gossip
 
 import (
+	"bytes"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -15,6 +16,10 @@ import (
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/iotaledger/goshimmer/packages/gossip/proto"
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
 )
 
 var testData = []byte("foobar")
@@ -62,6 +67,46 @@ func TestNeighborWrite(t *testing.T) {
 	assert.Eventually(t, func() bool { return atomic.LoadUint32(&count) == 1 }, time.Second, 10*time.Millisecond)
 }
 
+func TestNeighborWriteBatched(t *testing.T) {
+	a, b, teardown := newPipe()
+	defer teardown()
+
+	neighborA := NewNeighbor(newTestPeer("A", a), NeighborsGroupAuto, a, log.Named("A"),
+		WithNeighborCompression(server.CompressionDeflate), WithNeighborBatching(10*time.Millisecond, maxPacketSize))
+	defer neighborA.Close()
+	neighborA.Listen()
+
+	neighborB := newTestNeighbor("B", b)
+	defer neighborB.Close()
+
+	var received atomic.Value
+	neighborB.Events.ReceiveMessage.Attach(events.NewClosure(func(data []byte) {
+		received.Store(append([]byte{}, data...))
+	}))
+	neighborB.Listen()
+
+	packet := bytes.Repeat(testData, 10)
+	for i := 0; i < 3; i++ {
+		_, err := neighborA.Write(packet)
+		require.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool { return received.Load() != nil }, time.Second, 10*time.Millisecond)
+
+	// the packets are received as a single compressed batch
+	data := received.Load().([]byte)
+	require.Equal(t, pb.PacketCompressed, pb.PacketType(data[0]))
+	compressed := new(pb.Compressed)
+	require.NoError(t, proto.Unmarshal(data[1:], compressed))
+	decompressed, err := decompress(server.CompressionDeflate, compressed.GetData())
+	require.NoError(t, err)
+	require.Equal(t, pb.PacketBatch, pb.PacketType(decompressed[0]))
+	batch := new(pb.Batch)
+	require.NoError(t, proto.Unmarshal(decompressed[1:], batch))
+	assert.Equal(t, [][]byte{packet, packet, packet}, batch.GetPackets())
+	assert.Equal(t, uint64(3*(packetHeaderSize+len(packet))), neighborA.PacketBytesWritten())
+	assert.Equal(t, neighborA.PacketBytesWritten()-uint64(packetHeaderSize+len(data)), neighborA.SavedBytes())
+}
+
 func TestNeighborParallelWrite(t *testing.T) {
 	a, b, teardown := newPipe()
 	defer teardown()
@@ -11,6 +11,9 @@ type PacketType uint
 const (
 	PacketMessage PacketType = 20 + iota
 	PacketMessageRequest
+	PacketBatch
+	PacketCompressed
+	PacketMessageRequestBatch
 )
 
 // Packet extends the proto.Message interface with additional util functions.
@@ -34,3 +37,21 @@ func (m *MessageRequest) Name() string { return "message_request" }
 
 // Type returns the packet type id of the message request packet.
 func (m *MessageRequest) Type() PacketType { return PacketMessageRequest }
+
+// Name returns the name of the batch packet.
+func (m *Batch) Name() string { return "batch" }
+
+// Type returns the packet type id of the batch packet.
+func (m *Batch) Type() PacketType { return PacketBatch }
+
+// Name returns the name of the compressed packet.
+func (m *Compressed) Name() string { return "compressed" }
+
+// Type returns the packet type id of the compressed packet.
+func (m *Compressed) Type() PacketType { return PacketCompressed }
+
+// Name returns the name of the message request batch packet.
+func (m *MessageRequestBatch) Name() string { return "message_request_batch" }
+
+// Type returns the packet type id of the message request batch packet.
+func (m *MessageRequestBatch) Type() PacketType { return PacketMessageRequestBatch }
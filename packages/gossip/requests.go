@@ -0,0 +1,123 @@
+package gossip
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+// maxRequestBatchSize defines the maximum amount of message ids in a single message request batch.
+const maxRequestBatchSize = 1000
+
+// requestQueue queues the message requests for a neighbor and limits the amount of requests that have been sent to
+// the neighbor but have not been answered yet.
+type requestQueue struct {
+	maxQueued   int
+	maxInFlight int
+	timeout     time.Duration
+	queue       []tangle.MessageID
+	queued      map[tangle.MessageID]struct{}
+	inFlight    map[tangle.MessageID]time.Time
+	mutex       sync.Mutex
+}
+
+// newRequestQueue creates a queue that holds at most maxQueued requests and allows maxInFlight unanswered requests. A
+// request that is not answered within the timeout no longer counts towards that limit.
+func newRequestQueue(maxQueued int, maxInFlight int, timeout time.Duration) *requestQueue {
+	return &requestQueue{
+		maxQueued:   maxQueued,
+		maxInFlight: maxInFlight,
+		timeout:     timeout,
+		queued:      make(map[tangle.MessageID]struct{}),
+		inFlight:    make(map[tangle.MessageID]time.Time),
+	}
+}
+
+// Add queues a request for the given message, unless it is already queued or in flight or the queue is full. It returns
+// true if the request was added.
+func (q *requestQueue) Add(messageID tangle.MessageID) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if _, queued := q.queued[messageID]; queued {
+		return false
+	}
+	if _, inFlight := q.inFlight[messageID]; inFlight {
+		return false
+	}
+	if len(q.queued) >= q.maxQueued {
+		return false
+	}
+	// the removed requests are only dropped from the queue once they make up half of it
+	if len(q.queue) >= 2*q.maxQueued {
+		q.compact()
+	}
+	q.queue = append(q.queue, messageID)
+	q.queued[messageID] = struct{}{}
+
+	return true
+}
+
+// Remove removes the request for the given message, e.g. because the message was received.
+func (q *requestQueue) Remove(messageID tangle.MessageID) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	// the queue itself is cleaned up lazily in Add and next
+	delete(q.queued, messageID)
+	delete(q.inFlight, messageID)
+}
+
+// Next returns the queued requests that can be sent without exceeding the maximum amount of requests in flight and
+// marks them as in flight.
+func (q *requestQueue) Next() tangle.MessageIDs {
+	return q.next(time.Now())
+}
+
+// Size returns the amount of queued requests and the amount of requests in flight.
+func (q *requestQueue) Size() (queued int, inFlight int) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return len(q.queued), len(q.inFlight)
+}
+
+// compact drops the removed requests from the queue.
+func (q *requestQueue) compact() {
+	queue := make([]tangle.MessageID, 0, len(q.queued))
+	for _, messageID := range q.queue {
+		if _, queued := q.queued[messageID]; queued {
+			queue = append(queue, messageID)
+		}
+	}
+	q.queue = queue
+}
+
+func (q *requestQueue) next(now time.Time) (messageIDs tangle.MessageIDs) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	for messageID, sent := range q.inFlight {
+		if now.Sub(sent) >= q.timeout {
+			delete(q.inFlight, messageID)
+		}
+	}
+
+	for len(q.queue) > 0 && len(q.inFlight) < q.maxInFlight {
+		messageID := q.queue[0]
+		q.queue = q.queue[1:]
+		if _, queued := q.queued[messageID]; !queued {
+			continue
+		}
+		delete(q.queued, messageID)
+		q.inFlight[messageID] = now
+		messageIDs = append(messageIDs, messageID)
+	}
+	if len(q.queue) == 0 {
+		// release the memory of the consumed part of the queue
+		q.queue = nil
+	}
+
+	return messageIDs
+}
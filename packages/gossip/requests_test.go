@@ -0,0 +1,70 @@
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/tangle"
+)
+
+func TestRequestQueue(t *testing.T) {
+	q := newRequestQueue(10, 2, time.Minute)
+	now := time.Now()
+
+	assert.True(t, q.Add(tangle.MessageID{1}))
+	assert.True(t, q.Add(tangle.MessageID{2}))
+	assert.True(t, q.Add(tangle.MessageID{3}))
+	assert.False(t, q.Add(tangle.MessageID{1}))
+
+	// at most two requests are in flight
+	assert.Equal(t, tangle.MessageIDs{{1}, {2}}, q.next(now))
+	assert.Empty(t, q.next(now))
+	assert.False(t, q.Add(tangle.MessageID{2}))
+	queued, inFlight := q.Size()
+	assert.Equal(t, 1, queued)
+	assert.Equal(t, 2, inFlight)
+
+	// answered requests are no longer in flight
+	q.Remove(tangle.MessageID{1})
+	assert.Equal(t, tangle.MessageIDs{{3}}, q.next(now))
+
+	// removed requests are not sent
+	assert.True(t, q.Add(tangle.MessageID{4}))
+	q.Remove(tangle.MessageID{4})
+	assert.True(t, q.Add(tangle.MessageID{5}))
+	assert.Empty(t, q.next(now))
+
+	// unanswered requests time out
+	assert.Equal(t, tangle.MessageIDs{{5}}, q.next(now.Add(time.Minute)))
+	queued, inFlight = q.Size()
+	assert.Equal(t, 0, queued)
+	assert.Equal(t, 1, inFlight)
+}
+
+func TestRequestQueue_MaxQueued(t *testing.T) {
+	q := newRequestQueue(2, 1, time.Minute)
+
+	assert.True(t, q.Add(tangle.MessageID{1}))
+	assert.True(t, q.Add(tangle.MessageID{2}))
+	assert.False(t, q.Add(tangle.MessageID{3}))
+
+	// removed and sent requests free up the queue
+	q.Remove(tangle.MessageID{1})
+	assert.True(t, q.Add(tangle.MessageID{3}))
+	assert.Equal(t, tangle.MessageIDs{{2}}, q.next(time.Now()))
+	assert.True(t, q.Add(tangle.MessageID{4}))
+	assert.False(t, q.Add(tangle.MessageID{5}))
+
+	// the removed requests are dropped from the queue, so that it does not grow without bound
+	q.Remove(tangle.MessageID{4})
+	for i := byte(10); i < 50; i++ {
+		assert.True(t, q.Add(tangle.MessageID{i}))
+		q.Remove(tangle.MessageID{i})
+		assert.LessOrEqual(t, len(q.queue), 4)
+	}
+	queued, inFlight := q.Size()
+	assert.Equal(t, 1, queued)
+	assert.Equal(t, 1, inFlight)
+}
@@ -0,0 +1,62 @@
+package gossip
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// decayingScore is a score that decays exponentially towards zero, i.e. the penalties of a neighbor are forgotten over
+// time.
+type decayingScore struct {
+	halfLife time.Duration
+	value    float64
+	updated  time.Time
+	mutex    sync.Mutex
+}
+
+// newDecayingScore creates a score that halves every halfLife. A halfLife of 0 disables the decay.
+func newDecayingScore(halfLife time.Duration) *decayingScore {
+	return &decayingScore{
+		halfLife: halfLife,
+		updated:  time.Now(),
+	}
+}
+
+// Add adds the given delta to the score and returns the resulting score.
+func (s *decayingScore) Add(delta float64) float64 {
+	return s.add(delta, time.Now())
+}
+
+// Value returns the current score.
+func (s *decayingScore) Value() float64 {
+	return s.valueAt(time.Now())
+}
+
+func (s *decayingScore) add(delta float64, now time.Time) float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.decay(now)
+	s.value += delta
+
+	return s.value
+}
+
+func (s *decayingScore) valueAt(now time.Time) float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.decay(now)
+
+	return s.value
+}
+
+func (s *decayingScore) decay(now time.Time) {
+	if elapsed := now.Sub(s.updated); s.halfLife > 0 && elapsed > 0 {
+		s.value *= math.Exp2(-float64(elapsed) / float64(s.halfLife))
+	}
+	if now.After(s.updated) {
+		s.updated = now
+	}
+}
@@ -0,0 +1,31 @@
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecayingScore(t *testing.T) {
+	score := newDecayingScore(time.Minute)
+	start := score.updated
+
+	assert.EqualValues(t, -8, score.add(-8, start))
+	assert.InDelta(t, -4, score.valueAt(start.Add(time.Minute)), 1e-9)
+	assert.InDelta(t, -2, score.valueAt(start.Add(2*time.Minute)), 1e-9)
+
+	// the decay is applied before the new penalty is added
+	assert.InDelta(t, -3, score.add(-1, start.Add(2*time.Minute)), 1e-9)
+
+	// older points in time do not change the score
+	assert.InDelta(t, -3, score.valueAt(start), 1e-9)
+}
+
+func TestDecayingScoreWithoutDecay(t *testing.T) {
+	score := newDecayingScore(0)
+	start := score.updated
+
+	score.add(-8, start)
+	assert.EqualValues(t, -8, score.valueAt(start.Add(time.Hour)))
+}
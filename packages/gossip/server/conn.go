@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net"
+)
+
+// Compression is the name of an algorithm that compresses the packets of a connection.
+type Compression string
+
+const (
+	// CompressionNone is used if the packets of a connection are not compressed.
+	CompressionNone Compression = ""
+	// CompressionDeflate compresses the packets of a connection with DEFLATE (RFC 1951).
+	CompressionDeflate Compression = "deflate"
+)
+
+// String returns the name of the Compression.
+func (c Compression) String() string {
+	return string(c)
+}
+
+// Conn is an established gossip connection together with the features that were negotiated in its handshake.
+type Conn struct {
+	net.Conn
+
+	// Compression is the algorithm that is used to compress the packets of the connection.
+	Compression Compression
+	// Batching is true if the remote peer accepts batched packets.
+	Batching bool
+	// RequestBatching is true if the remote peer accepts batched message requests.
+	RequestBatching bool
+}
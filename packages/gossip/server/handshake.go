@@ -20,18 +20,27 @@ func isExpired(ts int64) bool {
 	return time.Since(time.Unix(ts, 0)) >= handshakeExpiration
 }
 
-func newHandshakeRequest(toAddr string) ([]byte, error) {
+func newHandshakeRequest(toAddr string, compressions []Compression) ([]byte, error) {
 	m := &pb.HandshakeRequest{
-		Version:   versionNum,
-		To:        toAddr,
-		Timestamp: time.Now().Unix(),
+		Version:         versionNum,
+		To:              toAddr,
+		Timestamp:       time.Now().Unix(),
+		Compression:     make([]string, len(compressions)),
+		Batching:        true,
+		RequestBatching: true,
+	}
+	for i, compression := range compressions {
+		m.Compression[i] = compression.String()
 	}
 	return proto.Marshal(m)
 }
 
-func newHandshakeResponse(reqData []byte) ([]byte, error) {
+func newHandshakeResponse(reqData []byte, compression Compression) ([]byte, error) {
 	m := &pb.HandshakeResponse{
-		ReqHash: server.PacketHash(reqData),
+		ReqHash:         server.PacketHash(reqData),
+		Compression:     compression.String(),
+		Batching:        true,
+		RequestBatching: true,
 	}
 	return proto.Marshal(m)
 }
@@ -60,20 +69,42 @@ func (t *TCP) validateHandshakeRequest(reqData []byte) bool {
 	return true
 }
 
-func (t *TCP) validateHandshakeResponse(resData []byte, reqData []byte) bool {
+// negotiateFeatures returns the features of the connection that was requested with the given (valid) handshake request.
+func (t *TCP) negotiateFeatures(reqData []byte) (compression Compression, batching bool, requestBatching bool) {
+	m := new(pb.HandshakeRequest)
+	if err := proto.Unmarshal(reqData, m); err != nil {
+		return CompressionNone, false, false
+	}
+
+	for _, requested := range m.GetCompression() {
+		if t.supportsCompression(Compression(requested)) {
+			return Compression(requested), m.GetBatching(), m.GetRequestBatching()
+		}
+	}
+	return CompressionNone, m.GetBatching(), m.GetRequestBatching()
+}
+
+func (t *TCP) validateHandshakeResponse(resData []byte, reqData []byte) (compression Compression, batching bool, requestBatching bool, valid bool) {
 	m := new(pb.HandshakeResponse)
 	if err := proto.Unmarshal(resData, m); err != nil {
 		t.log.Debugw("invalid handshake",
 			"err", err,
 		)
-		return false
+		return CompressionNone, false, false, false
 	}
 	if !bytes.Equal(m.GetReqHash(), server.PacketHash(reqData)) {
 		t.log.Debugw("invalid handshake",
 			"hash", m.GetReqHash(),
 		)
-		return false
+		return CompressionNone, false, false, false
+	}
+	compression = Compression(m.GetCompression())
+	if compression != CompressionNone && !t.supportsCompression(compression) {
+		t.log.Debugw("invalid handshake",
+			"compression", compression,
+		)
+		return CompressionNone, false, false, false
 	}
 
-	return true
+	return compression, m.GetBatching(), m.GetRequestBatching(), true
 }
@@ -50,9 +50,10 @@ var dialRetryPolicy = backoff.ConstantBackOff(500 * time.Millisecond).With(backo
 
 // TCP establishes verified incoming and outgoing TCP connections to other peers.
 type TCP struct {
-	local    *peer.Local
-	listener *net.TCPListener
-	log      *zap.SugaredLogger
+	local        *peer.Local
+	listener     *net.TCPListener
+	log          *zap.SugaredLogger
+	compressions []Compression
 
 	acceptReceivedCh chan accept
 	matchersMap      map[identity.ID]*acceptMatcher
@@ -65,7 +66,7 @@ type TCP struct {
 
 // connectResult contains the result of an incoming connection.
 type connectResult struct {
-	c   net.Conn
+	c   *Conn
 	err error
 }
 
@@ -85,7 +86,7 @@ type accept struct {
 }
 
 // ServeTCP creates the object and starts listening for incoming connections.
-func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger) *TCP {
+func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger, opts ...Option) *TCP {
 	t := &TCP{
 		local:            local,
 		listener:         listener,
@@ -94,6 +95,9 @@ func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogg
 		matchersMap:      map[identity.ID]*acceptMatcher{},
 		closing:          make(chan struct{}),
 	}
+	for _, o := range opts {
+		o(t)
+	}
 
 	t.log.Debugw("server started",
 		"network", listener.Addr().Network(),
@@ -122,6 +126,27 @@ func (t *TCP) LocalAddr() net.Addr {
 	return t.listener.Addr()
 }
 
+// Option defines an option for the TCP server.
+type Option func(t *TCP)
+
+// WithCompression returns an Option that offers the given compression algorithms (in order of preference) in the
+// handshake. The packets of a connection are compressed if both peers support one of the algorithms.
+func WithCompression(compressions ...Compression) Option {
+	return func(t *TCP) {
+		t.compressions = compressions
+	}
+}
+
+// supportsCompression returns true if the given compression algorithm was enabled for the server.
+func (t *TCP) supportsCompression(compression Compression) bool {
+	for _, supported := range t.compressions {
+		if supported == compression {
+			return true
+		}
+	}
+	return false
+}
+
 // ConnectPeerOption defines an option for the DialPeer and AcceptPeer methods.
 type ConnectPeerOption func(conf *connectPeerConfig)
 
@@ -148,27 +173,26 @@ func WithNoDefaultTimeout() ConnectPeerOption {
 
 // DialPeer establishes a gossip connection to the given peer.
 // If the peer does not accept the connection or the handshake fails, an error is returned.
-func (t *TCP) DialPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOption) (net.Conn, error) {
+func (t *TCP) DialPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOption) (*Conn, error) {
 	conf := buildConnectPeerConfig(opts)
 	gossipEndpoint := p.Services().Get(service.GossipKey)
 	if gossipEndpoint == nil {
 		return nil, ErrNoGossip
 	}
 
-	var conn net.Conn
+	var conn *Conn
 	if err := backoff.Retry(dialRetryPolicy, func() error {
-		var err error
 		address := net.JoinHostPort(p.IP().String(), strconv.Itoa(gossipEndpoint.Port()))
 		dialer := &net.Dialer{}
 		if conf.useDefaultTimeout {
 			dialer.Timeout = defaultDialTimeout
 		}
-		conn, err = dialer.DialContext(ctx, "tcp", address)
+		netConn, err := dialer.DialContext(ctx, "tcp", address)
 		if err != nil {
 			return fmt.Errorf("dial %s / %s failed: %w", address, p.ID(), err)
 		}
 
-		if err = t.doHandshake(p.PublicKey(), address, conn); err != nil {
+		if conn, err = t.doHandshake(p.PublicKey(), address, netConn); err != nil {
 			return fmt.Errorf("handshake %s / %s failed: %w", address, p.ID(), err)
 		}
 		return nil
@@ -179,13 +203,14 @@ func (t *TCP) DialPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOpt
 	t.log.Debugw("outgoing connection established",
 		"id", p.ID(),
 		"addr", conn.RemoteAddr(),
+		"compression", conn.Compression,
 	)
 	return conn, nil
 }
 
 // AcceptPeer awaits an incoming connection from the given peer.
 // If the peer does not establish the connection or the handshake fails, an error is returned.
-func (t *TCP) AcceptPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOption) (net.Conn, error) {
+func (t *TCP) AcceptPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerOption) (*Conn, error) {
 	gossipEndpoint := p.Services().Get(service.GossipKey)
 	if gossipEndpoint == nil {
 		return nil, ErrNoGossip
@@ -200,11 +225,12 @@ func (t *TCP) AcceptPeer(ctx context.Context, p *peer.Peer, opts ...ConnectPeerO
 	t.log.Debugw("incoming connection established",
 		"id", p.ID(),
 		"addr", conn.RemoteAddr(),
+		"compression", conn.Compression,
 	)
 	return conn, nil
 }
 
-func (t *TCP) acceptPeer(ctx context.Context, p *peer.Peer, opts []ConnectPeerOption) (net.Conn, error) {
+func (t *TCP) acceptPeer(ctx context.Context, p *peer.Peer, opts []ConnectPeerOption) (*Conn, error) {
 	t.wg.Add(1)
 	defer t.wg.Done()
 	conf := buildConnectPeerConfig(opts)
@@ -291,13 +317,14 @@ func (t *TCP) removeAcceptMatcher(m *acceptMatcher) {
 func (t *TCP) matchAccept(m *acceptMatcher, req []byte, conn net.Conn) {
 	defer t.wg.Done()
 
-	if err := t.writeHandshakeResponse(req, conn); err != nil {
+	compression, batching, requestBatching := t.negotiateFeatures(req)
+	if err := t.writeHandshakeResponse(req, compression, conn); err != nil {
 		m.connectCh <- connectResult{nil, fmt.Errorf("incoming handshake failed: %w", err)}
 
 		t.closeConnection(conn)
 		return
 	}
-	m.connectCh <- connectResult{conn, nil}
+	m.connectCh <- connectResult{&Conn{Conn: conn, Compression: compression, Batching: batching, RequestBatching: requestBatching}, nil}
 }
 
 func (t *TCP) listenLoop() {
@@ -338,10 +365,10 @@ func (t *TCP) listenLoop() {
 	}
 }
 
-func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Conn) error {
-	reqData, err := newHandshakeRequest(remoteAddr)
+func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Conn) (*Conn, error) {
+	reqData, err := newHandshakeRequest(remoteAddr, t.compressions)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pkt := &pb.Packet{
@@ -351,46 +378,47 @@ func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Con
 	}
 	b, err := proto.Marshal(pkt)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if l := len(b); l > maxHandshakePacketSize {
-		return fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
+		return nil, fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
 	}
 
 	err = conn.SetWriteDeadline(time.Now().Add(handshakeTimeout))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	_, err = conn.Write(b)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	b = make([]byte, maxHandshakePacketSize)
 	n, err := conn.Read(b)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pkt = &pb.Packet{}
 	err = proto.Unmarshal(b[:n], pkt)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	signer, err := peer.RecoverKeyFromSignedData(pkt)
 	if err != nil || !bytes.Equal(key.Bytes(), signer.Bytes()) {
-		return ErrInvalidHandshake
+		return nil, ErrInvalidHandshake
 	}
-	if !t.validateHandshakeResponse(pkt.GetData(), reqData) {
-		return ErrInvalidHandshake
+	compression, batching, requestBatching, valid := t.validateHandshakeResponse(pkt.GetData(), reqData)
+	if !valid {
+		return nil, ErrInvalidHandshake
 	}
 
-	return nil
+	return &Conn{Conn: conn, Compression: compression, Batching: batching, RequestBatching: requestBatching}, nil
 }
 
 func (t *TCP) readHandshakeRequest(conn net.Conn) (ed25519.PublicKey, []byte, error) {
@@ -421,8 +449,8 @@ func (t *TCP) readHandshakeRequest(conn net.Conn) (ed25519.PublicKey, []byte, er
 	return key, pkt.GetData(), nil
 }
 
-func (t *TCP) writeHandshakeResponse(reqData []byte, conn net.Conn) error {
-	data, err := newHandshakeResponse(reqData)
+func (t *TCP) writeHandshakeResponse(reqData []byte, compression Compression, conn net.Conn) error {
+	data, err := newHandshakeResponse(reqData, compression)
 	if err != nil {
 		return err
 	}
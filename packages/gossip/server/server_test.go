@@ -150,6 +150,57 @@ func TestConnect(t *testing.T) {
 	wg.Wait()
 }
 
+func TestConnectCompression(t *testing.T) {
+	transA, closeA := newTestServer(t, "A", WithCompression(CompressionDeflate))
+	defer closeA()
+	transB, closeB := newTestServer(t, "B", WithCompression("zstd", CompressionDeflate))
+	defer closeB()
+	transC, closeC := newTestServer(t, "C")
+	defer closeC()
+
+	connect := func(acceptor, dialer *TCP) (accepted, dialed *Conn) {
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			var err error
+			accepted, err = acceptor.AcceptPeer(context.Background(), getPeer(dialer))
+			assert.NoError(t, err)
+		}()
+		time.Sleep(graceTime)
+		go func() {
+			defer wg.Done()
+			var err error
+			dialed, err = dialer.DialPeer(context.Background(), getPeer(acceptor))
+			assert.NoError(t, err)
+		}()
+
+		wg.Wait()
+		require.NotNil(t, accepted)
+		require.NotNil(t, dialed)
+		return accepted, dialed
+	}
+
+	// the first algorithm offered by the dialer that is supported by the acceptor is used
+	accepted, dialed := connect(transA, transB)
+	assert.Equal(t, CompressionDeflate, accepted.Compression)
+	assert.Equal(t, CompressionDeflate, dialed.Compression)
+	assert.True(t, accepted.Batching)
+	assert.True(t, dialed.Batching)
+	assert.True(t, accepted.RequestBatching)
+	assert.True(t, dialed.RequestBatching)
+	_ = accepted.Close()
+	_ = dialed.Close()
+
+	// packets are not compressed if one of the peers does not support it
+	accepted, dialed = connect(transC, transA)
+	assert.Equal(t, CompressionNone, accepted.Compression)
+	assert.Equal(t, CompressionNone, dialed.Compression)
+	_ = accepted.Close()
+	_ = dialed.Close()
+}
+
 func TestWrongConnect(t *testing.T) {
 	transA, closeA := newTestServer(t, "A")
 	defer closeA()
@@ -182,7 +233,7 @@ func newTestDB(t require.TestingT) *peer.DB {
 	return db
 }
 
-func newTestServer(t require.TestingT, name string) (*TCP, func()) {
+func newTestServer(t require.TestingT, name string, opts ...Option) (*TCP, func()) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -197,7 +248,7 @@ func newTestServer(t require.TestingT, name string) (*TCP, func()) {
 	local, err := peer.NewLocal(lis.Addr().(*net.TCPAddr).IP, services, newTestDB(t))
 	require.NoError(t, err)
 
-	srv := ServeTCP(local, lis, l)
+	srv := ServeTCP(local, lis, l, opts...)
 
 	teardown := func() {
 		srv.Close()
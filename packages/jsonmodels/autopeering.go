@@ -13,6 +13,7 @@ type Neighbor struct {
 	ID        string        `json:"id"`        // comparable node identifier
 	PublicKey string        `json:"publicKey"` // public key used to verify signatures
 	Services  []PeerService `json:"services,omitempty"`
+	Score     float64       `json:"score"` // score of the connected neighbor, 0 if it has not been penalized
 }
 
 // PeerService contains information about a neighbor peer service
@@ -0,0 +1,27 @@
+package jsonmodels
+
+import (
+	"encoding/hex"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+// DatabaseBackupResponse represents the JSON model of a response from the database backup endpoint.
+type DatabaseBackupResponse struct {
+	Path            string `json:"path"`
+	DatabaseVersion uint8  `json:"databaseVersion"`
+	Time            int64  `json:"time"`
+	Objects         int    `json:"objects"`
+	Checksum        string `json:"checksum"`
+}
+
+// NewDatabaseBackupResponse returns a DatabaseBackupResponse for the backup at the given path.
+func NewDatabaseBackupResponse(path string, info *database.BackupInfo) *DatabaseBackupResponse {
+	return &DatabaseBackupResponse{
+		Path:            path,
+		DatabaseVersion: info.DatabaseVersion,
+		Time:            info.Time.Unix(),
+		Objects:         info.TotalObjects(),
+		Checksum:        hex.EncodeToString(info.Checksum[:]),
+	}
+}
@@ -40,3 +40,25 @@ type Randomness struct {
 	Timestamp  time.Time `json:"timestamp,omitempty"`
 	Randomness []byte    `json:"randomness,omitempty"`
 }
+
+// Beacon defines a verified beacon of the history of a DRNG instance.
+type Beacon struct {
+	InstanceID    uint32    `json:"instanceID"`
+	Round         uint64    `json:"round"`
+	Timestamp     time.Time `json:"timestamp"`
+	PrevSignature []byte    `json:"prevSignature"`
+	Signature     []byte    `json:"signature"`
+	Randomness    []byte    `json:"randomness"`
+}
+
+// BeaconResponse is the HTTP message containing a beacon of the history.
+type BeaconResponse struct {
+	Beacon *Beacon `json:"beacon,omitempty"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// BeaconsResponse is the HTTP message containing a range of beacons of the history.
+type BeaconsResponse struct {
+	Beacons []*Beacon `json:"beacons,omitempty"`
+	Error   string    `json:"error,omitempty"`
+}
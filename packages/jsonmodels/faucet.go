@@ -1,9 +1,30 @@
 package jsonmodels
 
+import (
+	"time"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/packages/faucet"
+)
+
 // FaucetResponse contains the ID of the message sent.
 type FaucetResponse struct {
 	ID    string `json:"id,omitempty"`
 	Error string `json:"error,omitempty"`
+	// Refusal is the reason why the faucet refused the request, if it did.
+	Refusal string `json:"refusal,omitempty"`
+	// RetryAfter is the number of seconds after which the refused request would be accepted.
+	RetryAfter int64 `json:"retryAfter,omitempty"`
+}
+
+// NewFaucetRefusalResponse returns the FaucetResponse of a request that was refused by the faucet.
+func NewFaucetRefusalResponse(refusalErr *faucet.RefusalError) FaucetResponse {
+	return FaucetResponse{
+		Error:      refusalErr.Error(),
+		Refusal:    refusalErr.Reason.String(),
+		RetryAfter: int64(refusalErr.RetryAfter.Round(time.Second) / time.Second),
+	}
 }
 
 // FaucetRequest contains the address to request funds from faucet.
@@ -13,3 +34,43 @@ type FaucetRequest struct {
 	ConsensusManaPledgeID string `json:"consensusManaPledgeID"`
 	Nonce                 uint64 `json:"nonce"`
 }
+
+// FaucetRequestStatusResponse contains the processing state of a funding request. The position in the queue starts at
+// 1 for the request that is funded next and is omitted if the request isn't queued.
+type FaucetRequestStatusResponse struct {
+	ID                    string    `json:"id,omitempty"`
+	Address               string    `json:"address,omitempty"`
+	AccessManaPledgeID    string    `json:"accessManaPledgeID,omitempty"`
+	ConsensusManaPledgeID string    `json:"consensusManaPledgeID,omitempty"`
+	Prioritized           bool      `json:"prioritized,omitempty"`
+	State                 string    `json:"state,omitempty"`
+	Position              int       `json:"position,omitempty"`
+	Refusal               string    `json:"refusal,omitempty"`
+	TransactionID         string    `json:"transactionID,omitempty"`
+	Received              time.Time `json:"received,omitempty"`
+	Updated               time.Time `json:"updated,omitempty"`
+	Error                 string    `json:"error,omitempty"`
+}
+
+// NewFaucetRequestStatusResponse returns the FaucetRequestStatusResponse of the given request and its zero based
+// position in the queue, which is -1 if the request isn't queued.
+func NewFaucetRequestStatusResponse(request *faucet.QueuedRequest, position int) FaucetRequestStatusResponse {
+	response := FaucetRequestStatusResponse{
+		ID:                    request.MessageID.Base58(),
+		Address:               request.Address.Base58(),
+		AccessManaPledgeID:    base58.Encode(request.AccessManaPledgeID.Bytes()),
+		ConsensusManaPledgeID: base58.Encode(request.ConsensusManaPledgeID.Bytes()),
+		Prioritized:           request.Prioritized,
+		State:                 request.State.String(),
+		Position:              position + 1,
+		Received:              request.Received,
+		Updated:               request.Updated,
+	}
+	if request.State == faucet.RequestRefused {
+		response.Refusal = request.Refusal.String()
+	}
+	if request.State == faucet.RequestFulfilled {
+		response.TransactionID = request.TransactionID.Base58()
+	}
+	return response
+}
@@ -18,6 +18,7 @@ import (
 type Address struct {
 	Type   string `json:"type"`
 	Base58 string `json:"base58"`
+	Bech32 string `json:"bech32"`
 }
 
 // NewAddress returns an Address from the given ledgerstate.Address.
@@ -25,6 +26,7 @@ func NewAddress(address ledgerstate.Address) *Address {
 	return &Address{
 		Type:   address.Type().String(),
 		Base58: address.Base58(),
+		Bech32: address.Bech32(),
 	}
 }
 
@@ -101,6 +103,16 @@ func (o *Output) ToLedgerstateOutput() (ledgerstate.Output, error) {
 			return nil, tErr
 		}
 		return res, nil
+	case ledgerstate.HashTimeLockedOutputType:
+		s, uErr := UnmarshalHashTimeLockedOutputFromBytes(o.Output)
+		if uErr != nil {
+			return nil, uErr
+		}
+		res, tErr := s.ToLedgerStateOutput(id)
+		if tErr != nil {
+			return nil, tErr
+		}
+		return res, nil
 	default:
 		return nil, errors.Errorf("not supported output type: %d", outputType)
 	}
@@ -134,6 +146,12 @@ func MarshalOutput(output ledgerstate.Output) []byte {
 		if err != nil {
 			return nil
 		}
+	case ledgerstate.HashTimeLockedOutputType:
+		var err error
+		res, err = HashTimeLockedOutputFromLedgerstate(output)
+		if err != nil {
+			return nil
+		}
 	default:
 		return nil
 	}
@@ -157,7 +175,7 @@ type SigLockedSingleOutput struct {
 
 // ToLedgerStateOutput builds a ledgerstate.Output from SigLockedSingleOutput with the given outputID.
 func (s *SigLockedSingleOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.Output, error) {
-	addy, err := ledgerstate.AddressFromBase58EncodedString(s.Address)
+	addy, err := ledgerstate.AddressFromString(s.Address)
 	if err != nil {
 		return nil, errors.Errorf("wrong address in SigLockedSingleOutput: %w", err)
 	}
@@ -201,7 +219,7 @@ type SigLockedColoredOutput struct {
 
 // ToLedgerStateOutput builds a ledgerstate.Output from SigLockedSingleOutput with the given outputID.
 func (s *SigLockedColoredOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.Output, error) {
-	addy, err := ledgerstate.AddressFromBase58EncodedString(s.Address)
+	addy, err := ledgerstate.AddressFromString(s.Address)
 	if err != nil {
 		return nil, errors.Errorf("wrong address in SigLockedSingleOutput: %w", err)
 	}
@@ -272,7 +290,7 @@ func (a *AliasOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.
 		return nil, errors.Errorf("wrong alias address in AliasOutput: %w", err)
 	}
 	// state address
-	stateAddy, aErr := ledgerstate.AddressFromBase58EncodedString(a.StateAddress)
+	stateAddy, aErr := ledgerstate.AddressFromString(a.StateAddress)
 	if aErr != nil {
 		return nil, errors.Errorf("wrong state address in AliasOutput: %w", err)
 	}
@@ -322,7 +340,7 @@ func (a *AliasOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.
 		}
 	}
 	if a.GoverningAddress != "" {
-		addy, gErr := ledgerstate.AddressFromBase58EncodedString(a.GoverningAddress)
+		addy, gErr := ledgerstate.AddressFromString(a.GoverningAddress)
 		if gErr != nil {
 			return nil, gErr
 		}
@@ -391,7 +409,7 @@ type ExtendedLockedOutput struct {
 
 // ToLedgerStateOutput builds a ledgerstate.Output from ExtendedLockedOutput with the given outputID.
 func (e *ExtendedLockedOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.Output, error) {
-	addy, err := ledgerstate.AddressFromBase58EncodedString(e.Address)
+	addy, err := ledgerstate.AddressFromString(e.Address)
 	if err != nil {
 		return nil, errors.Errorf("wrong address in ExtendedLockedOutput: %w", err)
 	}
@@ -403,7 +421,7 @@ func (e *ExtendedLockedOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (led
 	res := ledgerstate.NewExtendedLockedOutput(balances.Map(), addy)
 
 	if e.FallbackAddress != "" && e.FallbackDeadline != 0 {
-		fallbackAddy, fErr := ledgerstate.AddressFromBase58EncodedString(e.FallbackAddress)
+		fallbackAddy, fErr := ledgerstate.AddressFromString(e.FallbackAddress)
 		if fErr != nil {
 			return nil, errors.Errorf("wrong fallback address in ExtendedLockedOutput: %w", err)
 		}
@@ -455,6 +473,68 @@ func UnmarshalExtendedLockedOutputFromBytes(data []byte) (*ExtendedLockedOutput,
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region HashTimeLockedOutput /////////////////////////////////////////////////////////////////////////////////////////
+
+// HashTimeLockedOutput is the JSON model of a ledgerstate.HashTimeLockedOutput.
+type HashTimeLockedOutput struct {
+	Balances      map[string]uint64 `json:"balances"`
+	Address       string            `json:"address"`
+	HashLock      string            `json:"hashLock"`
+	Timeout       int64             `json:"timeout"`
+	RefundAddress string            `json:"refundAddress"`
+}
+
+// ToLedgerStateOutput builds a ledgerstate.Output from HashTimeLockedOutput with the given outputID.
+func (h *HashTimeLockedOutput) ToLedgerStateOutput(id ledgerstate.OutputID) (ledgerstate.Output, error) {
+	addy, err := ledgerstate.AddressFromString(h.Address)
+	if err != nil {
+		return nil, errors.Errorf("wrong address in HashTimeLockedOutput: %w", err)
+	}
+	refundAddy, err := ledgerstate.AddressFromString(h.RefundAddress)
+	if err != nil {
+		return nil, errors.Errorf("wrong refund address in HashTimeLockedOutput: %w", err)
+	}
+	hashLock, err := ledgerstate.HashLockFromBase58EncodedString(h.HashLock)
+	if err != nil {
+		return nil, errors.Errorf("wrong hash lock in HashTimeLockedOutput: %w", err)
+	}
+	balances, err := getColoredBalances(h.Balances)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse colored balances: %w", err)
+	}
+
+	res := ledgerstate.NewHashTimeLockedOutput(balances.Map(), addy, hashLock, time.Unix(h.Timeout, 0), refundAddy)
+	res.SetID(id)
+	return res, nil
+}
+
+// HashTimeLockedOutputFromLedgerstate creates a JSON compatible representation of a ledgerstate output.
+func HashTimeLockedOutputFromLedgerstate(output ledgerstate.Output) (*HashTimeLockedOutput, error) {
+	if output.Type() != ledgerstate.HashTimeLockedOutputType {
+		return nil, errors.Errorf("wrong output type: %s", output.Type().String())
+	}
+	castedOutput := output.(*ledgerstate.HashTimeLockedOutput)
+	return &HashTimeLockedOutput{
+		Balances:      getStringBalances(output),
+		Address:       castedOutput.Address().Base58(),
+		HashLock:      castedOutput.HashLock().Base58(),
+		Timeout:       castedOutput.Timeout().Unix(),
+		RefundAddress: castedOutput.RefundAddress().Base58(),
+	}, nil
+}
+
+// UnmarshalHashTimeLockedOutputFromBytes uses the json unmarshaler to unmarshal data into a HashTimeLockedOutput.
+func UnmarshalHashTimeLockedOutputFromBytes(data []byte) (*HashTimeLockedOutput, error) {
+	marshalledOutput := &HashTimeLockedOutput{}
+	err := json.Unmarshal(data, marshalledOutput)
+	if err != nil {
+		return nil, errors.Errorf("failed to unmarshal HashTimeLockedOutput: %w", err)
+	}
+	return marshalledOutput, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region OutputID /////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // OutputID represents the JSON model of a ledgerstate.OutputID.
@@ -475,6 +555,35 @@ func NewOutputID(outputID ledgerstate.OutputID) *OutputID {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region AddressHistoryEntry //////////////////////////////////////////////////////////////////////////////////////////
+
+// AddressHistoryEntry represents the JSON model of a ledgerstate.AddressHistoryEntry.
+type AddressHistoryEntry struct {
+	Type          string    `json:"type"`
+	OutputID      *OutputID `json:"outputID"`
+	TransactionID string    `json:"transactionID"`
+	Timestamp     int64     `json:"timestamp"`
+	Pending       bool      `json:"pending"`
+	Confirmed     bool      `json:"confirmed"`
+	Rejected      bool      `json:"rejected"`
+}
+
+// NewAddressHistoryEntry returns an AddressHistoryEntry from the given ledgerstate.AddressHistoryEntry and the
+// InclusionState of its Transaction.
+func NewAddressHistoryEntry(addressHistoryEntry *ledgerstate.AddressHistoryEntry, inclusionState ledgerstate.InclusionState) *AddressHistoryEntry {
+	return &AddressHistoryEntry{
+		Type:          addressHistoryEntry.Type().String(),
+		OutputID:      NewOutputID(addressHistoryEntry.OutputID()),
+		TransactionID: addressHistoryEntry.TransactionID().Base58(),
+		Timestamp:     addressHistoryEntry.Timestamp().Unix(),
+		Pending:       inclusionState == ledgerstate.Pending,
+		Confirmed:     inclusionState == ledgerstate.Confirmed,
+		Rejected:      inclusionState == ledgerstate.Rejected,
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region OutputMetadata ///////////////////////////////////////////////////////////////////////////////////////////////
 
 // OutputMetadata represents the JSON model of the ledgerstate.OutputMetadata.
@@ -717,6 +826,10 @@ type UnlockBlock struct {
 	SignatureType   ledgerstate.SignatureType `json:"signatureType,omitempty"`
 	PublicKey       string                    `json:"publicKey,omitempty"`
 	Signature       string                    `json:"signature,omitempty"`
+	Preimage        string                    `json:"preimage,omitempty"`
+	Threshold       uint8                     `json:"threshold,omitempty"`
+	PublicKeys      []string                  `json:"publicKeys,omitempty"`
+	Signatures      map[string]string         `json:"signatures,omitempty"`
 }
 
 // NewUnlockBlock returns an UnlockBlock from the given ledgerstate.UnlockBlock.
@@ -728,25 +841,46 @@ func NewUnlockBlock(unlockBlock ledgerstate.UnlockBlock) *UnlockBlock {
 	switch unlockBlock.Type() {
 	case ledgerstate.SignatureUnlockBlockType:
 		signature, _, _ := ledgerstate.SignatureFromBytes(unlockBlock.Bytes())
-		result.SignatureType = signature.Type()
-		switch signature.Type() {
-		case ledgerstate.ED25519SignatureType:
-			signature, _, _ := ledgerstate.ED25519SignatureFromBytes(signature.Bytes())
-			result.PublicKey = signature.PublicKey.String()
-			result.Signature = signature.Signature.String()
-
-		case ledgerstate.BLSSignatureType:
-			signature, _, _ := ledgerstate.BLSSignatureFromBytes(signature.Bytes())
-			result.Signature = signature.Signature.String()
-		}
+		result.setSignature(signature)
 	case ledgerstate.ReferenceUnlockBlockType:
 		referenceUnlockBlock, _, _ := ledgerstate.ReferenceUnlockBlockFromBytes(unlockBlock.Bytes())
 		result.ReferencedIndex = referenceUnlockBlock.ReferencedIndex()
+	case ledgerstate.HashTimeLockUnlockBlockType:
+		hashTimeLockUnlockBlock := unlockBlock.(*ledgerstate.HashTimeLockUnlockBlock)
+		result.Preimage = base58.Encode(hashTimeLockUnlockBlock.Preimage())
+		result.setSignature(hashTimeLockUnlockBlock.Signature())
 	}
 
 	return result
 }
 
+// setSignature fills the signature related fields of the UnlockBlock.
+func (u *UnlockBlock) setSignature(signature ledgerstate.Signature) {
+	u.SignatureType = signature.Type()
+	switch signature.Type() {
+	case ledgerstate.ED25519SignatureType:
+		signature, _, _ := ledgerstate.ED25519SignatureFromBytes(signature.Bytes())
+		u.PublicKey = signature.PublicKey.String()
+		u.Signature = signature.Signature.String()
+
+	case ledgerstate.BLSSignatureType:
+		signature, _, _ := ledgerstate.BLSSignatureFromBytes(signature.Bytes())
+		u.Signature = signature.Signature.String()
+
+	case ledgerstate.MultisigSignatureType:
+		signature := signature.(*ledgerstate.MultisigSignature)
+		u.Threshold = signature.Threshold()
+		u.PublicKeys = make([]string, len(signature.PublicKeys()))
+		for i, publicKey := range signature.PublicKeys() {
+			u.PublicKeys[i] = publicKey.String()
+		}
+		u.Signatures = make(map[string]string)
+		for publicKey, partialSignature := range signature.Signatures() {
+			u.Signatures[publicKey.String()] = partialSignature.String()
+		}
+	}
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region TransactionMetadata ///////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,123 @@
+package jsonmodels
+
+// region SubscriptionTopic ////////////////////////////////////////////////////////////////////////////////////////////
+
+// SubscriptionTopic is the name of a topic that clients of the subscriptions endpoint can subscribe to.
+type SubscriptionTopic string
+
+const (
+	// MessagesTopic delivers new messages, optionally filtered by their payload type.
+	MessagesTopic SubscriptionTopic = "messages"
+
+	// TransactionsTopic delivers new transactions that consume or create outputs on one of the given addresses.
+	TransactionsTopic SubscriptionTopic = "transactions"
+
+	// InclusionStateTopic delivers the changes of the inclusion state of the given transaction.
+	InclusionStateTopic SubscriptionTopic = "inclusionState"
+
+	// BranchConfirmationTopic delivers the confirmation and rejection of branches, optionally filtered by branch ID.
+	BranchConfirmationTopic SubscriptionTopic = "branchConfirmation"
+
+	// TipsTopic delivers the tips that get added to or removed from the TipManager.
+	TipsTopic SubscriptionTopic = "tips"
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SubscriptionRequest //////////////////////////////////////////////////////////////////////////////////////////
+
+// SubscriptionAction defines what a SubscriptionRequest is supposed to do.
+type SubscriptionAction string
+
+const (
+	// SubscribeAction creates a new subscription.
+	SubscribeAction SubscriptionAction = "subscribe"
+
+	// UnsubscribeAction removes an existing subscription.
+	UnsubscribeAction SubscriptionAction = "unsubscribe"
+)
+
+// SubscriptionRequest is the message that a client sends to the subscriptions endpoint to manage its subscriptions.
+type SubscriptionRequest struct {
+	Action         SubscriptionAction `json:"action"`
+	Topic          SubscriptionTopic  `json:"topic,omitempty"`
+	SubscriptionID uint64             `json:"subscriptionID,omitempty"`
+	PayloadType    *uint32            `json:"payloadType,omitempty"`
+	Addresses      []string           `json:"addresses,omitempty"`
+	TransactionID  string             `json:"transactionID,omitempty"`
+	BranchID       string             `json:"branchID,omitempty"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SubscriptionResponse /////////////////////////////////////////////////////////////////////////////////////////
+
+// SubscriptionResponseType defines the kind of a SubscriptionResponse.
+type SubscriptionResponseType string
+
+const (
+	// SubscriptionSubscribed confirms that a subscription was created.
+	SubscriptionSubscribed SubscriptionResponseType = "subscribed"
+
+	// SubscriptionUnsubscribed confirms that a subscription was removed.
+	SubscriptionUnsubscribed SubscriptionResponseType = "unsubscribed"
+
+	// SubscriptionEvent carries an event of a subscription.
+	SubscriptionEvent SubscriptionResponseType = "event"
+
+	// SubscriptionDropped informs the client that events were dropped because it did not keep up with reading them.
+	SubscriptionDropped SubscriptionResponseType = "dropped"
+
+	// SubscriptionError informs the client that its last request failed.
+	SubscriptionError SubscriptionResponseType = "error"
+)
+
+// SubscriptionResponse is the message that the subscriptions endpoint sends to its clients.
+type SubscriptionResponse struct {
+	Type           SubscriptionResponseType  `json:"type"`
+	SubscriptionID uint64                    `json:"subscriptionID,omitempty"`
+	Topic          SubscriptionTopic         `json:"topic,omitempty"`
+	Message        *SubscribedMessage        `json:"message,omitempty"`
+	Transaction    *SubscribedTransaction    `json:"transaction,omitempty"`
+	InclusionState *SubscribedInclusionState `json:"inclusionState,omitempty"`
+	Branch         *SubscribedBranch         `json:"branch,omitempty"`
+	Tip            *SubscribedTip            `json:"tip,omitempty"`
+	Dropped        uint64                    `json:"dropped,omitempty"`
+	Error          string                    `json:"error,omitempty"`
+}
+
+// SubscribedMessage is the event of the MessagesTopic.
+type SubscribedMessage struct {
+	ID              string `json:"id"`
+	PayloadType     uint32 `json:"payloadType"`
+	IssuerPublicKey string `json:"issuerPublicKey"`
+	IssuingTime     int64  `json:"issuingTime"`
+}
+
+// SubscribedTransaction is the event of the TransactionsTopic.
+type SubscribedTransaction struct {
+	ID        string   `json:"id"`
+	MessageID string   `json:"messageID"`
+	Addresses []string `json:"addresses"`
+}
+
+// SubscribedInclusionState is the event of the InclusionStateTopic.
+type SubscribedInclusionState struct {
+	TransactionID  string `json:"transactionID"`
+	InclusionState string `json:"inclusionState"`
+}
+
+// SubscribedBranch is the event of the BranchConfirmationTopic.
+type SubscribedBranch struct {
+	ID             string `json:"id"`
+	InclusionState string `json:"inclusionState"`
+}
+
+// SubscribedTip is the event of the TipsTopic.
+type SubscribedTip struct {
+	MessageID string `json:"messageID"`
+	TipType   string `json:"tipType"`
+	Added     bool   `json:"added"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,214 @@
+package jsonmodels
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+	"github.com/iotaledger/goshimmer/packages/vote/votelog"
+)
+
+// VoteTraceResponse is the HTTP message containing the logged trace of a vote.
+type VoteTraceResponse struct {
+	Trace *VoteTrace `json:"trace,omitempty"`
+	Error string     `json:"error,omitempty"`
+}
+
+// VoteTrace represents the JSON model of a votelog.Trace.
+type VoteTrace struct {
+	ID             string          `json:"id"`
+	Type           uint8           `json:"type"`
+	InitialOpinion string          `json:"initialOpinion"`
+	Start          time.Time       `json:"start"`
+	Parameters     *VoteParameters `json:"parameters"`
+	Rounds         []*VoteRound    `json:"rounds"`
+	Outcome        string          `json:"outcome"`
+	FinalOpinion   string          `json:"finalOpinion"`
+}
+
+// NewVoteTrace returns the JSON model of the given trace.
+func NewVoteTrace(trace *votelog.Trace) *VoteTrace {
+	rounds := make([]*VoteRound, len(trace.Rounds))
+	for i, round := range trace.Rounds {
+		rounds[i] = NewVoteRound(round)
+	}
+
+	return &VoteTrace{
+		ID:             trace.ID,
+		Type:           uint8(trace.Type),
+		InitialOpinion: trace.InitialOpinion.String(),
+		Start:          trace.Start,
+		Parameters:     NewVoteParameters(trace.Parameters),
+		Rounds:         rounds,
+		Outcome:        trace.Outcome.String(),
+		FinalOpinion:   trace.FinalOpinion.String(),
+	}
+}
+
+// ToTrace converts the JSON model back to a votelog.Trace, e.g. to replay it.
+func (t *VoteTrace) ToTrace() *votelog.Trace {
+	trace := &votelog.Trace{
+		ID:             t.ID,
+		Type:           vote.ObjectType(t.Type),
+		InitialOpinion: opinionFromString(t.InitialOpinion),
+		Start:          t.Start,
+		Rounds:         make([]*votelog.Round, len(t.Rounds)),
+		FinalOpinion:   opinionFromString(t.FinalOpinion),
+	}
+	if t.Parameters != nil {
+		trace.Parameters = t.Parameters.ToParameters()
+	}
+	for i, round := range t.Rounds {
+		trace.Rounds[i] = round.ToRound()
+	}
+	switch t.Outcome {
+	case votelog.Finalized.String():
+		trace.Outcome = votelog.Finalized
+	case votelog.Failed.String():
+		trace.Outcome = votelog.Failed
+	default:
+		trace.Outcome = votelog.Ongoing
+	}
+	return trace
+}
+
+// VoteRound represents the JSON model of a votelog.Round.
+type VoteRound struct {
+	Number          int                   `json:"number"`
+	Time            time.Time             `json:"time"`
+	RandUsed        float64               `json:"randUsed"`
+	OpinionFormed   bool                  `json:"opinionFormed"`
+	Eta             float64               `json:"eta"`
+	Opinion         string                `json:"opinion"`
+	ProportionLiked float64               `json:"proportionLiked"`
+	OwnWeight       float64               `json:"ownWeight"`
+	TotalWeight     float64               `json:"totalWeight"`
+	QueriedOpinions []*VoteQueriedOpinion `json:"queriedOpinions"`
+}
+
+// NewVoteRound returns the JSON model of the given round.
+func NewVoteRound(round *votelog.Round) *VoteRound {
+	queriedOpinions := make([]*VoteQueriedOpinion, len(round.QueriedOpinions))
+	for i, queriedOpinion := range round.QueriedOpinions {
+		queriedOpinions[i] = &VoteQueriedOpinion{
+			OpinionGiverID: queriedOpinion.OpinionGiverID,
+			Opinion:        queriedOpinion.Opinion.String(),
+			TimesCounted:   queriedOpinion.TimesCounted,
+			Mana:           queriedOpinion.Mana,
+		}
+	}
+
+	return &VoteRound{
+		Number:          round.Number,
+		Time:            round.Time,
+		RandUsed:        round.RandUsed,
+		OpinionFormed:   round.OpinionFormed,
+		Eta:             round.Eta,
+		Opinion:         round.Opinion.String(),
+		ProportionLiked: round.ProportionLiked,
+		OwnWeight:       round.OwnWeight,
+		TotalWeight:     round.TotalWeight,
+		QueriedOpinions: queriedOpinions,
+	}
+}
+
+// ToRound converts the JSON model back to a votelog.Round.
+func (r *VoteRound) ToRound() *votelog.Round {
+	queriedOpinions := make([]*votelog.QueriedOpinion, len(r.QueriedOpinions))
+	for i, queriedOpinion := range r.QueriedOpinions {
+		queriedOpinions[i] = &votelog.QueriedOpinion{
+			OpinionGiverID: queriedOpinion.OpinionGiverID,
+			Opinion:        opinionFromString(queriedOpinion.Opinion),
+			TimesCounted:   queriedOpinion.TimesCounted,
+			Mana:           queriedOpinion.Mana,
+		}
+	}
+
+	return &votelog.Round{
+		Number:          r.Number,
+		Time:            r.Time,
+		RandUsed:        r.RandUsed,
+		OpinionFormed:   r.OpinionFormed,
+		Eta:             r.Eta,
+		Opinion:         opinionFromString(r.Opinion),
+		ProportionLiked: r.ProportionLiked,
+		OwnWeight:       r.OwnWeight,
+		TotalWeight:     r.TotalWeight,
+		QueriedOpinions: queriedOpinions,
+	}
+}
+
+// VoteQueriedOpinion represents the JSON model of a votelog.QueriedOpinion.
+type VoteQueriedOpinion struct {
+	OpinionGiverID string  `json:"opinionGiverID"`
+	Opinion        string  `json:"opinion"`
+	TimesCounted   int     `json:"timesCounted"`
+	Mana           float64 `json:"mana"`
+}
+
+// VoteParameters represents the JSON model of the fpc.Parameters.
+type VoteParameters struct {
+	FirstRoundLowerBoundThreshold       float64       `json:"firstRoundLowerBoundThreshold"`
+	FirstRoundUpperBoundThreshold       float64       `json:"firstRoundUpperBoundThreshold"`
+	SubsequentRoundsLowerBoundThreshold float64       `json:"subsequentRoundsLowerBoundThreshold"`
+	SubsequentRoundsUpperBoundThreshold float64       `json:"subsequentRoundsUpperBoundThreshold"`
+	EndingRoundsFixedThreshold          float64       `json:"endingRoundsFixedThreshold"`
+	QuerySampleSize                     int           `json:"querySampleSize"`
+	MaxQuerySampleSize                  int           `json:"maxQuerySampleSize"`
+	TotalRoundsFinalization             int           `json:"totalRoundsFinalization"`
+	TotalRoundsFixedThreshold           int           `json:"totalRoundsFixedThreshold"`
+	TotalRoundsCoolingOffPeriod         int           `json:"totalRoundsCoolingOffPeriod"`
+	MaxRoundsPerVoteContext             int           `json:"maxRoundsPerVoteContext"`
+	QueryTimeout                        time.Duration `json:"queryTimeout"`
+	MinOpinionsReceived                 int           `json:"minOpinionsReceived"`
+}
+
+// NewVoteParameters returns the JSON model of the given parameters.
+func NewVoteParameters(paras *fpc.Parameters) *VoteParameters {
+	return &VoteParameters{
+		FirstRoundLowerBoundThreshold:       paras.FirstRoundLowerBoundThreshold,
+		FirstRoundUpperBoundThreshold:       paras.FirstRoundUpperBoundThreshold,
+		SubsequentRoundsLowerBoundThreshold: paras.SubsequentRoundsLowerBoundThreshold,
+		SubsequentRoundsUpperBoundThreshold: paras.SubsequentRoundsUpperBoundThreshold,
+		EndingRoundsFixedThreshold:          paras.EndingRoundsFixedThreshold,
+		QuerySampleSize:                     paras.QuerySampleSize,
+		MaxQuerySampleSize:                  paras.MaxQuerySampleSize,
+		TotalRoundsFinalization:             paras.TotalRoundsFinalization,
+		TotalRoundsFixedThreshold:           paras.TotalRoundsFixedThreshold,
+		TotalRoundsCoolingOffPeriod:         paras.TotalRoundsCoolingOffPeriod,
+		MaxRoundsPerVoteContext:             paras.MaxRoundsPerVoteContext,
+		QueryTimeout:                        paras.QueryTimeout,
+		MinOpinionsReceived:                 paras.MinOpinionsReceived,
+	}
+}
+
+// ToParameters converts the JSON model back to fpc.Parameters.
+func (p *VoteParameters) ToParameters() *fpc.Parameters {
+	return &fpc.Parameters{
+		FirstRoundLowerBoundThreshold:       p.FirstRoundLowerBoundThreshold,
+		FirstRoundUpperBoundThreshold:       p.FirstRoundUpperBoundThreshold,
+		SubsequentRoundsLowerBoundThreshold: p.SubsequentRoundsLowerBoundThreshold,
+		SubsequentRoundsUpperBoundThreshold: p.SubsequentRoundsUpperBoundThreshold,
+		EndingRoundsFixedThreshold:          p.EndingRoundsFixedThreshold,
+		QuerySampleSize:                     p.QuerySampleSize,
+		MaxQuerySampleSize:                  p.MaxQuerySampleSize,
+		TotalRoundsFinalization:             p.TotalRoundsFinalization,
+		TotalRoundsFixedThreshold:           p.TotalRoundsFixedThreshold,
+		TotalRoundsCoolingOffPeriod:         p.TotalRoundsCoolingOffPeriod,
+		MaxRoundsPerVoteContext:             p.MaxRoundsPerVoteContext,
+		QueryTimeout:                        p.QueryTimeout,
+		MinOpinionsReceived:                 p.MinOpinionsReceived,
+	}
+}
+
+func opinionFromString(s string) opinion.Opinion {
+	switch s {
+	case opinion.Like.String():
+		return opinion.Like
+	case opinion.Dislike.String():
+		return opinion.Dislike
+	default:
+		return opinion.Unknown
+	}
+}
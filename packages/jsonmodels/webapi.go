@@ -32,6 +32,18 @@ func NewGetAddressResponse(address ledgerstate.Address, outputs ledgerstate.Outp
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetAddressHistoryResponse ////////////////////////////////////////////////////////////////////////////////////
+
+// GetAddressHistoryResponse represents the JSON model of a response from the GetAddressHistory endpoint. NextCursor is
+// empty if there are no further entries.
+type GetAddressHistoryResponse struct {
+	Address    *Address               `json:"address"`
+	Entries    []*AddressHistoryEntry `json:"entries"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region PostAddressesUnspentOutputsRequest
 
 // PostAddressesUnspentOutputsRequest is a the request object for the /ledgerstate/addresses/unspentOutputs endpoint.
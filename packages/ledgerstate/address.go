@@ -2,6 +2,9 @@ package ledgerstate
 
 import (
 	"bytes"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/byteutils"
@@ -11,6 +14,8 @@ import (
 	"github.com/iotaledger/hive.go/stringify"
 	"github.com/mr-tron/base58"
 	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/bech32"
 )
 
 // region AddressType //////////////////////////////////////////////////////////////////////////////////////////////////
@@ -24,6 +29,9 @@ const (
 
 	// AliasAddressType represents ID used in AliasOutput and AliasLockOutput
 	AliasAddressType
+
+	// MultisigAddressType represents an Address secured by a threshold of ED25519 signatures.
+	MultisigAddressType
 )
 
 // AddressLength contains the length of an address (type length = 1, digest length = 32).
@@ -38,6 +46,7 @@ func (a AddressType) String() string {
 		"AddressTypeED25519",
 		"AddressTypeBLS",
 		"AliasAddress",
+		"AddressTypeMultisig",
 	}[a]
 }
 
@@ -68,6 +77,9 @@ type Address interface {
 	// Base58 returns a base58 encoded version of the Address.
 	Base58() string
 
+	// Bech32 returns a bech32 encoded version of the Address that uses the human-readable part of the network.
+	Bech32() string
+
 	// String returns a human readable version of the Address for debug purposes.
 	String() string
 }
@@ -99,6 +111,38 @@ func AddressFromBase58EncodedString(base58String string) (address Address, err e
 	return
 }
 
+// AddressFromBech32EncodedString creates an Address from a bech32 encoded string. It returns an error if the
+// human-readable part of the string does not belong to the network that was configured with SetBech32HRP.
+func AddressFromBech32EncodedString(bech32String string) (address Address, err error) {
+	hrp, bytes, err := bech32.Decode(bech32String)
+	if err != nil {
+		err = errors.Errorf("error while decoding bech32 encoded Address (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if hrp != Bech32HRP() {
+		err = errors.Errorf("Address with prefix '%s' does not belong to network '%s': %w", hrp, Bech32HRP(), ErrWrongNetwork)
+		return
+	}
+
+	if address, _, err = AddressFromBytes(bytes); err != nil {
+		err = errors.Errorf("failed to parse Address from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// AddressFromString creates an Address from a string that is either bech32 or base58 encoded. Strings that carry the
+// human-readable part of the network are always parsed as bech32, so that typos get detected by the checksum instead of
+// being silently misinterpreted.
+func AddressFromString(addressString string) (address Address, err error) {
+	if isBech32Address(addressString) {
+		return AddressFromBech32EncodedString(addressString)
+	}
+
+	return AddressFromBase58EncodedString(addressString)
+}
+
 // AddressFromMarshalUtil reads an Address from the bytes in the given MarshalUtil.
 func AddressFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (address Address, err error) {
 	addressType, err := marshalUtil.ReadByte()
@@ -115,6 +159,8 @@ func AddressFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (address Addre
 		return BLSAddressFromMarshalUtil(marshalUtil)
 	case AliasAddressType:
 		return AliasAddressFromMarshalUtil(marshalUtil)
+	case MultisigAddressType:
+		return MultisigAddressFromMarshalUtil(marshalUtil)
 	default:
 		err = errors.Errorf("unsupported address type (%X): %w", addressType, cerrors.ErrParseBytesFailed)
 		return
@@ -128,10 +174,60 @@ func AddressFromSignature(sig Signature) (Address, error) {
 		return NewED25519Address(s.PublicKey), nil
 	case *BLSSignature:
 		return NewBLSAddress(s.Signature.PublicKey.Bytes()), nil
+	case *MultisigSignature:
+		return s.Address(), nil
 	}
 	return nil, errors.New("signature has no corresponding address")
 }
 
+// isBech32Address returns true if the given string is supposed to be parsed as a bech32 encoded Address. This is the
+// case if it starts with the human-readable part of the network or if it is a valid bech32 string of another network
+// (base58 encoded Addresses never contain the separator at the position that is expected for bech32 strings).
+func isBech32Address(addressString string) bool {
+	if strings.HasPrefix(strings.ToLower(addressString), Bech32HRP()+"1") {
+		return true
+	}
+	_, _, err := bech32.Decode(addressString)
+
+	return err == nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Bech32HRP ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DefaultBech32HRP contains the human-readable part that is used for bech32 encoded Addresses if no other value was
+// configured.
+const DefaultBech32HRP = "atoi"
+
+// bech32HRP contains the human-readable part that is used for bech32 encoded Addresses.
+var bech32HRP = DefaultBech32HRP
+
+// SetBech32HRP sets the human-readable part that is used to encode and decode bech32 encoded Addresses. It identifies
+// the network that the Addresses belong to and should be set once during startup.
+func SetBech32HRP(hrp string) {
+	if _, err := bech32.Encode(hrp, nil); err != nil {
+		panic(fmt.Sprintf("invalid bech32 human-readable part '%s': %s", hrp, err))
+	}
+
+	bech32HRP = strings.ToLower(hrp)
+}
+
+// Bech32HRP returns the human-readable part that is used to encode and decode bech32 encoded Addresses.
+func Bech32HRP() string {
+	return bech32HRP
+}
+
+// encodeBech32 encodes the given marshaled Address using the configured human-readable part.
+func encodeBech32(addressBytes []byte) string {
+	bech32String, err := bech32.Encode(bech32HRP, addressBytes)
+	if err != nil {
+		panic(err)
+	}
+
+	return bech32String
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region ED25519Address ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -241,11 +337,17 @@ func (e *ED25519Address) Base58() string {
 	return base58.Encode(e.Bytes())
 }
 
+// Bech32 returns a bech32 encoded version of the address that uses the human-readable part of the network.
+func (e *ED25519Address) Bech32() string {
+	return encodeBech32(e.Bytes())
+}
+
 // String returns a human readable version of the addresses for debug purposes.
 func (e *ED25519Address) String() string {
 	return stringify.Struct("ED25519Address",
 		stringify.StructField("Digest", e.Digest()),
 		stringify.StructField("Base58", e.Base58()),
+		stringify.StructField("Bech32", e.Bech32()),
 	)
 }
 
@@ -361,11 +463,17 @@ func (b *BLSAddress) Base58() string {
 	return base58.Encode(b.Bytes())
 }
 
+// Bech32 returns a bech32 encoded version of the address that uses the human-readable part of the network.
+func (b *BLSAddress) Bech32() string {
+	return encodeBech32(b.Bytes())
+}
+
 // String returns a human readable version of the addresses for debug purposes.
 func (b *BLSAddress) String() string {
 	return stringify.Struct("BLSAddress",
 		stringify.StructField("Digest", b.Digest()),
 		stringify.StructField("Base58", b.Base58()),
+		stringify.StructField("Bech32", b.Bech32()),
 	)
 }
 
@@ -480,11 +588,17 @@ func (a *AliasAddress) Base58() string {
 	return base58.Encode(a.Bytes())
 }
 
+// Bech32 returns a bech32 encoded version of the address that uses the human-readable part of the network.
+func (a *AliasAddress) Bech32() string {
+	return encodeBech32(a.Bytes())
+}
+
 // String returns a human readable version of the addresses for debug purposes.
 func (a *AliasAddress) String() string {
 	return stringify.Struct("AliasAddress",
 		stringify.StructField("Digest", a.Digest()),
 		stringify.StructField("Base58", a.Base58()),
+		stringify.StructField("Bech32", a.Bech32()),
 	)
 }
 
@@ -497,3 +611,171 @@ func (a *AliasAddress) IsNil() bool {
 var _ Address = &AliasAddress{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MultisigAddress //////////////////////////////////////////////////////////////////////////////////////////////
+
+// MaxMultisigPublicKeys defines the maximum amount of public keys that can be part of a MultisigAddress.
+const MaxMultisigPublicKeys = 32
+
+// MultisigAddress represents an Address that is secured by a threshold of ED25519 signatures (m-of-n). The digest is the
+// hash of the threshold and the sorted list of public keys, which are only revealed in the MultisigSignature that
+// unlocks the Address.
+type MultisigAddress struct {
+	digest [32]byte
+}
+
+// NewMultisigAddress creates a new MultisigAddress that requires the given threshold of signatures of the given public
+// keys.
+func NewMultisigAddress(threshold uint8, publicKeys ...ed25519.PublicKey) (address *MultisigAddress, err error) {
+	sortedPublicKeys, err := sortMultisigPublicKeys(threshold, publicKeys)
+	if err != nil {
+		return
+	}
+
+	return &MultisigAddress{
+		digest: multisigDigest(threshold, sortedPublicKeys),
+	}, nil
+}
+
+// MultisigAddressFromBytes unmarshals a MultisigAddress from a sequence of bytes.
+func MultisigAddressFromBytes(bytes []byte) (address *MultisigAddress, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if address, err = MultisigAddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse MultisigAddress from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MultisigAddressFromBase58EncodedString creates a MultisigAddress from a base58 encoded string.
+func MultisigAddressFromBase58EncodedString(base58String string) (address *MultisigAddress, err error) {
+	bytes, err := base58.Decode(base58String)
+	if err != nil {
+		err = errors.Errorf("error while decoding base58 encoded MultisigAddress (%v): %w", err, cerrors.ErrBase58DecodeFailed)
+		return
+	}
+
+	if address, _, err = MultisigAddressFromBytes(bytes); err != nil {
+		err = errors.Errorf("failed to parse MultisigAddress from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// MultisigAddressFromMarshalUtil parses a MultisigAddress from the given MarshalUtil.
+func MultisigAddressFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (address *MultisigAddress, err error) {
+	addressType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("error parsing AddressType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if AddressType(addressType) != MultisigAddressType {
+		err = errors.Errorf("invalid AddressType (%X): %w", addressType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	digest, err := marshalUtil.ReadBytes(32)
+	if err != nil {
+		err = errors.Errorf("error parsing digest (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	address = &MultisigAddress{}
+	copy(address.digest[:], digest)
+
+	return
+}
+
+// Type returns the AddressType of the Address.
+func (m *MultisigAddress) Type() AddressType {
+	return MultisigAddressType
+}
+
+// Digest returns the hash of the threshold and the public keys of the Address.
+func (m *MultisigAddress) Digest() []byte {
+	return m.digest[:]
+}
+
+// Clone creates a copy of the Address.
+func (m *MultisigAddress) Clone() Address {
+	return &MultisigAddress{digest: m.digest}
+}
+
+// Equals returns true if the two Addresses are equal.
+func (m *MultisigAddress) Equals(other Address) bool {
+	return m.Type() == other.Type() && bytes.Equal(m.Digest(), other.Digest())
+}
+
+// Bytes returns a marshaled version of the Address.
+func (m *MultisigAddress) Bytes() []byte {
+	return byteutils.ConcatBytes([]byte{byte(MultisigAddressType)}, m.digest[:])
+}
+
+// Array returns an array of bytes that contains the marshaled version of the Address.
+func (m *MultisigAddress) Array() (array [AddressLength]byte) {
+	copy(array[:], m.Bytes())
+
+	return
+}
+
+// Base58 returns a base58 encoded version of the Address.
+func (m *MultisigAddress) Base58() string {
+	return base58.Encode(m.Bytes())
+}
+
+// Bech32 returns a bech32 encoded version of the address that uses the human-readable part of the network.
+func (m *MultisigAddress) Bech32() string {
+	return encodeBech32(m.Bytes())
+}
+
+// String returns a human readable version of the addresses for debug purposes.
+func (m *MultisigAddress) String() string {
+	return stringify.Struct("MultisigAddress",
+		stringify.StructField("Digest", m.Digest()),
+		stringify.StructField("Base58", m.Base58()),
+		stringify.StructField("Bech32", m.Bech32()),
+	)
+}
+
+// sortMultisigPublicKeys checks if the public keys and the threshold form a valid MultisigAddress and returns a sorted
+// copy of the public keys.
+func sortMultisigPublicKeys(threshold uint8, publicKeys []ed25519.PublicKey) (sortedPublicKeys []ed25519.PublicKey, err error) {
+	if len(publicKeys) == 0 || len(publicKeys) > MaxMultisigPublicKeys {
+		return nil, errors.Errorf("amount of public keys (%d) needs to be between 1 and %d", len(publicKeys), MaxMultisigPublicKeys)
+	}
+	if threshold == 0 || int(threshold) > len(publicKeys) {
+		return nil, errors.Errorf("threshold (%d) needs to be between 1 and the amount of public keys (%d)", threshold, len(publicKeys))
+	}
+
+	sortedPublicKeys = make([]ed25519.PublicKey, len(publicKeys))
+	copy(sortedPublicKeys, publicKeys)
+	sort.Slice(sortedPublicKeys, func(i, j int) bool {
+		return bytes.Compare(sortedPublicKeys[i][:], sortedPublicKeys[j][:]) < 0
+	})
+	for i := 1; i < len(sortedPublicKeys); i++ {
+		if sortedPublicKeys[i] == sortedPublicKeys[i-1] {
+			return nil, errors.Errorf("duplicate public key %s", sortedPublicKeys[i])
+		}
+	}
+
+	return sortedPublicKeys, nil
+}
+
+// multisigDigest returns the digest of a MultisigAddress with the given threshold and sorted public keys.
+func multisigDigest(threshold uint8, sortedPublicKeys []ed25519.PublicKey) [32]byte {
+	marshalUtil := marshalutil.New().
+		WriteUint8(threshold).
+		WriteUint8(uint8(len(sortedPublicKeys)))
+	for _, publicKey := range sortedPublicKeys {
+		marshalUtil.WriteBytes(publicKey.Bytes())
+	}
+
+	return blake2b.Sum256(marshalUtil.Bytes())
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ Address = &MultisigAddress{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
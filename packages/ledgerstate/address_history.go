@@ -0,0 +1,390 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+)
+
+// region AddressHistoryEntryType //////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// CreatedAddressHistoryEntryType represents an Output that was created on the Address.
+	CreatedAddressHistoryEntryType AddressHistoryEntryType = iota
+
+	// ConsumedAddressHistoryEntryType represents an Output on the Address that was consumed by a Transaction.
+	ConsumedAddressHistoryEntryType
+)
+
+// AddressHistoryEntryType represents the kind of change that an AddressHistoryEntry describes.
+type AddressHistoryEntryType uint8
+
+// AddressHistoryEntryTypeFromMarshalUtil unmarshals an AddressHistoryEntryType using a MarshalUtil (for easier
+// unmarshaling).
+func AddressHistoryEntryTypeFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (entryType AddressHistoryEntryType, err error) {
+	entryTypeByte, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("failed to parse AddressHistoryEntryType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if entryType = AddressHistoryEntryType(entryTypeByte); entryType > ConsumedAddressHistoryEntryType {
+		err = errors.Errorf("invalid AddressHistoryEntryType (%d): %w", entryTypeByte, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the AddressHistoryEntryType.
+func (a AddressHistoryEntryType) Bytes() []byte {
+	return []byte{byte(a)}
+}
+
+// String returns a human readable representation of the AddressHistoryEntryType.
+func (a AddressHistoryEntryType) String() string {
+	return [...]string{
+		"Created",
+		"Consumed",
+	}[a]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AddressHistoryEpoch //////////////////////////////////////////////////////////////////////////////////////////
+
+// AddressHistoryEpochDuration defines the length of the time intervals that the address history is partitioned into.
+const AddressHistoryEpochDuration = time.Hour
+
+// AddressHistoryEpochFromTime returns the index of the epoch of the address history that contains the given time.
+func AddressHistoryEpochFromTime(t time.Time) uint64 {
+	return uint64(t.UnixNano() / int64(AddressHistoryEpochDuration))
+}
+
+// AddressHistoryEpoch records that the history of the Addresses contains entries of the epoch with the given index, so
+// that the history can be traversed (and pruned) epoch by epoch without scanning all the entries.
+type AddressHistoryEpoch struct {
+	index uint64
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAddressHistoryEpoch returns a new AddressHistoryEpoch.
+func NewAddressHistoryEpoch(index uint64) *AddressHistoryEpoch {
+	return &AddressHistoryEpoch{
+		index: index,
+	}
+}
+
+// AddressHistoryEpochFromBytes unmarshals an AddressHistoryEpoch from a sequence of bytes.
+func AddressHistoryEpochFromBytes(bytes []byte) (addressHistoryEpoch *AddressHistoryEpoch, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if addressHistoryEpoch, err = AddressHistoryEpochFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AddressHistoryEpoch from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AddressHistoryEpochFromMarshalUtil unmarshals an AddressHistoryEpoch using a MarshalUtil (for easier unmarshaling).
+func AddressHistoryEpochFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (addressHistoryEpoch *AddressHistoryEpoch, err error) {
+	indexBytes, err := marshalUtil.ReadBytes(marshalutil.Uint64Size)
+	if err != nil {
+		err = errors.Errorf("failed to parse epoch index (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return NewAddressHistoryEpoch(binary.BigEndian.Uint64(indexBytes)), nil
+}
+
+// AddressHistoryEpochFromObjectStorage is a factory method that creates a new AddressHistoryEpoch instance from a
+// storage key of the object storage. It is used by the object storage, to create new instances of this entity.
+func AddressHistoryEpochFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = AddressHistoryEpochFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse AddressHistoryEpoch from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Index returns the index of the epoch.
+func (a *AddressHistoryEpoch) Index() uint64 {
+	return a.index
+}
+
+// Bytes marshals the AddressHistoryEpoch into a sequence of bytes.
+func (a *AddressHistoryEpoch) Bytes() []byte {
+	return a.ObjectStorageKey()
+}
+
+// String returns a human readable version of the AddressHistoryEpoch.
+func (a *AddressHistoryEpoch) String() string {
+	return stringify.Struct("AddressHistoryEpoch",
+		stringify.StructField("index", a.index),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AddressHistoryEpoch) Update(other objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AddressHistoryEpoch) ObjectStorageKey() []byte {
+	return addressHistoryEpochBytes(a.index)
+}
+
+// ObjectStorageValue marshals the AddressHistoryEpoch into a sequence of bytes that are used as the value part in the
+// object storage.
+func (a *AddressHistoryEpoch) ObjectStorageValue() []byte {
+	return nil
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &AddressHistoryEpoch{}
+
+// addressHistoryEpochBytes returns the big endian encoded index of an epoch, which sorts the epochs chronologically.
+func addressHistoryEpochBytes(index uint64) []byte {
+	indexBytes := make([]byte, marshalutil.Uint64Size)
+	binary.BigEndian.PutUint64(indexBytes, index)
+
+	return indexBytes
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AddressHistoryEntry //////////////////////////////////////////////////////////////////////////////////////////
+
+// AddressHistoryEntryKeyPartition defines the partition of the storage key of the AddressHistoryEntry model.
+var AddressHistoryEntryKeyPartition = objectstorage.PartitionKey(marshalutil.Uint64Size, AddressLength, marshalutil.Uint64Size, OutputIDLength, 1)
+
+// AddressHistoryEntry represents an entry of the history of an Address. It records that an Output on the Address was
+// either created or consumed by a Transaction at a given time. The storage key starts with the epoch of the timestamp,
+// followed by the Address and the big endian encoded timestamp, so the entries of an Address can be iterated in
+// chronological order epoch by epoch and whole epochs can be pruned without touching the other ones.
+type AddressHistoryEntry struct {
+	address       Address
+	timestamp     time.Time
+	outputID      OutputID
+	entryType     AddressHistoryEntryType
+	transactionID TransactionID
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewAddressHistoryEntry returns a new AddressHistoryEntry.
+func NewAddressHistoryEntry(address Address, timestamp time.Time, outputID OutputID, entryType AddressHistoryEntryType, transactionID TransactionID) *AddressHistoryEntry {
+	return &AddressHistoryEntry{
+		address:       address,
+		timestamp:     timestamp,
+		outputID:      outputID,
+		entryType:     entryType,
+		transactionID: transactionID,
+	}
+}
+
+// AddressHistoryEntryFromBytes unmarshals an AddressHistoryEntry from a sequence of bytes.
+func AddressHistoryEntryFromBytes(bytes []byte) (addressHistoryEntry *AddressHistoryEntry, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if addressHistoryEntry, err = AddressHistoryEntryFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AddressHistoryEntry from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// AddressHistoryEntryFromMarshalUtil unmarshals an AddressHistoryEntry using a MarshalUtil (for easier unmarshaling).
+func AddressHistoryEntryFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (addressHistoryEntry *AddressHistoryEntry, err error) {
+	addressHistoryEntry = &AddressHistoryEntry{}
+	if _, err = marshalUtil.ReadBytes(marshalutil.Uint64Size); err != nil {
+		err = errors.Errorf("failed to parse epoch index (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if addressHistoryEntry.address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Address from MarshalUtil: %w", err)
+		return
+	}
+	timestampBytes, err := marshalUtil.ReadBytes(marshalutil.Uint64Size)
+	if err != nil {
+		err = errors.Errorf("failed to parse timestamp (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	addressHistoryEntry.timestamp = time.Unix(0, int64(binary.BigEndian.Uint64(timestampBytes)))
+	if addressHistoryEntry.outputID, err = OutputIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse OutputID from MarshalUtil: %w", err)
+		return
+	}
+	if addressHistoryEntry.entryType, err = AddressHistoryEntryTypeFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse AddressHistoryEntryType from MarshalUtil: %w", err)
+		return
+	}
+	if addressHistoryEntry.transactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse TransactionID from MarshalUtil: %w", err)
+		return
+	}
+
+	return
+}
+
+// AddressHistoryEntryFromObjectStorage is a factory method that creates a new AddressHistoryEntry instance from a
+// storage key of the object storage. It is used by the object storage, to create new instances of this entity.
+func AddressHistoryEntryFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = AddressHistoryEntryFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse AddressHistoryEntry from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// Address returns the Address that the AddressHistoryEntry belongs to.
+func (a *AddressHistoryEntry) Address() Address {
+	return a.address
+}
+
+// Timestamp returns the timestamp of the Transaction that created or consumed the Output.
+func (a *AddressHistoryEntry) Timestamp() time.Time {
+	return a.timestamp
+}
+
+// OutputID returns the OutputID of the Output that was created or consumed.
+func (a *AddressHistoryEntry) OutputID() OutputID {
+	return a.outputID
+}
+
+// Type returns the AddressHistoryEntryType of the AddressHistoryEntry.
+func (a *AddressHistoryEntry) Type() AddressHistoryEntryType {
+	return a.entryType
+}
+
+// TransactionID returns the TransactionID of the Transaction that created or consumed the Output.
+func (a *AddressHistoryEntry) TransactionID() TransactionID {
+	return a.transactionID
+}
+
+// Bytes marshals the AddressHistoryEntry into a sequence of bytes.
+func (a *AddressHistoryEntry) Bytes() []byte {
+	return byteutils.ConcatBytes(a.ObjectStorageKey(), a.ObjectStorageValue())
+}
+
+// String returns a human readable version of the AddressHistoryEntry.
+func (a *AddressHistoryEntry) String() string {
+	return stringify.Struct("AddressHistoryEntry",
+		stringify.StructField("address", a.address),
+		stringify.StructField("timestamp", a.timestamp),
+		stringify.StructField("outputID", a.outputID),
+		stringify.StructField("type", a.entryType),
+		stringify.StructField("transactionID", a.transactionID),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (a *AddressHistoryEntry) Update(other objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (a *AddressHistoryEntry) ObjectStorageKey() []byte {
+	timestampBytes := make([]byte, marshalutil.Uint64Size)
+	binary.BigEndian.PutUint64(timestampBytes, uint64(a.timestamp.UnixNano()))
+
+	return byteutils.ConcatBytes(addressHistoryEpochBytes(AddressHistoryEpochFromTime(a.timestamp)), a.address.Bytes(), timestampBytes, a.outputID.Bytes(), a.entryType.Bytes())
+}
+
+// ObjectStorageValue marshals the AddressHistoryEntry into a sequence of bytes that are used as the value part in the
+// object storage.
+func (a *AddressHistoryEntry) ObjectStorageValue() []byte {
+	return a.transactionID.Bytes()
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &AddressHistoryEntry{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AddressHistoryEntries ////////////////////////////////////////////////////////////////////////////////////////
+
+// AddressHistoryEntries represents a collection of AddressHistoryEntry objects.
+type AddressHistoryEntries []*AddressHistoryEntry
+
+// Sort sorts the AddressHistoryEntries by their storage key which results in a chronological order for the entries of
+// the same Address.
+func (a AddressHistoryEntries) Sort() AddressHistoryEntries {
+	sort.Slice(a, func(i, j int) bool {
+		return bytes.Compare(a[i].ObjectStorageKey(), a[j].ObjectStorageKey()) < 0
+	})
+
+	return a
+}
+
+// String returns a human readable version of the AddressHistoryEntries.
+func (a AddressHistoryEntries) String() string {
+	structBuilder := stringify.StructBuilder("AddressHistoryEntries")
+	for i, addressHistoryEntry := range a {
+		structBuilder.AddField(stringify.StructField(strconv.Itoa(i), addressHistoryEntry))
+	}
+
+	return structBuilder.String()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedAddressHistoryEntry ////////////////////////////////////////////////////////////////////////////////////
+
+// CachedAddressHistoryEntry is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedAddressHistoryEntry struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks the CachedObject to still be in use by the program.
+func (c *CachedAddressHistoryEntry) Retain() *CachedAddressHistoryEntry {
+	return &CachedAddressHistoryEntry{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedAddressHistoryEntry) Unwrap() *AddressHistoryEntry {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*AddressHistoryEntry)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedAddressHistoryEntry) Consume(consumer func(addressHistoryEntry *AddressHistoryEntry), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*AddressHistoryEntry))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedAddressHistoryEntry.
+func (c *CachedAddressHistoryEntry) String() string {
+	return stringify.Struct("CachedAddressHistoryEntry",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
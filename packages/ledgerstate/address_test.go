@@ -2,8 +2,10 @@ package ledgerstate
 
 import (
 	"math/rand"
+	"strings"
 	"testing"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -82,3 +84,73 @@ func TestAliasAddressIsNil(t *testing.T) {
 	require.False(t, notNilAddr.IsNil())
 	require.True(t, nilAddr.Equals(&AliasAddress{}))
 }
+
+func TestMultisigAddress(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+
+	address, err := NewMultisigAddress(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+
+	// the order of the public keys does not change the address
+	reorderedAddress, err := NewMultisigAddress(2, keyPairs[2].PublicKey, keyPairs[0].PublicKey, keyPairs[1].PublicKey)
+	require.NoError(t, err)
+	assert.True(t, address.Equals(reorderedAddress))
+
+	// the threshold changes the address
+	otherThresholdAddress, err := NewMultisigAddress(3, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+	assert.False(t, address.Equals(otherThresholdAddress))
+
+	// Multisig address from bytes using AddressFromBytes
+	address1, _, err := AddressFromBytes(address.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), address1.Type())
+	assert.Equal(t, address.Digest(), address1.Digest())
+
+	// Multisig address from base58 string
+	addressFromBase58, err := AddressFromBase58EncodedString(address.Base58())
+	require.NoError(t, err)
+	assert.Equal(t, address.Type(), addressFromBase58.Type())
+	assert.Equal(t, address.Digest(), addressFromBase58.Digest())
+
+	// invalid thresholds and duplicate keys
+	_, err = NewMultisigAddress(0, keyPairs[0].PublicKey)
+	assert.Error(t, err)
+	_, err = NewMultisigAddress(2, keyPairs[0].PublicKey)
+	assert.Error(t, err)
+	_, err = NewMultisigAddress(1, keyPairs[0].PublicKey, keyPairs[0].PublicKey)
+	assert.Error(t, err)
+}
+
+func TestAddressBech32(t *testing.T) {
+	defer SetBech32HRP(DefaultBech32HRP)
+
+	address := NewED25519Address(ed25519.GenerateKeyPair().PublicKey)
+	assert.True(t, strings.HasPrefix(address.Bech32(), DefaultBech32HRP+"1"))
+
+	// both encodings are accepted by AddressFromString
+	for _, addressString := range []string{address.Bech32(), strings.ToUpper(address.Bech32()), address.Base58()} {
+		parsedAddress, err := AddressFromString(addressString)
+		require.NoError(t, err)
+		assert.True(t, address.Equals(parsedAddress))
+	}
+
+	// typos are detected by the checksum
+	bech32String := []byte(address.Bech32())
+	if bech32String[10] == 'q' {
+		bech32String[10] = 'p'
+	} else {
+		bech32String[10] = 'q'
+	}
+	_, err := AddressFromString(string(bech32String))
+	assert.Error(t, err)
+
+	// addresses of other networks are rejected
+	SetBech32HRP("iota")
+	_, err = AddressFromString(NewAliasAddress([]byte("alias")).Bech32())
+	require.NoError(t, err)
+	otherNetworkAddress := address.Bech32()
+	SetBech32HRP(DefaultBech32HRP)
+	_, err = AddressFromString(otherNetworkAddress)
+	assert.True(t, errors.Is(err, ErrWrongNetwork))
+}
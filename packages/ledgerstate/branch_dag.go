@@ -424,6 +424,14 @@ func (b *BranchDAG) Prune() (err error) {
 	return
 }
 
+// Flush persists the cached objects of the BranchDAG without shutting it down.
+func (b *BranchDAG) Flush() {
+	b.branchStorage.Flush()
+	b.childBranchStorage.Flush()
+	b.conflictStorage.Flush()
+	b.conflictMemberStorage.Flush()
+}
+
 // Shutdown shuts down the BranchDAG and persists its state.
 func (b *BranchDAG) Shutdown() {
 	b.shutdownOnce.Do(func() {
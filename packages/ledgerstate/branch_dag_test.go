@@ -7,6 +7,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/database"
 
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -867,46 +868,48 @@ func TestBranchDAG_SetBranchLiked2(t *testing.T) {
 }
 
 func TestBranchDAG_ConflictMembers(t *testing.T) {
-	branchDAG := NewBranchDAG(mapdb.NewMapDB(), database.NewCacheTimeProvider(0))
-	err := branchDAG.Prune()
-	require.NoError(t, err)
-	defer branchDAG.Shutdown()
-
-	// create initial branches
-	cachedBranch2, newBranchCreated, _ := branchDAG.CreateConflictBranch(BranchID{2}, NewBranchIDs(MasterBranchID), NewConflictIDs(ConflictID{0}))
-	defer cachedBranch2.Release()
-	branch2 := cachedBranch2.Unwrap()
-	assert.True(t, newBranchCreated)
-	cachedBranch3, newBranchCreated, _ := branchDAG.CreateConflictBranch(BranchID{3}, NewBranchIDs(MasterBranchID), NewConflictIDs(ConflictID{0}))
-	defer cachedBranch3.Release()
-	branch3 := cachedBranch3.Unwrap()
-	assert.True(t, newBranchCreated)
-
-	// assert conflict members
-	expectedConflictMembers := map[BranchID]struct{}{
-		branch2.ID(): {}, branch3.ID(): {},
-	}
-	actualConflictMembers := map[BranchID]struct{}{}
-	branchDAG.ConflictMembers(ConflictID{0}).Consume(func(conflictMember *ConflictMember) {
-		actualConflictMembers[conflictMember.BranchID()] = struct{}{}
-	})
-	assert.Equal(t, expectedConflictMembers, actualConflictMembers)
-
-	// add branch 4
-	cachedBranch4, newBranchCreated, _ := branchDAG.CreateConflictBranch(BranchID{4}, NewBranchIDs(MasterBranchID), NewConflictIDs(ConflictID{0}))
-	defer cachedBranch4.Release()
-	branch4 := cachedBranch4.Unwrap()
-	assert.True(t, newBranchCreated)
-
-	// branch 4 should now also be part of the conflict set
-	expectedConflictMembers = map[BranchID]struct{}{
-		branch2.ID(): {}, branch3.ID(): {}, branch4.ID(): {},
-	}
-	actualConflictMembers = map[BranchID]struct{}{}
-	branchDAG.ConflictMembers(ConflictID{0}).Consume(func(conflictMember *ConflictMember) {
-		actualConflictMembers[conflictMember.BranchID()] = struct{}{}
+	database.ForEachStore(t, func(t *testing.T, store kvstore.KVStore) {
+		branchDAG := NewBranchDAG(store, database.NewCacheTimeProvider(0))
+		err := branchDAG.Prune()
+		require.NoError(t, err)
+		defer branchDAG.Shutdown()
+
+		// create initial branches
+		cachedBranch2, newBranchCreated, _ := branchDAG.CreateConflictBranch(BranchID{2}, NewBranchIDs(MasterBranchID), NewConflictIDs(ConflictID{0}))
+		defer cachedBranch2.Release()
+		branch2 := cachedBranch2.Unwrap()
+		assert.True(t, newBranchCreated)
+		cachedBranch3, newBranchCreated, _ := branchDAG.CreateConflictBranch(BranchID{3}, NewBranchIDs(MasterBranchID), NewConflictIDs(ConflictID{0}))
+		defer cachedBranch3.Release()
+		branch3 := cachedBranch3.Unwrap()
+		assert.True(t, newBranchCreated)
+
+		// assert conflict members
+		expectedConflictMembers := map[BranchID]struct{}{
+			branch2.ID(): {}, branch3.ID(): {},
+		}
+		actualConflictMembers := map[BranchID]struct{}{}
+		branchDAG.ConflictMembers(ConflictID{0}).Consume(func(conflictMember *ConflictMember) {
+			actualConflictMembers[conflictMember.BranchID()] = struct{}{}
+		})
+		assert.Equal(t, expectedConflictMembers, actualConflictMembers)
+
+		// add branch 4
+		cachedBranch4, newBranchCreated, _ := branchDAG.CreateConflictBranch(BranchID{4}, NewBranchIDs(MasterBranchID), NewConflictIDs(ConflictID{0}))
+		defer cachedBranch4.Release()
+		branch4 := cachedBranch4.Unwrap()
+		assert.True(t, newBranchCreated)
+
+		// branch 4 should now also be part of the conflict set
+		expectedConflictMembers = map[BranchID]struct{}{
+			branch2.ID(): {}, branch3.ID(): {}, branch4.ID(): {},
+		}
+		actualConflictMembers = map[BranchID]struct{}{}
+		branchDAG.ConflictMembers(ConflictID{0}).Consume(func(conflictMember *ConflictMember) {
+			actualConflictMembers[conflictMember.BranchID()] = struct{}{}
+		})
+		assert.Equal(t, expectedConflictMembers, actualConflictMembers)
 	})
-	assert.Equal(t, expectedConflictMembers, actualConflictMembers)
 }
 
 func TestBranchDAG_MergeToMaster(t *testing.T) {
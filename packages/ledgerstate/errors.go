@@ -13,4 +13,25 @@ var (
 
 	// ErrInvalidStateTransition is returned if there is an invalid state transition in the ledger state.
 	ErrInvalidStateTransition = errors.New("invalid state transition")
+
+	// ErrUnsupportedSnapshotVersion is returned if a snapshot is read that was written in an unknown format.
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+
+	// ErrSnapshotChecksumMismatch is returned if the checksum of a snapshot does not match its content.
+	ErrSnapshotChecksumMismatch = errors.New("snapshot checksum mismatch")
+
+	// ErrSnapshotNotDelta is returned if a delta operation is performed on a snapshot that is not a delta snapshot.
+	ErrSnapshotNotDelta = errors.New("snapshot is not a delta snapshot")
+
+	// ErrSnapshotBaseMismatch is returned if a delta snapshot is applied to a snapshot that it is not based on.
+	ErrSnapshotBaseMismatch = errors.New("snapshot does not match base of delta snapshot")
+
+	// ErrSnapshotClosed is returned if an entry is written to a snapshot that has been closed already.
+	ErrSnapshotClosed = errors.New("snapshot closed")
+
+	// ErrAddressHistoryDisabled is returned if the history of an Address is requested while the index is disabled.
+	ErrAddressHistoryDisabled = errors.New("address history disabled")
+
+	// ErrWrongNetwork is returned if a bech32 encoded Address belongs to a different network.
+	ErrWrongNetwork = errors.New("address belongs to a different network")
 )
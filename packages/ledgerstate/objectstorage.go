@@ -38,6 +38,12 @@ const (
 
 	// PrefixAddressOutputMappingStorage defines the storage prefix for the AddressOutputMapping object storage.
 	PrefixAddressOutputMappingStorage
+
+	// PrefixAddressHistoryStorage defines the storage prefix for the AddressHistoryEntry object storage.
+	PrefixAddressHistoryStorage
+
+	// PrefixAddressHistoryEpochStorage defines the storage prefix for the AddressHistoryEpoch object storage.
+	PrefixAddressHistoryEpochStorage
 )
 
 // block of default cache time
@@ -80,6 +86,12 @@ type storageOptions struct {
 
 	// addressOutputMappingStorageOptions contains a list of default settings for the AddressOutputMapping object storage.
 	addressOutputMappingStorageOptions []objectstorage.Option
+
+	// addressHistoryStorageOptions contains a list of default settings for the AddressHistoryEntry object storage.
+	addressHistoryStorageOptions []objectstorage.Option
+
+	// addressHistoryEpochStorageOptions contains a list of default settings for the AddressHistoryEpoch object storage.
+	addressHistoryEpochStorageOptions []objectstorage.Option
 }
 
 func buildObjectStorageOptions(cacheProvider *database.CacheTimeProvider) *storageOptions {
@@ -144,5 +156,18 @@ func buildObjectStorageOptions(cacheProvider *database.CacheTimeProvider) *stora
 		objectstorage.StoreOnCreation(true),
 	}
 
+	options.addressHistoryStorageOptions = []objectstorage.Option{
+		AddressHistoryEntryKeyPartition,
+		cacheProvider.CacheTime(addressCacheTime),
+		objectstorage.LeakDetectionEnabled(false),
+		objectstorage.StoreOnCreation(true),
+	}
+
+	options.addressHistoryEpochStorageOptions = []objectstorage.Option{
+		cacheProvider.CacheTime(addressCacheTime),
+		objectstorage.LeakDetectionEnabled(false),
+		objectstorage.StoreOnCreation(true),
+	}
+
 	return &options
 }
@@ -58,6 +58,9 @@ const (
 
 	// ExtendedLockedOutputType represents an Output which extends SigLockedColoredOutput with alias locking and fallback
 	ExtendedLockedOutputType
+
+	// HashTimeLockedOutputType represents an Output that is locked by a hash lock until a timeout and refunded afterwards
+	HashTimeLockedOutputType
 )
 
 // String returns a human readable representation of the OutputType.
@@ -67,6 +70,7 @@ func (o OutputType) String() string {
 		"SigLockedColoredOutputType",
 		"AliasOutputType",
 		"ExtendedLockedOutputType",
+		"HashTimeLockedOutputType",
 	}[o]
 }
 
@@ -77,6 +81,7 @@ func OutputTypeFromString(ot string) (OutputType, error) {
 		"SigLockedColoredOutputType": SigLockedColoredOutputType,
 		"AliasOutputType":            AliasOutputType,
 		"ExtendedLockedOutputType":   ExtendedLockedOutputType,
+		"HashTimeLockedOutputType":   HashTimeLockedOutputType,
 	}[ot]
 	if !ok {
 		return res, errors.New(fmt.Sprintf("unsupported output type: %s", ot))
@@ -278,6 +283,11 @@ func OutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output Output,
 			err = errors.Errorf("failed to parse ExtendedOutput: %w", err)
 			return
 		}
+	case HashTimeLockedOutputType:
+		if output, err = HashTimeLockedOutputFromMarshalUtil(marshalUtil); err != nil {
+			err = errors.Errorf("failed to parse HashTimeLockedOutput: %w", err)
+			return
+		}
 
 	default:
 		err = errors.Errorf("unsupported OutputType (%X): %w", outputType, cerrors.ErrParseBytesFailed)
@@ -2215,6 +2225,334 @@ var _ Output = &ExtendedLockedOutput{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region HashLock /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// HashLockLength contains the amount of bytes that a marshaled version of the HashLock contains.
+const HashLockLength = blake2b.Size256
+
+// MaxHashLockPreimageSize defines the maximum size of a preimage that can be used to unlock a HashLock.
+const MaxHashLockPreimageSize = 64
+
+// HashLock represents the blake2b-256 hash of a secret preimage that needs to be revealed to unlock a
+// HashTimeLockedOutput.
+type HashLock [HashLockLength]byte
+
+// NewHashLock creates the HashLock that gets unlocked by the given preimage.
+func NewHashLock(preimage []byte) HashLock {
+	return blake2b.Sum256(preimage)
+}
+
+// HashLockFromBytes unmarshals a HashLock from a sequence of bytes.
+func HashLockFromBytes(hashLockBytes []byte) (hashLock HashLock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(hashLockBytes)
+	if hashLock, err = HashLockFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse HashLock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// HashLockFromBase58EncodedString creates a HashLock from a base58 encoded string.
+func HashLockFromBase58EncodedString(base58String string) (hashLock HashLock, err error) {
+	parsedBytes, err := base58.Decode(base58String)
+	if err != nil {
+		err = errors.Errorf("error while decoding base58 encoded HashLock (%v): %w", err, cerrors.ErrBase58DecodeFailed)
+		return
+	}
+
+	if hashLock, _, err = HashLockFromBytes(parsedBytes); err != nil {
+		err = errors.Errorf("failed to parse HashLock from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// HashLockFromMarshalUtil unmarshals a HashLock using a MarshalUtil (for easier unmarshaling).
+func HashLockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (hashLock HashLock, err error) {
+	hashLockBytes, err := marshalUtil.ReadBytes(HashLockLength)
+	if err != nil {
+		err = errors.Errorf("failed to parse HashLock (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	copy(hashLock[:], hashLockBytes)
+
+	return
+}
+
+// Unlocks returns true if the given preimage hashes to the HashLock.
+func (h HashLock) Unlocks(preimage []byte) bool {
+	return len(preimage) <= MaxHashLockPreimageSize && NewHashLock(preimage) == h
+}
+
+// Bytes marshals the HashLock into a sequence of bytes.
+func (h HashLock) Bytes() []byte {
+	return h[:]
+}
+
+// Base58 returns a base58 encoded version of the HashLock.
+func (h HashLock) Base58() string {
+	return base58.Encode(h.Bytes())
+}
+
+// String returns a human readable version of the HashLock.
+func (h HashLock) String() string {
+	return "HashLock(" + h.Base58() + ")"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HashTimeLockedOutput /////////////////////////////////////////////////////////////////////////////////////////
+
+// HashTimeLockedOutput is an Output that holds colored coins which are locked by a HashLock and a timeout. Until the
+// timeout it can only be unlocked by a HashTimeLockUnlockBlock that reveals the preimage of the HashLock and that
+// carries a valid signature of the recipient address. After the timeout, the funds can only be refunded by a signature
+// of the refund address. This allows to atomically swap assets without involving a trusted third party.
+type HashTimeLockedOutput struct {
+	id            OutputID
+	idMutex       sync.RWMutex
+	balances      *ColoredBalances
+	address       Address
+	hashLock      HashLock
+	timeout       time.Time
+	refundAddress Address
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewHashTimeLockedOutput is the constructor for a HashTimeLockedOutput.
+func NewHashTimeLockedOutput(balances map[Color]uint64, address Address, hashLock HashLock, timeout time.Time, refundAddress Address) *HashTimeLockedOutput {
+	return &HashTimeLockedOutput{
+		balances:      NewColoredBalances(balances),
+		address:       address.Clone(),
+		hashLock:      hashLock,
+		timeout:       timeout,
+		refundAddress: refundAddress.Clone(),
+	}
+}
+
+// HashTimeLockedOutputFromBytes unmarshals a HashTimeLockedOutput from a sequence of bytes.
+func HashTimeLockedOutputFromBytes(data []byte) (output *HashTimeLockedOutput, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(data)
+	if output, err = HashTimeLockedOutputFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse HashTimeLockedOutput from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// HashTimeLockedOutputFromMarshalUtil unmarshals a HashTimeLockedOutput using a MarshalUtil (for easier unmarshaling).
+func HashTimeLockedOutputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output *HashTimeLockedOutput, err error) {
+	outputType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("failed to parse OutputType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if OutputType(outputType) != HashTimeLockedOutputType {
+		err = errors.Errorf("invalid OutputType (%X): %w", outputType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	output = &HashTimeLockedOutput{}
+	if output.balances, err = ColoredBalancesFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse ColoredBalances: %w", err)
+		return
+	}
+	if output.address, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Address (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.hashLock, err = HashLockFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse HashLock: %w", err)
+		return
+	}
+	if output.timeout, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse timeout (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if output.refundAddress, err = AddressFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse refundAddress (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// ID returns the identifier of the Output that is used to address the Output in the UTXODAG.
+func (h *HashTimeLockedOutput) ID() OutputID {
+	h.idMutex.RLock()
+	defer h.idMutex.RUnlock()
+
+	return h.id
+}
+
+// SetID allows to set the identifier of the Output. We offer a setter for the property since Outputs that are
+// created to become part of a transaction usually do not have an identifier, yet as their identifier depends on
+// the TransactionID that is only determinable after the Transaction has been fully constructed. The ID is therefore
+// only accessed when the Output is supposed to be persisted by the node.
+func (h *HashTimeLockedOutput) SetID(outputID OutputID) Output {
+	h.idMutex.Lock()
+	defer h.idMutex.Unlock()
+
+	h.id = outputID
+
+	return h
+}
+
+// Type returns the type of the Output which allows us to generically handle Outputs of different types.
+func (h *HashTimeLockedOutput) Type() OutputType {
+	return HashTimeLockedOutputType
+}
+
+// Balances returns the funds that are associated with the Output.
+func (h *HashTimeLockedOutput) Balances() *ColoredBalances {
+	return h.balances
+}
+
+// UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
+func (h *HashTimeLockedOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs []Output) (unlockValid bool, err error) {
+	if h.ExpiredNow(tx.Essence().Timestamp()) {
+		// after the timeout the funds can only be refunded
+		signatureUnlockBlock, correctType := unlockBlock.(*SignatureUnlockBlock)
+		if !correctType {
+			return false, errors.Errorf("HashTimeLockedOutput: expired output can only be unlocked by a SignatureUnlockBlock of the refund address, got %s", unlockBlock.Type())
+		}
+
+		return signatureUnlockBlock.AddressSignatureValid(h.refundAddress, tx.Essence().Bytes()), nil
+	}
+
+	hashTimeLockUnlockBlock, correctType := unlockBlock.(*HashTimeLockUnlockBlock)
+	if !correctType {
+		return false, errors.Errorf("HashTimeLockedOutput: output can only be unlocked by a HashTimeLockUnlockBlock before its timeout, got %s", unlockBlock.Type())
+	}
+	if !h.hashLock.Unlocks(hashTimeLockUnlockBlock.Preimage()) {
+		return false, errors.New("HashTimeLockedOutput: preimage does not match the hash lock")
+	}
+
+	return hashTimeLockUnlockBlock.AddressSignatureValid(h.address, tx.Essence().Bytes()), nil
+}
+
+// Address returns the Address of the recipient that can unlock the Output by revealing the preimage.
+func (h *HashTimeLockedOutput) Address() Address {
+	return h.address
+}
+
+// RefundAddress returns the Address that can unlock the Output after the timeout.
+func (h *HashTimeLockedOutput) RefundAddress() Address {
+	return h.refundAddress
+}
+
+// HashLock returns the HashLock that needs to be unlocked by the recipient.
+func (h *HashTimeLockedOutput) HashLock() HashLock {
+	return h.hashLock
+}
+
+// Timeout returns the time after which the Output can only be refunded.
+func (h *HashTimeLockedOutput) Timeout() time.Time {
+	return h.timeout
+}
+
+// ExpiredNow checks if the timeout of the Output has passed at the given moment.
+func (h *HashTimeLockedOutput) ExpiredNow(nowis time.Time) bool {
+	return nowis.After(h.timeout)
+}
+
+// UnlockAddressNow returns the Address that is able to unlock the Output at the given moment.
+func (h *HashTimeLockedOutput) UnlockAddressNow(nowis time.Time) Address {
+	if h.ExpiredNow(nowis) {
+		return h.refundAddress
+	}
+
+	return h.address
+}
+
+// Input returns an Input that references the Output.
+func (h *HashTimeLockedOutput) Input() Input {
+	if h.ID() == EmptyOutputID {
+		panic("HashTimeLockedOutput: Outputs that haven't been assigned an ID, yet cannot be converted to an Input")
+	}
+
+	return NewUTXOInput(h.ID())
+}
+
+// Clone creates a copy of the Output.
+func (h *HashTimeLockedOutput) Clone() Output {
+	clonedOutput := NewHashTimeLockedOutput(h.balances.Map(), h.address, h.hashLock, h.timeout, h.refundAddress)
+	copy(clonedOutput.id[:], h.id[:])
+
+	return clonedOutput
+}
+
+// UpdateMintingColor replaces the ColorMint in the balances of the Output with the hash of the OutputID. It returns a
+// copy of the original Output with the modified balances.
+func (h *HashTimeLockedOutput) UpdateMintingColor() Output {
+	coloredBalances := h.Balances().Map()
+	if mintedCoins, mintedCoinsExist := coloredBalances[ColorMint]; mintedCoinsExist {
+		delete(coloredBalances, ColorMint)
+		coloredBalances[Color(blake2b.Sum256(h.ID().Bytes()))] = mintedCoins
+	}
+	updatedOutput := NewHashTimeLockedOutput(coloredBalances, h.address, h.hashLock, h.timeout, h.refundAddress)
+	updatedOutput.SetID(h.ID())
+
+	return updatedOutput
+}
+
+// Bytes returns a marshaled version of the Output.
+func (h *HashTimeLockedOutput) Bytes() []byte {
+	return h.ObjectStorageValue()
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (h *HashTimeLockedOutput) Update(objectstorage.StorableObject) {
+	panic("HashTimeLockedOutput: updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (h *HashTimeLockedOutput) ObjectStorageKey() []byte {
+	return h.id.Bytes()
+}
+
+// ObjectStorageValue marshals the Output into a sequence of bytes. The ID is not serialized here as it is only used as
+// a key in the ObjectStorage.
+func (h *HashTimeLockedOutput) ObjectStorageValue() []byte {
+	return marshalutil.New().
+		WriteByte(byte(HashTimeLockedOutputType)).
+		WriteBytes(h.balances.Bytes()).
+		WriteBytes(h.address.Bytes()).
+		WriteBytes(h.hashLock.Bytes()).
+		WriteTime(h.timeout).
+		WriteBytes(h.refundAddress.Bytes()).
+		Bytes()
+}
+
+// Compare offers a comparator for Outputs which returns -1 if the other Output is bigger, 1 if it is smaller and 0 if
+// they are the same.
+func (h *HashTimeLockedOutput) Compare(other Output) int {
+	return bytes.Compare(h.Bytes(), other.Bytes())
+}
+
+// String returns a human readable version of the Output.
+func (h *HashTimeLockedOutput) String() string {
+	return stringify.Struct("HashTimeLockedOutput",
+		stringify.StructField("id", h.ID()),
+		stringify.StructField("address", h.address),
+		stringify.StructField("balances", h.balances),
+		stringify.StructField("hashLock", h.hashLock),
+		stringify.StructField("timeout", h.timeout),
+		stringify.StructField("refundAddress", h.refundAddress),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ Output = &HashTimeLockedOutput{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region CachedOutput /////////////////////////////////////////////////////////////////////////////////////////////////
 
 // CachedOutput is a wrapper for the generic CachedObject returned by the object storage that overrides the accessor
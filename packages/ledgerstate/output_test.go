@@ -2339,6 +2339,127 @@ func TestExtendedLockedOutput_Clone(t *testing.T) {
 
 // endregion
 
+// region HashTimeLockedOutput Tests
+
+func TestHashTimeLockedOutput_Bytes(t *testing.T) {
+	preimage := []byte("secret")
+	output := NewHashTimeLockedOutput(map[Color]uint64{ColorIOTA: 1337}, randEd25119Address(), NewHashLock(preimage), time.Unix(1616150000, 0), randEd25119Address())
+	output.SetID(randOutputID())
+
+	restored, consumedBytes, err := OutputFromBytes(output.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(output.Bytes()), consumedBytes)
+	castedRestored, ok := restored.(*HashTimeLockedOutput)
+	require.True(t, ok)
+	assert.True(t, output.Address().Equals(castedRestored.Address()))
+	assert.True(t, output.RefundAddress().Equals(castedRestored.RefundAddress()))
+	assert.Equal(t, output.HashLock(), castedRestored.HashLock())
+	assert.True(t, output.Timeout().Equal(castedRestored.Timeout()))
+	assert.Equal(t, output.Balances().Bytes(), castedRestored.Balances().Bytes())
+	assert.True(t, castedRestored.HashLock().Unlocks(preimage))
+
+	_, _, err = OutputFromBytes(output.Bytes()[:len(output.Bytes())-1])
+	assert.Error(t, err)
+}
+
+func TestHashTimeLockedOutput_UnlockAddressNow(t *testing.T) {
+	timeout := time.Unix(1616150000, 0)
+	output := NewHashTimeLockedOutput(map[Color]uint64{ColorIOTA: 1}, randEd25119Address(), NewHashLock([]byte("secret")), timeout, randEd25119Address())
+
+	assert.True(t, output.Address().Equals(output.UnlockAddressNow(timeout)))
+	assert.True(t, output.RefundAddress().Equals(output.UnlockAddressNow(timeout.Add(time.Second))))
+}
+
+func TestHashTimeLockedOutput_UnlockValid(t *testing.T) {
+	preimage := []byte("secret")
+	recipient := genRandomWallet()
+	refunder := genRandomWallet()
+
+	newInput := func(timeout time.Time) *HashTimeLockedOutput {
+		input := NewHashTimeLockedOutput(map[Color]uint64{ColorIOTA: 1}, recipient.address, NewHashLock(preimage), timeout, refunder.address)
+		input.SetID(randOutputID())
+		return input
+	}
+	newEssence := func(input Output) *TransactionEssence {
+		output := NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 1}), randEd25119Address())
+		return NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(input.Input()), NewOutputs(output))
+	}
+
+	t.Run("CASE: Happy path, claimed with preimage", func(t *testing.T) {
+		input := newInput(time.Now().Add(time.Hour))
+		essence := newEssence(input)
+		unlockBlock := NewHashTimeLockUnlockBlock(preimage, recipient.sign(essence))
+
+		valid, err := input.UnlockValid(NewTransaction(essence, UnlockBlocks{unlockBlock}), unlockBlock, Outputs{input})
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("CASE: Wrong preimage", func(t *testing.T) {
+		input := newInput(time.Now().Add(time.Hour))
+		essence := newEssence(input)
+		unlockBlock := NewHashTimeLockUnlockBlock([]byte("guess"), recipient.sign(essence))
+
+		valid, err := input.UnlockValid(NewTransaction(essence, UnlockBlocks{unlockBlock}), unlockBlock, Outputs{input})
+		assert.Error(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("CASE: Preimage signed by refund address", func(t *testing.T) {
+		input := newInput(time.Now().Add(time.Hour))
+		essence := newEssence(input)
+		unlockBlock := NewHashTimeLockUnlockBlock(preimage, refunder.sign(essence))
+
+		valid, err := input.UnlockValid(NewTransaction(essence, UnlockBlocks{unlockBlock}), unlockBlock, Outputs{input})
+		assert.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("CASE: Refund before timeout", func(t *testing.T) {
+		input := newInput(time.Now().Add(time.Hour))
+		essence := newEssence(input)
+		unlockBlock := NewSignatureUnlockBlock(refunder.sign(essence))
+
+		valid, err := input.UnlockValid(NewTransaction(essence, UnlockBlocks{unlockBlock}), unlockBlock, Outputs{input})
+		assert.Error(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("CASE: Happy path, refunded after timeout", func(t *testing.T) {
+		input := newInput(time.Now().Add(-time.Hour))
+		essence := newEssence(input)
+		unlockBlock := NewSignatureUnlockBlock(refunder.sign(essence))
+
+		valid, err := input.UnlockValid(NewTransaction(essence, UnlockBlocks{unlockBlock}), unlockBlock, Outputs{input})
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("CASE: Claim after timeout", func(t *testing.T) {
+		input := newInput(time.Now().Add(-time.Hour))
+		essence := newEssence(input)
+		unlockBlock := NewHashTimeLockUnlockBlock(preimage, recipient.sign(essence))
+
+		valid, err := input.UnlockValid(NewTransaction(essence, UnlockBlocks{unlockBlock}), unlockBlock, Outputs{input})
+		assert.Error(t, err)
+		assert.False(t, valid)
+	})
+}
+
+func TestHashTimeLockedOutput_UpdateMintingColor(t *testing.T) {
+	output := NewHashTimeLockedOutput(map[Color]uint64{ColorMint: 10}, randEd25119Address(), NewHashLock([]byte("secret")), time.Unix(1616150000, 0), randEd25119Address())
+	output.SetID(randOutputID())
+
+	updated := output.UpdateMintingColor().(*HashTimeLockedOutput)
+	mintedBalance, exists := updated.Balances().Get(Color(blake2b.Sum256(output.ID().Bytes())))
+	assert.True(t, exists)
+	assert.Equal(t, uint64(10), mintedBalance)
+	assert.Equal(t, output.HashLock(), updated.HashLock())
+	assert.Equal(t, output.ID(), updated.ID())
+}
+
+// endregion
+
 // region test utils
 
 func genRandomWallet() wallet {
@@ -2,6 +2,8 @@ package ledgerstate
 
 import (
 	"bytes"
+	"sort"
+	"sync"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/byteutils"
@@ -22,6 +24,9 @@ const (
 
 	// BLSSignatureType represents a BLS Signature.
 	BLSSignatureType
+
+	// MultisigSignatureType represents a threshold of ED25519 Signatures.
+	MultisigSignatureType
 )
 
 // SignatureType represents the type of the signature scheme.
@@ -32,6 +37,7 @@ func (s SignatureType) String() string {
 	return [...]string{
 		"ED25519SignatureType",
 		"BLSSignatureType",
+		"MultisigSignatureType",
 	}[s]
 }
 
@@ -108,6 +114,11 @@ func SignatureFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (signature S
 			err = errors.Errorf("failed to parse BLSSignature: %w", err)
 			return
 		}
+	case MultisigSignatureType:
+		if signature, err = MultisigSignatureFromMarshalUtil(marshalUtil); err != nil {
+			err = errors.Errorf("failed to parse MultisigSignature: %w", err)
+			return
+		}
 	default:
 		err = errors.Errorf("unsupported SignatureType (%X): %w", signatureType, cerrors.ErrParseBytesFailed)
 		return
@@ -342,3 +353,264 @@ func (b *BLSSignature) String() string {
 var _ Signature = &BLSSignature{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MultisigSignature ////////////////////////////////////////////////////////////////////////////////////////////
+
+// MultisigSignature represents a Signature that unlocks a MultisigAddress. It reveals the threshold and the public keys
+// of the Address and carries the partial ED25519 signatures of the key holders. Partial signatures can be added one by
+// one, so the Signature can be passed around to collect the signatures of the different key holders.
+type MultisigSignature struct {
+	threshold       uint8
+	publicKeys      []ed25519.PublicKey
+	signatures      map[uint8]ed25519.Signature
+	signaturesMutex sync.RWMutex
+}
+
+// NewMultisigSignature is the constructor of a MultisigSignature without any partial signatures.
+func NewMultisigSignature(threshold uint8, publicKeys ...ed25519.PublicKey) (signature *MultisigSignature, err error) {
+	sortedPublicKeys, err := sortMultisigPublicKeys(threshold, publicKeys)
+	if err != nil {
+		return
+	}
+
+	return &MultisigSignature{
+		threshold:  threshold,
+		publicKeys: sortedPublicKeys,
+		signatures: make(map[uint8]ed25519.Signature),
+	}, nil
+}
+
+// MultisigSignatureFromBytes unmarshals a MultisigSignature from a sequence of bytes.
+func MultisigSignatureFromBytes(bytes []byte) (signature *MultisigSignature, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if signature, err = MultisigSignatureFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse MultisigSignature from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MultisigSignatureFromBase58EncodedString creates a MultisigSignature from a base58 encoded string.
+func MultisigSignatureFromBase58EncodedString(base58String string) (signature *MultisigSignature, err error) {
+	decodedBytes, err := base58.Decode(base58String)
+	if err != nil {
+		err = errors.Errorf("error while decoding base58 encoded MultisigSignature (%v): %w", err, cerrors.ErrBase58DecodeFailed)
+		return
+	}
+
+	if signature, _, err = MultisigSignatureFromBytes(decodedBytes); err != nil {
+		err = errors.Errorf("failed to parse MultisigSignature from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// MultisigSignatureFromMarshalUtil unmarshals a MultisigSignature using a MarshalUtil (for easier unmarshaling).
+func MultisigSignatureFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (signature *MultisigSignature, err error) {
+	signatureType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("failed to parse SignatureType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if SignatureType(signatureType) != MultisigSignatureType {
+		err = errors.Errorf("invalid SignatureType (%X): %w", signatureType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	signature = &MultisigSignature{signatures: make(map[uint8]ed25519.Signature)}
+	if signature.threshold, err = marshalUtil.ReadUint8(); err != nil {
+		err = errors.Errorf("failed to parse threshold (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	publicKeyCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = errors.Errorf("failed to parse public key count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if publicKeyCount == 0 || publicKeyCount > MaxMultisigPublicKeys || signature.threshold == 0 || signature.threshold > publicKeyCount {
+		err = errors.Errorf("invalid threshold (%d) of public keys (%d): %w", signature.threshold, publicKeyCount, cerrors.ErrParseBytesFailed)
+		return
+	}
+	signature.publicKeys = make([]ed25519.PublicKey, publicKeyCount)
+	for i := range signature.publicKeys {
+		if signature.publicKeys[i], err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+			err = errors.Errorf("failed to parse public key (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+		// the public keys need to be sorted to have a unique representation of the signature
+		if i > 0 && bytes.Compare(signature.publicKeys[i-1][:], signature.publicKeys[i][:]) >= 0 {
+			err = errors.Errorf("public keys are not sorted or contain duplicates: %w", cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+
+	signatureCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = errors.Errorf("failed to parse signature count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	// additional signatures would allow to change the bytes of the Signature without invalidating it
+	if signatureCount > signature.threshold {
+		err = errors.Errorf("signature count (%d) exceeds the threshold (%d): %w", signatureCount, signature.threshold, cerrors.ErrParseBytesFailed)
+		return
+	}
+	for i := 0; i < int(signatureCount); i++ {
+		keyIndex, keyIndexErr := marshalUtil.ReadUint8()
+		if keyIndexErr != nil {
+			err = errors.Errorf("failed to parse key index (%v): %w", keyIndexErr, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if keyIndex >= publicKeyCount {
+			err = errors.Errorf("key index (%d) out of bounds: %w", keyIndex, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if _, exists := signature.signatures[keyIndex]; exists {
+			err = errors.Errorf("duplicate signature for key index %d: %w", keyIndex, cerrors.ErrParseBytesFailed)
+			return
+		}
+		if signature.signatures[keyIndex], err = ed25519.ParseSignature(marshalUtil); err != nil {
+			err = errors.Errorf("failed to parse signature (%v): %w", err, cerrors.ErrParseBytesFailed)
+			return
+		}
+	}
+
+	return
+}
+
+// AddSignature adds the partial signature of the given public key. It returns an error if the key is not part of the
+// MultisigSignature or if the threshold of partial signatures has been reached already, as the Signature has to carry
+// exactly threshold many partial signatures.
+func (m *MultisigSignature) AddSignature(publicKey ed25519.PublicKey, signature ed25519.Signature) error {
+	for i, multisigPublicKey := range m.publicKeys {
+		if multisigPublicKey != publicKey {
+			continue
+		}
+
+		m.signaturesMutex.Lock()
+		defer m.signaturesMutex.Unlock()
+
+		if _, exists := m.signatures[uint8(i)]; !exists && len(m.signatures) >= int(m.threshold) {
+			return errors.Errorf("the MultisigSignature already contains %d partial signatures", m.threshold)
+		}
+		m.signatures[uint8(i)] = signature
+
+		return nil
+	}
+
+	return errors.Errorf("public key %s is not part of the MultisigSignature", publicKey)
+}
+
+// Threshold returns the amount of valid partial signatures that are required to unlock the MultisigAddress.
+func (m *MultisigSignature) Threshold() uint8 {
+	return m.threshold
+}
+
+// PublicKeys returns the sorted public keys of the MultisigAddress.
+func (m *MultisigSignature) PublicKeys() []ed25519.PublicKey {
+	return m.publicKeys
+}
+
+// Signatures returns the partial signatures indexed by the public keys that created them.
+func (m *MultisigSignature) Signatures() map[ed25519.PublicKey]ed25519.Signature {
+	m.signaturesMutex.RLock()
+	defer m.signaturesMutex.RUnlock()
+
+	signatures := make(map[ed25519.PublicKey]ed25519.Signature, len(m.signatures))
+	for keyIndex, signature := range m.signatures {
+		signatures[m.publicKeys[keyIndex]] = signature
+	}
+
+	return signatures
+}
+
+// Address returns the MultisigAddress that is unlocked by the Signature.
+func (m *MultisigSignature) Address() *MultisigAddress {
+	return &MultisigAddress{digest: multisigDigest(m.threshold, m.publicKeys)}
+}
+
+// Type returns the SignatureType of this Signature.
+func (m *MultisigSignature) Type() SignatureType {
+	return MultisigSignatureType
+}
+
+// SignatureValid returns true if the Signature carries exactly threshold many partial signatures and all of them sign
+// the given data. Invalid or additional partial signatures are rejected, as they would change the bytes of the
+// Signature (and therefore the TransactionID) without invalidating it.
+func (m *MultisigSignature) SignatureValid(data []byte) bool {
+	m.signaturesMutex.RLock()
+	defer m.signaturesMutex.RUnlock()
+
+	if len(m.signatures) != int(m.threshold) {
+		return false
+	}
+
+	for keyIndex, signature := range m.signatures {
+		if int(keyIndex) >= len(m.publicKeys) || !m.publicKeys[keyIndex].VerifySignature(data, signature) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddressSignatureValid returns true if the Signature signs the given Address.
+func (m *MultisigSignature) AddressSignatureValid(address Address, data []byte) bool {
+	if address.Type() != MultisigAddressType {
+		return false
+	}
+
+	if !bytes.Equal(m.Address().Digest(), address.Digest()) {
+		return false
+	}
+
+	return m.SignatureValid(data)
+}
+
+// Bytes returns a marshaled version of the Signature.
+func (m *MultisigSignature) Bytes() []byte {
+	m.signaturesMutex.RLock()
+	defer m.signaturesMutex.RUnlock()
+
+	marshalUtil := marshalutil.New().
+		WriteByte(byte(MultisigSignatureType)).
+		WriteUint8(m.threshold).
+		WriteUint8(uint8(len(m.publicKeys)))
+	for _, publicKey := range m.publicKeys {
+		marshalUtil.WriteBytes(publicKey.Bytes())
+	}
+
+	keyIndexes := make([]int, 0, len(m.signatures))
+	for keyIndex := range m.signatures {
+		keyIndexes = append(keyIndexes, int(keyIndex))
+	}
+	sort.Ints(keyIndexes)
+	marshalUtil.WriteUint8(uint8(len(keyIndexes)))
+	for _, keyIndex := range keyIndexes {
+		marshalUtil.WriteUint8(uint8(keyIndex)).
+			WriteBytes(m.signatures[uint8(keyIndex)].Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// Base58 returns a base58 encoded version of the Signature.
+func (m *MultisigSignature) Base58() string {
+	return base58.Encode(m.Bytes())
+}
+
+// String returns a human readable version of the Signature.
+func (m *MultisigSignature) String() string {
+	return stringify.Struct("MultisigSignature",
+		stringify.StructField("threshold", m.threshold),
+		stringify.StructField("publicKeys", m.publicKeys),
+		stringify.StructField("signatures", m.Signatures()),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ Signature = &MultisigSignature{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
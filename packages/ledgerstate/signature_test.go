@@ -0,0 +1,112 @@
+package ledgerstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultisigSignature(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+	data := []byte("essence")
+
+	address, err := NewMultisigAddress(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+	signature, err := NewMultisigSignature(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+	assert.True(t, signature.Address().Equals(address))
+
+	// not enough signatures
+	require.NoError(t, signature.AddSignature(keyPairs[2].PublicKey, keyPairs[2].PrivateKey.Sign(data)))
+	assert.False(t, signature.AddressSignatureValid(address, data))
+
+	// an invalid partial signature invalidates the signature
+	require.NoError(t, signature.AddSignature(keyPairs[0].PublicKey, keyPairs[0].PrivateKey.Sign([]byte("other data"))))
+	assert.False(t, signature.AddressSignatureValid(address, data))
+
+	// replacing the invalid signature reaches the threshold
+	require.NoError(t, signature.AddSignature(keyPairs[0].PublicKey, keyPairs[0].PrivateKey.Sign(data)))
+	assert.True(t, signature.AddressSignatureValid(address, data))
+	assert.False(t, signature.AddressSignatureValid(NewED25519Address(keyPairs[0].PublicKey), data))
+
+	// more signatures than the threshold can not be added
+	assert.Error(t, signature.AddSignature(keyPairs[1].PublicKey, keyPairs[1].PrivateKey.Sign(data)))
+	assert.True(t, signature.AddressSignatureValid(address, data))
+
+	// keys that are not part of the address can not sign
+	foreignKeyPair := ed25519.GenerateKeyPair()
+	assert.Error(t, signature.AddSignature(foreignKeyPair.PublicKey, foreignKeyPair.PrivateKey.Sign(data)))
+
+	// marshaling round trip
+	restoredSignature, _, err := SignatureFromBytes(signature.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, signature.Bytes(), restoredSignature.Bytes())
+	assert.True(t, restoredSignature.AddressSignatureValid(address, data))
+
+	restoredAddress, err := AddressFromSignature(restoredSignature)
+	require.NoError(t, err)
+	assert.True(t, restoredAddress.Equals(address))
+
+	// unsorted public keys are rejected
+	signatureBytes := signature.Bytes()
+	publicKeysOffset := 3
+	firstKey := append([]byte{}, signatureBytes[publicKeysOffset:publicKeysOffset+ed25519.PublicKeySize]...)
+	copy(signatureBytes[publicKeysOffset:], signatureBytes[publicKeysOffset+ed25519.PublicKeySize:publicKeysOffset+2*ed25519.PublicKeySize])
+	copy(signatureBytes[publicKeysOffset+ed25519.PublicKeySize:], firstKey)
+	_, _, err = SignatureFromBytes(signatureBytes)
+	assert.Error(t, err)
+
+	// more signatures than the threshold are rejected
+	signatureBytes = append(signature.Bytes(), 1)
+	signatureBytes = append(signatureBytes, keyPairs[1].PrivateKey.Sign(data).Bytes()...)
+	signatureBytes[signatureCountOffset(signature)]++
+	_, _, err = SignatureFromBytes(signatureBytes)
+	assert.Error(t, err)
+
+	// duplicate key indices are rejected
+	signatureBytes = signature.Bytes()
+	signatureBytes[signatureCountOffset(signature)+1] = signatureBytes[signatureCountOffset(signature)+2+ed25519.SignatureSize]
+	_, _, err = SignatureFromBytes(signatureBytes)
+	assert.Error(t, err)
+
+	// out of range key indices are rejected
+	signatureBytes = signature.Bytes()
+	signatureBytes[signatureCountOffset(signature)+1] = 3
+	_, _, err = SignatureFromBytes(signatureBytes)
+	assert.Error(t, err)
+}
+
+// signatureCountOffset returns the offset of the signature count in the serialized form of the MultisigSignature.
+func signatureCountOffset(signature *MultisigSignature) int {
+	return 3 + len(signature.PublicKeys())*ed25519.PublicKeySize
+}
+
+func TestMultisigSignature_UnlockValid(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+	address, err := NewMultisigAddress(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+
+	input := NewSigLockedSingleOutput(100, address)
+	input.SetID(NewOutputID(GenesisTransactionID, 0))
+	essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{}, NewInputs(NewUTXOInput(input.ID())), NewOutputs(NewSigLockedSingleOutput(100, NewED25519Address(keyPairs[0].PublicKey))))
+
+	signature, err := NewMultisigSignature(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+	require.NoError(t, signature.AddSignature(keyPairs[1].PublicKey, keyPairs[1].PrivateKey.Sign(essence.Bytes())))
+
+	tx := NewTransaction(essence, UnlockBlocks{NewSignatureUnlockBlock(signature)})
+	unlockValid, err := UnlockBlocksValidWithError(Outputs{input}, tx)
+	require.NoError(t, err)
+	assert.False(t, unlockValid)
+
+	require.NoError(t, signature.AddSignature(keyPairs[2].PublicKey, keyPairs[2].PrivateKey.Sign(essence.Bytes())))
+	tx, _, err = TransactionFromBytes(NewTransaction(essence, UnlockBlocks{NewSignatureUnlockBlock(signature)}).Bytes())
+	require.NoError(t, err)
+	unlockValid, err = UnlockBlocksValidWithError(Outputs{input}, tx)
+	require.NoError(t, err)
+	assert.True(t, unlockValid)
+}
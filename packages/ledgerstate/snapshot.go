@@ -1,19 +1,57 @@
 package ledgerstate
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash"
 	"io"
+	"sort"
 	"time"
 
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 )
 
+const (
+	// SnapshotVersion is the version of the snapshot format that is written by the SnapshotWriter.
+	SnapshotVersion uint8 = 1
+
+	// SnapshotChecksumLength contains the amount of bytes of the checksum of a snapshot.
+	SnapshotChecksumLength = blake2b.Size256
+
+	// SnapshotMessageIDLength contains the amount of bytes of the id of the last confirmed message in a SnapshotHeader.
+	SnapshotMessageIDLength = 32
+
+	// MaxSnapshotEntryLength contains the maximum amount of bytes of the payload of a SnapshotEntry (the largest entries
+	// contain a transaction, its id and the unspent flags of its outputs).
+	MaxSnapshotEntryLength = TransactionIDLength + payload.MaxSize + marshalutil.Uint16Size + MaxOutputCount
+)
+
+// snapshotMagic is the prefix of every versioned snapshot, which allows to distinguish it from the legacy format (that
+// starts with the number of transactions).
+var snapshotMagic = [4]byte{'G', 'S', 'S', 'N'}
+
+// region Snapshot /////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // Snapshot defines a snapshot of the ledger state.
 type Snapshot struct {
-	Transactions     map[TransactionID]Record
-	AccessManaByNode map[identity.ID]AccessMana
+	// Header contains the metadata of the snapshot (it is nil for snapshots that were read from the legacy format).
+	Header *SnapshotHeader
+
+	Transactions        map[TransactionID]Record
+	AccessManaByNode    map[identity.ID]AccessMana
+	ConsensusManaByNode map[identity.ID]float64
+
+	// SpentOutputs contains the outputs of the base snapshot that were spent (only used by delta snapshots).
+	SpentOutputs []OutputID
 }
 
 // AccessMana defines the info for the aMana snapshot.
@@ -29,79 +67,165 @@ type Record struct {
 	UnspentOutputs []bool
 }
 
-// WriteTo writes the snapshot data to the given writer.
+// WriteTo writes the snapshot data to the given writer in the versioned format. Entries are written in the order of
+// their keys, so that the same snapshot always results in the same bytes (and checksum).
 func (s *Snapshot) WriteTo(writer io.Writer) (int64, error) {
-	var bytesWritten int64
-	if err := binary.Write(writer, binary.LittleEndian, uint32(len(s.Transactions))); err != nil {
-		return 0, fmt.Errorf("unable to write transactions count: %w", err)
+	header := s.Header
+	if header == nil {
+		header = &SnapshotHeader{SnapshotTime: time.Now()}
 	}
-	bytesWritten += 4
-	for transactionID, record := range s.Transactions {
-		if err := binary.Write(writer, binary.LittleEndian, uint32(len(record.Essence.Bytes()))); err != nil {
-			return 0, fmt.Errorf("unable to write length of transaction with %s: %w", transactionID, err)
-		}
-		bytesWritten += 4
 
-		if err := binary.Write(writer, binary.LittleEndian, transactionID.Bytes()); err != nil {
-			return 0, fmt.Errorf("unable to write transactionID with %s: %w", transactionID, err)
-		}
-		bytesWritten += TransactionIDLength
+	snapshotWriter, err := NewSnapshotWriter(writer, header)
+	if err != nil {
+		return 0, err
+	}
 
-		if err := binary.Write(writer, binary.LittleEndian, record.Essence.Bytes()); err != nil {
-			return 0, fmt.Errorf("unable to write transaction with %s: %w", transactionID, err)
+	transactionIDs := make([]TransactionID, 0, len(s.Transactions))
+	for transactionID := range s.Transactions {
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+	sort.Slice(transactionIDs, func(i, j int) bool {
+		return bytes.Compare(transactionIDs[i].Bytes(), transactionIDs[j].Bytes()) < 0
+	})
+	for _, transactionID := range transactionIDs {
+		if err = snapshotWriter.WriteTransaction(transactionID, s.Transactions[transactionID]); err != nil {
+			return snapshotWriter.BytesWritten(), err
 		}
-		bytesWritten += int64(len(record.Essence.Bytes()))
+	}
 
-		// fmt.Printf("Writing unlockBlock : %s", s.Transactions)
-		unlockBlocksLength := uint32(len(record.UnlockBlocks.Bytes()))
-		if err := binary.Write(writer, binary.LittleEndian, unlockBlocksLength); err != nil {
-			return 0, fmt.Errorf("unable to write unspent output index length with %s: %w", transactionID, err)
+	for _, outputID := range s.SpentOutputs {
+		if err = snapshotWriter.WriteSpentOutput(outputID); err != nil {
+			return snapshotWriter.BytesWritten(), err
 		}
-		bytesWritten += 4
+	}
 
-		if err := binary.Write(writer, binary.LittleEndian, record.UnlockBlocks.Bytes()); err != nil {
-			return 0, fmt.Errorf("unable to write unlockBlocks with %s: %w", transactionID, err)
+	for _, nodeID := range sortedNodeIDs(s.AccessManaByNode) {
+		if err = snapshotWriter.WriteAccessMana(nodeID, s.AccessManaByNode[nodeID]); err != nil {
+			return snapshotWriter.BytesWritten(), err
 		}
-		bytesWritten += int64(unlockBlocksLength)
+	}
 
-		if err := binary.Write(writer, binary.LittleEndian, uint32(len(record.UnspentOutputs))); err != nil {
-			return 0, fmt.Errorf("unable to write unspent output index length with %s: %w", transactionID, err)
+	consensusNodeIDs := make([]identity.ID, 0, len(s.ConsensusManaByNode))
+	for nodeID := range s.ConsensusManaByNode {
+		consensusNodeIDs = append(consensusNodeIDs, nodeID)
+	}
+	sortIdentityIDs(consensusNodeIDs)
+	for _, nodeID := range consensusNodeIDs {
+		if err = snapshotWriter.WriteConsensusMana(nodeID, s.ConsensusManaByNode[nodeID]); err != nil {
+			return snapshotWriter.BytesWritten(), err
 		}
-		bytesWritten += 4
+	}
+
+	if err = snapshotWriter.Close(); err != nil {
+		return snapshotWriter.BytesWritten(), err
+	}
+	s.Header = snapshotWriter.Header()
+
+	return snapshotWriter.BytesWritten(), nil
+}
+
+// ReadFrom reads the snapshot bytes from the given reader. It supports both, the versioned and the legacy format.
+// This function overrides existing content of the snapshot.
+func (s *Snapshot) ReadFrom(reader io.Reader) (int64, error) {
+	var magic [len(snapshotMagic)]byte
+	if _, err := io.ReadFull(reader, magic[:]); err != nil {
+		return 0, fmt.Errorf("unable to read snapshot prefix: %w", err)
+	}
+	if magic != snapshotMagic {
+		return s.readLegacy(io.MultiReader(bytes.NewReader(magic[:]), reader))
+	}
 
-		for _, unspentOutput := range record.UnspentOutputs {
-			if err := binary.Write(writer, binary.LittleEndian, unspentOutput); err != nil {
-				return 0, fmt.Errorf("unable to write unspent output index with %s: %w", transactionID, err)
+	snapshotReader, err := newSnapshotReader(reader, int64(len(magic)))
+	if err != nil {
+		return 0, err
+	}
+
+	s.Header = snapshotReader.Header()
+	s.Transactions = make(map[TransactionID]Record)
+	s.AccessManaByNode = make(map[identity.ID]AccessMana)
+	s.ConsensusManaByNode = make(map[identity.ID]float64)
+	s.SpentOutputs = nil
+
+	for {
+		entry, readErr := snapshotReader.ReadEntry()
+		if readErr != nil {
+			if readErr == io.EOF {
+				return snapshotReader.BytesRead(), nil
 			}
+			return snapshotReader.BytesRead(), readErr
+		}
+
+		switch entry.Type {
+		case TransactionSnapshotEntry:
+			s.Transactions[entry.TransactionID] = entry.Record
+		case SpentOutputSnapshotEntry:
+			s.SpentOutputs = append(s.SpentOutputs, entry.OutputID)
+		case AccessManaSnapshotEntry:
+			s.AccessManaByNode[entry.NodeID] = entry.AccessMana
+		case ConsensusManaSnapshotEntry:
+			s.ConsensusManaByNode[entry.NodeID] = entry.ConsensusMana
 		}
-		bytesWritten += int64(len(record.UnspentOutputs))
 	}
+}
 
-	if err := binary.Write(writer, binary.LittleEndian, uint32(len(s.AccessManaByNode))); err != nil {
-		return 0, fmt.Errorf("unable to write AccessMana count: %w", err)
+// ApplyDelta applies the given delta snapshot to the snapshot, so that it afterwards represents the ledger state at
+// the time of the delta. The delta has to be based on exactly this snapshot (see SnapshotHeader.BaseChecksum).
+func (s *Snapshot) ApplyDelta(delta *Snapshot) error {
+	if delta.Header == nil || delta.Header.Type != DeltaSnapshot {
+		return errors.Errorf("failed to apply snapshot delta: %w", ErrSnapshotNotDelta)
 	}
-	bytesWritten += 4
-	for nodeID, accessMana := range s.AccessManaByNode {
-		if err := binary.Write(writer, binary.LittleEndian, nodeID.Bytes()); err != nil {
-			return 0, fmt.Errorf("unable to write nodeID with %s: %w", nodeID, err)
-		}
-		bytesWritten += identity.IDLength
-		if err := binary.Write(writer, binary.LittleEndian, accessMana.Value); err != nil {
-			return 0, fmt.Errorf("unable to write access mana : %w", err)
+	if s.Header == nil || s.Header.Checksum != delta.Header.BaseChecksum {
+		return errors.Errorf("failed to apply snapshot delta to snapshot %s: %w", s.checksumString(), ErrSnapshotBaseMismatch)
+	}
+
+	for _, outputID := range delta.SpentOutputs {
+		record, exists := s.Transactions[outputID.TransactionID()]
+		if !exists || int(outputID.OutputIndex()) >= len(record.UnspentOutputs) {
+			return errors.Errorf("failed to apply snapshot delta: spent output %s is unknown: %w", outputID, ErrSnapshotBaseMismatch)
 		}
-		bytesWritten += 8
-		if err := binary.Write(writer, binary.LittleEndian, accessMana.Timestamp.Unix()); err != nil {
-			return 0, fmt.Errorf("unable to write timestamp : %w", err)
+		record.UnspentOutputs[outputID.OutputIndex()] = false
+
+		if !record.hasUnspentOutputs() {
+			delete(s.Transactions, outputID.TransactionID())
 		}
-		bytesWritten += 8
 	}
 
-	return bytesWritten, nil
+	for transactionID, record := range delta.Transactions {
+		s.Transactions[transactionID] = record
+	}
+
+	// mana is always contained completely in a delta, as it changes for (almost) every node over time
+	if len(delta.AccessManaByNode) != 0 {
+		s.AccessManaByNode = delta.AccessManaByNode
+	}
+	if len(delta.ConsensusManaByNode) != 0 {
+		s.ConsensusManaByNode = delta.ConsensusManaByNode
+	}
+
+	// the resulting snapshot keeps the checksum of the delta, so that further deltas can be chained on top of it
+	header := *delta.Header
+	header.Type = FullSnapshot
+	header.BaseChecksum = [SnapshotChecksumLength]byte{}
+	s.Header = &header
+	s.SpentOutputs = nil
+
+	return nil
 }
 
-// ReadFrom reads the snapshot bytes from the given reader.
-// This function overrides existing content of the snapshot.
-func (s *Snapshot) ReadFrom(reader io.Reader) (int64, error) {
+func (s *Snapshot) checksumString() string {
+	if s.Header == nil {
+		return "<legacy>"
+	}
+
+	return s.Header.ChecksumString()
+}
+
+// readLegacy reads a snapshot in the legacy (headerless) format.
+func (s *Snapshot) readLegacy(reader io.Reader) (int64, error) {
+	s.Header = nil
+	s.ConsensusManaByNode = make(map[identity.ID]float64)
+	s.SpentOutputs = nil
+
 	bytesTransactions, err := s.readTransactions(reader)
 	if err != nil {
 		return bytesTransactions, err
@@ -241,3 +365,604 @@ func (s *Snapshot) readAccessMana(reader io.Reader) (int64, error) {
 
 	return bytesRead, nil
 }
+
+// hasUnspentOutputs returns true if at least one of the outputs of the Record is unspent.
+func (r Record) hasUnspentOutputs() bool {
+	for _, unspent := range r.UnspentOutputs {
+		if unspent {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortedNodeIDs(accessManaByNode map[identity.ID]AccessMana) (nodeIDs []identity.ID) {
+	nodeIDs = make([]identity.ID, 0, len(accessManaByNode))
+	for nodeID := range accessManaByNode {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sortIdentityIDs(nodeIDs)
+
+	return
+}
+
+func sortIdentityIDs(nodeIDs []identity.ID) {
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		return bytes.Compare(nodeIDs[i].Bytes(), nodeIDs[j].Bytes()) < 0
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotType /////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// FullSnapshot is the type of a snapshot that contains the complete ledger state.
+	FullSnapshot SnapshotType = iota
+
+	// DeltaSnapshot is the type of a snapshot that only contains the changes since a base snapshot.
+	DeltaSnapshot
+)
+
+// SnapshotType represents the type of a snapshot.
+type SnapshotType uint8
+
+// String returns a human readable version of the SnapshotType.
+func (s SnapshotType) String() string {
+	switch s {
+	case FullSnapshot:
+		return "FullSnapshot"
+	case DeltaSnapshot:
+		return "DeltaSnapshot"
+	default:
+		return fmt.Sprintf("SnapshotType(%d)", uint8(s))
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotHeader ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// snapshotHeaderLength contains the amount of bytes of a marshaled SnapshotHeader (without the magic prefix).
+const snapshotHeaderLength = 2*marshalutil.Uint8Size + marshalutil.Uint32Size + 3*marshalutil.TimeSize +
+	SnapshotMessageIDLength + SnapshotChecksumLength
+
+// SnapshotHeader contains the metadata of a snapshot.
+type SnapshotHeader struct {
+	Version                  uint8
+	Type                     SnapshotType
+	NetworkID                uint32
+	GenesisTime              time.Time
+	SnapshotTime             time.Time
+	LastConfirmedMessageID   [SnapshotMessageIDLength]byte
+	LastConfirmedMessageTime time.Time
+
+	// BaseChecksum contains the checksum of the snapshot that a DeltaSnapshot is based on.
+	BaseChecksum [SnapshotChecksumLength]byte
+
+	// Checksum contains the checksum of the snapshot. Since the checksum is only known once all the entries have been
+	// streamed, it is written at the end of the snapshot and set by the SnapshotWriter and SnapshotReader on completion.
+	Checksum [SnapshotChecksumLength]byte
+}
+
+// snapshotHeaderFromMarshalUtil unmarshals a SnapshotHeader using a MarshalUtil (for easier unmarshaling).
+func snapshotHeaderFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (header *SnapshotHeader, err error) {
+	header = &SnapshotHeader{}
+	if header.Version, err = marshalUtil.ReadUint8(); err != nil {
+		return nil, errors.Errorf("failed to parse snapshot version (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if header.Version == 0 || header.Version > SnapshotVersion {
+		return nil, errors.Errorf("failed to parse snapshot header with version %d: %w", header.Version, ErrUnsupportedSnapshotVersion)
+	}
+	snapshotType, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse snapshot type (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	header.Type = SnapshotType(snapshotType)
+	if header.NetworkID, err = marshalUtil.ReadUint32(); err != nil {
+		return nil, errors.Errorf("failed to parse network id (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if header.GenesisTime, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse genesis time (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if header.SnapshotTime, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse snapshot time (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	messageIDBytes, err := marshalUtil.ReadBytes(SnapshotMessageIDLength)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse last confirmed message id (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	copy(header.LastConfirmedMessageID[:], messageIDBytes)
+	if header.LastConfirmedMessageTime, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse last confirmed message time (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	baseChecksumBytes, err := marshalUtil.ReadBytes(SnapshotChecksumLength)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse base checksum (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	copy(header.BaseChecksum[:], baseChecksumBytes)
+
+	return header, nil
+}
+
+// Bytes returns a marshaled version of the SnapshotHeader (without the checksum, which is written as a trailer).
+func (s *SnapshotHeader) Bytes() []byte {
+	return marshalutil.New(snapshotHeaderLength).
+		WriteUint8(s.Version).
+		WriteUint8(uint8(s.Type)).
+		WriteUint32(s.NetworkID).
+		WriteTime(s.GenesisTime).
+		WriteTime(s.SnapshotTime).
+		WriteBytes(s.LastConfirmedMessageID[:]).
+		WriteTime(s.LastConfirmedMessageTime).
+		WriteBytes(s.BaseChecksum[:]).
+		Bytes()
+}
+
+// ChecksumString returns a human readable version of the checksum of the snapshot.
+func (s *SnapshotHeader) ChecksumString() string {
+	return fmt.Sprintf("%x", s.Checksum)
+}
+
+// String returns a human readable version of the SnapshotHeader.
+func (s *SnapshotHeader) String() string {
+	return stringify.Struct("SnapshotHeader",
+		stringify.StructField("version", s.Version),
+		stringify.StructField("type", s.Type),
+		stringify.StructField("networkID", s.NetworkID),
+		stringify.StructField("genesisTime", s.GenesisTime),
+		stringify.StructField("snapshotTime", s.SnapshotTime),
+		stringify.StructField("lastConfirmedMessageID", fmt.Sprintf("%x", s.LastConfirmedMessageID)),
+		stringify.StructField("lastConfirmedMessageTime", s.LastConfirmedMessageTime),
+		stringify.StructField("baseChecksum", fmt.Sprintf("%x", s.BaseChecksum)),
+		stringify.StructField("checksum", s.ChecksumString()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotEntry ////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// endSnapshotEntry marks the end of the entries of a snapshot.
+	endSnapshotEntry SnapshotEntryType = iota
+
+	// TransactionSnapshotEntry is the type of an entry that contains a transaction with (some) unspent outputs.
+	TransactionSnapshotEntry
+
+	// SpentOutputSnapshotEntry is the type of an entry that contains an output of the base snapshot that was spent.
+	SpentOutputSnapshotEntry
+
+	// AccessManaSnapshotEntry is the type of an entry that contains the access mana of a node.
+	AccessManaSnapshotEntry
+
+	// ConsensusManaSnapshotEntry is the type of an entry that contains the consensus mana of a node.
+	ConsensusManaSnapshotEntry
+)
+
+// SnapshotEntryType represents the type of an entry in a snapshot.
+type SnapshotEntryType uint8
+
+// SnapshotEntry is a single entry that is read from a snapshot. Depending on its Type only the corresponding fields
+// are set.
+type SnapshotEntry struct {
+	Type          SnapshotEntryType
+	TransactionID TransactionID
+	Record        Record
+	OutputID      OutputID
+	NodeID        identity.ID
+	AccessMana    AccessMana
+	ConsensusMana float64
+}
+
+// snapshotEntryFromMarshalUtil unmarshals the payload of a SnapshotEntry of the given type.
+func snapshotEntryFromMarshalUtil(entryType SnapshotEntryType, marshalUtil *marshalutil.MarshalUtil) (entry *SnapshotEntry, err error) {
+	entry = &SnapshotEntry{Type: entryType}
+
+	switch entryType {
+	case TransactionSnapshotEntry:
+		if entry.TransactionID, err = TransactionIDFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse TransactionID: %w", err)
+		}
+		if entry.Record.Essence, err = TransactionEssenceFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse TransactionEssence of %s: %w", entry.TransactionID, err)
+		}
+		if entry.Record.UnlockBlocks, err = UnlockBlocksFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse UnlockBlocks of %s: %w", entry.TransactionID, err)
+		}
+		outputCount, outputCountErr := marshalUtil.ReadUint16()
+		if outputCountErr != nil {
+			return nil, errors.Errorf("failed to parse output count of %s (%v): %w", entry.TransactionID, outputCountErr, cerrors.ErrParseBytesFailed)
+		}
+		entry.Record.UnspentOutputs = make([]bool, outputCount)
+		for i := range entry.Record.UnspentOutputs {
+			if entry.Record.UnspentOutputs[i], err = marshalUtil.ReadBool(); err != nil {
+				return nil, errors.Errorf("failed to parse unspent flag of output %d of %s (%v): %w", i, entry.TransactionID, err, cerrors.ErrParseBytesFailed)
+			}
+		}
+	case SpentOutputSnapshotEntry:
+		if entry.OutputID, err = OutputIDFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse OutputID: %w", err)
+		}
+	case AccessManaSnapshotEntry:
+		if entry.NodeID, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse node id (%v): %w", err, cerrors.ErrParseBytesFailed)
+		}
+		if entry.AccessMana.Value, err = marshalUtil.ReadFloat64(); err != nil {
+			return nil, errors.Errorf("failed to parse access mana of %s (%v): %w", entry.NodeID, err, cerrors.ErrParseBytesFailed)
+		}
+		if entry.AccessMana.Timestamp, err = marshalUtil.ReadTime(); err != nil {
+			return nil, errors.Errorf("failed to parse access mana timestamp of %s (%v): %w", entry.NodeID, err, cerrors.ErrParseBytesFailed)
+		}
+	case ConsensusManaSnapshotEntry:
+		if entry.NodeID, err = identity.IDFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse node id (%v): %w", err, cerrors.ErrParseBytesFailed)
+		}
+		if entry.ConsensusMana, err = marshalUtil.ReadFloat64(); err != nil {
+			return nil, errors.Errorf("failed to parse consensus mana of %s (%v): %w", entry.NodeID, err, cerrors.ErrParseBytesFailed)
+		}
+	default:
+		return nil, errors.Errorf("unsupported snapshot entry type %d: %w", entryType, cerrors.ErrParseBytesFailed)
+	}
+
+	return entry, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotWriter ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// SnapshotWriter writes a snapshot entry by entry, so that the ledger state never has to be held in memory completely.
+// Every entry is prefixed with its type and length and the snapshot is terminated by a checksum over all previous bytes.
+//
+// A SnapshotWriter that is created with NewDeltaSnapshotWriter receives the same (complete) stream of transactions but
+// only writes the transactions that are not part of the base snapshot, and the outputs of the base snapshot that have
+// been spent in the meantime.
+type SnapshotWriter struct {
+	header       *SnapshotHeader
+	buffer       *bufio.Writer
+	checksum     hash.Hash
+	bytesWritten int64
+	closed       bool
+
+	base                 *Snapshot
+	seenBaseTransactions map[TransactionID]bool
+}
+
+// NewSnapshotWriter creates a SnapshotWriter for a FullSnapshot and writes the given header to the writer.
+func NewSnapshotWriter(writer io.Writer, header *SnapshotHeader) (snapshotWriter *SnapshotWriter, err error) {
+	headerCopy := *header
+	headerCopy.Type = FullSnapshot
+	headerCopy.BaseChecksum = [SnapshotChecksumLength]byte{}
+
+	return newSnapshotWriter(writer, &headerCopy, nil)
+}
+
+// NewDeltaSnapshotWriter creates a SnapshotWriter for a DeltaSnapshot that is based on the given snapshot and writes the
+// given header to the writer.
+func NewDeltaSnapshotWriter(writer io.Writer, header *SnapshotHeader, base *Snapshot) (snapshotWriter *SnapshotWriter, err error) {
+	if base.Header == nil || base.Header.Type != FullSnapshot {
+		return nil, errors.Errorf("failed to create delta snapshot writer: base snapshot is not a versioned full snapshot: %w", ErrSnapshotBaseMismatch)
+	}
+
+	headerCopy := *header
+	headerCopy.Type = DeltaSnapshot
+	headerCopy.BaseChecksum = base.Header.Checksum
+
+	return newSnapshotWriter(writer, &headerCopy, base)
+}
+
+func newSnapshotWriter(writer io.Writer, header *SnapshotHeader, base *Snapshot) (snapshotWriter *SnapshotWriter, err error) {
+	header.Version = SnapshotVersion
+	header.Checksum = [SnapshotChecksumLength]byte{}
+
+	checksum, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to create snapshot checksum: %w", err)
+	}
+
+	snapshotWriter = &SnapshotWriter{
+		header:               header,
+		buffer:               bufio.NewWriter(writer),
+		checksum:             checksum,
+		base:                 base,
+		seenBaseTransactions: make(map[TransactionID]bool),
+	}
+
+	if err = snapshotWriter.write(snapshotMagic[:]); err != nil {
+		return nil, errors.Errorf("failed to write snapshot prefix: %w", err)
+	}
+	if err = snapshotWriter.write(header.Bytes()); err != nil {
+		return nil, errors.Errorf("failed to write snapshot header: %w", err)
+	}
+
+	return snapshotWriter, nil
+}
+
+// Header returns the header of the snapshot (the checksum is only set after the SnapshotWriter was closed).
+func (s *SnapshotWriter) Header() *SnapshotHeader {
+	return s.header
+}
+
+// BytesWritten returns the amount of bytes that were written so far.
+func (s *SnapshotWriter) BytesWritten() int64 {
+	return s.bytesWritten
+}
+
+// WriteTransaction writes a transaction with its unspent outputs to the snapshot.
+func (s *SnapshotWriter) WriteTransaction(transactionID TransactionID, record Record) (err error) {
+	if s.base != nil {
+		if baseRecord, exists := s.base.Transactions[transactionID]; exists {
+			s.seenBaseTransactions[transactionID] = true
+
+			return s.writeSpentOutputs(transactionID, baseRecord, record.UnspentOutputs)
+		}
+	}
+
+	marshalUtil := marshalutil.New().
+		Write(transactionID).
+		WriteBytes(record.Essence.Bytes()).
+		WriteBytes(record.UnlockBlocks.Bytes()).
+		WriteUint16(uint16(len(record.UnspentOutputs)))
+	for _, unspentOutput := range record.UnspentOutputs {
+		marshalUtil.WriteBool(unspentOutput)
+	}
+
+	if err = s.writeEntry(TransactionSnapshotEntry, marshalUtil.Bytes()); err != nil {
+		return errors.Errorf("failed to write transaction with %s: %w", transactionID, err)
+	}
+
+	return nil
+}
+
+// WriteSpentOutput writes an output of the base snapshot that has been spent to a DeltaSnapshot.
+func (s *SnapshotWriter) WriteSpentOutput(outputID OutputID) (err error) {
+	if s.header.Type != DeltaSnapshot {
+		return errors.Errorf("failed to write spent output %s: %w", outputID, ErrSnapshotNotDelta)
+	}
+
+	if err = s.writeEntry(SpentOutputSnapshotEntry, outputID.Bytes()); err != nil {
+		return errors.Errorf("failed to write spent output %s: %w", outputID, err)
+	}
+
+	return nil
+}
+
+// WriteAccessMana writes the access mana of the given node to the snapshot.
+func (s *SnapshotWriter) WriteAccessMana(nodeID identity.ID, accessMana AccessMana) (err error) {
+	if err = s.writeEntry(AccessManaSnapshotEntry, marshalutil.New(identity.IDLength+marshalutil.Float64Size+marshalutil.TimeSize).
+		WriteBytes(nodeID.Bytes()).
+		WriteFloat64(accessMana.Value).
+		WriteTime(accessMana.Timestamp).
+		Bytes(),
+	); err != nil {
+		return errors.Errorf("failed to write access mana of %s: %w", nodeID, err)
+	}
+
+	return nil
+}
+
+// WriteConsensusMana writes the consensus mana of the given node to the snapshot.
+func (s *SnapshotWriter) WriteConsensusMana(nodeID identity.ID, consensusMana float64) (err error) {
+	if err = s.writeEntry(ConsensusManaSnapshotEntry, marshalutil.New(identity.IDLength+marshalutil.Float64Size).
+		WriteBytes(nodeID.Bytes()).
+		WriteFloat64(consensusMana).
+		Bytes(),
+	); err != nil {
+		return errors.Errorf("failed to write consensus mana of %s: %w", nodeID, err)
+	}
+
+	return nil
+}
+
+// Close terminates the snapshot by writing the checksum and flushes the underlying writer. For a DeltaSnapshot it
+// additionally writes all the outputs of base transactions that have not been passed to WriteTransaction anymore.
+func (s *SnapshotWriter) Close() (err error) {
+	if s.closed {
+		return nil
+	}
+
+	if s.base != nil {
+		baseTransactionIDs := make([]TransactionID, 0, len(s.base.Transactions))
+		for transactionID := range s.base.Transactions {
+			if !s.seenBaseTransactions[transactionID] {
+				baseTransactionIDs = append(baseTransactionIDs, transactionID)
+			}
+		}
+		sort.Slice(baseTransactionIDs, func(i, j int) bool {
+			return bytes.Compare(baseTransactionIDs[i].Bytes(), baseTransactionIDs[j].Bytes()) < 0
+		})
+
+		for _, transactionID := range baseTransactionIDs {
+			if err = s.writeSpentOutputs(transactionID, s.base.Transactions[transactionID], nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err = s.writeEntry(endSnapshotEntry, nil); err != nil {
+		return errors.Errorf("failed to write end of snapshot: %w", err)
+	}
+	s.closed = true
+
+	copy(s.header.Checksum[:], s.checksum.Sum(nil))
+	if _, err = s.buffer.Write(s.header.Checksum[:]); err != nil {
+		return errors.Errorf("failed to write snapshot checksum: %w", err)
+	}
+	s.bytesWritten += SnapshotChecksumLength
+
+	if err = s.buffer.Flush(); err != nil {
+		return errors.Errorf("failed to flush snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// writeSpentOutputs writes the outputs that were unspent in the base record but are not unspent anymore.
+func (s *SnapshotWriter) writeSpentOutputs(transactionID TransactionID, baseRecord Record, unspentOutputs []bool) (err error) {
+	for i, unspentInBase := range baseRecord.UnspentOutputs {
+		if !unspentInBase || (i < len(unspentOutputs) && unspentOutputs[i]) {
+			continue
+		}
+
+		if err = s.WriteSpentOutput(NewOutputID(transactionID, uint16(i))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SnapshotWriter) writeEntry(entryType SnapshotEntryType, payload []byte) (err error) {
+	if s.closed {
+		return errors.Errorf("failed to write to closed snapshot writer: %w", ErrSnapshotClosed)
+	}
+
+	if err = s.write(marshalutil.New(marshalutil.Uint8Size + marshalutil.Uint32Size).
+		WriteUint8(uint8(entryType)).
+		WriteUint32(uint32(len(payload))).
+		Bytes(),
+	); err != nil {
+		return err
+	}
+
+	return s.write(payload)
+}
+
+func (s *SnapshotWriter) write(data []byte) (err error) {
+	if _, err = s.buffer.Write(data); err != nil {
+		return err
+	}
+	s.checksum.Write(data)
+	s.bytesWritten += int64(len(data))
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SnapshotReader ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// SnapshotReader reads a versioned snapshot entry by entry.
+type SnapshotReader struct {
+	header    *SnapshotHeader
+	buffer    *bufio.Reader
+	checksum  hash.Hash
+	bytesRead int64
+	done      bool
+}
+
+// NewSnapshotReader creates a SnapshotReader that reads the header of the snapshot from the given reader.
+func NewSnapshotReader(reader io.Reader) (snapshotReader *SnapshotReader, err error) {
+	var magic [len(snapshotMagic)]byte
+	if _, err = io.ReadFull(reader, magic[:]); err != nil {
+		return nil, errors.Errorf("failed to read snapshot prefix: %w", err)
+	}
+	if magic != snapshotMagic {
+		return nil, errors.Errorf("failed to read snapshot prefix: %w", ErrUnsupportedSnapshotVersion)
+	}
+
+	return newSnapshotReader(reader, int64(len(magic)))
+}
+
+// newSnapshotReader creates a SnapshotReader for a reader whose magic prefix has already been consumed.
+func newSnapshotReader(reader io.Reader, bytesRead int64) (snapshotReader *SnapshotReader, err error) {
+	checksum, err := blake2b.New256(nil)
+	if err != nil {
+		return nil, errors.Errorf("failed to create snapshot checksum: %w", err)
+	}
+	checksum.Write(snapshotMagic[:])
+
+	snapshotReader = &SnapshotReader{
+		buffer:    bufio.NewReader(reader),
+		checksum:  checksum,
+		bytesRead: bytesRead,
+	}
+
+	headerBytes, err := snapshotReader.read(snapshotHeaderLength)
+	if err != nil {
+		return nil, errors.Errorf("failed to read snapshot header: %w", err)
+	}
+	if snapshotReader.header, err = snapshotHeaderFromMarshalUtil(marshalutil.New(headerBytes)); err != nil {
+		return nil, err
+	}
+
+	return snapshotReader, nil
+}
+
+// Header returns the header of the snapshot (the checksum is only set after all entries have been read).
+func (s *SnapshotReader) Header() *SnapshotHeader {
+	return s.header
+}
+
+// BytesRead returns the amount of bytes that were read so far.
+func (s *SnapshotReader) BytesRead() int64 {
+	return s.bytesRead
+}
+
+// ReadEntry reads the next entry of the snapshot. It returns io.EOF after the last entry has been read and the checksum
+// of the snapshot has been verified.
+func (s *SnapshotReader) ReadEntry() (entry *SnapshotEntry, err error) {
+	if s.done {
+		return nil, io.EOF
+	}
+
+	entryHeaderBytes, err := s.read(marshalutil.Uint8Size + marshalutil.Uint32Size)
+	if err != nil {
+		return nil, errors.Errorf("failed to read snapshot entry: %w", err)
+	}
+	entryHeader := marshalutil.New(entryHeaderBytes)
+	entryType, _ := entryHeader.ReadUint8()
+	payloadLength, _ := entryHeader.ReadUint32()
+
+	if SnapshotEntryType(entryType) == endSnapshotEntry {
+		return nil, s.verifyChecksum()
+	}
+	if payloadLength > MaxSnapshotEntryLength {
+		return nil, errors.Errorf("snapshot entry payload of %d bytes exceeds the maximum of %d bytes: %w", payloadLength, MaxSnapshotEntryLength, cerrors.ErrParseBytesFailed)
+	}
+
+	entryPayload, err := s.read(int(payloadLength))
+	if err != nil {
+		return nil, errors.Errorf("failed to read snapshot entry payload: %w", err)
+	}
+
+	return snapshotEntryFromMarshalUtil(SnapshotEntryType(entryType), marshalutil.New(entryPayload))
+}
+
+func (s *SnapshotReader) verifyChecksum() (err error) {
+	s.done = true
+
+	var checksum [SnapshotChecksumLength]byte
+	if _, err = io.ReadFull(s.buffer, checksum[:]); err != nil {
+		return errors.Errorf("failed to read snapshot checksum: %w", io.ErrUnexpectedEOF)
+	}
+	s.bytesRead += SnapshotChecksumLength
+
+	var expectedChecksum [SnapshotChecksumLength]byte
+	copy(expectedChecksum[:], s.checksum.Sum(nil))
+	if checksum != expectedChecksum {
+		return errors.Errorf("snapshot checksum %x does not match content %x: %w", checksum, expectedChecksum, ErrSnapshotChecksumMismatch)
+	}
+	s.header.Checksum = checksum
+
+	return io.EOF
+}
+
+func (s *SnapshotReader) read(length int) (data []byte, err error) {
+	data = make([]byte, length)
+	if _, err = io.ReadFull(s.buffer, data); err != nil {
+		// the end of a snapshot is marked explicitly, so running out of data always means that it is truncated
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	s.checksum.Write(data)
+	s.bytesRead += int64(length)
+
+	return data, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,173 @@
+package ledgerstate
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_WriteToReadFrom(t *testing.T) {
+	snapshot := &Snapshot{
+		Header: &SnapshotHeader{
+			NetworkID:                36,
+			GenesisTime:              time.Unix(1616144400, 0),
+			SnapshotTime:             time.Unix(1616150000, 0),
+			LastConfirmedMessageID:   [SnapshotMessageIDLength]byte{1, 2, 3},
+			LastConfirmedMessageTime: time.Unix(1616149000, 0),
+		},
+		Transactions:        make(map[TransactionID]Record),
+		AccessManaByNode:    map[identity.ID]AccessMana{{1}: {Value: 10, Timestamp: time.Unix(1616149000, 0)}},
+		ConsensusManaByNode: map[identity.ID]float64{{1}: 100, {2}: 50},
+	}
+	for i := 0; i < 3; i++ {
+		transactionID, record := snapshotRecord(uint16(i), 2)
+		snapshot.Transactions[transactionID] = record
+	}
+
+	var buffer bytes.Buffer
+	bytesWritten, err := snapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, int64(buffer.Len()), bytesWritten)
+	assert.NotEqual(t, [SnapshotChecksumLength]byte{}, snapshot.Header.Checksum)
+
+	// writing the same snapshot again results in exactly the same bytes
+	var secondBuffer bytes.Buffer
+	_, err = snapshot.WriteTo(&secondBuffer)
+	require.NoError(t, err)
+	assert.Equal(t, buffer.Bytes(), secondBuffer.Bytes())
+
+	readSnapshot := &Snapshot{}
+	bytesRead, err := readSnapshot.ReadFrom(bytes.NewReader(buffer.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, bytesWritten, bytesRead)
+
+	assert.Equal(t, SnapshotVersion, readSnapshot.Header.Version)
+	assert.Equal(t, FullSnapshot, readSnapshot.Header.Type)
+	assert.Equal(t, uint32(36), readSnapshot.Header.NetworkID)
+	assert.True(t, snapshot.Header.GenesisTime.Equal(readSnapshot.Header.GenesisTime))
+	assert.Equal(t, snapshot.Header.LastConfirmedMessageID, readSnapshot.Header.LastConfirmedMessageID)
+	assert.Equal(t, snapshot.Header.Checksum, readSnapshot.Header.Checksum)
+	assert.Equal(t, snapshot.ConsensusManaByNode, readSnapshot.ConsensusManaByNode)
+	assert.Equal(t, 10.0, readSnapshot.AccessManaByNode[identity.ID{1}].Value)
+
+	require.Len(t, readSnapshot.Transactions, len(snapshot.Transactions))
+	for transactionID, record := range snapshot.Transactions {
+		assert.Equal(t, record.Essence.Bytes(), readSnapshot.Transactions[transactionID].Essence.Bytes())
+		assert.Equal(t, record.UnspentOutputs, readSnapshot.Transactions[transactionID].UnspentOutputs)
+	}
+
+	// a modified snapshot is detected by its checksum
+	corrupted := buffer.Bytes()
+	corrupted[len(corrupted)-SnapshotChecksumLength-10]++
+	_, err = (&Snapshot{}).ReadFrom(bytes.NewReader(corrupted))
+	assert.Error(t, err)
+
+	// a truncated snapshot is detected
+	_, err = (&Snapshot{}).ReadFrom(bytes.NewReader(buffer.Bytes()[:buffer.Len()/2]))
+	assert.Error(t, err)
+}
+
+func TestSnapshot_ReadEntryLength(t *testing.T) {
+	snapshot := &Snapshot{
+		Header:              &SnapshotHeader{SnapshotTime: time.Unix(1616150000, 0)},
+		Transactions:        make(map[TransactionID]Record),
+		AccessManaByNode:    make(map[identity.ID]AccessMana),
+		ConsensusManaByNode: make(map[identity.ID]float64),
+	}
+
+	var buffer bytes.Buffer
+	_, err := snapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+
+	// replace the end of the snapshot with an entry that announces a huge payload
+	entryHeaderLength := marshalutil.Uint8Size + marshalutil.Uint32Size
+	crafted := append([]byte{}, buffer.Bytes()[:buffer.Len()-SnapshotChecksumLength-entryHeaderLength]...)
+	crafted = append(crafted, byte(TransactionSnapshotEntry), 0xff, 0xff, 0xff, 0xff)
+
+	_, err = (&Snapshot{}).ReadFrom(bytes.NewReader(crafted))
+	assert.ErrorIs(t, err, cerrors.ErrParseBytesFailed)
+}
+
+func TestSnapshot_Delta(t *testing.T) {
+	spentTransactionID, spentRecord := snapshotRecord(0, 1)
+	partiallySpentTransactionID, partiallySpentRecord := snapshotRecord(1, 2)
+	unchangedTransactionID, unchangedRecord := snapshotRecord(2, 1)
+	createdTransactionID, createdRecord := snapshotRecord(3, 1)
+
+	base := &Snapshot{
+		Header: &SnapshotHeader{SnapshotTime: time.Unix(1616150000, 0)},
+		Transactions: map[TransactionID]Record{
+			spentTransactionID:          spentRecord,
+			partiallySpentTransactionID: partiallySpentRecord,
+			unchangedTransactionID:      unchangedRecord,
+		},
+	}
+	var baseBuffer bytes.Buffer
+	_, err := base.WriteTo(&baseBuffer)
+	require.NoError(t, err)
+
+	// stream the current ledger state through a delta writer
+	var deltaBuffer bytes.Buffer
+	deltaWriter, err := NewDeltaSnapshotWriter(&deltaBuffer, &SnapshotHeader{SnapshotTime: time.Unix(1616160000, 0)}, base)
+	require.NoError(t, err)
+	require.NoError(t, deltaWriter.WriteTransaction(partiallySpentTransactionID, Record{
+		Essence:        partiallySpentRecord.Essence,
+		UnlockBlocks:   partiallySpentRecord.UnlockBlocks,
+		UnspentOutputs: []bool{false, true},
+	}))
+	require.NoError(t, deltaWriter.WriteTransaction(unchangedTransactionID, unchangedRecord))
+	require.NoError(t, deltaWriter.WriteTransaction(createdTransactionID, createdRecord))
+	require.NoError(t, deltaWriter.Close())
+	assert.Less(t, deltaBuffer.Len(), baseBuffer.Len())
+
+	delta := &Snapshot{}
+	_, err = delta.ReadFrom(&deltaBuffer)
+	require.NoError(t, err)
+	assert.Equal(t, DeltaSnapshot, delta.Header.Type)
+	assert.Equal(t, base.Header.Checksum, delta.Header.BaseChecksum)
+	assert.Len(t, delta.Transactions, 1)
+	assert.ElementsMatch(t, []OutputID{NewOutputID(spentTransactionID, 0), NewOutputID(partiallySpentTransactionID, 0)}, delta.SpentOutputs)
+
+	// the delta can only be applied to the snapshot it is based on
+	assert.Error(t, (&Snapshot{Header: &SnapshotHeader{}, Transactions: map[TransactionID]Record{}}).ApplyDelta(delta))
+
+	readBase := &Snapshot{}
+	_, err = readBase.ReadFrom(&baseBuffer)
+	require.NoError(t, err)
+	require.NoError(t, readBase.ApplyDelta(delta))
+
+	assert.Equal(t, FullSnapshot, readBase.Header.Type)
+	assert.Len(t, readBase.Transactions, 3)
+	assert.NotContains(t, readBase.Transactions, spentTransactionID)
+	assert.Equal(t, []bool{false, true}, readBase.Transactions[partiallySpentTransactionID].UnspentOutputs)
+	assert.Contains(t, readBase.Transactions, unchangedTransactionID)
+	assert.Contains(t, readBase.Transactions, createdTransactionID)
+}
+
+// snapshotRecord creates a Record with the given amount of unspent outputs that spends the given genesis output.
+func snapshotRecord(genesisOutputIndex uint16, outputCount int) (TransactionID, Record) {
+	outputs := make([]Output, outputCount)
+	unspentOutputs := make([]bool, outputCount)
+	for i := range outputs {
+		outputs[i] = NewSigLockedSingleOutput(uint64(100+i), NewED25519Address(ed25519.GenerateKeyPair().PublicKey))
+		unspentOutputs[i] = true
+	}
+
+	transaction := NewTransaction(NewTransactionEssence(0, time.Unix(1616144400, 0), identity.ID{}, identity.ID{},
+		NewInputs(NewUTXOInput(NewOutputID(GenesisTransactionID, genesisOutputIndex))),
+		NewOutputs(outputs...),
+	), UnlockBlocks{NewReferenceUnlockBlock(0)})
+
+	return transaction.ID(), Record{
+		Essence:        transaction.Essence(),
+		UnlockBlocks:   transaction.UnlockBlocks(),
+		UnspentOutputs: unspentOutputs,
+	}
+}
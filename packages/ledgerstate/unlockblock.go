@@ -22,6 +22,9 @@ const (
 
 	// AliasUnlockBlockType represents the type of a AliasUnlockBlock
 	AliasUnlockBlockType
+
+	// HashTimeLockUnlockBlockType represents the type of a HashTimeLockUnlockBlock.
+	HashTimeLockUnlockBlockType
 )
 
 // UnlockBlockType represents the type of the UnlockBlock. Different types of UnlockBlocks can unlock different types of
@@ -34,6 +37,7 @@ func (a UnlockBlockType) String() string {
 		"SignatureUnlockBlockType",
 		"ReferenceUnlockBlockType",
 		"AliasUnlockBlockType",
+		"HashTimeLockUnlockBlockType",
 	}[a]
 }
 
@@ -91,6 +95,11 @@ func UnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlo
 			err = errors.Errorf("failed to parse AliasUnlockBlock from MarshalUtil: %w", err)
 			return
 		}
+	case HashTimeLockUnlockBlockType:
+		if unlockBlock, err = HashTimeLockUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+			err = errors.Errorf("failed to parse HashTimeLockUnlockBlock from MarshalUtil: %w", err)
+			return
+		}
 
 	default:
 		err = errors.Errorf("unsupported UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
@@ -409,3 +418,110 @@ func (r *AliasUnlockBlock) String() string {
 var _ UnlockBlock = &AliasUnlockBlock{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region HashTimeLockUnlockBlock //////////////////////////////////////////////////////////////////////////////////////
+
+// HashTimeLockUnlockBlock represents an UnlockBlock that reveals the preimage of the HashLock of a HashTimeLockedOutput
+// and that contains a Signature of the recipient Address.
+type HashTimeLockUnlockBlock struct {
+	preimage  []byte
+	signature Signature
+}
+
+// NewHashTimeLockUnlockBlock is the constructor for HashTimeLockUnlockBlock objects.
+func NewHashTimeLockUnlockBlock(preimage []byte, signature Signature) *HashTimeLockUnlockBlock {
+	return &HashTimeLockUnlockBlock{
+		preimage:  byteutils.ConcatBytes(preimage),
+		signature: signature,
+	}
+}
+
+// HashTimeLockUnlockBlockFromBytes unmarshals a HashTimeLockUnlockBlock from a sequence of bytes.
+func HashTimeLockUnlockBlockFromBytes(bytes []byte) (unlockBlock *HashTimeLockUnlockBlock, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	if unlockBlock, err = HashTimeLockUnlockBlockFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse HashTimeLockUnlockBlock from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// HashTimeLockUnlockBlockFromMarshalUtil unmarshals a HashTimeLockUnlockBlock using a MarshalUtil (for easier
+// unmarshaling).
+func HashTimeLockUnlockBlockFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (unlockBlock *HashTimeLockUnlockBlock, err error) {
+	unlockBlockType, err := marshalUtil.ReadByte()
+	if err != nil {
+		err = errors.Errorf("failed to parse UnlockBlockType (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if UnlockBlockType(unlockBlockType) != HashTimeLockUnlockBlockType {
+		err = errors.Errorf("invalid UnlockBlockType (%X): %w", unlockBlockType, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	preimageLength, err := marshalUtil.ReadUint8()
+	if err != nil {
+		err = errors.Errorf("failed to parse preimage length (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if preimageLength == 0 || preimageLength > MaxHashLockPreimageSize {
+		err = errors.Errorf("invalid preimage length (%d): %w", preimageLength, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	unlockBlock = &HashTimeLockUnlockBlock{}
+	if unlockBlock.preimage, err = marshalUtil.ReadBytes(int(preimageLength)); err != nil {
+		err = errors.Errorf("failed to parse preimage (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if unlockBlock.signature, err = SignatureFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse Signature from MarshalUtil: %w", err)
+		return
+	}
+	return
+}
+
+// AddressSignatureValid returns true if the UnlockBlock correctly signs the given Address.
+func (h *HashTimeLockUnlockBlock) AddressSignatureValid(address Address, signedData []byte) bool {
+	return h.signature.AddressSignatureValid(address, signedData)
+}
+
+// Preimage returns the revealed preimage of the HashLock.
+func (h *HashTimeLockUnlockBlock) Preimage() []byte {
+	return h.preimage
+}
+
+// Signature returns the Signature of the recipient.
+func (h *HashTimeLockUnlockBlock) Signature() Signature {
+	return h.signature
+}
+
+// Type returns the UnlockBlockType of the UnlockBlock.
+func (h *HashTimeLockUnlockBlock) Type() UnlockBlockType {
+	return HashTimeLockUnlockBlockType
+}
+
+// Bytes returns a marshaled version of the UnlockBlock.
+func (h *HashTimeLockUnlockBlock) Bytes() []byte {
+	return marshalutil.New().
+		WriteByte(byte(HashTimeLockUnlockBlockType)).
+		WriteUint8(uint8(len(h.preimage))).
+		WriteBytes(h.preimage).
+		WriteBytes(h.signature.Bytes()).
+		Bytes()
+}
+
+// String returns a human readable version of the UnlockBlock.
+func (h *HashTimeLockUnlockBlock) String() string {
+	return stringify.Struct("HashTimeLockUnlockBlock",
+		stringify.StructField("preimage", h.preimage),
+		stringify.StructField("signature", h.signature),
+	)
+}
+
+// code contract (make sure the type implements all required methods)
+var _ UnlockBlock = &HashTimeLockUnlockBlock{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
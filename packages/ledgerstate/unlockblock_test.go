@@ -15,6 +15,7 @@ func TestUnlockBlockFromMarshalUtil(t *testing.T) {
 		unlockBlocks := UnlockBlocks{
 			NewSignatureUnlockBlock(NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign([]byte("testdata")))),
 			NewReferenceUnlockBlock(0),
+			NewHashTimeLockUnlockBlock([]byte("secret"), NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign([]byte("testdata")))),
 		}
 		marshaledUnlockBlocks := unlockBlocks.Bytes()
 		parsedUnlockBlocks, consumedBytes, err := UnlockBlocksFromBytes(marshaledUnlockBlocks)
@@ -34,3 +35,19 @@ func TestUnlockBlockFromMarshalUtil(t *testing.T) {
 		assert.Error(t, err)
 	}
 }
+
+func TestHashTimeLockUnlockBlockFromMarshalUtil(t *testing.T) {
+	keyPair := ed25519.GenerateKeyPair()
+	signature := NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign([]byte("testdata")))
+
+	unlockBlock := NewHashTimeLockUnlockBlock([]byte("secret"), signature)
+	parsedUnlockBlock, consumedBytes, err := UnlockBlockFromBytes(unlockBlock.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, len(unlockBlock.Bytes()), consumedBytes)
+	assert.Equal(t, []byte("secret"), parsedUnlockBlock.(*HashTimeLockUnlockBlock).Preimage())
+	assert.True(t, parsedUnlockBlock.(*HashTimeLockUnlockBlock).AddressSignatureValid(NewED25519Address(keyPair.PublicKey), []byte("testdata")))
+
+	// preimages exceeding the maximum size are rejected
+	_, _, err = UnlockBlockFromBytes(NewHashTimeLockUnlockBlock(make([]byte, MaxHashLockPreimageSize+1), signature).Bytes())
+	assert.Error(t, err)
+}
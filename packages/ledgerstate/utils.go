@@ -132,8 +132,8 @@ func NewUnlockGraph(blocks UnlockBlocks) (*UnlockGraph, error) {
 	for i, block := range blocks {
 		g.Vertices[i] = uint16(i)
 		switch block.Type() {
-		case SignatureUnlockBlockType:
-			// no adjacent vertex as a SignatureUnlockBlockType can't reference an other one
+		case SignatureUnlockBlockType, HashTimeLockUnlockBlockType:
+			// no adjacent vertex as a SignatureUnlockBlockType or HashTimeLockUnlockBlockType can't reference an other one
 		case ReferenceUnlockBlockType:
 			// a reference unlock block can not point to another reference unlock block
 			refIndex := block.(*ReferenceUnlockBlock).ReferencedIndex()
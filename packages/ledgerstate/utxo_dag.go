@@ -1,10 +1,14 @@
 package ledgerstate
 
 import (
+	"bytes"
 	"container/list"
+	"encoding/binary"
 	"fmt"
+	"sort"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/byteutils"
@@ -35,30 +39,52 @@ type UTXODAG struct {
 	outputMetadataStorage       *objectstorage.ObjectStorage
 	consumerStorage             *objectstorage.ObjectStorage
 	addressOutputMappingStorage *objectstorage.ObjectStorage
+	addressHistoryStorage       *objectstorage.ObjectStorage
+	addressHistoryEpochStorage  *objectstorage.ObjectStorage
+	addressHistoryEnabled       bool
 	branchDAG                   *BranchDAG
 	shutdownOnce                sync.Once
 }
 
 // NewUTXODAG create a new UTXODAG from the given details.
-func NewUTXODAG(store kvstore.KVStore, cacheProvider *database.CacheTimeProvider, branchDAG *BranchDAG) (utxoDAG *UTXODAG) {
-	options := buildObjectStorageOptions(cacheProvider)
+func NewUTXODAG(store kvstore.KVStore, cacheProvider *database.CacheTimeProvider, branchDAG *BranchDAG, options ...UTXODAGOption) (utxoDAG *UTXODAG) {
+	storageOptions := buildObjectStorageOptions(cacheProvider)
 	osFactory := objectstorage.NewFactory(store, database.PrefixLedgerState)
 	utxoDAG = &UTXODAG{
 		Events: &UTXODAGEvents{
 			TransactionBranchIDUpdated: events.NewEvent(transactionIDEventHandler),
 			TransactionConfirmed:       events.NewEvent(transactionIDEventHandler),
 		},
-		transactionStorage:          osFactory.New(PrefixTransactionStorage, TransactionFromObjectStorage, options.transactionStorageOptions...),
-		transactionMetadataStorage:  osFactory.New(PrefixTransactionMetadataStorage, TransactionMetadataFromObjectStorage, options.transactionMetadataStorageOptions...),
-		outputStorage:               osFactory.New(PrefixOutputStorage, OutputFromObjectStorage, options.outputStorageOptions...),
-		outputMetadataStorage:       osFactory.New(PrefixOutputMetadataStorage, OutputMetadataFromObjectStorage, options.outputMetadataStorageOptions...),
-		consumerStorage:             osFactory.New(PrefixConsumerStorage, ConsumerFromObjectStorage, options.consumerStorageOptions...),
-		addressOutputMappingStorage: osFactory.New(PrefixAddressOutputMappingStorage, AddressOutputMappingFromObjectStorage, options.addressOutputMappingStorageOptions...),
+		transactionStorage:          osFactory.New(PrefixTransactionStorage, TransactionFromObjectStorage, storageOptions.transactionStorageOptions...),
+		transactionMetadataStorage:  osFactory.New(PrefixTransactionMetadataStorage, TransactionMetadataFromObjectStorage, storageOptions.transactionMetadataStorageOptions...),
+		outputStorage:               osFactory.New(PrefixOutputStorage, OutputFromObjectStorage, storageOptions.outputStorageOptions...),
+		outputMetadataStorage:       osFactory.New(PrefixOutputMetadataStorage, OutputMetadataFromObjectStorage, storageOptions.outputMetadataStorageOptions...),
+		consumerStorage:             osFactory.New(PrefixConsumerStorage, ConsumerFromObjectStorage, storageOptions.consumerStorageOptions...),
+		addressOutputMappingStorage: osFactory.New(PrefixAddressOutputMappingStorage, AddressOutputMappingFromObjectStorage, storageOptions.addressOutputMappingStorageOptions...),
+		addressHistoryStorage:       osFactory.New(PrefixAddressHistoryStorage, AddressHistoryEntryFromObjectStorage, storageOptions.addressHistoryStorageOptions...),
+		addressHistoryEpochStorage:  osFactory.New(PrefixAddressHistoryEpochStorage, AddressHistoryEpochFromObjectStorage, storageOptions.addressHistoryEpochStorageOptions...),
 		branchDAG:                   branchDAG,
 	}
+
+	for _, option := range options {
+		option(utxoDAG)
+	}
+
 	return
 }
 
+// Flush persists the cached objects of the UTXODAG without shutting it down.
+func (u *UTXODAG) Flush() {
+	u.transactionStorage.Flush()
+	u.transactionMetadataStorage.Flush()
+	u.outputStorage.Flush()
+	u.outputMetadataStorage.Flush()
+	u.consumerStorage.Flush()
+	u.addressOutputMappingStorage.Flush()
+	u.addressHistoryStorage.Flush()
+	u.addressHistoryEpochStorage.Flush()
+}
+
 // Shutdown shuts down the UTXODAG and persists its state.
 func (u *UTXODAG) Shutdown() {
 	u.shutdownOnce.Do(func() {
@@ -68,6 +94,8 @@ func (u *UTXODAG) Shutdown() {
 		u.outputMetadataStorage.Shutdown()
 		u.consumerStorage.Shutdown()
 		u.addressOutputMappingStorage.Shutdown()
+		u.addressHistoryStorage.Shutdown()
+		u.addressHistoryEpochStorage.Shutdown()
 	})
 }
 
@@ -122,6 +150,11 @@ func (u *UTXODAG) BookTransaction(transaction *Transaction) (targetBranch Branch
 	// store Transaction
 	u.transactionStorage.Store(transaction).Release()
 
+	// index the Transaction in the history of the affected Addresses
+	if u.addressHistoryEnabled {
+		u.storeAddressHistory(transaction, consumedOutputs)
+	}
+
 	// retrieve the metadata of the Inputs
 	cachedInputsMetadata := u.transactionInputsMetadata(transaction)
 	defer cachedInputsMetadata.Release()
@@ -239,6 +272,17 @@ func (u *UTXODAG) Transactions() (transactions map[TransactionID]*Transaction) {
 	return
 }
 
+// ForEachTransaction iterates through all the Transactions in the UTXODAG until the consumer returns false.
+func (u *UTXODAG) ForEachTransaction(consumer func(transaction *Transaction) bool) {
+	u.transactionStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		continueIteration := true
+		(&CachedTransaction{CachedObject: cachedObject}).Consume(func(transaction *Transaction) {
+			continueIteration = consumer(transaction)
+		})
+		return continueIteration
+	})
+}
+
 // CachedTransactionMetadata retrieves the TransactionMetadata with the given TransactionID from the object storage.
 func (u *UTXODAG) CachedTransactionMetadata(transactionID TransactionID) (cachedTransactionMetadata *CachedTransactionMetadata) {
 	return &CachedTransactionMetadata{CachedObject: u.transactionMetadataStorage.Load(transactionID.Bytes())}
@@ -322,6 +366,109 @@ func (u *UTXODAG) CachedAddressOutputMapping(address Address) (cachedAddressOutp
 	return
 }
 
+// AddressHistoryEnabled returns true if the UTXODAG maintains the history of the Addresses.
+func (u *UTXODAG) AddressHistoryEnabled() bool {
+	return u.addressHistoryEnabled
+}
+
+// AddressHistory returns up to limit entries of the history of the given Address that follow the cursor in
+// chronological order, together with the cursor of the next page (nil if there are no further entries). The cursor is
+// the storage key of the last entry of the previous page, an empty cursor starts at the beginning of the history and a
+// limit <= 0 returns all entries. The epochs are loaded one by one, so only the epochs up to the one that contains the
+// entry after the last returned one are read. It returns an error if the UTXODAG does not maintain the history of the
+// Addresses.
+func (u *UTXODAG) AddressHistory(address Address, cursor []byte, limit int) (addressHistoryEntries AddressHistoryEntries, nextCursor []byte, err error) {
+	if !u.addressHistoryEnabled {
+		return nil, nil, errors.Errorf("failed to retrieve history of %s: %w", address, ErrAddressHistoryDisabled)
+	}
+
+	var cursorEpoch uint64
+	if len(cursor) >= marshalutil.Uint64Size {
+		cursorEpoch = binary.BigEndian.Uint64(cursor)
+	}
+
+	addressHistoryEntries = make(AddressHistoryEntries, 0)
+	for _, epoch := range u.addressHistoryEpochs() {
+		if epoch < cursorEpoch {
+			continue
+		}
+
+		// the storage doesn't guarantee the order of the iteration, so we have to sort the entries of each epoch
+		epochEntries := make(AddressHistoryEntries, 0)
+		u.addressHistoryStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+			if bytes.Compare(key, cursor) <= 0 {
+				cachedObject.Release()
+				return true
+			}
+
+			(&CachedAddressHistoryEntry{CachedObject: cachedObject}).Consume(func(addressHistoryEntry *AddressHistoryEntry) {
+				epochEntries = append(epochEntries, addressHistoryEntry)
+			})
+			return true
+		}, objectstorage.WithIteratorPrefix(byteutils.ConcatBytes(addressHistoryEpochBytes(epoch), address.Bytes())))
+		addressHistoryEntries = append(addressHistoryEntries, epochEntries.Sort()...)
+
+		// stop as soon as we know that there is a next page
+		if limit > 0 && len(addressHistoryEntries) > limit {
+			addressHistoryEntries = addressHistoryEntries[:limit]
+			nextCursor = addressHistoryEntries[limit-1].ObjectStorageKey()
+			break
+		}
+	}
+
+	return addressHistoryEntries, nextCursor, nil
+}
+
+// PruneAddressHistory removes all AddressHistoryEntries of Transactions that were issued before the given threshold. Only
+// the epochs up to the one of the threshold are visited.
+func (u *UTXODAG) PruneAddressHistory(threshold time.Time) (prunedEntries int) {
+	thresholdEpoch := AddressHistoryEpochFromTime(threshold)
+	for _, epoch := range u.addressHistoryEpochs() {
+		if epoch > thresholdEpoch {
+			break
+		}
+
+		keysToPrune := make([][]byte, 0)
+		epochPrunedCompletely := true
+		u.addressHistoryStorage.ForEachKeyOnly(func(key []byte) bool {
+			if epoch == thresholdEpoch && !addressHistoryEntryTimestamp(key).Before(threshold) {
+				epochPrunedCompletely = false
+				return true
+			}
+
+			keysToPrune = append(keysToPrune, byteutils.ConcatBytes(key))
+			return true
+		}, objectstorage.WithIteratorPrefix(addressHistoryEpochBytes(epoch)))
+
+		for _, key := range keysToPrune {
+			u.addressHistoryStorage.Delete(key)
+		}
+		if epochPrunedCompletely {
+			u.addressHistoryEpochStorage.Delete(addressHistoryEpochBytes(epoch))
+		}
+		prunedEntries += len(keysToPrune)
+	}
+
+	return prunedEntries
+}
+
+// addressHistoryEpochs returns the sorted indices of the epochs that contain AddressHistoryEntries.
+func (u *UTXODAG) addressHistoryEpochs() (epochs []uint64) {
+	epochs = make([]uint64, 0)
+	u.addressHistoryEpochStorage.ForEachKeyOnly(func(key []byte) bool {
+		epochs = append(epochs, binary.BigEndian.Uint64(key))
+		return true
+	})
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+
+	return
+}
+
+// addressHistoryEntryTimestamp extracts the timestamp from the storage key of an AddressHistoryEntry.
+func addressHistoryEntryTimestamp(key []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(key[marshalutil.Uint64Size+AddressLength:])))
+}
+
 // SetTransactionConfirmed marks a Transaction (and all Transactions in its past cone) as confirmed. It also marks the
 // conflicting Transactions to be rejected.
 func (u *UTXODAG) SetTransactionConfirmed(transactionID TransactionID) (err error) {
@@ -889,33 +1036,77 @@ func (u *UTXODAG) consumedBranchIDs(transactionID TransactionID) (branchIDs Bran
 
 // ManageStoreAddressOutputMapping mangages how to store the address-output mapping dependent on which type of output it is.
 func (u *UTXODAG) ManageStoreAddressOutputMapping(output Output) {
+	for _, address := range outputAddresses(output) {
+		u.StoreAddressOutputMapping(address, output.ID())
+	}
+}
+
+// StoreAddressOutputMapping stores the address-output mapping.
+func (u *UTXODAG) StoreAddressOutputMapping(address Address, outputID OutputID) {
+	result, stored := u.addressOutputMappingStorage.StoreIfAbsent(NewAddressOutputMapping(address, outputID))
+	if stored {
+		result.Release()
+	}
+}
+
+// storeAddressHistory records the Outputs that the given Transaction creates and consumes in the history of the
+// affected Addresses.
+func (u *UTXODAG) storeAddressHistory(transaction *Transaction, consumedOutputs Outputs) {
+	timestamp := transaction.Essence().Timestamp()
+	transactionID := transaction.ID()
+
+	for _, output := range transaction.Essence().Outputs() {
+		for _, address := range outputAddresses(output) {
+			u.storeAddressHistoryEntry(NewAddressHistoryEntry(address, timestamp, output.ID(), CreatedAddressHistoryEntryType, transactionID))
+		}
+	}
+
+	for _, consumedOutput := range consumedOutputs {
+		if typeutils.IsInterfaceNil(consumedOutput) {
+			continue
+		}
+
+		for _, address := range outputAddresses(consumedOutput) {
+			u.storeAddressHistoryEntry(NewAddressHistoryEntry(address, timestamp, consumedOutput.ID(), ConsumedAddressHistoryEntryType, transactionID))
+		}
+	}
+}
+
+// storeAddressHistoryEntry stores the given AddressHistoryEntry and its epoch if they do not exist, yet.
+func (u *UTXODAG) storeAddressHistoryEntry(addressHistoryEntry *AddressHistoryEntry) {
+	if cachedAddressHistoryEpoch, stored := u.addressHistoryEpochStorage.StoreIfAbsent(NewAddressHistoryEpoch(AddressHistoryEpochFromTime(addressHistoryEntry.Timestamp()))); stored {
+		cachedAddressHistoryEpoch.Release()
+	}
+	if cachedAddressHistoryEntry, stored := u.addressHistoryStorage.StoreIfAbsent(addressHistoryEntry); stored {
+		cachedAddressHistoryEntry.Release()
+	}
+}
+
+// outputAddresses returns the Addresses that are affected by the given Output dependent on which type of output it is.
+func outputAddresses(output Output) (addresses []Address) {
 	switch output.Type() {
 	case AliasOutputType:
 		castedOutput := output.(*AliasOutput)
 		// if it is an origin alias output, we don't have the aliasaddress from the parsed bytes.
 		// that happens in utxodag output booking, so we calculate the alias address here
-		u.StoreAddressOutputMapping(castedOutput.GetAliasAddress(), output.ID())
-		u.StoreAddressOutputMapping(castedOutput.GetStateAddress(), output.ID())
+		addresses = append(addresses, castedOutput.GetAliasAddress(), castedOutput.GetStateAddress())
 		if !castedOutput.IsSelfGoverned() {
-			u.StoreAddressOutputMapping(castedOutput.GetGoverningAddress(), output.ID())
+			addresses = append(addresses, castedOutput.GetGoverningAddress())
 		}
 	case ExtendedLockedOutputType:
 		castedOutput := output.(*ExtendedLockedOutput)
 		if castedOutput.FallbackAddress() != nil {
-			u.StoreAddressOutputMapping(castedOutput.FallbackAddress(), output.ID())
+			addresses = append(addresses, castedOutput.FallbackAddress())
 		}
-		u.StoreAddressOutputMapping(output.Address(), output.ID())
+		addresses = append(addresses, output.Address())
+	case HashTimeLockedOutputType:
+		castedOutput := output.(*HashTimeLockedOutput)
+		addresses = append(addresses, castedOutput.RefundAddress(), output.Address())
 	default:
-		u.StoreAddressOutputMapping(output.Address(), output.ID())
+		addresses = append(addresses, output.Address())
 	}
-}
 
-// StoreAddressOutputMapping stores the address-output mapping.
-func (u *UTXODAG) StoreAddressOutputMapping(address Address, outputID OutputID) {
-	result, stored := u.addressOutputMappingStorage.StoreIfAbsent(NewAddressOutputMapping(address, outputID))
-	if stored {
-		result.Release()
-	}
+	return
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -937,6 +1128,22 @@ func (u *UTXODAG) lockTransaction(transaction *Transaction) {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region UTXODAGOption ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// UTXODAGOption represents the return type of optional parameters that can be handed into the constructor of the
+// UTXODAG to configure its behavior.
+type UTXODAGOption func(utxoDAG *UTXODAG)
+
+// WithAddressHistory is an UTXODAGOption that defines whether the UTXODAG maintains an index of the Outputs that were
+// created and consumed on each Address.
+func WithAddressHistory(enabled bool) UTXODAGOption {
+	return func(utxoDAG *UTXODAG) {
+		utxoDAG.addressHistoryEnabled = enabled
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region UTXODAGEvents ////////////////////////////////////////////////////////////////////////////////////////////////
 
 // UTXODAGEvents is a container for all of the UTXODAG related events.
@@ -5,10 +5,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/errors"
+
 	"github.com/iotaledger/goshimmer/packages/database"
 
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/hive.go/objectstorage"
 	"github.com/iotaledger/hive.go/types"
@@ -788,6 +791,107 @@ func TestAddressOutputMapping(t *testing.T) {
 	assert.Equal(t, 1, len(res))
 }
 
+func TestAddressHistory(t *testing.T) {
+	database.ForEachStore(t, func(t *testing.T, store kvstore.KVStore) {
+		cacheTimeProvider := database.NewCacheTimeProvider(0)
+		branchDAG := NewBranchDAG(store, cacheTimeProvider)
+		require.NoError(t, branchDAG.Prune())
+		defer branchDAG.Shutdown()
+		utxoDAG := NewUTXODAG(store, cacheTimeProvider, branchDAG, WithAddressHistory(true))
+		defer utxoDAG.Shutdown()
+
+		wallets := createWallets(2)
+		input := generateOutput(utxoDAG, wallets[0].address, 0)
+		tx := buildTransaction(utxoDAG, wallets[0], wallets[1], []*SigLockedSingleOutput{input})
+		_, err := utxoDAG.BookTransaction(tx)
+		require.NoError(t, err)
+
+		senderHistory, _, err := utxoDAG.AddressHistory(wallets[0].address, nil, 0)
+		require.NoError(t, err)
+		require.Len(t, senderHistory, 1)
+		assert.Equal(t, ConsumedAddressHistoryEntryType, senderHistory[0].Type())
+		assert.Equal(t, input.ID(), senderHistory[0].OutputID())
+		assert.Equal(t, tx.ID(), senderHistory[0].TransactionID())
+		assert.True(t, tx.Essence().Timestamp().Equal(senderHistory[0].Timestamp()))
+
+		receiverHistory, _, err := utxoDAG.AddressHistory(wallets[1].address, nil, 0)
+		require.NoError(t, err)
+		require.Len(t, receiverHistory, 1)
+		assert.Equal(t, CreatedAddressHistoryEntryType, receiverHistory[0].Type())
+		assert.Equal(t, NewOutputID(tx.ID(), 0), receiverHistory[0].OutputID())
+
+		// the entries survive a round trip through their marshaled form
+		restoredEntry, _, err := AddressHistoryEntryFromBytes(receiverHistory[0].Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, receiverHistory[0].Bytes(), restoredEntry.Bytes())
+
+		// entries older than the threshold are pruned
+		assert.Equal(t, 0, utxoDAG.PruneAddressHistory(tx.Essence().Timestamp()))
+		assert.Equal(t, 2, utxoDAG.PruneAddressHistory(tx.Essence().Timestamp().Add(time.Second)))
+		senderHistory, _, err = utxoDAG.AddressHistory(wallets[0].address, nil, 0)
+		require.NoError(t, err)
+		assert.Empty(t, senderHistory)
+
+		// the history is not available if the index is disabled
+		disabledUTXODAG := NewUTXODAG(store, cacheTimeProvider, branchDAG)
+		defer disabledUTXODAG.Shutdown()
+		_, _, err = disabledUTXODAG.AddressHistory(wallets[0].address, nil, 0)
+		assert.True(t, errors.Is(err, ErrAddressHistoryDisabled))
+	})
+}
+
+func TestAddressHistory_Pagination(t *testing.T) {
+	database.ForEachStore(t, func(t *testing.T, store kvstore.KVStore) {
+		cacheTimeProvider := database.NewCacheTimeProvider(0)
+		branchDAG := NewBranchDAG(store, cacheTimeProvider)
+		require.NoError(t, branchDAG.Prune())
+		defer branchDAG.Shutdown()
+		utxoDAG := NewUTXODAG(store, cacheTimeProvider, branchDAG, WithAddressHistory(true))
+		defer utxoDAG.Shutdown()
+
+		// spread the entries of the address over several epochs
+		address := genRandomWallet().address
+		otherAddress := genRandomWallet().address
+		start := time.Now().Truncate(AddressHistoryEpochDuration)
+		expectedEntries := make(AddressHistoryEntries, 0)
+		for i := 0; i < 7; i++ {
+			timestamp := start.Add(time.Duration(i) * AddressHistoryEpochDuration / 2)
+			entry := NewAddressHistoryEntry(address, timestamp, randOutputID(), CreatedAddressHistoryEntryType, randOutputID().TransactionID())
+			utxoDAG.storeAddressHistoryEntry(entry)
+			utxoDAG.storeAddressHistoryEntry(NewAddressHistoryEntry(otherAddress, timestamp, randOutputID(), CreatedAddressHistoryEntryType, randOutputID().TransactionID()))
+			expectedEntries = append(expectedEntries, entry)
+		}
+
+		var cursor []byte
+		pagedEntries := make(AddressHistoryEntries, 0)
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 4)
+
+			entries, nextCursor, err := utxoDAG.AddressHistory(address, cursor, 2)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(entries), 2)
+			pagedEntries = append(pagedEntries, entries...)
+			if nextCursor == nil {
+				break
+			}
+			cursor = nextCursor
+		}
+		require.Len(t, pagedEntries, len(expectedEntries))
+		for i := range expectedEntries {
+			assert.Equal(t, expectedEntries[i].Bytes(), pagedEntries[i].Bytes())
+		}
+
+		// only the epochs before the threshold are pruned completely
+		assert.Equal(t, 6, utxoDAG.PruneAddressHistory(start.Add(AddressHistoryEpochDuration+time.Nanosecond)))
+		assert.Len(t, utxoDAG.addressHistoryEpochs(), 3)
+		entries, nextCursor, err := utxoDAG.AddressHistory(address, nil, 0)
+		require.NoError(t, err)
+		assert.Nil(t, nextCursor)
+		require.Len(t, entries, 4)
+		assert.Equal(t, expectedEntries[3].Bytes(), entries[0].Bytes())
+	})
+}
+
 func TestUTXODAG_CheckTransaction(t *testing.T) {
 	branchDAG, utxoDAG := setupDependencies(t)
 	defer branchDAG.Shutdown()
@@ -19,6 +19,7 @@ import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/logger"
 )
 
@@ -48,12 +49,18 @@ const (
 type KnownPeerToAdd struct {
 	PublicKey ed25519.PublicKey `json:"publicKey"`
 	Address   string            `json:"address"`
+	// Label is an optional name that helps the node operator to identify the peer.
+	Label string `json:"label,omitempty"`
+	// NeverDrop exempts the peer from being dropped by the gossip layer when its score falls below the threshold.
+	NeverDrop bool `json:"neverDrop,omitempty"`
 }
 
 // KnownPeer defines a peer record in the manualpeering layer.
 type KnownPeer struct {
 	PublicKey     ed25519.PublicKey   `json:"publicKey"`
 	Address       string              `json:"address"`
+	Label         string              `json:"label,omitempty"`
+	NeverDrop     bool                `json:"neverDrop"`
 	ConnDirection ConnectionDirection `json:"connectionDirection"`
 	ConnStatus    ConnectionStatus    `json:"connectionStatus"`
 }
@@ -66,8 +73,11 @@ type KnownPeer struct {
 // And vice versa, if a peer is being removed from the list of known peers,
 // manager will make sure gossip drops that connection.
 // Manager also subscribes to the gossip events and in case the connection with a manual peer fails it will reconnect.
+// The peers that are added via AddPeer are persisted in the store of the manager (if provided) and are restored on
+// Start, while the peers of the config file are set with SetConfigPeers.
 type Manager struct {
 	gm                *gossip.Manager
+	config            *managerConfig
 	log               *logger.Logger
 	local             *peer.Local
 	startOnce         sync.Once
@@ -84,9 +94,10 @@ type Manager struct {
 }
 
 // NewManager initializes a new Manager instance.
-func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger) *Manager {
+func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger, opts ...ManagerOption) *Manager {
 	m := &Manager{
 		gm:                gm,
+		config:            buildManagerConfig(opts),
 		local:             local,
 		log:               log,
 		reconnectInterval: defaultReconnectInterval,
@@ -97,11 +108,40 @@ func NewManager(gm *gossip.Manager, local *peer.Local, log *logger.Logger) *Mana
 	return m
 }
 
-// AddPeer adds multiple peers to the list of known peers.
+// AddPeer adds multiple peers to the list of known peers and persists them. If a peer is already known, its address
+// and options are updated.
 func (m *Manager) AddPeer(peers ...*KnownPeerToAdd) error {
 	var resultErr error
 	for _, p := range peers {
-		if err := m.addPeer(p); err != nil {
+		if err := m.addPeer(p, peerSourceAPI); err != nil {
+			resultErr = errors.CombineErrors(resultErr, err)
+			continue
+		}
+		if err := m.storePeer(p); err != nil {
+			resultErr = errors.CombineErrors(resultErr, err)
+		}
+	}
+	return resultErr
+}
+
+// SetConfigPeers sets the peers of the config file. Peers that were set by a previous call but are no longer part of
+// the config are removed, unless they were also added via AddPeer.
+func (m *Manager) SetConfigPeers(peers []*KnownPeerToAdd) error {
+	configured := make(map[identity.ID]struct{}, len(peers))
+	for _, p := range peers {
+		configured[identity.NewID(p.PublicKey)] = struct{}{}
+	}
+
+	var resultErr error
+	for _, peerID := range m.configuredPeers() {
+		if _, exists := configured[peerID]; !exists {
+			if err := m.removePeerSource(peerID, peerSourceConfig); err != nil {
+				resultErr = errors.CombineErrors(resultErr, err)
+			}
+		}
+	}
+	for _, p := range peers {
+		if err := m.addPeer(p, peerSourceConfig); err != nil {
 			resultErr = errors.CombineErrors(resultErr, err)
 		}
 	}
@@ -164,6 +204,8 @@ func (m *Manager) GetPeers(opts ...GetPeersOption) []*KnownPeer {
 			peers = append(peers, &KnownPeer{
 				PublicKey:     kp.peer.PublicKey(),
 				Address:       kp.peerAddress,
+				Label:         kp.label,
+				NeverDrop:     kp.neverDrop,
 				ConnDirection: kp.connDirection,
 				ConnStatus:    connStatus,
 			})
@@ -172,14 +214,16 @@ func (m *Manager) GetPeers(opts ...GetPeersOption) []*KnownPeer {
 	return peers
 }
 
-// Start subscribes to the gossip layer events and starts internal background workers.
+// Start subscribes to the gossip layer events, restores the persisted peers and starts internal background workers.
 // Calling multiple times has no effect.
-func (m *Manager) Start() {
+func (m *Manager) Start() (err error) {
 	m.startOnce.Do(func() {
 		m.gm.NeighborsEvents(gossip.NeighborsGroupManual).NeighborRemoved.Attach(m.onGossipNeighborRemovedClosure)
 		m.gm.NeighborsEvents(gossip.NeighborsGroupManual).NeighborAdded.Attach(m.onGossipNeighborAddedClosure)
 		m.isStarted.Set()
+		err = m.restorePeers()
 	})
+	return err
 }
 
 // Stop terminates internal background workers. Calling multiple times has no effect.
@@ -198,9 +242,22 @@ func (m *Manager) Stop() (err error) {
 	return err
 }
 
+// peerSource is a bitmask of the sources that added a known peer.
+type peerSource uint8
+
+const (
+	// peerSourceAPI marks the peers that were added via AddPeer (or restored from the store).
+	peerSourceAPI peerSource = 1 << iota
+	// peerSourceConfig marks the peers that were set via SetConfigPeers.
+	peerSourceConfig
+)
+
 type knownPeer struct {
 	peer          *peer.Peer
 	peerAddress   string
+	label         string
+	neverDrop     bool
+	sources       peerSource
 	connDirection ConnectionDirection
 	connStatus    *atomic.Value
 	removeCh      chan struct{}
@@ -220,6 +277,8 @@ func newKnownPeer(p *KnownPeerToAdd, connDirection ConnectionDirection) (*knownP
 	kp := &knownPeer{
 		peer:          peer.NewPeer(identity.New(p.PublicKey), tcpAddress.IP, services),
 		peerAddress:   p.Address,
+		label:         p.Label,
+		neverDrop:     p.NeverDrop,
 		connDirection: connDirection,
 		connStatus:    &atomic.Value{},
 		removeCh:      make(chan struct{}),
@@ -237,7 +296,7 @@ func (kp *knownPeer) setConnStatus(cs ConnectionStatus) {
 	kp.connStatus.Store(cs)
 }
 
-func (m *Manager) addPeer(p *KnownPeerToAdd) error {
+func (m *Manager) addPeer(p *KnownPeerToAdd, source peerSource) error {
 	if !m.isStarted.IsSet() {
 		return errors.New("manualpeering manager hasn't been started yet")
 	}
@@ -256,11 +315,24 @@ func (m *Manager) addPeer(p *KnownPeerToAdd) error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	if _, exists := m.knownPeers[kp.peer.ID()]; exists {
-		return nil
+	if existing, exists := m.knownPeers[kp.peer.ID()]; exists {
+		if existing.peerAddress == kp.peerAddress {
+			existing.label = kp.label
+			existing.neverDrop = kp.neverDrop
+			existing.sources |= source
+			m.gm.SetNeverDrop(existing.peer.ID(), existing.neverDrop)
+			return nil
+		}
+		// the address changed, so we need to reconnect to the peer
+		kp.sources = existing.sources
+		if err := m.removePeerByID(existing.peer.ID()); err != nil {
+			return errors.WithStack(err)
+		}
 	}
+	kp.sources |= source
 	m.log.Infow("Adding new peer to the list of known peers in manualpeering", "peer", p)
 	m.knownPeers[kp.peer.ID()] = kp
+	m.gm.SetNeverDrop(kp.peer.ID(), kp.neverDrop)
 	go func() {
 		defer close(kp.doneCh)
 		m.keepPeerConnected(kp)
@@ -274,8 +346,40 @@ func (m *Manager) removePeer(key ed25519.PublicKey) error {
 	m.log.Infow("Removing peer from from the list of known peers in manualpeering",
 		"publicKey", key)
 	peerID := identity.NewID(key)
-	err := m.removePeerByID(peerID)
-	return errors.WithStack(err)
+	if err := m.removePeerByID(peerID); err != nil {
+		return errors.WithStack(err)
+	}
+	return m.deleteStoredPeer(key)
+}
+
+// removePeerSource removes the given source from the known peer and removes the peer if no source is left.
+func (m *Manager) removePeerSource(peerID identity.ID, source peerSource) error {
+	m.knownPeersMutex.Lock()
+	defer m.knownPeersMutex.Unlock()
+	kp, exists := m.knownPeers[peerID]
+	if !exists {
+		return nil
+	}
+	kp.sources &^= source
+	if kp.sources != 0 {
+		return nil
+	}
+	m.log.Infow("Removing peer from from the list of known peers in manualpeering",
+		"publicKey", kp.peer.PublicKey())
+	return errors.WithStack(m.removePeerByID(peerID))
+}
+
+// configuredPeers returns the IDs of the known peers that were set via SetConfigPeers.
+func (m *Manager) configuredPeers() []identity.ID {
+	m.knownPeersMutex.RLock()
+	defer m.knownPeersMutex.RUnlock()
+	var peerIDs []identity.ID
+	for peerID, kp := range m.knownPeers {
+		if kp.sources&peerSourceConfig != 0 {
+			peerIDs = append(peerIDs, peerID)
+		}
+	}
+	return peerIDs
 }
 
 func (m *Manager) removeAllKnownPeers() error {
@@ -296,6 +400,7 @@ func (m *Manager) removePeerByID(peerID identity.ID) error {
 		return nil
 	}
 	delete(m.knownPeers, peerID)
+	m.gm.SetNeverDrop(peerID, false)
 	close(kp.removeCh)
 	<-kp.doneCh
 	if err := m.gm.DropNeighbor(peerID, gossip.NeighborsGroupManual); err != nil && !errors.Is(err, gossip.ErrUnknownNeighbor) {
@@ -328,7 +433,9 @@ func (m *Manager) keepPeerConnected(kp *knownPeer) {
 			} else if kp.connDirection == ConnDirectionInbound {
 				err = m.gm.AddInbound(ctx, kp.peer, gossip.NeighborsGroupManual, server.WithNoDefaultTimeout())
 			}
-			if err != nil && !errors.Is(err, gossip.ErrDuplicateNeighbor) && !errors.Is(err, context.Canceled) {
+			if errors.Is(err, gossip.ErrNeighborBanned) {
+				m.log.Debugw("Peer is banned in the gossip layer", "peerID", peerID)
+			} else if err != nil && !errors.Is(err, gossip.ErrDuplicateNeighbor) && !errors.Is(err, context.Canceled) {
 				m.log.Errorw(
 					"Failed to connect a neighbor in the gossip layer",
 					"peerID", peerID, "connectionDirection", kp.connDirection, "err", err,
@@ -380,3 +487,29 @@ func (m *Manager) connectionDirection(peerPK ed25519.PublicKey) (ConnectionDirec
 		)
 	}
 }
+
+// region ManagerOption ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ManagerOption defines an option for the Manager.
+type ManagerOption func(conf *managerConfig)
+
+type managerConfig struct {
+	store kvstore.KVStore
+}
+
+func buildManagerConfig(opts []ManagerOption) *managerConfig {
+	conf := &managerConfig{}
+	for _, o := range opts {
+		o(conf)
+	}
+	return conf
+}
+
+// WithStore returns a ManagerOption that persists the peers that are added via AddPeer in the given store.
+func WithStore(store kvstore.KVStore) ManagerOption {
+	return func(conf *managerConfig) {
+		conf.store = store
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,98 @@
+package manualpeering
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+)
+
+// storePeer persists the given peer, so that it is restored on the next start of the manager.
+func (m *Manager) storePeer(p *KnownPeerToAdd) error {
+	if m.config.store == nil {
+		return nil
+	}
+	if err := m.config.store.Set(p.PublicKey.Bytes(), knownPeerToAddBytes(p)); err != nil {
+		return errors.Wrapf(err, "failed to store known peer %s", p.PublicKey)
+	}
+	return nil
+}
+
+// deleteStoredPeer removes the persisted peer with the given public key.
+func (m *Manager) deleteStoredPeer(key ed25519.PublicKey) error {
+	if m.config.store == nil {
+		return nil
+	}
+	if err := m.config.store.Delete(key.Bytes()); err != nil {
+		return errors.Wrapf(err, "failed to delete stored known peer %s", key)
+	}
+	return nil
+}
+
+// restorePeers adds the persisted peers to the list of known peers.
+func (m *Manager) restorePeers() error {
+	if m.config.store == nil {
+		return nil
+	}
+
+	var peers []*KnownPeerToAdd
+	var resultErr error
+	if err := m.config.store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		p, err := knownPeerToAddFromBytes(key, value)
+		if err != nil {
+			resultErr = errors.CombineErrors(resultErr, err)
+			return true
+		}
+		peers = append(peers, p)
+		return true
+	}); err != nil {
+		return errors.Wrap(err, "failed to iterate stored known peers")
+	}
+
+	m.log.Infow("Restoring stored known peers", "peers", peers)
+	for _, p := range peers {
+		if err := m.addPeer(p, peerSourceAPI); err != nil {
+			resultErr = errors.CombineErrors(resultErr, err)
+		}
+	}
+	return resultErr
+}
+
+// knownPeerToAddBytes marshals the address and the options of the peer. The public key is used as the key.
+func knownPeerToAddBytes(p *KnownPeerToAdd) []byte {
+	return marshalutil.New(2*marshalutil.Uint16Size + len(p.Address) + len(p.Label) + marshalutil.BoolSize).
+		WriteUint16(uint16(len(p.Address))).
+		WriteBytes([]byte(p.Address)).
+		WriteUint16(uint16(len(p.Label))).
+		WriteBytes([]byte(p.Label)).
+		WriteBool(p.NeverDrop).
+		Bytes()
+}
+
+// knownPeerToAddFromBytes unmarshals a peer that was marshaled with knownPeerToAddBytes.
+func knownPeerToAddFromBytes(key, value []byte) (p *KnownPeerToAdd, err error) {
+	p = &KnownPeerToAdd{}
+	if p.PublicKey, _, err = ed25519.PublicKeyFromBytes(key); err != nil {
+		return nil, errors.Wrap(err, "failed to parse public key of stored known peer")
+	}
+
+	marshalUtil := marshalutil.New(value)
+	readString := func() (string, error) {
+		length, err := marshalUtil.ReadUint16()
+		if err != nil {
+			return "", err
+		}
+		bytes, err := marshalUtil.ReadBytes(int(length))
+		return string(bytes), err
+	}
+	if p.Address, err = readString(); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse address of stored known peer %s", p.PublicKey)
+	}
+	if p.Label, err = readString(); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse label of stored known peer %s", p.PublicKey)
+	}
+	if p.NeverDrop, err = marshalUtil.ReadBool(); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse options of stored known peer %s", p.PublicKey)
+	}
+	return p, nil
+}
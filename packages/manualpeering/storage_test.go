@@ -0,0 +1,151 @@
+package manualpeering
+
+import (
+	"net"
+	"testing"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+)
+
+func TestKnownPeerToAddBytes(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+
+	for _, p := range []*KnownPeerToAdd{
+		{PublicKey: publicKey, Address: "127.0.0.1:14666"},
+		{PublicKey: publicKey, Address: "[::1]:14666", Label: "entry node", NeverDrop: true},
+	} {
+		restored, err := knownPeerToAddFromBytes(p.PublicKey.Bytes(), knownPeerToAddBytes(p))
+		require.NoError(t, err)
+		assert.Equal(t, p, restored)
+	}
+
+	_, err = knownPeerToAddFromBytes(publicKey.Bytes(), []byte{0, 5, 'a'})
+	assert.Error(t, err)
+}
+
+func TestManager_SetConfigPeers(t *testing.T) {
+	store := mapdb.NewMapDB()
+	mgr := newTestManager(t, store)
+	configPeer, sharedPeer := newTestKnownPeerToAdd(t, "127.0.0.1:14666"), newTestKnownPeerToAdd(t, "127.0.0.1:14667")
+
+	require.NoError(t, mgr.SetConfigPeers([]*KnownPeerToAdd{configPeer, sharedPeer}))
+	require.NoError(t, mgr.AddPeer(sharedPeer))
+	assert.ElementsMatch(t, []string{configPeer.Address, sharedPeer.Address}, peerAddresses(mgr))
+
+	// only the peers that were added via AddPeer are persisted
+	assertStored(t, store, configPeer, false)
+	assertStored(t, store, sharedPeer, true)
+
+	// removing the peers from the config keeps the peers that were also added via AddPeer
+	require.NoError(t, mgr.SetConfigPeers(nil))
+	assert.ElementsMatch(t, []string{sharedPeer.Address}, peerAddresses(mgr))
+
+	// removing the peer via RemovePeer removes it regardless of its sources
+	require.NoError(t, mgr.SetConfigPeers([]*KnownPeerToAdd{configPeer, sharedPeer}))
+	require.NoError(t, mgr.RemovePeer(sharedPeer.PublicKey))
+	assert.ElementsMatch(t, []string{configPeer.Address}, peerAddresses(mgr))
+	assertStored(t, store, sharedPeer, false)
+}
+
+func TestManager_StartRestoresPeers(t *testing.T) {
+	store := mapdb.NewMapDB()
+	storedPeer := newTestKnownPeerToAdd(t, "127.0.0.1:14666")
+	storedPeer.Label, storedPeer.NeverDrop = "entry node", true
+
+	mgr := newTestManager(t, store)
+	require.NoError(t, mgr.AddPeer(storedPeer))
+	require.NoError(t, mgr.SetConfigPeers([]*KnownPeerToAdd{newTestKnownPeerToAdd(t, "127.0.0.1:14667")}))
+	require.NoError(t, mgr.Stop())
+
+	// the peers of the config are not restored, as they are set again after the start
+	restartedMgr := newTestManager(t, store)
+	peers := restartedMgr.GetPeers()
+	require.Len(t, peers, 1)
+	assert.Equal(t, storedPeer.PublicKey, peers[0].PublicKey)
+	assert.Equal(t, storedPeer.Address, peers[0].Address)
+	assert.Equal(t, storedPeer.Label, peers[0].Label)
+	assert.True(t, peers[0].NeverDrop)
+}
+
+func TestManager_AddPeerAddressChange(t *testing.T) {
+	store := mapdb.NewMapDB()
+	mgr := newTestManager(t, store)
+	knownPeer := newTestKnownPeerToAdd(t, "127.0.0.1:14666")
+	peerID := identity.NewID(knownPeer.PublicKey)
+
+	require.NoError(t, mgr.AddPeer(knownPeer))
+	require.NoError(t, mgr.SetConfigPeers([]*KnownPeerToAdd{knownPeer}))
+	previousKnownPeer := mgr.knownPeers[peerID]
+
+	movedPeer := &KnownPeerToAdd{PublicKey: knownPeer.PublicKey, Address: "127.0.0.1:14667"}
+	require.NoError(t, mgr.AddPeer(movedPeer))
+	assert.ElementsMatch(t, []string{movedPeer.Address}, peerAddresses(mgr))
+	assertStored(t, store, movedPeer, true)
+
+	// the connection loop of the previous address was stopped and a new one was started for the new address
+	select {
+	case <-previousKnownPeer.doneCh:
+	default:
+		t.Fatal("the connection loop of the previous address is still running")
+	}
+	assert.NotSame(t, previousKnownPeer, mgr.knownPeers[peerID])
+
+	// the peer keeps its sources
+	require.NoError(t, mgr.SetConfigPeers(nil))
+	assert.ElementsMatch(t, []string{movedPeer.Address}, peerAddresses(mgr))
+}
+
+// newTestManager creates and starts a Manager with a gossip layer that is not connected to the network.
+func newTestManager(t *testing.T, store kvstore.KVStore) *Manager {
+	db, err := peer.NewDB(mapdb.NewMapDB())
+	require.NoError(t, err)
+	services := service.New()
+	services.Update(service.PeeringKey, "peering", 14626)
+	services.Update(service.GossipKey, "tcp", 14665)
+	local, err := peer.NewLocal(net.IPv4(127, 0, 0, 1), services, db)
+	require.NoError(t, err)
+
+	log := logger.NewExampleLogger("manualpeering")
+	mgr := NewManager(gossip.NewManager(local, nil, log), local, log, WithStore(store))
+	require.NoError(t, mgr.Start())
+	t.Cleanup(func() { _ = mgr.Stop() })
+
+	return mgr
+}
+
+func newTestKnownPeerToAdd(t *testing.T, address string) *KnownPeerToAdd {
+	publicKey, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+
+	return &KnownPeerToAdd{PublicKey: publicKey, Address: address}
+}
+
+func peerAddresses(mgr *Manager) (addresses []string) {
+	for _, p := range mgr.GetPeers() {
+		addresses = append(addresses, p.Address)
+	}
+	return addresses
+}
+
+func assertStored(t *testing.T, store kvstore.KVStore, p *KnownPeerToAdd, stored bool) {
+	value, err := store.Get(p.PublicKey.Bytes())
+	if !stored {
+		assert.ErrorIs(t, err, kvstore.ErrKeyNotFound)
+		return
+	}
+	require.NoError(t, err)
+	restored, err := knownPeerToAddFromBytes(p.PublicKey.Bytes(), value)
+	require.NoError(t, err)
+	assert.Equal(t, p, restored)
+}
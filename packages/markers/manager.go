@@ -300,18 +300,33 @@ func (m *Manager) UnregisterSequenceAliasMapping(sequenceAlias SequenceAlias, se
 	return
 }
 
+// Flush persists the state of the Manager without shutting it down.
+func (m *Manager) Flush() {
+	m.storeSequenceIDCounter()
+	m.sequenceStore.Flush()
+	m.sequenceAliasMappingStore.Flush()
+}
+
 // Shutdown shuts down the Manager and persists its state.
 func (m *Manager) Shutdown() {
 	m.shutdownOnce.Do(func() {
-		if err := m.store.Set(kvstore.Key("sequenceIDCounter"), m.sequenceIDCounter.Bytes()); err != nil {
-			panic(err)
-		}
+		m.storeSequenceIDCounter()
 
 		m.sequenceStore.Shutdown()
 		m.sequenceAliasMappingStore.Shutdown()
 	})
 }
 
+// storeSequenceIDCounter is an internal utility function that persists the SequenceID counter.
+func (m *Manager) storeSequenceIDCounter() {
+	m.sequenceIDCounterMutex.Lock()
+	defer m.sequenceIDCounterMutex.Unlock()
+
+	if err := m.store.Set(kvstore.Key("sequenceIDCounter"), m.sequenceIDCounter.Bytes()); err != nil {
+		panic(err)
+	}
+}
+
 // normalizeMarkers takes a set of Markers and removes each Marker that is already referenced by another Marker in the
 // same set (the remaining Markers are the "most special" Markers that reference all Markers in the set grouped by the
 // rank of their corresponding Sequence). In addition, the method returns all SequenceIDs of the Markers that were not
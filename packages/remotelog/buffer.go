@@ -0,0 +1,359 @@
+package remotelog
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// BufferedSink passes the records to another sink. While that sink is unavailable, the records are appended to a
+// buffer file on disk instead, which is sent as soon as the sink is available again. As the buffer is kept on disk, the
+// records also survive a restart of the node.
+type BufferedSink struct {
+	sink       Sink
+	path       string
+	maxSize    int64
+	bufferFile *os.File
+	size       int64
+	mutex      sync.Mutex
+
+	// flushing is set while the records of the buffer that was swapped out by Flush are sent, which holds flushingSize
+	// bytes.
+	flushing     bool
+	flushingSize int64
+
+	// sending is set while Send passes a record to the underlying sink without holding the mutex. The records that are
+	// sent in the meantime are buffered behind it.
+	sending bool
+
+	flushRequests chan struct{}
+	closing       chan struct{}
+	closeOnce     sync.Once
+	wg            sync.WaitGroup
+}
+
+// NewBufferedSink creates a sink that buffers the records for the given sink in the file with the given path, which
+// grows to at most maxSize bytes. The sink tries to send the buffered records every retryInterval.
+func NewBufferedSink(sink Sink, path string, maxSize int64, retryInterval time.Duration) (*BufferedSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, errors.Errorf("failed to create directory of %s: %w", path, err)
+	}
+
+	b := &BufferedSink{
+		sink:          sink,
+		path:          path,
+		maxSize:       maxSize,
+		flushRequests: make(chan struct{}, 1),
+		closing:       make(chan struct{}),
+	}
+	// records that were buffered before the last shutdown are sent first
+	if _, err := os.Stat(b.flushPath()); err == nil {
+		if err = b.restoreUnsent(0); err != nil {
+			return nil, err
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Errorf("failed to open buffer %s: %w", b.flushPath(), err)
+	}
+	if info, err := os.Stat(path); err == nil {
+		b.size = info.Size()
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Errorf("failed to open buffer %s: %w", path, err)
+	}
+
+	b.wg.Add(1)
+	go b.retryLoop(retryInterval)
+
+	return b, nil
+}
+
+// Send sends the record to the underlying sink or buffers it, if the sink is unavailable or if there are still buffered
+// records. It returns ErrBufferFull if the record was dropped. The mutex is not held while the record is passed to the
+// underlying sink, so that a sink which is slow to connect does not block the other callers.
+func (b *BufferedSink) Send(record []byte) error {
+	b.mutex.Lock()
+	// keep the order of the records
+	if b.size != 0 || b.flushing || b.sending {
+		defer b.mutex.Unlock()
+
+		return b.buffer(record)
+	}
+	b.sending = true
+	b.mutex.Unlock()
+
+	sendErr := b.sink.Send(record)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.sending = false
+	if sendErr != nil {
+		// the records that were buffered in the meantime have to be sent after this one
+		return b.bufferInFront(record)
+	}
+	if b.size != 0 {
+		b.requestFlush()
+	}
+
+	return nil
+}
+
+// BufferSize returns the size of the records that are currently buffered in bytes.
+func (b *BufferedSink) BufferSize() int64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.size + b.flushingSize
+}
+
+// Flush tries to send the buffered records to the underlying sink. The records that could not be sent remain buffered.
+// The buffer is swapped out before its records are sent, so that Send does not wait for the flush, but buffers the new
+// records behind the flushed ones.
+func (b *BufferedSink) Flush() error {
+	for {
+		swapped, err := b.swapBuffer()
+		if err != nil || !swapped {
+			return err
+		}
+
+		sent, sendErr := b.replay()
+		if done, err := b.finishFlush(sent, sendErr); err != nil || done {
+			return err
+		}
+	}
+}
+
+// Close stops the retries, closes the buffer and the underlying sink. The buffered records are kept on disk.
+func (b *BufferedSink) Close() error {
+	b.closeOnce.Do(func() {
+		close(b.closing)
+	})
+	b.wg.Wait()
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return errors.CombineErrors(b.closeBufferFile(), b.sink.Close())
+}
+
+func (b *BufferedSink) retryLoop(retryInterval time.Duration) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = b.Flush()
+		case <-b.flushRequests:
+			_ = b.Flush()
+		case <-b.closing:
+			return
+		}
+	}
+}
+
+func (b *BufferedSink) buffer(record []byte) error {
+	if b.size+b.flushingSize+int64(len(record))+1 > b.maxSize {
+		return errors.Errorf("failed to buffer record for %s: %w", b.path, ErrBufferFull)
+	}
+
+	if b.bufferFile == nil {
+		file, err := os.OpenFile(b.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return errors.Errorf("failed to open buffer %s: %w", b.path, err)
+		}
+		b.bufferFile = file
+	}
+
+	n, err := b.bufferFile.Write(line(record))
+	b.size += int64(n)
+	if err != nil {
+		return errors.Errorf("failed to write buffer %s: %w", b.path, err)
+	}
+	return nil
+}
+
+// bufferInFront buffers the record in front of the records that are already buffered.
+func (b *BufferedSink) bufferInFront(record []byte) (err error) {
+	if b.size == 0 {
+		return b.buffer(record)
+	}
+	if b.size+b.flushingSize+int64(len(record))+1 > b.maxSize {
+		return errors.Errorf("failed to buffer record for %s: %w", b.path, ErrBufferFull)
+	}
+	if err = b.closeBufferFile(); err != nil {
+		return err
+	}
+
+	tmpPath := b.path + ".tmp"
+	tmpFile, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Errorf("failed to create %s: %w", tmpPath, err)
+	}
+	if _, err = tmpFile.Write(line(record)); err == nil {
+		err = appendFile(tmpFile, b.path, 0)
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, b.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Errorf("failed to buffer record for %s: %w", b.path, err)
+	}
+
+	b.size += int64(len(record)) + 1
+	return nil
+}
+
+// requestFlush makes the retry loop flush the buffer without waiting for the next retry.
+func (b *BufferedSink) requestFlush() {
+	select {
+	case b.flushRequests <- struct{}{}:
+	default:
+	}
+}
+
+// swapBuffer moves the buffer to the flushPath, unless it is empty, another flush is in progress or Send passes a
+// record to the underlying sink.
+func (b *BufferedSink) swapBuffer() (swapped bool, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.size == 0 || b.flushing || b.sending {
+		return false, nil
+	}
+	if err = b.closeBufferFile(); err != nil {
+		return false, err
+	}
+	if err = os.Rename(b.path, b.flushPath()); err != nil {
+		return false, errors.Errorf("failed to swap buffer %s: %w", b.path, err)
+	}
+
+	b.flushing, b.flushingSize, b.size = true, b.size, 0
+	return true, nil
+}
+
+// replay sends the records of the swapped buffer to the underlying sink and returns the amount of bytes that were sent.
+func (b *BufferedSink) replay() (sent int64, err error) {
+	file, err := os.Open(b.flushPath())
+	if err != nil {
+		return 0, errors.Errorf("failed to open buffer %s: %w", b.flushPath(), err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		record, readErr := reader.ReadBytes('\n')
+		if errors.Is(readErr, io.EOF) {
+			// a truncated record at the end of the buffer (e.g. after a crash) is dropped
+			return sent, nil
+		}
+		if readErr != nil {
+			return sent, errors.Errorf("failed to read buffer %s: %w", b.flushPath(), readErr)
+		}
+		if sendErr := b.sink.Send(record[:len(record)-1]); sendErr != nil {
+			return sent, sendErr
+		}
+		sent += int64(len(record))
+	}
+}
+
+// finishFlush removes the swapped buffer if all of its records were sent or puts the unsent records back in front of
+// the records that were buffered in the meantime. It returns true if no records are left to flush.
+func (b *BufferedSink) finishFlush(sent int64, sendErr error) (done bool, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	defer func() {
+		b.flushing, b.flushingSize = false, 0
+	}()
+
+	if sendErr == nil {
+		if err = os.Remove(b.flushPath()); err != nil {
+			return true, errors.Errorf("failed to remove buffer %s: %w", b.flushPath(), err)
+		}
+		return b.size == 0, nil
+	}
+
+	if err = b.restoreUnsent(sent); err != nil {
+		return true, errors.CombineErrors(sendErr, err)
+	}
+	b.size += b.flushingSize - sent
+	return true, sendErr
+}
+
+// restoreUnsent replaces the buffer with the records of the swapped buffer (skipping the first sent bytes), followed by
+// the records of the current buffer.
+func (b *BufferedSink) restoreUnsent(sent int64) (err error) {
+	if err = b.closeBufferFile(); err != nil {
+		return err
+	}
+
+	tmpPath := b.path + ".tmp"
+	tmpFile, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Errorf("failed to create %s: %w", tmpPath, err)
+	}
+	if err = appendFile(tmpFile, b.flushPath(), sent); err == nil {
+		err = appendFile(tmpFile, b.path, 0)
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, b.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Errorf("failed to restore buffer %s: %w", b.path, err)
+	}
+
+	if err = os.Remove(b.flushPath()); err != nil {
+		return errors.Errorf("failed to remove buffer %s: %w", b.flushPath(), err)
+	}
+	return nil
+}
+
+// flushPath returns the path of the buffer while its records are sent by Flush.
+func (b *BufferedSink) flushPath() string {
+	return b.path + ".flush"
+}
+
+func (b *BufferedSink) closeBufferFile() error {
+	if b.bufferFile == nil {
+		return nil
+	}
+	err := b.bufferFile.Close()
+	b.bufferFile = nil
+	if err != nil {
+		return errors.Errorf("failed to close buffer %s: %w", b.path, err)
+	}
+	return nil
+}
+
+// appendFile appends the content of the file with the given path (starting at the given offset) to the target. A file
+// that does not exist is treated as empty.
+func appendFile(target io.Writer, path string, offset int64) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = io.Copy(target, file)
+	return err
+}
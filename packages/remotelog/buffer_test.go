@@ -0,0 +1,250 @@
+package remotelog
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferedSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "buffer", "udp.buffer")
+	sink := &unreliableSink{}
+	buffered, err := NewBufferedSink(sink, path, 20, time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, buffered.Send([]byte(`{"n":1}`)))
+	assert.Equal(t, []string{`{"n":1}`}, sink.Records())
+	assert.EqualValues(t, 0, buffered.BufferSize())
+
+	// the records are buffered while the sink is unavailable and dropped if the buffer is full
+	sink.SetAvailable(false)
+	require.NoError(t, buffered.Send([]byte(`{"n":2}`)))
+	require.NoError(t, buffered.Send([]byte(`{"n":3}`)))
+	assert.ErrorIs(t, buffered.Send([]byte(`{"n":4}`)), ErrBufferFull)
+	assert.EqualValues(t, 16, buffered.BufferSize())
+	assert.Error(t, buffered.Flush())
+
+	// the buffered records are sent once the sink is available again
+	sink.SetAvailable(true)
+	require.NoError(t, buffered.Flush())
+	assert.Equal(t, []string{`{"n":1}`, `{"n":2}`, `{"n":3}`}, sink.Records())
+	assert.EqualValues(t, 0, buffered.BufferSize())
+
+	// the buffered records are sent before the new ones
+	sink.SetAvailable(false)
+	require.NoError(t, buffered.Send([]byte(`{"n":5}`)))
+	sink.SetAvailable(true)
+	require.NoError(t, buffered.Send([]byte(`{"n":6}`)))
+	assert.Equal(t, []string{`{"n":1}`, `{"n":2}`, `{"n":3}`}, sink.Records())
+	assert.EqualValues(t, 16, buffered.BufferSize())
+
+	// the buffer survives a restart
+	require.NoError(t, buffered.Close())
+	sink = &unreliableSink{}
+	buffered, err = NewBufferedSink(sink, path, 20, time.Hour)
+	require.NoError(t, err)
+	assert.EqualValues(t, 16, buffered.BufferSize())
+	require.NoError(t, buffered.Flush())
+	assert.Equal(t, []string{`{"n":5}`, `{"n":6}`}, sink.Records())
+	assert.EqualValues(t, 0, buffered.BufferSize())
+	assert.NoFileExists(t, path)
+	require.NoError(t, buffered.Close())
+}
+
+func TestBufferedSinkPartialFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tcp.buffer")
+	sink := &unreliableSink{}
+	sink.SetAvailable(false)
+	buffered, err := NewBufferedSink(sink, path, 100, time.Hour)
+	require.NoError(t, err)
+	defer buffered.Close()
+
+	for _, record := range []string{`{"n":1}`, `{"n":2}`, `{"n":3}`} {
+		require.NoError(t, buffered.Send([]byte(record)))
+	}
+
+	// the records that could not be sent remain buffered
+	sink.SetAvailable(true)
+	sink.FailAfter(1)
+	assert.Error(t, buffered.Flush())
+	assert.Equal(t, []string{`{"n":1}`}, sink.Records())
+	assert.EqualValues(t, 16, buffered.BufferSize())
+
+	sink.SetAvailable(true)
+	require.NoError(t, buffered.Flush())
+	assert.Equal(t, []string{`{"n":1}`, `{"n":2}`, `{"n":3}`}, sink.Records())
+}
+
+func TestBufferedSinkSendDuringFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tcp.buffer")
+	sink := &blockingSink{unreliableSink: &unreliableSink{}}
+	sink.SetAvailable(false)
+	buffered, err := NewBufferedSink(sink, path, 100, time.Hour)
+	require.NoError(t, err)
+	defer buffered.Close()
+
+	require.NoError(t, buffered.Send([]byte(`{"n":1}`)))
+	require.NoError(t, buffered.Send([]byte(`{"n":2}`)))
+	sink.SetAvailable(true)
+	entered, release := sink.BlockNext()
+
+	flushed := make(chan error)
+	go func() {
+		flushed <- buffered.Flush()
+	}()
+	<-entered
+
+	// the records that are sent while the buffer is flushed are buffered behind the flushed ones without waiting
+	require.NoError(t, buffered.Send([]byte(`{"n":3}`)))
+	assert.EqualValues(t, 24, buffered.BufferSize())
+
+	close(release)
+	require.NoError(t, <-flushed)
+	assert.Equal(t, []string{`{"n":1}`, `{"n":2}`, `{"n":3}`}, sink.Records())
+	assert.EqualValues(t, 0, buffered.BufferSize())
+	assert.NoFileExists(t, path)
+}
+
+func TestBufferedSinkSendDuringSend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tcp.buffer")
+	sink := &blockingSink{unreliableSink: &unreliableSink{}}
+	buffered, err := NewBufferedSink(sink, path, 100, time.Hour)
+	require.NoError(t, err)
+	defer buffered.Close()
+
+	// the records that are sent while the sink blocks are buffered without waiting
+	entered, release := sink.BlockNext()
+	sent := make(chan error)
+	go func() {
+		sent <- buffered.Send([]byte(`{"n":1}`))
+	}()
+	<-entered
+	require.NoError(t, buffered.Send([]byte(`{"n":2}`)))
+	assert.EqualValues(t, 8, buffered.BufferSize())
+
+	// a record that fails is buffered in front of the records that were buffered in the meantime
+	sink.SetAvailable(false)
+	close(release)
+	require.NoError(t, <-sent)
+	assert.EqualValues(t, 16, buffered.BufferSize())
+
+	sink.SetAvailable(true)
+	require.NoError(t, buffered.Flush())
+	assert.Equal(t, []string{`{"n":1}`, `{"n":2}`}, sink.Records())
+
+	// the records that were buffered behind a successful record are flushed right away
+	entered, release = sink.BlockNext()
+	go func() {
+		sent <- buffered.Send([]byte(`{"n":3}`))
+	}()
+	<-entered
+	require.NoError(t, buffered.Send([]byte(`{"n":4}`)))
+	close(release)
+	require.NoError(t, <-sent)
+	assert.Eventually(t, func() bool {
+		return buffered.BufferSize() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{`{"n":1}`, `{"n":2}`, `{"n":3}`, `{"n":4}`}, sink.Records())
+}
+
+func TestBufferedSinkInterruptedFlush(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tcp.buffer")
+	require.NoError(t, os.WriteFile(path+".flush", []byte("{\"n\":1}\n"), 0o644))
+	require.NoError(t, os.WriteFile(path, []byte("{\"n\":2}\n"), 0o644))
+
+	// the records of a flush that was interrupted by a shutdown are sent before the ones that were buffered later
+	sink := &unreliableSink{}
+	buffered, err := NewBufferedSink(sink, path, 100, time.Hour)
+	require.NoError(t, err)
+	defer buffered.Close()
+	assert.EqualValues(t, 16, buffered.BufferSize())
+	assert.NoFileExists(t, path+".flush")
+
+	require.NoError(t, buffered.Flush())
+	assert.Equal(t, []string{`{"n":1}`, `{"n":2}`}, sink.Records())
+}
+
+// blockingSink is a sink that can block a record until it is released.
+type blockingSink struct {
+	*unreliableSink
+	entered chan struct{}
+	release chan struct{}
+}
+
+// BlockNext makes the sink block the next record until the returned release channel is closed. The returned entered
+// channel is closed once the record is blocked.
+func (b *blockingSink) BlockNext() (entered, release chan struct{}) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.entered, b.release = make(chan struct{}), make(chan struct{})
+	return b.entered, b.release
+}
+
+func (b *blockingSink) Send(record []byte) error {
+	b.mutex.Lock()
+	entered, release := b.entered, b.release
+	b.entered = nil
+	b.mutex.Unlock()
+
+	if entered != nil {
+		close(entered)
+		<-release
+	}
+	return b.unreliableSink.Send(record)
+}
+
+// unreliableSink is a sink that can be made unavailable.
+type unreliableSink struct {
+	unavailable bool
+	failAfter   int
+	records     []string
+	mutex       sync.Mutex
+}
+
+func (u *unreliableSink) Send(record []byte) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	if u.failAfter > 0 {
+		u.failAfter--
+		if u.failAfter == 0 {
+			u.unavailable = true
+		}
+	} else if u.unavailable {
+		return ErrSinkUnavailable
+	}
+	u.records = append(u.records, string(record))
+	return nil
+}
+
+func (u *unreliableSink) Close() error {
+	return nil
+}
+
+func (u *unreliableSink) SetAvailable(available bool) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.unavailable = !available
+}
+
+// FailAfter makes the sink unavailable after it received the given amount of records.
+func (u *unreliableSink) FailAfter(records int) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.failAfter = records
+}
+
+func (u *unreliableSink) Records() []string {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return append([]string{}, u.records...)
+}
@@ -0,0 +1,131 @@
+package remotelog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+// FileSink appends the records to a file as newline delimited JSON. Once the file exceeds the maximum size, it is
+// renamed to <path>.1 (the existing rotated files are shifted to <path>.2 and so on) and a new file is started.
+type FileSink struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+	file     *os.File
+	size     int64
+	mutex    sync.Mutex
+}
+
+// NewFileSink creates a sink that writes to the file with the given path. The file is rotated once it exceeds maxSize
+// bytes and at most maxFiles rotated files are kept.
+func NewFileSink(path string, maxSize int64, maxFiles int) (*FileSink, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, errors.Errorf("failed to create directory of %s: %w", path, err)
+		}
+	}
+
+	f := &FileSink{
+		path:     path,
+		maxSize:  maxSize,
+		maxFiles: maxFiles,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Send sends the record to the sink.
+func (f *FileSink) Send(record []byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return ErrSinkClosed
+	}
+	if f.size > 0 && f.size+int64(len(record))+1 > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(line(record))
+	f.size += int64(n)
+	if err != nil {
+		return errors.Errorf("failed to write record to %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// Close closes the sink.
+func (f *FileSink) Close() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *FileSink) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return errors.Errorf("failed to open %s: %w", f.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Errorf("failed to open %s: %w", f.path, err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate starts a new file. If the files cannot be renamed, the records are still appended to the current file.
+func (f *FileSink) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return errors.Errorf("failed to close %s: %w", f.path, err)
+	}
+	f.file = nil
+
+	renameErr := f.renameFiles()
+	if err := f.open(); err != nil {
+		return err
+	}
+	return renameErr
+}
+
+// renameFiles shifts the rotated files and renames the current file to the first rotated file.
+func (f *FileSink) renameFiles() error {
+	if f.maxFiles <= 0 {
+		if err := os.Remove(f.path); err != nil {
+			return errors.Errorf("failed to rotate %s: %w", f.path, err)
+		}
+		return nil
+	}
+
+	for i := f.maxFiles - 1; i > 0; i-- {
+		if err := os.Rename(rotatedPath(f.path, i), rotatedPath(f.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Errorf("failed to rotate %s: %w", f.path, err)
+		}
+	}
+	if err := os.Rename(f.path, rotatedPath(f.path, 1)); err != nil {
+		return errors.Errorf("failed to rotate %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// rotatedPath returns the path of the rotated file with the given index.
+func rotatedPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
@@ -0,0 +1,41 @@
+package remotelog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "remotelog.json")
+	sink, err := NewFileSink(path, 20, 2)
+	require.NoError(t, err)
+
+	// every file holds two records of 10 bytes (including the newline)
+	for _, record := range []string{`{"n":"1"}`, `{"n":"2"}`, `{"n":"3"}`, `{"n":"4"}`, `{"n":"5"}`, `{"n":"6"}`, `{"n":"7"}`} {
+		require.NoError(t, sink.Send([]byte(record)))
+	}
+	require.NoError(t, sink.Close())
+	assert.ErrorIs(t, sink.Send([]byte(`{}`)), ErrSinkClosed)
+
+	assertFileContent(t, path, "{\"n\":\"7\"}\n")
+	assertFileContent(t, rotatedPath(path, 1), "{\"n\":\"5\"}\n{\"n\":\"6\"}\n")
+	assertFileContent(t, rotatedPath(path, 2), "{\"n\":\"3\"}\n{\"n\":\"4\"}\n")
+	assert.NoFileExists(t, rotatedPath(path, 3))
+
+	// the records are appended to an existing file
+	sink, err = NewFileSink(path, 20, 2)
+	require.NoError(t, err)
+	require.NoError(t, sink.Send([]byte(`{"n":"8"}`)))
+	require.NoError(t, sink.Close())
+	assertFileContent(t, path, "{\"n\":\"7\"}\n{\"n\":\"8\"}\n")
+}
+
+func assertFileContent(t *testing.T, path string, expected string) {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(content))
+}
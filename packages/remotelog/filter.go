@@ -0,0 +1,77 @@
+package remotelog
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/iotaledger/hive.go/logger"
+)
+
+// Filter decides which records are sent to the sinks.
+type Filter struct {
+	minLevel        logger.Level
+	loggers         []string
+	excludedLoggers []string
+	types           map[string]struct{}
+}
+
+// NewFilter creates a filter that only lets through log messages of at least the given level. If loggers is not empty,
+// only the messages of these loggers (and their child loggers) are sent, while the messages of the excluded loggers are
+// never sent. If types is not empty, only the records of these types are sent.
+func NewFilter(minLevel logger.Level, loggers, excludedLoggers, types []string) *Filter {
+	f := &Filter{
+		minLevel:        minLevel,
+		loggers:         loggers,
+		excludedLoggers: excludedLoggers,
+		types:           make(map[string]struct{}, len(types)),
+	}
+	for _, recordType := range types {
+		f.types[recordType] = struct{}{}
+	}
+	return f
+}
+
+// AllowsLog returns true if a log message of the given level and logger is sent.
+func (f *Filter) AllowsLog(level logger.Level, name string) bool {
+	if level < f.minLevel {
+		return false
+	}
+	if matchesLogger(f.excludedLoggers, name) {
+		return false
+	}
+	return len(f.loggers) == 0 || matchesLogger(f.loggers, name)
+}
+
+// AllowsType returns true if records of the given type are sent.
+func (f *Filter) AllowsType(recordType string) bool {
+	if len(f.types) == 0 {
+		return true
+	}
+	_, allowed := f.types[recordType]
+	return allowed
+}
+
+// AllowsRecord returns true if the given record is sent, based on the type that is contained in its "type" field.
+func (f *Filter) AllowsRecord(record []byte) bool {
+	if len(f.types) == 0 {
+		return true
+	}
+
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(record, &header); err != nil {
+		return false
+	}
+	return f.AllowsType(header.Type)
+}
+
+// matchesLogger returns true if the logger with the given name is one of the loggers or a child of one of them.
+func matchesLogger(loggers []string, name string) bool {
+	for _, l := range loggers {
+		if name == l || strings.HasPrefix(name, l+".") {
+			return true
+		}
+	}
+	return false
+}
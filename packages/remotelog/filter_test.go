@@ -0,0 +1,27 @@
+package remotelog
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	filter := NewFilter(logger.LevelInfo, []string{"Tangle", "Gossip"}, []string{"Tangle.Booker"}, nil)
+
+	assert.True(t, filter.AllowsLog(logger.LevelInfo, "Tangle"))
+	assert.True(t, filter.AllowsLog(logger.LevelError, "Tangle.Solidifier"))
+	assert.False(t, filter.AllowsLog(logger.LevelDebug, "Tangle"))
+	assert.False(t, filter.AllowsLog(logger.LevelInfo, "Tangle.Booker"))
+	assert.False(t, filter.AllowsLog(logger.LevelInfo, "TangleWidget"))
+	assert.False(t, filter.AllowsLog(logger.LevelInfo, "Autopeering"))
+	assert.True(t, filter.AllowsRecord([]byte(`{"type":"drng"}`)))
+
+	filter = NewFilter(logger.LevelDebug, nil, nil, []string{"log", "sync"})
+
+	assert.True(t, filter.AllowsLog(logger.LevelDebug, "Autopeering"))
+	assert.True(t, filter.AllowsRecord([]byte(`{"type":"sync","nodeId":"abc"}`)))
+	assert.False(t, filter.AllowsRecord([]byte(`{"type":"drng"}`)))
+	assert.False(t, filter.AllowsRecord([]byte(`{}`)))
+}
@@ -0,0 +1,115 @@
+package remotelog
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// DefaultReconnectInterval defines how long a TCP sink waits before it reconnects by default.
+	DefaultReconnectInterval = 5 * time.Second
+	// DefaultFileMaxSize defines the size at which the file of a file sink is rotated by default.
+	DefaultFileMaxSize = 100 * 1024 * 1024
+	// DefaultFileMaxFiles defines how many rotated files of a file sink are kept by default.
+	DefaultFileMaxFiles = 5
+)
+
+var (
+	// ErrUnsupportedSink is returned if a sink is configured with an unknown scheme.
+	ErrUnsupportedSink = errors.New("unsupported sink")
+	// ErrSinkUnavailable is returned if a record is sent to a sink that can currently not be reached.
+	ErrSinkUnavailable = errors.New("sink unavailable")
+	// ErrBufferFull is returned if a record can neither be sent nor buffered.
+	ErrBufferFull = errors.New("buffer full")
+	// ErrSinkClosed is returned if a record is sent to a sink that has been closed.
+	ErrSinkClosed = errors.New("sink closed")
+)
+
+// Sink is the destination of the records of the remote logger. Every record is a single JSON object.
+type Sink interface {
+	// Send sends the record to the sink.
+	Send(record []byte) error
+	// Close closes the sink.
+	Close() error
+}
+
+// NewSink creates the sink that is described by the given URL. The scheme of the URL determines the type of the sink:
+//
+//	udp://host:port             sends every record as a single datagram.
+//	tcp://host:port             sends the records as newline delimited JSON and reconnects if the connection is lost.
+//	file:path or file:///path   appends the records to a file as newline delimited JSON and rotates it.
+func NewSink(sinkURL string, opts ...SinkOption) (Sink, error) {
+	u, err := url.Parse(sinkURL)
+	if err != nil {
+		return nil, errors.Errorf("failed to parse sink %s: %w", sinkURL, err)
+	}
+
+	conf := buildSinkConfig(opts)
+	switch strings.ToLower(u.Scheme) {
+	case "udp":
+		return NewUDPSink(u.Host)
+	case "tcp":
+		return NewTCPSink(u.Host, conf.reconnectInterval), nil
+	case "file":
+		path := u.Path
+		if u.Opaque != "" {
+			path = u.Opaque
+		}
+		return NewFileSink(path, conf.fileMaxSize, conf.fileMaxFiles)
+	default:
+		return nil, errors.Errorf("%s: %w", sinkURL, ErrUnsupportedSink)
+	}
+}
+
+// line returns a copy of the record that is terminated by a newline.
+func line(record []byte) []byte {
+	data := make([]byte, len(record)+1)
+	copy(data, record)
+	data[len(record)] = '\n'
+	return data
+}
+
+// region SinkOption ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SinkOption defines an option for the sinks created by NewSink.
+type SinkOption func(conf *sinkConfig)
+
+type sinkConfig struct {
+	reconnectInterval time.Duration
+	fileMaxSize       int64
+	fileMaxFiles      int
+}
+
+func buildSinkConfig(opts []SinkOption) *sinkConfig {
+	conf := &sinkConfig{
+		reconnectInterval: DefaultReconnectInterval,
+		fileMaxSize:       DefaultFileMaxSize,
+		fileMaxFiles:      DefaultFileMaxFiles,
+	}
+	for _, o := range opts {
+		o(conf)
+	}
+	return conf
+}
+
+// WithReconnectInterval returns a SinkOption that defines how long a TCP sink waits before it reconnects after the
+// connection was lost.
+func WithReconnectInterval(interval time.Duration) SinkOption {
+	return func(conf *sinkConfig) {
+		conf.reconnectInterval = interval
+	}
+}
+
+// WithFileRotation returns a SinkOption that rotates the file of a file sink once it exceeds maxSize bytes and keeps at
+// most maxFiles rotated files.
+func WithFileRotation(maxSize int64, maxFiles int) SinkOption {
+	return func(conf *sinkConfig) {
+		conf.fileMaxSize = maxSize
+		conf.fileMaxFiles = maxFiles
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
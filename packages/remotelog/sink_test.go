@@ -0,0 +1,39 @@
+package remotelog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSink(t *testing.T) {
+	sink, err := NewSink("udp://127.0.0.1:5213")
+	require.NoError(t, err)
+	assert.IsType(t, &UDPSink{}, sink)
+	require.NoError(t, sink.Close())
+
+	sink, err = NewSink("tcp://127.0.0.1:5213")
+	require.NoError(t, err)
+	assert.IsType(t, &TCPSink{}, sink)
+	require.NoError(t, sink.Close())
+
+	path := filepath.Join(t.TempDir(), "remotelog.json")
+	sink, err = NewSink("file://"+filepath.ToSlash(path), WithFileRotation(1024, 1))
+	require.NoError(t, err)
+	require.IsType(t, &FileSink{}, sink)
+	assert.Equal(t, path, sink.(*FileSink).path)
+	assert.EqualValues(t, 1024, sink.(*FileSink).maxSize)
+	require.NoError(t, sink.Close())
+
+	sink, err = NewSink("file:remotelog.json")
+	require.NoError(t, err)
+	assert.Equal(t, "remotelog.json", sink.(*FileSink).path)
+	require.NoError(t, sink.Close())
+	require.NoError(t, os.Remove("remotelog.json"))
+
+	_, err = NewSink("http://127.0.0.1:5213")
+	assert.ErrorIs(t, err, ErrUnsupportedSink)
+}
@@ -0,0 +1,86 @@
+package remotelog
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// tcpWriteTimeout defines how long a record may take to be written to the connection of a TCP sink.
+const tcpWriteTimeout = 5 * time.Second
+
+// TCPSink sends the records as newline delimited JSON over a TCP connection. If the connection is lost, it is
+// reestablished with the next record, but at most once per reconnect interval.
+type TCPSink struct {
+	address           string
+	reconnectInterval time.Duration
+	conn              net.Conn
+	lastDial          time.Time
+	closed            bool
+	mutex             sync.Mutex
+}
+
+// NewTCPSink creates a sink that sends the records to the given address. The connection is established with the first
+// record.
+func NewTCPSink(address string, reconnectInterval time.Duration) *TCPSink {
+	return &TCPSink{
+		address:           address,
+		reconnectInterval: reconnectInterval,
+	}
+}
+
+// Send sends the record to the sink. It returns ErrSinkUnavailable if the sink could not be reached recently.
+func (t *TCPSink) Send(record []byte) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.closed {
+		return ErrSinkClosed
+	}
+	if t.conn == nil {
+		if err := t.dial(); err != nil {
+			return err
+		}
+	}
+
+	err := t.conn.SetWriteDeadline(time.Now().Add(tcpWriteTimeout))
+	if err == nil {
+		_, err = t.conn.Write(line(record))
+	}
+	if err != nil {
+		_ = t.conn.Close()
+		t.conn = nil
+		return errors.Errorf("failed to send record to %s: %w", t.address, err)
+	}
+	return nil
+}
+
+// Close closes the sink.
+func (t *TCPSink) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.closed = true
+	if t.conn == nil {
+		return nil
+	}
+	err := t.conn.Close()
+	t.conn = nil
+	return err
+}
+
+func (t *TCPSink) dial() error {
+	if time.Since(t.lastDial) < t.reconnectInterval {
+		return errors.Errorf("%s: %w", t.address, ErrSinkUnavailable)
+	}
+	t.lastDial = time.Now()
+
+	conn, err := net.DialTimeout("tcp", t.address, t.reconnectInterval)
+	if err != nil {
+		return errors.Errorf("failed to connect to %s (%v): %w", t.address, err, ErrSinkUnavailable)
+	}
+	t.conn = conn
+	return nil
+}
@@ -0,0 +1,58 @@
+package remotelog
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCPSinkReconnect(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+
+	sink := NewTCPSink(address, 50*time.Millisecond)
+	defer sink.Close()
+
+	received := make(chan string, 10)
+	connections := make(chan net.Conn, 1)
+	accept := func(listener net.Listener) {
+		conn, acceptErr := listener.Accept()
+		if acceptErr != nil {
+			return
+		}
+		connections <- conn
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			received <- scanner.Text()
+		}
+	}
+	go accept(listener)
+
+	require.NoError(t, sink.Send([]byte(`{"n":1}`)))
+	assert.Equal(t, `{"n":1}`, <-received)
+
+	// the sink is unavailable while the server is down
+	require.NoError(t, listener.Close())
+	require.NoError(t, (<-connections).Close())
+	require.Eventually(t, func() bool {
+		return sink.Send([]byte(`{"n":2}`)) != nil
+	}, time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, sink.Send([]byte(`{"n":3}`)), ErrSinkUnavailable)
+
+	// the sink reconnects once the server is up again
+	listener, err = net.Listen("tcp", address)
+	require.NoError(t, err)
+	defer listener.Close()
+	go accept(listener)
+
+	require.Eventually(t, func() bool {
+		return sink.Send([]byte(`{"n":4}`)) == nil
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, `{"n":4}`, <-received)
+	require.NoError(t, (<-connections).Close())
+}
@@ -0,0 +1,33 @@
+package remotelog
+
+import (
+	"net"
+
+	"github.com/cockroachdb/errors"
+)
+
+// UDPSink sends every record as a single UDP datagram.
+type UDPSink struct {
+	conn net.Conn
+}
+
+// NewUDPSink creates a sink that sends the records to the given address.
+func NewUDPSink(address string) (*UDPSink, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, errors.Errorf("could not create UDP socket to '%s': %w", address, err)
+	}
+
+	return &UDPSink{conn: conn}, nil
+}
+
+// Send sends the record to the sink.
+func (u *UDPSink) Send(record []byte) error {
+	_, err := u.conn.Write(record)
+	return err
+}
+
+// Close closes the sink.
+func (u *UDPSink) Close() error {
+	return u.conn.Close()
+}
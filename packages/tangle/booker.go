@@ -143,21 +143,9 @@ func (b *Booker) MessageBranchID(messageID MessageID) (branchID ledgerstate.Bran
 	}
 
 	if !b.tangle.Storage.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
-		if branchID = messageMetadata.BranchID(); branchID != ledgerstate.UndefinedBranchID {
-			return
-		}
-
-		structureDetails := messageMetadata.StructureDetails()
-		if structureDetails == nil {
-			err = errors.Errorf("failed to retrieve StructureDetails of %s: %w", messageID, cerrors.ErrFatal)
-			return
-		}
-		if structureDetails.PastMarkers.Size() != 1 {
-			err = errors.Errorf("BranchID of %s should have been mapped in the MessageMetadata (multiple PastMarkers): %w", messageID, cerrors.ErrFatal)
-			return
-		}
-
-		branchID = b.MarkersManager.BranchID(structureDetails.PastMarkers.Marker())
+		branchID, err = b.mappedBranchID(messageID, messageMetadata.BranchID(), messageMetadata.StructureDetails())
+	}) && !b.tangle.Storage.PrunedMessage(messageID).Consume(func(prunedMessage *PrunedMessage) {
+		branchID, err = b.mappedBranchID(messageID, prunedMessage.BranchID(), prunedMessage.StructureDetails())
 	}) {
 		err = errors.Errorf("failed to load MessageMetadata of %s: %w", messageID, cerrors.ErrFatal)
 		return
@@ -166,6 +154,23 @@ func (b *Booker) MessageBranchID(messageID MessageID) (branchID ledgerstate.Bran
 	return
 }
 
+// mappedBranchID returns the BranchID of a Message given the BranchID that was mapped in its metadata and its
+// StructureDetails.
+func (b *Booker) mappedBranchID(messageID MessageID, branchID ledgerstate.BranchID, structureDetails *markers.StructureDetails) (ledgerstate.BranchID, error) {
+	if branchID != ledgerstate.UndefinedBranchID {
+		return branchID, nil
+	}
+
+	if structureDetails == nil {
+		return ledgerstate.UndefinedBranchID, errors.Errorf("failed to retrieve StructureDetails of %s: %w", messageID, cerrors.ErrFatal)
+	}
+	if structureDetails.PastMarkers.Size() != 1 {
+		return ledgerstate.UndefinedBranchID, errors.Errorf("BranchID of %s should have been mapped in the MessageMetadata (multiple PastMarkers): %w", messageID, cerrors.ErrFatal)
+	}
+
+	return b.MarkersManager.BranchID(structureDetails.PastMarkers.Marker()), nil
+}
+
 // Shutdown shuts down the Booker and persists its state.
 func (b *Booker) Shutdown() {
 	b.MarkersManager.Shutdown()
@@ -196,6 +201,12 @@ func (b *Booker) parentsBranchIDs(message *Message) (branchIDs ledgerstate.Branc
 			}
 
 			branchIDs[b.MarkersManager.BranchID(structureDetailsOfMessage.PastMarkers.Marker())] = types.Void
+		}) && !b.tangle.Storage.PrunedMessage(messageID).Consume(func(prunedMessage *PrunedMessage) {
+			branchID, err := b.mappedBranchID(messageID, prunedMessage.BranchID(), prunedMessage.StructureDetails())
+			if err != nil {
+				panic(fmt.Errorf("tried to retrieve BranchID from PrunedMessage with %s: %w", messageID, err))
+			}
+			branchIDs[branchID] = types.Void
 		}) {
 			panic(fmt.Errorf("failed to load MessageMetadata with %s", messageID))
 		}
@@ -206,19 +217,23 @@ func (b *Booker) parentsBranchIDs(message *Message) (branchIDs ledgerstate.Branc
 			return
 		}
 
+		var transactionID *ledgerstate.TransactionID
 		if !b.tangle.Storage.Message(parentMessageID).Consume(func(message *Message) {
 			if payload := message.Payload(); payload != nil && payload.Type() == ledgerstate.TransactionType {
-				transactionID := payload.(*ledgerstate.Transaction).ID()
-
-				if !b.tangle.LedgerState.UTXODAG.CachedTransactionMetadata(transactionID).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
-					branchIDs[transactionMetadata.BranchID()] = types.Void
-				}) {
-					panic(fmt.Errorf("failed to load TransactionMetadata with %s", transactionID))
-				}
+				id := payload.(*ledgerstate.Transaction).ID()
+				transactionID = &id
 			}
+		}) && !b.tangle.Storage.PrunedMessage(parentMessageID).Consume(func(prunedMessage *PrunedMessage) {
+			transactionID = prunedMessage.TransactionID()
 		}) {
 			panic(fmt.Errorf("failed to load MessageMetadata with %s", parentMessageID))
 		}
+
+		if transactionID != nil && !b.tangle.LedgerState.UTXODAG.CachedTransactionMetadata(*transactionID).Consume(func(transactionMetadata *ledgerstate.TransactionMetadata) {
+			branchIDs[transactionMetadata.BranchID()] = types.Void
+		}) {
+			panic(fmt.Errorf("failed to load TransactionMetadata with %s", *transactionID))
+		}
 	})
 
 	return branchIDs
@@ -537,6 +552,8 @@ func (m *MarkersManager) structureDetailsOfStrongParents(message *Message) (stru
 	message.ForEachStrongParent(func(parentMessageID MessageID) {
 		if !m.tangle.Storage.MessageMetadata(parentMessageID).Consume(func(messageMetadata *MessageMetadata) {
 			structureDetails = append(structureDetails, messageMetadata.StructureDetails())
+		}) && !m.tangle.Storage.PrunedMessage(parentMessageID).Consume(func(prunedMessage *PrunedMessage) {
+			structureDetails = append(structureDetails, prunedMessage.StructureDetails())
 		}) {
 			panic(fmt.Errorf("failed to load MessageMetadata of Message with %s", parentMessageID))
 		}
@@ -50,6 +50,15 @@ func (o *ConsensusManager) Shutdown() {
 	o.tangle.Options.ConsensusMechanism.Shutdown()
 }
 
+// Flush persists the state of the component without shutting it down.
+func (o *ConsensusManager) Flush() {
+	if o.tangle.Options.ConsensusMechanism == nil {
+		return
+	}
+
+	o.tangle.Options.ConsensusMechanism.Flush()
+}
+
 // PayloadLiked returns the opinion of the given MessageID.
 func (o *ConsensusManager) PayloadLiked(messageID MessageID) (liked bool) {
 	o.tangle.Storage.Message(messageID).Consume(func(message *Message) {
@@ -120,6 +129,9 @@ type ConsensusMechanism interface {
 	// Shutdown shuts down the ConsensusMechanism and persists its state.
 	Shutdown()
 
+	// Flush persists the state of the ConsensusMechanism without shutting it down.
+	Flush()
+
 	// SetTransactionLiked sets the transaction like status.
 	SetTransactionLiked(transactionID ledgerstate.TransactionID, liked bool) (modified bool)
 }
@@ -81,7 +81,9 @@ func (s *FIFOScheduler) mainLoop() {
 	for {
 		select {
 		case messageID := <-s.inbox:
-			s.scheduleMessage(messageID)
+			s.tangle.ProcessingGate.RunPending(func() {
+				s.scheduleMessage(messageID)
+			})
 		case <-s.shutdownSignal:
 			if len(s.inbox) == 0 {
 				return
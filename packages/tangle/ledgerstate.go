@@ -28,10 +28,16 @@ func NewLedgerState(tangle *Tangle) (ledgerState *LedgerState) {
 	return &LedgerState{
 		tangle:    tangle,
 		BranchDAG: branchDAG,
-		UTXODAG:   ledgerstate.NewUTXODAG(tangle.Options.Store, tangle.Options.CacheTimeProvider, branchDAG),
+		UTXODAG:   ledgerstate.NewUTXODAG(tangle.Options.Store, tangle.Options.CacheTimeProvider, branchDAG, ledgerstate.WithAddressHistory(tangle.Options.AddressHistory)),
 	}
 }
 
+// Flush persists the cached objects of the LedgerState without shutting it down.
+func (l *LedgerState) Flush() {
+	l.UTXODAG.Flush()
+	l.BranchDAG.Flush()
+}
+
 // Shutdown shuts down the LedgerState and persists its state.
 func (l *LedgerState) Shutdown() {
 	l.UTXODAG.Shutdown()
@@ -191,25 +197,48 @@ func (l *LedgerState) LoadSnapshot(snapshot *ledgerstate.Snapshot) (err error) {
 
 // SnapshotUTXO returns the UTXO snapshot, which is a list of transactions with unspent outputs.
 func (l *LedgerState) SnapshotUTXO() (snapshot *ledgerstate.Snapshot) {
-	// The following parameter should be larger than the max allowed timestamp variation, and the required time for confirmation.
-	// We can snapshot this far in the past, since global snapshots dont occur frequent and it is ok to ignore the last few minutes.
-	minAge := 120 * time.Second
 	snapshot = &ledgerstate.Snapshot{
 		Transactions: make(map[ledgerstate.TransactionID]ledgerstate.Record),
 	}
 
+	l.ForEachSnapshotRecord(func(transactionID ledgerstate.TransactionID, record ledgerstate.Record) bool {
+		snapshot.Transactions[transactionID] = record
+		return true
+	})
+
+	// TODO ??? due to possible race conditions we could add a check for the consistency of the UTXO snapshot
+
+	return snapshot
+}
+
+// WriteSnapshot streams the transactions with unspent outputs to the given SnapshotWriter without collecting them in
+// memory first.
+func (l *LedgerState) WriteSnapshot(writer *ledgerstate.SnapshotWriter) (err error) {
+	l.ForEachSnapshotRecord(func(transactionID ledgerstate.TransactionID, record ledgerstate.Record) bool {
+		err = writer.WriteTransaction(transactionID, record)
+		return err == nil
+	})
+
+	return err
+}
+
+// ForEachSnapshotRecord iterates through the confirmed transactions that have at least one unspent output and passes
+// them (as a snapshot Record) to the given consumer until it returns false.
+func (l *LedgerState) ForEachSnapshotRecord(consumer func(transactionID ledgerstate.TransactionID, record ledgerstate.Record) bool) {
+	// The following parameter should be larger than the max allowed timestamp variation, and the required time for confirmation.
+	// We can snapshot this far in the past, since global snapshots dont occur frequent and it is ok to ignore the last few minutes.
+	minAge := 120 * time.Second
 	startSnapshot := time.Now()
-	copyLedgerState := l.Transactions() // consider that this may take quite some time
 
-	for _, transaction := range copyLedgerState {
+	l.UTXODAG.ForEachTransaction(func(transaction *ledgerstate.Transaction) bool {
 		// skip unconfirmed transactions
 		inclusionState, err := l.TransactionInclusionState(transaction.ID())
 		if err != nil || inclusionState != ledgerstate.Confirmed {
-			continue
+			return true
 		}
 		// skip transactions that are too recent before startSnapshot
 		if startSnapshot.Sub(transaction.Essence().Timestamp()) < minAge {
-			continue
+			return true
 		}
 		unspentOutputs := make([]bool, len(transaction.Essence().Outputs()))
 		includeTransaction := false
@@ -218,29 +247,33 @@ func (l *LedgerState) SnapshotUTXO() (snapshot *ledgerstate.Snapshot) {
 				if outputMetadata.ConfirmedConsumer() == ledgerstate.GenesisTransactionID { // no consumer yet
 					unspentOutputs[i] = true
 					includeTransaction = true
-				} else {
-					tx, exist := copyLedgerState[outputMetadata.ConfirmedConsumer()]
-					// ignore consumers that are not confirmed long enough or even in the future.
-					if !exist || startSnapshot.Sub(tx.Essence().Timestamp()) < minAge {
+					return
+				}
+
+				// ignore consumers that are not confirmed long enough or even in the future.
+				consumerConfirmedLongEnough := l.UTXODAG.CachedTransaction(outputMetadata.ConfirmedConsumer()).Consume(func(consumer *ledgerstate.Transaction) {
+					if startSnapshot.Sub(consumer.Essence().Timestamp()) < minAge {
 						unspentOutputs[i] = true
 						includeTransaction = true
 					}
+				})
+				if !consumerConfirmedLongEnough {
+					unspentOutputs[i] = true
+					includeTransaction = true
 				}
 			})
 		}
 		// include only transactions with at least one unspent output
-		if includeTransaction {
-			snapshot.Transactions[transaction.ID()] = ledgerstate.Record{
-				Essence:        transaction.Essence(),
-				UnlockBlocks:   transaction.UnlockBlocks(),
-				UnspentOutputs: unspentOutputs,
-			}
+		if !includeTransaction {
+			return true
 		}
-	}
-
-	// TODO ??? due to possible race conditions we could add a check for the consistency of the UTXO snapshot
 
-	return snapshot
+		return consumer(transaction.ID(), ledgerstate.Record{
+			Essence:        transaction.Essence(),
+			UnlockBlocks:   transaction.UnlockBlocks(),
+			UnspentOutputs: unspentOutputs,
+		})
+	})
 }
 
 // ReturnTransaction returns a specific transaction.
@@ -271,6 +304,12 @@ func (l *LedgerState) CachedOutputsOnAddress(address ledgerstate.Address) (cache
 	return
 }
 
+// AddressHistory retrieves up to limit entries of the history of an address that follow the cursor in chronological
+// order, together with the cursor of the next page (if the address history is enabled).
+func (l *LedgerState) AddressHistory(address ledgerstate.Address, cursor []byte, limit int) (addressHistoryEntries ledgerstate.AddressHistoryEntries, nextCursor []byte, err error) {
+	return l.UTXODAG.AddressHistory(address, cursor, limit)
+}
+
 // CheckTransaction contains fast checks that have to be performed before booking a Transaction.
 func (l *LedgerState) CheckTransaction(transaction *ledgerstate.Transaction) (err error) {
 	return l.UTXODAG.CheckTransaction(transaction)
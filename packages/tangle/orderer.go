@@ -66,36 +66,21 @@ func (o *Orderer) run() {
 		for {
 			select {
 			case bookedMessage := <-o.bookedMessageChan:
-				if _, exists := o.parentsMap[bookedMessage]; !exists {
-					continue
-				}
-
-				for _, childID := range o.parentsMap[bookedMessage] {
-					o.tryToSchedule(childID)
-				}
-				delete(o.parentsMap, bookedMessage)
+				o.tangle.ProcessingGate.RunPending(func() {
+					o.scheduleChildren(bookedMessage)
+				})
 			default:
 			}
 
 			select {
 			case bookedMessage := <-o.bookedMessageChan:
-				if _, exists := o.parentsMap[bookedMessage]; !exists {
-					continue
-				}
-
-				for _, childID := range o.parentsMap[bookedMessage] {
-					o.tryToSchedule(childID)
-				}
-				delete(o.parentsMap, bookedMessage)
+				o.tangle.ProcessingGate.RunPending(func() {
+					o.scheduleChildren(bookedMessage)
+				})
 			case messageID := <-o.inbox:
-				parentsToBook := o.tryToSchedule(messageID)
-
-				for _, parent := range parentsToBook {
-					if _, exists := o.parentsMap[parent]; !exists {
-						o.parentsMap[parent] = make([]MessageID, 0)
-					}
-					o.parentsMap[parent] = append(o.parentsMap[parent], messageID)
-				}
+				o.tangle.ProcessingGate.RunPending(func() {
+					o.schedule(messageID)
+				})
 			case <-o.shutdownSignal:
 				if len(o.inbox) == 0 {
 					return
@@ -105,6 +90,26 @@ func (o *Orderer) run() {
 	}()
 }
 
+// schedule tries to schedule the given message or waits for its parents to be booked.
+func (o *Orderer) schedule(messageID MessageID) {
+	for _, parent := range o.tryToSchedule(messageID) {
+		o.parentsMap[parent] = append(o.parentsMap[parent], messageID)
+	}
+}
+
+// scheduleChildren tries to schedule the messages that waited for the given message to be booked.
+func (o *Orderer) scheduleChildren(bookedMessage MessageID) {
+	children, exists := o.parentsMap[bookedMessage]
+	if !exists {
+		return
+	}
+	delete(o.parentsMap, bookedMessage)
+
+	for _, childID := range children {
+		o.tryToSchedule(childID)
+	}
+}
+
 func (o *Orderer) parentsToBook(messageID MessageID) (parents MessageIDs) {
 	o.tangle.Storage.Message(messageID).Consume(func(message *Message) {
 		message.ForEachParent(func(parent Parent) {
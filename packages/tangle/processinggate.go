@@ -0,0 +1,110 @@
+package tangle
+
+import (
+	"sync"
+)
+
+// region ProcessingGate ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ProcessingGate allows to pause the processing of the Tangle, i.e. to persist a consistent state of all of its
+// storages before a backup of the database is taken.
+//
+// The components that create new work (i.e. the storing of received messages, the scheduling of buffered messages,
+// the pruning and timed consensus decisions) run it through Run. The components that process work which was already
+// handed over to them (i.e. the Orderer) run it through RunPending. Pausing first blocks new work and lets the pending
+// work drain, before it blocks the pending work as well. This way a unit of work never waits for a component that was
+// paused before it finished.
+type ProcessingGate struct {
+	state       processingGateState
+	activeUnits int
+	mutex       sync.Mutex
+	cond        *sync.Cond
+}
+
+// NewProcessingGate is the constructor of the ProcessingGate.
+func NewProcessingGate() (processingGate *ProcessingGate) {
+	processingGate = &ProcessingGate{}
+	processingGate.cond = sync.NewCond(&processingGate.mutex)
+
+	return
+}
+
+// Run executes a unit of new work and waits before it starts while the processing is paused. It must not be called
+// from within another unit of work.
+func (p *ProcessingGate) Run(unitOfWork func()) {
+	p.enter(func() bool { return p.state == processingGateOpen })
+	defer p.leave()
+
+	unitOfWork()
+}
+
+// RunPending executes a unit of work that was already handed over to the calling component. It only waits before it
+// starts while the processing is paused completely, so the pending work can drain while a pause is in progress.
+func (p *ProcessingGate) RunPending(unitOfWork func()) {
+	p.enter(func() bool { return p.state != processingGateClosed })
+	defer p.leave()
+
+	unitOfWork()
+}
+
+// Pause blocks until all running units of work have finished and prevents new ones from starting until Resume is
+// called.
+func (p *ProcessingGate) Pause() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for p.state != processingGateOpen {
+		p.cond.Wait()
+	}
+
+	p.state = processingGateDraining
+	for p.activeUnits != 0 {
+		p.cond.Wait()
+	}
+	p.state = processingGateClosed
+}
+
+// Resume continues the processing after it was paused.
+func (p *ProcessingGate) Resume() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.state = processingGateOpen
+	p.cond.Broadcast()
+}
+
+func (p *ProcessingGate) enter(mayEnter func() bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for !mayEnter() {
+		p.cond.Wait()
+	}
+	p.activeUnits++
+}
+
+func (p *ProcessingGate) leave() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.activeUnits--
+	if p.activeUnits == 0 {
+		p.cond.Broadcast()
+	}
+}
+
+// processingGateState is the state of the ProcessingGate.
+type processingGateState uint8
+
+const (
+	// processingGateOpen is the state of a ProcessingGate that runs all units of work.
+	processingGateOpen processingGateState = iota
+
+	// processingGateDraining is the state of a ProcessingGate that only runs the pending units of work.
+	processingGateDraining
+
+	// processingGateClosed is the state of a ProcessingGate that does not run any units of work.
+	processingGateClosed
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,48 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func TestProcessingGate(t *testing.T) {
+	processingGate := NewProcessingGate()
+
+	// a running unit of work hands over work to a pending unit while the gate is being paused
+	pendingWork := make(chan struct{})
+	unitStarted := make(chan struct{})
+	go processingGate.Run(func() {
+		close(unitStarted)
+		time.Sleep(100 * time.Millisecond)
+		pendingWork <- struct{}{}
+	})
+	go func() {
+		<-pendingWork
+		processingGate.RunPending(func() {})
+	}()
+	<-unitStarted
+
+	paused := make(chan struct{})
+	go func() {
+		processingGate.Pause()
+		close(paused)
+	}()
+	select {
+	case <-paused:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the pending work did not drain while the gate was paused")
+	}
+
+	// new units of work wait until the gate is resumed
+	var executed atomic.Bool
+	go processingGate.Run(func() { executed.Store(true) })
+	go processingGate.RunPending(func() { executed.Store(true) })
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, executed.Load())
+
+	processingGate.Resume()
+	assert.Eventually(t, executed.Load, 5*time.Second, 10*time.Millisecond)
+}
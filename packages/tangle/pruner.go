@@ -0,0 +1,242 @@
+package tangle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/timeutil"
+)
+
+const (
+	prunedUntilKey = "PrunedUntil"
+
+	// MinPruningHorizon is the smallest allowed pruning horizon. Messages younger than this can still be referenced by
+	// new Messages (see maxParentsTimeDifference), so they must never be pruned.
+	MinPruningHorizon = maxParentsTimeDifference
+
+	// DefaultPruningInterval is the default interval in which the Pruner checks for Messages that can be pruned.
+	DefaultPruningInterval = 10 * time.Minute
+
+	// DefaultPruningBatchSize is the default number of Messages that are pruned in a single batch.
+	DefaultPruningBatchSize = 1000
+)
+
+// region PruningParams ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PruningParams represents the parameters for the Pruner.
+type PruningParams struct {
+	// Enabled defines whether the Pruner removes old Messages in the background.
+	Enabled bool
+
+	// Horizon defines how far behind the TangleTime a Message has to be to get pruned.
+	Horizon time.Duration
+
+	// Interval defines the interval in which the Pruner checks for Messages that can be pruned.
+	Interval time.Duration
+
+	// BatchSize defines the number of Messages that are pruned at once before checking for a shutdown.
+	BatchSize int
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Pruner ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Pruner is a Tangle component that incrementally removes Messages (and all the objects that are related to them) from
+// the Storage, once they are older than the configured horizon behind the TangleTime. Every removed Message is replaced
+// by a PrunedMessage, so that children that arrive later are still processed without requesting it again. The ledger
+// state is not touched, except for the history of the Addresses which is pruned using the same threshold.
+type Pruner struct {
+	Events *PrunerEvents
+
+	tangle           *Tangle
+	prunedUntil      time.Time
+	prunedUntilMutex sync.RWMutex
+	pruningMutex     sync.Mutex
+
+	shutdownSignal chan struct{}
+	shutdownOnce   sync.Once
+}
+
+// NewPruner is the constructor of the Pruner.
+func NewPruner(tangle *Tangle) (pruner *Pruner) {
+	pruner = &Pruner{
+		Events: &PrunerEvents{
+			MessagePruned:    events.NewEvent(MessageIDCaller),
+			PruningCompleted: events.NewEvent(pruningCompletedEventCaller),
+		},
+		tangle:         tangle,
+		shutdownSignal: make(chan struct{}),
+	}
+
+	marshaledPrunedUntil, err := tangle.Options.Store.Get(kvstore.Key(prunedUntilKey))
+	if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+		panic(err)
+	}
+	if marshaledPrunedUntil != nil {
+		if err = pruner.prunedUntil.UnmarshalBinary(marshaledPrunedUntil); err != nil {
+			panic(err)
+		}
+	}
+
+	return
+}
+
+// Setup sets up the behavior of the component by making it attach to the relevant events of other components.
+func (p *Pruner) Setup() {}
+
+// Start starts the background pruning of the Pruner (if it is enabled).
+func (p *Pruner) Start() {
+	if !p.tangle.Options.PruningParams.Enabled {
+		return
+	}
+
+	timeutil.NewTicker(func() {
+		p.Prune(p.tangle.TimeManager.Time().Add(-p.horizon()))
+	}, p.interval(), p.shutdownSignal)
+}
+
+// PrunedUntil returns the highest threshold that the Pruner has ever used, i.e. Messages issued before this time might
+// have been removed from the Storage.
+func (p *Pruner) PrunedUntil() time.Time {
+	p.prunedUntilMutex.RLock()
+	defer p.prunedUntilMutex.RUnlock()
+
+	return p.prunedUntil
+}
+
+// Prune removes all Messages that were issued before the given threshold from the Storage. It collects the candidates
+// from the issuing time index of the Storage, removes them in batches and returns early if the Pruner is shut down.
+func (p *Pruner) Prune(threshold time.Time) (prunedMessages int) {
+	p.pruningMutex.Lock()
+	defer p.pruningMutex.Unlock()
+
+	startTime := time.Now()
+	p.updatePrunedUntil(threshold)
+
+	candidates := p.tangle.Storage.MessageIDsIssuedBefore(threshold)
+	batchSize := p.batchSize()
+	for start := 0; start < len(candidates); start += batchSize {
+		end := start + batchSize
+		if end > len(candidates) {
+			end = len(candidates)
+		}
+		p.tangle.ProcessingGate.Run(func() {
+			for _, messageID := range candidates[start:end] {
+				if p.tangle.Storage.PruneMessage(messageID) {
+					p.Events.MessagePruned.Trigger(messageID)
+					prunedMessages++
+				}
+			}
+		})
+
+		select {
+		case <-p.shutdownSignal:
+			return
+		default:
+		}
+	}
+
+	if p.tangle.LedgerState.UTXODAG.AddressHistoryEnabled() {
+		p.tangle.LedgerState.UTXODAG.PruneAddressHistory(threshold)
+	}
+
+	p.Events.PruningCompleted.Trigger(&PruningCompletedEvent{
+		Threshold:      threshold,
+		PrunedMessages: prunedMessages,
+		Duration:       time.Since(startTime),
+	})
+
+	return
+}
+
+// Shutdown shuts down the Pruner and persists its state.
+func (p *Pruner) Shutdown() {
+	p.shutdownOnce.Do(func() {
+		close(p.shutdownSignal)
+	})
+
+	p.pruningMutex.Lock()
+	defer p.pruningMutex.Unlock()
+
+	p.persistPrunedUntil()
+}
+
+// updatePrunedUntil raises the pruning threshold to the given time and persists it.
+func (p *Pruner) updatePrunedUntil(threshold time.Time) {
+	p.prunedUntilMutex.Lock()
+	if !threshold.After(p.prunedUntil) {
+		p.prunedUntilMutex.Unlock()
+		return
+	}
+	p.prunedUntil = threshold
+	p.prunedUntilMutex.Unlock()
+
+	p.persistPrunedUntil()
+}
+
+func (p *Pruner) persistPrunedUntil() {
+	marshaledPrunedUntil, err := p.PrunedUntil().MarshalBinary()
+	if err != nil {
+		p.tangle.Events.Error.Trigger(errors.Errorf("failed to marshal pruning threshold: %w", err))
+		return
+	}
+
+	if err = p.tangle.Options.Store.Set(kvstore.Key(prunedUntilKey), marshaledPrunedUntil); err != nil {
+		p.tangle.Events.Error.Trigger(errors.Errorf("failed to persist pruning threshold (%v): %w", err, cerrors.ErrFatal))
+	}
+}
+
+func (p *Pruner) horizon() time.Duration {
+	if p.tangle.Options.PruningParams.Horizon < MinPruningHorizon {
+		return MinPruningHorizon
+	}
+
+	return p.tangle.Options.PruningParams.Horizon
+}
+
+func (p *Pruner) interval() time.Duration {
+	if p.tangle.Options.PruningParams.Interval <= 0 {
+		return DefaultPruningInterval
+	}
+
+	return p.tangle.Options.PruningParams.Interval
+}
+
+func (p *Pruner) batchSize() int {
+	if p.tangle.Options.PruningParams.BatchSize <= 0 {
+		return DefaultPruningBatchSize
+	}
+
+	return p.tangle.Options.PruningParams.BatchSize
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PrunerEvents /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PrunerEvents represents events happening in the Pruner.
+type PrunerEvents struct {
+	// MessagePruned is triggered when a Message was removed from the Storage by the Pruner.
+	MessagePruned *events.Event
+
+	// PruningCompleted is triggered when the Pruner removed all Messages older than the current threshold.
+	PruningCompleted *events.Event
+}
+
+// PruningCompletedEvent represents the parameters of PruningCompleted event.
+type PruningCompletedEvent struct {
+	Threshold      time.Time
+	PrunedMessages int
+	Duration       time.Duration
+}
+
+func pruningCompletedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(*PruningCompletedEvent))(params[0].(*PruningCompletedEvent))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
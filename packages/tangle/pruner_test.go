@@ -0,0 +1,109 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+func TestPruner_Prune(t *testing.T) {
+	tangle := newTestTangle()
+	defer tangle.Shutdown()
+	// prune every candidate in its own batch
+	tangle.Options.PruningParams.BatchSize = 1
+
+	issuer := identity.GenerateIdentity().PublicKey()
+	now := time.Now()
+
+	oldMessage := newMessageWithParentsAndTime(issuer, MessageIDs{EmptyMessageID}, now.Add(-2*time.Hour))
+	oldApprover := newMessageWithParentsAndTime(issuer, MessageIDs{oldMessage.ID()}, now.Add(-time.Hour-30*time.Minute))
+	recentMessage := newMessageWithParentsAndTime(issuer, MessageIDs{oldApprover.ID()}, now.Add(-time.Hour-10*time.Minute))
+
+	for _, message := range []*Message{oldMessage, oldApprover, recentMessage} {
+		tangle.Storage.StoreMessage(message)
+		tangle.Storage.MessageMetadata(message.ID()).Consume(func(messageMetadata *MessageMetadata) {
+			messageMetadata.SetSolid(true)
+			messageMetadata.SetBooked(true)
+			messageMetadata.SetBranchID(ledgerstate.MasterBranchID)
+			messageMetadata.SetStructureDetails(&markers.StructureDetails{
+				PastMarkers:   markers.NewMarkers(),
+				FutureMarkers: markers.NewMarkers(),
+			})
+		})
+	}
+
+	prunedMessages := make(map[MessageID]bool)
+	tangle.Pruner.Events.MessagePruned.Attach(events.NewClosure(func(messageID MessageID) {
+		prunedMessages[messageID] = true
+	}))
+
+	var completedEvent *PruningCompletedEvent
+	tangle.Pruner.Events.PruningCompleted.Attach(events.NewClosure(func(ev *PruningCompletedEvent) {
+		completedEvent = ev
+	}))
+
+	threshold := now.Add(-time.Hour - 20*time.Minute)
+	assert.Equal(t, 2, tangle.Pruner.Prune(threshold))
+	assert.Equal(t, map[MessageID]bool{oldMessage.ID(): true, oldApprover.ID(): true}, prunedMessages)
+	assert.Equal(t, 2, completedEvent.PrunedMessages)
+	assert.True(t, tangle.Pruner.PrunedUntil().Equal(threshold))
+
+	assert.False(t, tangle.Storage.Message(oldMessage.ID()).Consume(func(*Message) {}))
+	assert.False(t, tangle.Storage.MessageMetadata(oldApprover.ID()).Consume(func(*MessageMetadata) {}))
+	assert.False(t, tangle.Storage.Approvers(oldMessage.ID()).Consume(func(*Approver) {}))
+	assert.False(t, tangle.Storage.Approvers(oldApprover.ID()).Consume(func(*Approver) {}))
+	assert.True(t, tangle.Storage.Message(recentMessage.ID()).Consume(func(*Message) {}))
+
+	// the parent of the remaining message has been replaced by a PrunedMessage, so it is not requested anymore
+	assert.True(t, tangle.Storage.PrunedMessage(oldApprover.ID()).Consume(func(prunedMessage *PrunedMessage) {
+		assert.True(t, prunedMessage.IssuingTime().Equal(oldApprover.IssuingTime()))
+		assert.Equal(t, ledgerstate.MasterBranchID, prunedMessage.BranchID())
+	}))
+	assert.True(t, tangle.Solidifier.isMessageMarkedAsSolid(oldApprover.ID()))
+	assert.True(t, tangle.Solidifier.isParentMessageValid(oldApprover.ID(), recentMessage))
+	assert.Empty(t, tangle.Storage.MissingMessages())
+
+	// the BranchID and StructureDetails of pruned parents are still available for booking
+	branchID, err := tangle.Booker.MessageBranchID(oldApprover.ID())
+	require.NoError(t, err)
+	assert.Equal(t, ledgerstate.MasterBranchID, branchID)
+	assert.Len(t, tangle.Booker.MarkersManager.structureDetailsOfStrongParents(recentMessage), 1)
+
+	// the timestamp of pruned parents is still checked
+	assert.False(t, tangle.Solidifier.isParentMessageValid(oldApprover.ID(), newMessageWithParentsAndTime(issuer, MessageIDs{oldApprover.ID()}, now)))
+
+	// pruned messages are not stored again
+	tangle.Storage.StoreMessage(oldApprover)
+	assert.False(t, tangle.Storage.Message(oldApprover.ID()).Consume(func(*Message) {}))
+
+	// unknown parents are still requested, even if the child claims to be old
+	unknownParent := newMessageWithParentsAndTime(issuer, MessageIDs{EmptyMessageID}, now.Add(-3*time.Hour))
+	assert.False(t, tangle.Solidifier.isMessageMarkedAsSolid(unknownParent.ID()))
+	assert.Equal(t, []MessageID{unknownParent.ID()}, tangle.Storage.MissingMessages())
+
+	// the issuing time index only contains the remaining message
+	assert.Empty(t, tangle.Storage.MessageIDsIssuedBefore(threshold))
+	assert.Equal(t, MessageIDs{recentMessage.ID()}, tangle.Storage.MessageIDsIssuedBefore(now))
+}
+
+func newMessageWithParentsAndTime(issuerPublicKey ed25519.PublicKey, parents MessageIDs, issuingTime time.Time) *Message {
+	return NewMessage(
+		parents,
+		[]MessageID{},
+		issuingTime,
+		issuerPublicKey,
+		0,
+		payload.NewGenericDataPayload([]byte("")),
+		0,
+		ed25519.Signature{},
+	)
+}
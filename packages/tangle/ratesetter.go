@@ -31,6 +31,8 @@ var (
 	ErrInvalidIssuer = errors.New("message not issued by local node")
 	// ErrStopped is returned when a message is passed to a stopped rate setter.
 	ErrStopped = errors.New("rate setter stopped")
+	// ErrLocalQueueFull is returned when the local issuing queue can not take any further messages.
+	ErrLocalQueueFull = errors.New("local issuing queue is full")
 )
 
 // Initial is the rate in bytes per second
@@ -40,6 +42,7 @@ var Initial = 20000.0
 
 // RateSetterParams represents the parameters for RateSetter.
 type RateSetterParams struct {
+	Enabled bool
 	Initial *float64
 }
 
@@ -62,9 +65,14 @@ type RateSetter struct {
 
 // NewRateSetter returns a new RateSetter.
 func NewRateSetter(tangle *Tangle) *RateSetter {
+	if tangle.Options.RateSetterParams.Initial != nil {
+		Initial = *tangle.Options.RateSetterParams.Initial
+	}
+
 	rateSetter := &RateSetter{
 		tangle: tangle,
 		Events: &RateSetterEvents{
+			MessageIssued:    events.NewEvent(MessageIDCaller),
 			MessageDiscarded: events.NewEvent(MessageIDCaller),
 		},
 		self:           tangle.Options.Identity.ID(),
@@ -75,9 +83,6 @@ func NewRateSetter(tangle *Tangle) *RateSetter {
 		shutdownSignal: make(chan struct{}),
 		shutdownOnce:   sync.Once{},
 	}
-	if tangle.Options.RateSetterParams.Initial != nil {
-		Initial = *tangle.Options.RateSetterParams.Initial
-	}
 
 	go rateSetter.issuerLoop()
 	return rateSetter
@@ -97,6 +102,11 @@ func (r *RateSetter) Setup() {
 	}))
 }
 
+// Enabled returns true if messages issued by the local node are supposed to pass through the RateSetter.
+func (r *RateSetter) Enabled() bool {
+	return r.tangle.Options.RateSetterParams.Enabled
+}
+
 // Issue submits a message to the local issuing queue.
 func (r *RateSetter) Issue(message *Message) error {
 	if identity.NewID(message.IssuerPublicKey()) != r.self {
@@ -130,8 +140,8 @@ func (r *RateSetter) Size() int {
 
 // rateSetting updates the rate ownRate at which messages can be issued by the node.
 func (r *RateSetter) rateSetting() {
-	ownMana := r.tangle.Options.SchedulerParams.AccessManaRetrieveFunc(r.self)
-	totalMana := r.tangle.Options.SchedulerParams.TotalAccessManaRetrieveFunc()
+	ownMana := math.Max(r.tangle.Options.SchedulerParams.AccessManaRetrieveFunc(r.self), MinMana)
+	totalMana := math.Max(r.tangle.Options.SchedulerParams.TotalAccessManaRetrieveFunc(), ownMana)
 
 	ownRate := r.ownRate.Load()
 	if float64(r.tangle.Scheduler.NodeQueueSize(r.self))/ownMana > Backoff {
@@ -162,9 +172,13 @@ loop:
 			}
 
 			msg := r.issuingQueue.PopFront().(*Message)
-			if err := r.tangle.Scheduler.SubmitAndReady(msg.ID()); err != nil {
-				r.Events.MessageDiscarded.Trigger(msg.ID())
-			}
+			r.tangle.ProcessingGate.RunPending(func() {
+				if err := r.tangle.Scheduler.SubmitAndReady(msg.ID()); err != nil {
+					r.Events.MessageDiscarded.Trigger(msg.ID())
+				} else {
+					r.Events.MessageIssued.Trigger(msg.ID())
+				}
+			})
 			lastIssueTime = time.Now()
 
 			if next := r.issuingQueue.Front(); next != nil {
@@ -214,6 +228,10 @@ func (r *RateSetter) issueInterval(msg *Message) time.Duration {
 
 // RateSetterEvents represents events happening in the rate setter.
 type RateSetterEvents struct {
+	// MessageIssued is triggered when a message of the local node has been handed over to the Scheduler.
+	MessageIssued *events.Event
+
+	// MessageDiscarded is triggered when a message of the local node has been dropped by the RateSetter.
 	MessageDiscarded *events.Event
 }
 
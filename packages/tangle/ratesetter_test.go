@@ -75,3 +75,39 @@ func TestRateSetter_ErrorHandling(t *testing.T) {
 		}
 	}, 1*time.Second, 10*time.Millisecond)
 }
+
+func TestRateSetter_Schedule(t *testing.T) {
+	localID := identity.GenerateLocalIdentity()
+	localNode := identity.New(localID.PublicKey())
+
+	tangle := newTestTangle(Identity(localID), RateSetterConfig(RateSetterParams{Enabled: true, Initial: &testInitial}))
+	defer tangle.Shutdown()
+	tangle.Scheduler.Start()
+
+	messageIssued := make(chan MessageID, 1)
+	tangle.RateSetter.Events.MessageIssued.Attach(events.NewClosure(func(id MessageID) { messageIssued <- id }))
+	messageScheduled := make(chan MessageID, 2)
+	tangle.Scheduler.Events.MessageScheduled.Attach(events.NewClosure(func(id MessageID) { messageScheduled <- id }))
+
+	msg := newMessage(localNode.PublicKey())
+	tangle.Storage.StoreMessage(msg)
+	tangle.schedule(msg.ID())
+
+	assert.Eventually(t, func() bool {
+		select {
+		case id := <-messageIssued:
+			return assert.Equal(t, msg.ID(), id)
+		default:
+			return false
+		}
+	}, 1*time.Second, 10*time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		select {
+		case id := <-messageScheduled:
+			return assert.Equal(t, msg.ID(), id)
+		default:
+			return false
+		}
+	}, 1*time.Second, 10*time.Millisecond)
+}
@@ -1,9 +1,11 @@
 package tangle
 
 import (
+	"container/list"
 	"sync"
 	"time"
 
+	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/events"
 )
 
@@ -13,6 +15,9 @@ const (
 
 	// the maximum amount of requests before we abort
 	maxRequestThreshold = 500
+
+	// the maximum amount of parents of recently parsed messages whose sender is remembered
+	maxParentSenders = 10000
 )
 
 // RequesterOptions holds options for a message requester.
@@ -44,12 +49,19 @@ func RetryInterval(interval time.Duration) RequesterOption {
 
 // region Requester /////////////////////////////////////////////////////////////////////////////////////////////
 
-// Requester takes care of requesting messages.
+// Requester takes care of requesting messages. A missing message is first requested from the peer that sent the
+// message referencing it and only requested from all neighbors if it was not received within the retry interval.
 type Requester struct {
 	tangle            *Tangle
 	scheduledRequests map[MessageID]*time.Timer
-	options           *RequesterOptions
-	Events            *MessageRequesterEvents
+	unsentRequests    map[MessageID]struct{}
+	// the senders of the recently parsed messages indexed by the parents of the messages (oldest first in
+	// parentSendersOrder), so that a missing parent can be requested from the sender no matter whether the message was
+	// parsed before or after the parent was found to be missing.
+	parentSenders      map[MessageID]*list.Element
+	parentSendersOrder *list.List
+	options            *RequesterOptions
+	Events             *MessageRequesterEvents
 
 	scheduledRequestsMutex sync.RWMutex
 }
@@ -60,11 +72,15 @@ type MessageExistsFunc func(messageId MessageID) bool
 // NewRequester creates a new message requester.
 func NewRequester(tangle *Tangle, optionalOptions ...RequesterOption) *Requester {
 	requester := &Requester{
-		tangle:            tangle,
-		scheduledRequests: make(map[MessageID]*time.Timer),
-		options:           newRequesterOptions(optionalOptions),
+		tangle:             tangle,
+		scheduledRequests:  make(map[MessageID]*time.Timer),
+		unsentRequests:     make(map[MessageID]struct{}),
+		parentSenders:      make(map[MessageID]*list.Element),
+		parentSendersOrder: list.New(),
+		options:            newRequesterOptions(optionalOptions),
 		Events: &MessageRequesterEvents{
-			SendRequest: events.NewEvent(sendRequestEventHandler),
+			SendRequest:   events.NewEvent(sendRequestEventHandler),
+			RequestFailed: events.NewEvent(MessageIDCaller),
 		},
 	}
 
@@ -83,9 +99,14 @@ func NewRequester(tangle *Tangle, optionalOptions ...RequesterOption) *Requester
 func (r *Requester) Setup() {
 	r.tangle.Solidifier.Events.MessageMissing.Attach(events.NewClosure(r.StartRequest))
 	r.tangle.Storage.Events.MissingMessageStored.Attach(events.NewClosure(r.StopRequest))
+	r.tangle.Parser.Events.MessageParsed.Attach(events.NewClosure(func(event *MessageParsedEvent) {
+		r.requestParentsFromSender(event.Message, event.Peer)
+	}))
 }
 
-// StartRequest initiates a regular triggering of the StartRequest event until it has been stopped using StopRequest.
+// StartRequest initiates a regular triggering of the SendRequest event until it has been stopped using StopRequest.
+// The first request is sent to the peer that sent the message referencing the missing message, as soon as that message
+// was parsed. If there is no such peer, the message is requested from all neighbors after the retry interval.
 func (r *Requester) StartRequest(id MessageID) {
 	r.scheduledRequestsMutex.Lock()
 
@@ -95,10 +116,17 @@ func (r *Requester) StartRequest(id MessageID) {
 		return
 	}
 
-	// schedule the next request and trigger the event
+	// schedule the next request
 	r.scheduledRequests[id] = time.AfterFunc(r.options.retryInterval, r.createReRequest(id, 0))
+	sender, senderKnown := r.removeParentSender(id)
+	if !senderKnown {
+		r.unsentRequests[id] = struct{}{}
+	}
 	r.scheduledRequestsMutex.Unlock()
-	r.Events.SendRequest.Trigger(&SendRequestEvent{ID: id})
+
+	if senderKnown {
+		r.Events.SendRequest.Trigger(&SendRequestEvent{ID: id, Peer: sender})
+	}
 }
 
 // StopRequest stops requests for the given message to further happen.
@@ -110,30 +138,86 @@ func (r *Requester) StopRequest(id MessageID) {
 		timer.Stop()
 		delete(r.scheduledRequests, id)
 	}
+	delete(r.unsentRequests, id)
+	r.removeParentSender(id)
 }
 
-func (r *Requester) reRequest(id MessageID, count int) {
-	r.Events.SendRequest.Trigger(&SendRequestEvent{ID: id})
+// requestParentsFromSender triggers the first request for the missing parents of the given message and addresses it
+// to the peer that sent the message. The sender is remembered for the parents that are not requested yet, in case
+// they are found to be missing later.
+func (r *Requester) requestParentsFromSender(message *Message, sender *peer.Peer) {
+	var parentIDs MessageIDs
+	r.scheduledRequestsMutex.Lock()
+	message.ForEachParent(func(parent Parent) {
+		if _, unsent := r.unsentRequests[parent.ID]; unsent {
+			delete(r.unsentRequests, parent.ID)
+			parentIDs = append(parentIDs, parent.ID)
+			return
+		}
+		if _, scheduled := r.scheduledRequests[parent.ID]; !scheduled && sender != nil {
+			r.addParentSender(parent.ID, sender)
+		}
+	})
+	r.scheduledRequestsMutex.Unlock()
+
+	for _, parentID := range parentIDs {
+		r.Events.SendRequest.Trigger(&SendRequestEvent{ID: parentID, Peer: sender})
+	}
+}
+
+// addParentSender remembers the sender of a message with the given parent and forgets the oldest sender if too many
+// are remembered. It must be called while holding the scheduledRequestsMutex.
+func (r *Requester) addParentSender(parentID MessageID, sender *peer.Peer) {
+	if _, exists := r.parentSenders[parentID]; exists {
+		return
+	}
+
+	r.parentSenders[parentID] = r.parentSendersOrder.PushBack(&parentSender{parentID: parentID, sender: sender})
+	if r.parentSendersOrder.Len() > maxParentSenders {
+		r.removeParentSender(r.parentSendersOrder.Front().Value.(*parentSender).parentID)
+	}
+}
 
-	// as we schedule a request at most once per id we do not need to make the trigger and the re-schedule atomic
+// removeParentSender forgets and returns the remembered sender of a message with the given parent. It must be called
+// while holding the scheduledRequestsMutex.
+func (r *Requester) removeParentSender(parentID MessageID) (sender *peer.Peer, exists bool) {
+	element, exists := r.parentSenders[parentID]
+	if !exists {
+		return nil, false
+	}
+	delete(r.parentSenders, parentID)
+	r.parentSendersOrder.Remove(element)
+
+	return element.Value.(*parentSender).sender, true
+}
+
+func (r *Requester) reRequest(id MessageID, count int) {
 	r.scheduledRequestsMutex.Lock()
-	defer r.scheduledRequestsMutex.Unlock()
 
-	// reschedule, if the request has not been stopped in the meantime
-	if _, exists := r.scheduledRequests[id]; exists {
-		// increase the request counter
-		count++
+	// do not send the request, if it has been stopped in the meantime
+	if _, exists := r.scheduledRequests[id]; !exists {
+		r.scheduledRequestsMutex.Unlock()
+		return
+	}
+	delete(r.unsentRequests, id)
 
-		// if we have requested too often => stop the requests
-		if count > maxRequestThreshold {
-			delete(r.scheduledRequests, id)
+	// increase the request counter
+	count++
 
-			return
-		}
+	// if we have requested too often => stop the requests
+	if count > maxRequestThreshold {
+		delete(r.scheduledRequests, id)
+		r.scheduledRequestsMutex.Unlock()
 
-		r.scheduledRequests[id] = time.AfterFunc(r.options.retryInterval, r.createReRequest(id, count))
+		r.Events.RequestFailed.Trigger(id)
 		return
 	}
+
+	r.scheduledRequests[id] = time.AfterFunc(r.options.retryInterval, r.createReRequest(id, count))
+	r.scheduledRequestsMutex.Unlock()
+
+	// the retries are sent to all neighbors
+	r.Events.SendRequest.Trigger(&SendRequestEvent{ID: id})
 }
 
 // RequestQueueSize returns the number of scheduled message requests.
@@ -147,6 +231,12 @@ func (r *Requester) createReRequest(msgID MessageID, count int) func() {
 	return func() { r.reRequest(msgID, count) }
 }
 
+// parentSender is the sender of a recently parsed message with the given parent.
+type parentSender struct {
+	parentID MessageID
+	sender   *peer.Peer
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region MessageRequesterEvents ///////////////////////////////////////////////////////////////////////////////////////
@@ -155,6 +245,8 @@ func (r *Requester) createReRequest(msgID MessageID, count int) func() {
 type MessageRequesterEvents struct {
 	// Fired when a request for a given message should be sent.
 	SendRequest *events.Event
+	// Fired when a message could not be received after the maximum amount of requests and is no longer requested.
+	RequestFailed *events.Event
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -164,6 +256,9 @@ type MessageRequesterEvents struct {
 // SendRequestEvent represents the parameters of sendRequestEventHandler
 type SendRequestEvent struct {
 	ID MessageID
+	// Peer contains the peer that the request should be sent to. It is nil if the request should be sent to all
+	// neighbors.
+	Peer *peer.Peer
 }
 
 func sendRequestEventHandler(handler interface{}, params ...interface{}) {
@@ -0,0 +1,82 @@
+package tangle
+
+import (
+	"net"
+	"testing"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequester_RequestFromSender(t *testing.T) {
+	tangle := newTestTangle()
+	defer tangle.Shutdown()
+	tangle.Setup()
+
+	var sentRequests []*SendRequestEvent
+	tangle.Requester.Events.SendRequest.Attach(events.NewClosure(func(event *SendRequestEvent) {
+		sentRequests = append(sentRequests, event)
+	}))
+
+	services := service.New()
+	services.Update(service.PeeringKey, "udp", 0)
+	sender := peer.NewPeer(identity.GenerateIdentity(), net.IPv4zero, services)
+	missingParent := randomMessageID()
+	message := newTestParentsDataMessage("child", []MessageID{missingParent}, nil)
+	tangle.Parser.Events.MessageParsed.Trigger(&MessageParsedEvent{Message: message, Peer: sender})
+
+	// the missing parent is requested once from the sender of the child
+	require.Len(t, sentRequests, 1)
+	assert.Equal(t, missingParent, sentRequests[0].ID)
+	assert.Equal(t, sender, sentRequests[0].Peer)
+	assert.Equal(t, 1, tangle.Requester.RequestQueueSize())
+
+	// the second child of the same missing parent does not trigger another request
+	message = newTestParentsDataMessage("second child", []MessageID{missingParent}, nil)
+	tangle.Parser.Events.MessageParsed.Trigger(&MessageParsedEvent{Message: message, Peer: sender})
+	assert.Len(t, sentRequests, 1)
+
+	tangle.Requester.StopRequest(missingParent)
+	assert.Equal(t, 0, tangle.Requester.RequestQueueSize())
+}
+
+func TestRequester_RequestFromSenderEventOrder(t *testing.T) {
+	tangle := newTestTangle()
+	defer tangle.Shutdown()
+
+	var sentRequests []*SendRequestEvent
+	tangle.Requester.Events.SendRequest.Attach(events.NewClosure(func(event *SendRequestEvent) {
+		sentRequests = append(sentRequests, event)
+	}))
+
+	services := service.New()
+	services.Update(service.PeeringKey, "udp", 0)
+	sender := peer.NewPeer(identity.GenerateIdentity(), net.IPv4zero, services)
+
+	// the parent is found to be missing before the referencing message is passed to the requester
+	missingParent := randomMessageID()
+	tangle.Requester.StartRequest(missingParent)
+	assert.Empty(t, sentRequests)
+	tangle.Requester.requestParentsFromSender(newTestParentsDataMessage("child", []MessageID{missingParent}, nil), sender)
+	require.Len(t, sentRequests, 1)
+	assert.Equal(t, missingParent, sentRequests[0].ID)
+	assert.Equal(t, sender, sentRequests[0].Peer)
+
+	// the referencing message is passed to the requester before the parent is found to be missing
+	missingParent = randomMessageID()
+	tangle.Requester.requestParentsFromSender(newTestParentsDataMessage("other child", []MessageID{missingParent}, nil), sender)
+	assert.Len(t, sentRequests, 1)
+	tangle.Requester.StartRequest(missingParent)
+	require.Len(t, sentRequests, 2)
+	assert.Equal(t, missingParent, sentRequests[1].ID)
+	assert.Equal(t, sender, sentRequests[1].Peer)
+
+	// a stopped request is not sent anymore
+	tangle.Requester.StopRequest(missingParent)
+	tangle.Requester.reRequest(missingParent, 0)
+	assert.Len(t, sentRequests, 2)
+}
@@ -331,13 +331,15 @@ loop:
 		// every rate time units
 		case <-s.ticker.C:
 			// TODO: pause the ticker, if there are no ready messages
-			if msg := s.schedule(); msg != nil {
-				s.tangle.Storage.MessageMetadata(msg.ID()).Consume(func(messageMetadata *MessageMetadata) {
-					if messageMetadata.SetScheduled(true) {
-						s.Events.MessageScheduled.Trigger(msg.ID())
-					}
-				})
-			}
+			s.tangle.ProcessingGate.Run(func() {
+				if msg := s.schedule(); msg != nil {
+					s.tangle.Storage.MessageMetadata(msg.ID()).Consume(func(messageMetadata *MessageMetadata) {
+						if messageMetadata.SetScheduled(true) {
+							s.Events.MessageScheduled.Trigger(msg.ID())
+						}
+					})
+				}
+			})
 
 		// on close, exit the loop
 		case <-s.shutdownSignal:
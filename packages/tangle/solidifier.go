@@ -103,13 +103,21 @@ func (s *Solidifier) isMessageSolid(message *Message, messageMetadata *MessageMe
 	return
 }
 
-// isMessageMarkedAsSolid checks whether the given message is solid and marks it as missing if it isn't known.
+// isMessageMarkedAsSolid checks whether the given message is solid and marks it as missing if it isn't known. Parents
+// that have been removed by the Pruner are not requested again. They are only solid if they were booked before, as
+// their children can't be booked otherwise.
 func (s *Solidifier) isMessageMarkedAsSolid(messageID MessageID) (solid bool) {
 	if messageID == EmptyMessageID {
 		return true
 	}
 
 	s.tangle.Storage.MessageMetadata(messageID, func() *MessageMetadata {
+		if s.tangle.Storage.PrunedMessage(messageID).Consume(func(prunedMessage *PrunedMessage) {
+			solid = prunedMessage.IsSolid() && prunedMessage.IsBooked()
+		}) {
+			return nil
+		}
+
 		if cachedMissingMessage, stored := s.tangle.Storage.StoreMissingMessage(NewMissingMessage(messageID)); stored {
 			cachedMissingMessage.Consume(func(missingMessage *MissingMessage) {
 				s.Events.MessageMissing.Trigger(messageID)
@@ -150,11 +158,19 @@ func (s *Solidifier) isParentMessageValid(parentMessageID MessageID, childMessag
 		return
 	}
 
-	s.tangle.Storage.Message(parentMessageID).Consume(func(parentMessage *Message) {
+	if !s.tangle.Storage.Message(parentMessageID).Consume(func(parentMessage *Message) {
 		timeDifference := childMessage.IssuingTime().Sub(parentMessage.IssuingTime())
 
 		valid = timeDifference >= minParentsTimeDifference && timeDifference <= maxParentsTimeDifference
-	})
+	}) {
+		// the timestamp and validity of pruned parents are kept by their PrunedMessage
+		s.tangle.Storage.PrunedMessage(parentMessageID).Consume(func(prunedMessage *PrunedMessage) {
+			timeDifference := childMessage.IssuingTime().Sub(prunedMessage.IssuingTime())
+
+			valid = timeDifference >= minParentsTimeDifference && timeDifference <= maxParentsTimeDifference && !prunedMessage.IsInvalid()
+		})
+		return
+	}
 
 	s.tangle.Storage.MessageMetadata(parentMessageID).Consume(func(messageMetadata *MessageMetadata) {
 		valid = valid && !messageMetadata.IsInvalid()
@@ -3,6 +3,7 @@ package tangle
 import (
 	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -56,11 +57,20 @@ const (
 	// PrefixMarkerMessageMapping defines the storage prefix for the MarkerMessageMapping.
 	PrefixMarkerMessageMapping
 
+	// PrefixPrunedMessage defines the storage prefix for the PrunedMessage.
+	PrefixPrunedMessage
+
+	// PrefixMessageIssuingTime defines the storage prefix for the index of the Messages by their issuing time.
+	PrefixMessageIssuingTime
+
 	// DBSequenceNumber defines the db sequence number.
 	DBSequenceNumber = "seq"
 
 	// cacheTime defines the number of seconds an object will wait in storage cache
 	cacheTime = 2 * time.Second
+
+	// issuingTimeBucket defines the time span of the Messages that share a prefix in the issuing time index.
+	issuingTimeBucket = time.Minute
 )
 
 // region Storage //////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -80,6 +90,12 @@ type Storage struct {
 	statementStorage                  *objectstorage.ObjectStorage
 	branchWeightStorage               *objectstorage.ObjectStorage
 	markerMessageMappingStorage       *objectstorage.ObjectStorage
+	prunedMessageStorage              *objectstorage.ObjectStorage
+	issuingTimeStorage                *objectstorage.ObjectStorage
+
+	// the oldest bucket of the issuing time index that might contain Messages (-1 if it wasn't determined, yet).
+	oldestIssuingTimeBucket      int64
+	oldestIssuingTimeBucketMutex sync.Mutex
 
 	Events   *StorageEvents
 	shutdown chan struct{}
@@ -105,6 +121,9 @@ func NewStorage(tangle *Tangle) (storage *Storage) {
 		statementStorage:                  osFactory.New(PrefixStatement, StatementFromObjectStorage, cacheProvider.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
 		branchWeightStorage:               osFactory.New(PrefixBranchWeight, BranchWeightFromObjectStorage, cacheProvider.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false)),
 		markerMessageMappingStorage:       osFactory.New(PrefixMarkerMessageMapping, MarkerMessageMappingFromObjectStorage, cacheProvider.CacheTime(cacheTime), MarkerMessageMappingPartitionKeys, objectstorage.StoreOnCreation(true)),
+		prunedMessageStorage:              osFactory.New(PrefixPrunedMessage, PrunedMessageFromObjectStorage, cacheProvider.CacheTime(cacheTime), objectstorage.LeakDetectionEnabled(false), objectstorage.StoreOnCreation(true)),
+		issuingTimeStorage:                osFactory.New(PrefixMessageIssuingTime, messageIssuingTimeFromObjectStorage, cacheProvider.CacheTime(cacheTime), objectstorage.PartitionKey(marshalutil.Uint64Size, marshalutil.Int64Size, MessageIDLength), objectstorage.LeakDetectionEnabled(false), objectstorage.StoreOnCreation(true)),
+		oldestIssuingTimeBucket:           -1,
 
 		Events: &StorageEvents{
 			MessageStored:        events.NewEvent(MessageIDCaller),
@@ -121,7 +140,9 @@ func NewStorage(tangle *Tangle) (storage *Storage) {
 // Setup sets up the behavior of the component by making it attach to the relevant events of other components.
 func (s *Storage) Setup() {
 	s.tangle.Parser.Events.MessageParsed.Attach(events.NewClosure(func(msgParsedEvent *MessageParsedEvent) {
-		s.tangle.Storage.StoreMessage(msgParsedEvent.Message)
+		s.tangle.ProcessingGate.Run(func() {
+			s.tangle.Storage.StoreMessage(msgParsedEvent.Message)
+		})
 	}))
 	s.tangle.Scheduler.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID MessageID) {
 		s.DeleteMessage(messageID)
@@ -133,6 +154,11 @@ func (s *Storage) StoreMessage(message *Message) {
 	// retrieve MessageID
 	messageID := message.ID()
 
+	// Messages that were removed by the Pruner are not stored again
+	if s.prunedMessageStorage.Contains(messageID.Bytes()) {
+		return
+	}
+
 	// store Messages only once by using the existence of the Metadata as a guard
 	storedMetadata, stored := s.messageMetadataStorage.StoreIfAbsent(NewMessageMetadata(messageID))
 	if !stored {
@@ -146,6 +172,7 @@ func (s *Storage) StoreMessage(message *Message) {
 	// store Message
 	cachedMessage := &CachedMessage{CachedObject: s.messageStorage.Store(message)}
 	defer cachedMessage.Release()
+	s.storeIssuingTime(message)
 
 	// TODO: approval switch: we probably need to introduce approver types
 	// store approvers
@@ -265,9 +292,103 @@ func (s *Storage) DeleteMessage(messageID MessageID) {
 
 		s.messageMetadataStorage.Delete(messageID[:])
 		s.messageStorage.Delete(messageID[:])
+		s.issuingTimeStorage.Delete(newMessageIssuingTime(currentMsg).ObjectStorageKey())
+
+		s.Events.MessageRemoved.Trigger(messageID)
+	})
+}
+
+// PruneMessage removes a Message together with all of the objects that reference it (its MessageMetadata, Approvers,
+// Attachment, MarkerMessageMapping and IndividuallyMappedMessage) from the object storage and replaces it with a
+// PrunedMessage, which keeps the details that are needed to process its children. Contrary to DeleteMessage it does not
+// only un-mark the Message as an approver of its parents but also removes its own approvers, as its whole past cone is
+// supposed to be pruned as well.
+func (s *Storage) PruneMessage(messageID MessageID) (pruned bool) {
+	s.Message(messageID).Consume(func(message *Message) {
+		message.ForEachStrongParent(func(parentMessageID MessageID) {
+			s.deleteStrongApprover(parentMessageID, messageID)
+		})
+		message.ForEachWeakParent(func(parentMessageID MessageID) {
+			s.deleteWeakApprover(parentMessageID, messageID)
+		})
+
+		var approverKeys [][]byte
+		s.approverStorage.ForEachKeyOnly(func(key []byte) bool {
+			approverKeys = append(approverKeys, key)
+			return true
+		}, objectstorage.WithIteratorPrefix(messageID.Bytes()))
+		for _, approverKey := range approverKeys {
+			s.approverStorage.Delete(approverKey)
+		}
+
+		if message.Payload().Type() == ledgerstate.TransactionType {
+			s.attachmentStorage.Delete(NewAttachment(message.Payload().(*ledgerstate.Transaction).ID(), messageID).ObjectStorageKey())
+		}
+
+		s.MessageMetadata(messageID).Consume(func(messageMetadata *MessageMetadata) {
+			s.prunedMessageStorage.Store(NewPrunedMessage(message, messageMetadata)).Release()
+			s.individuallyMappedMessageStorage.Delete(byteutils.ConcatBytes(messageMetadata.BranchID().Bytes(), messageID.Bytes()))
+
+			if structureDetails := messageMetadata.StructureDetails(); structureDetails != nil && structureDetails.IsPastMarker {
+				structureDetails.PastMarkers.ForEach(func(sequenceID markers.SequenceID, index markers.Index) bool {
+					s.markerMessageMappingStorage.Delete(markers.NewMarker(sequenceID, index).Bytes())
+					return true
+				})
+			}
+		})
+
+		s.messageMetadataStorage.Delete(messageID[:])
+		s.messageStorage.Delete(messageID[:])
+		s.missingMessageStorage.Delete(messageID[:])
+		s.issuingTimeStorage.Delete(newMessageIssuingTime(message).ObjectStorageKey())
 
 		s.Events.MessageRemoved.Trigger(messageID)
+
+		pruned = true
 	})
+
+	return
+}
+
+// PrunedMessage retrieves the PrunedMessage that replaced the Message with the given MessageID in the Storage.
+func (s *Storage) PrunedMessage(messageID MessageID) *CachedPrunedMessage {
+	return &CachedPrunedMessage{CachedObject: s.prunedMessageStorage.Load(messageID[:])}
+}
+
+// MessageIDsIssuedBefore returns the MessageIDs of all stored Messages that were issued before the given time. It only
+// iterates the buckets of the issuing time index that can contain such Messages.
+func (s *Storage) MessageIDsIssuedBefore(threshold time.Time) (messageIDs MessageIDs) {
+	startBucket := s.oldestIssuingTimeBucketOrInit()
+	thresholdBucket := issuingTimeBucketOf(threshold)
+
+	firstBucket := thresholdBucket
+	if startBucket > thresholdBucket {
+		firstBucket = startBucket
+	}
+	for bucket := startBucket; bucket <= thresholdBucket; bucket++ {
+		s.issuingTimeStorage.ForEachKeyOnly(func(key []byte) bool {
+			issuingTime, err := messageIssuingTimeFromBytes(key)
+			if err != nil {
+				return true
+			}
+			if bucket < firstBucket {
+				firstBucket = bucket
+			}
+			if issuingTime.issuingTime.Before(threshold) {
+				messageIDs = append(messageIDs, issuingTime.messageID)
+			}
+			return true
+		}, objectstorage.WithIteratorPrefix(issuingTimeBucketBytes(bucket)))
+	}
+
+	// Messages with an older issuing time might have been stored in the meantime
+	s.oldestIssuingTimeBucketMutex.Lock()
+	if s.oldestIssuingTimeBucket == startBucket {
+		s.oldestIssuingTimeBucket = firstBucket
+	}
+	s.oldestIssuingTimeBucketMutex.Unlock()
+
+	return messageIDs
 }
 
 // DeleteMissingMessage deletes a message from the missingMessageStorage.
@@ -380,6 +501,40 @@ func (s *Storage) BranchWeight(branchID ledgerstate.BranchID, computeIfAbsentCal
 	return &CachedBranchWeight{CachedObject: s.branchWeightStorage.Load(branchID.Bytes())}
 }
 
+// storeIssuingTime adds the Message to the issuing time index.
+func (s *Storage) storeIssuingTime(message *Message) {
+	s.issuingTimeStorage.Store(newMessageIssuingTime(message)).Release()
+
+	s.oldestIssuingTimeBucketMutex.Lock()
+	defer s.oldestIssuingTimeBucketMutex.Unlock()
+	if bucket := issuingTimeBucketOf(message.IssuingTime()); s.oldestIssuingTimeBucket > bucket {
+		s.oldestIssuingTimeBucket = bucket
+	}
+}
+
+// oldestIssuingTimeBucketOrInit returns the oldest bucket of the issuing time index that might contain Messages. It is
+// determined with a single pass over the keys of the index, the first time it is needed.
+func (s *Storage) oldestIssuingTimeBucketOrInit() int64 {
+	s.oldestIssuingTimeBucketMutex.Lock()
+	defer s.oldestIssuingTimeBucketMutex.Unlock()
+
+	if s.oldestIssuingTimeBucket >= 0 {
+		return s.oldestIssuingTimeBucket
+	}
+
+	s.oldestIssuingTimeBucket = issuingTimeBucketOf(clock.SyncedTime())
+	s.issuingTimeStorage.ForEachKeyOnly(func(key []byte) bool {
+		if issuingTime, err := messageIssuingTimeFromBytes(key); err == nil {
+			if bucket := issuingTimeBucketOf(issuingTime.issuingTime); bucket < s.oldestIssuingTimeBucket {
+				s.oldestIssuingTimeBucket = bucket
+			}
+		}
+		return true
+	})
+
+	return s.oldestIssuingTimeBucket
+}
+
 func (s *Storage) storeGenesis() {
 	s.MessageMetadata(EmptyMessageID, func() *MessageMetadata {
 		genesisMetadata := &MessageMetadata{
@@ -415,6 +570,24 @@ func (s *Storage) deleteWeakApprover(approvedMessageID MessageID, approvingMessa
 	s.approverStorage.Delete(byteutils.ConcatBytes(approvedMessageID.Bytes(), WeakApprover.Bytes(), approvingMessage.Bytes()))
 }
 
+// Flush persists the cached objects of the Storage without shutting it down.
+func (s *Storage) Flush() {
+	s.messageStorage.Flush()
+	s.messageMetadataStorage.Flush()
+	s.approverStorage.Flush()
+	s.missingMessageStorage.Flush()
+	s.attachmentStorage.Flush()
+	s.markerIndexBranchIDMappingStorage.Flush()
+	s.individuallyMappedMessageStorage.Flush()
+	s.sequenceSupportersStorage.Flush()
+	s.branchSupportersStorage.Flush()
+	s.statementStorage.Flush()
+	s.branchWeightStorage.Flush()
+	s.markerMessageMappingStorage.Flush()
+	s.prunedMessageStorage.Flush()
+	s.issuingTimeStorage.Flush()
+}
+
 // Shutdown marks the tangle as stopped, so it will not accept any new messages (waits for all backgroundTasks to finish).
 func (s *Storage) Shutdown() {
 	s.messageStorage.Shutdown()
@@ -429,6 +602,8 @@ func (s *Storage) Shutdown() {
 	s.statementStorage.Shutdown()
 	s.branchWeightStorage.Shutdown()
 	s.markerMessageMappingStorage.Shutdown()
+	s.prunedMessageStorage.Shutdown()
+	s.issuingTimeStorage.Shutdown()
 
 	close(s.shutdown)
 }
@@ -448,6 +623,8 @@ func (s *Storage) Prune() error {
 		s.statementStorage,
 		s.branchWeightStorage,
 		s.markerMessageMappingStorage,
+		s.prunedMessageStorage,
+		s.issuingTimeStorage,
 	} {
 		if err := storage.Prune(); err != nil {
 			err = fmt.Errorf("failed to prune storage: %w", err)
@@ -455,6 +632,10 @@ func (s *Storage) Prune() error {
 		}
 	}
 
+	s.oldestIssuingTimeBucketMutex.Lock()
+	s.oldestIssuingTimeBucket = -1
+	s.oldestIssuingTimeBucketMutex.Unlock()
+
 	s.storeGenesis()
 
 	return nil
@@ -1101,3 +1282,320 @@ func (c *CachedMissingMessage) String() string {
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region PrunedMessage ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// PrunedMessage is the tombstone of a Message that was removed by the Pruner. It keeps the details that are needed to
+// solidify, validate and book the children of the Message, which can still arrive after it was pruned.
+type PrunedMessage struct {
+	objectstorage.StorableObjectFlags
+
+	messageID        MessageID
+	issuingTime      time.Time
+	solid            bool
+	booked           bool
+	invalid          bool
+	branchID         ledgerstate.BranchID
+	structureDetails *markers.StructureDetails
+	transactionID    *ledgerstate.TransactionID
+}
+
+// NewPrunedMessage creates the PrunedMessage of the given Message.
+func NewPrunedMessage(message *Message, messageMetadata *MessageMetadata) (prunedMessage *PrunedMessage) {
+	prunedMessage = &PrunedMessage{
+		messageID:        message.ID(),
+		issuingTime:      message.IssuingTime(),
+		solid:            messageMetadata.IsSolid(),
+		booked:           messageMetadata.IsBooked(),
+		invalid:          messageMetadata.IsInvalid(),
+		branchID:         messageMetadata.BranchID(),
+		structureDetails: messageMetadata.StructureDetails(),
+	}
+	if messagePayload := message.Payload(); messagePayload != nil && messagePayload.Type() == ledgerstate.TransactionType {
+		transactionID := messagePayload.(*ledgerstate.Transaction).ID()
+		prunedMessage.transactionID = &transactionID
+	}
+
+	return prunedMessage
+}
+
+// PrunedMessageFromBytes parses the given bytes into a PrunedMessage.
+func PrunedMessageFromBytes(bytes []byte) (result *PrunedMessage, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	result, err = PrunedMessageFromMarshalUtil(marshalUtil)
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// PrunedMessageFromMarshalUtil parses a PrunedMessage from the given MarshalUtil.
+func PrunedMessageFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (result *PrunedMessage, err error) {
+	result = &PrunedMessage{}
+
+	if result.messageID, err = MessageIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse message ID of pruned message: %w", err)
+		return
+	}
+	if result.issuingTime, err = marshalUtil.ReadTime(); err != nil {
+		err = errors.Errorf("failed to parse issuing time of pruned message (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.solid, err = marshalUtil.ReadBool(); err != nil {
+		err = errors.Errorf("failed to parse solid flag of pruned message (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.booked, err = marshalUtil.ReadBool(); err != nil {
+		err = errors.Errorf("failed to parse booked flag of pruned message (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.invalid, err = marshalUtil.ReadBool(); err != nil {
+		err = errors.Errorf("failed to parse invalid flag of pruned message (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if result.branchID, err = ledgerstate.BranchIDFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse BranchID of pruned message: %w", err)
+		return
+	}
+	if result.structureDetails, err = markers.StructureDetailsFromMarshalUtil(marshalUtil); err != nil {
+		err = errors.Errorf("failed to parse StructureDetails of pruned message: %w", err)
+		return
+	}
+	hasTransaction, err := marshalUtil.ReadBool()
+	if err != nil {
+		err = errors.Errorf("failed to parse transaction flag of pruned message (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if hasTransaction {
+		transactionID, transactionIDErr := ledgerstate.TransactionIDFromMarshalUtil(marshalUtil)
+		if transactionIDErr != nil {
+			err = errors.Errorf("failed to parse TransactionID of pruned message: %w", transactionIDErr)
+			return
+		}
+		result.transactionID = &transactionID
+	}
+
+	return
+}
+
+// PrunedMessageFromObjectStorage restores a PrunedMessage from the ObjectStorage.
+func PrunedMessageFromObjectStorage(key []byte, data []byte) (result objectstorage.StorableObject, err error) {
+	if result, _, err = PrunedMessageFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = errors.Errorf("failed to parse pruned message from object storage: %w", err)
+		return
+	}
+
+	return
+}
+
+// ID returns the MessageID of the pruned Message.
+func (p *PrunedMessage) ID() MessageID {
+	return p.messageID
+}
+
+// IssuingTime returns the issuing time of the pruned Message.
+func (p *PrunedMessage) IssuingTime() time.Time {
+	return p.issuingTime
+}
+
+// IsSolid returns true if the pruned Message was solid.
+func (p *PrunedMessage) IsSolid() bool {
+	return p.solid
+}
+
+// IsBooked returns true if the pruned Message was booked.
+func (p *PrunedMessage) IsBooked() bool {
+	return p.booked
+}
+
+// IsInvalid returns true if the pruned Message was invalid.
+func (p *PrunedMessage) IsInvalid() bool {
+	return p.invalid
+}
+
+// BranchID returns the BranchID that was mapped in the MessageMetadata of the pruned Message.
+func (p *PrunedMessage) BranchID() ledgerstate.BranchID {
+	return p.branchID
+}
+
+// StructureDetails returns the StructureDetails of the pruned Message.
+func (p *PrunedMessage) StructureDetails() *markers.StructureDetails {
+	return p.structureDetails
+}
+
+// TransactionID returns the ID of the Transaction contained in the pruned Message (nil if it didn't contain one).
+func (p *PrunedMessage) TransactionID() *ledgerstate.TransactionID {
+	return p.transactionID
+}
+
+// Bytes returns a marshaled version of the PrunedMessage.
+func (p *PrunedMessage) Bytes() []byte {
+	return byteutils.ConcatBytes(p.ObjectStorageKey(), p.ObjectStorageValue())
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (p *PrunedMessage) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database.
+func (p *PrunedMessage) ObjectStorageKey() []byte {
+	return p.messageID.Bytes()
+}
+
+// ObjectStorageValue marshals the PrunedMessage into a sequence of bytes that are used as the value part in the object
+// storage.
+func (p *PrunedMessage) ObjectStorageValue() []byte {
+	marshalUtil := marshalutil.New().
+		WriteTime(p.issuingTime).
+		WriteBool(p.solid).
+		WriteBool(p.booked).
+		WriteBool(p.invalid).
+		Write(p.branchID).
+		Write(p.structureDetails).
+		WriteBool(p.transactionID != nil)
+	if p.transactionID != nil {
+		marshalUtil.Write(p.transactionID)
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the PrunedMessage.
+func (p *PrunedMessage) String() string {
+	return stringify.Struct("PrunedMessage",
+		stringify.StructField("messageID", p.messageID),
+		stringify.StructField("issuingTime", p.issuingTime),
+		stringify.StructField("solid", p.solid),
+		stringify.StructField("booked", p.booked),
+		stringify.StructField("invalid", p.invalid),
+		stringify.StructField("branchID", p.branchID),
+		stringify.StructField("structureDetails", p.structureDetails),
+		stringify.StructField("transactionID", p.transactionID),
+	)
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &PrunedMessage{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedPrunedMessage //////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedPrunedMessage is a wrapper for the generic CachedObject returned by the object storage that overrides the
+// accessor methods with a type-casted one.
+type CachedPrunedMessage struct {
+	objectstorage.CachedObject
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedPrunedMessage) Unwrap() *PrunedMessage {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*PrunedMessage)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (c *CachedPrunedMessage) Consume(consumer func(prunedMessage *PrunedMessage), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*PrunedMessage))
+	}, forceRelease...)
+}
+
+// String returns a human readable version of the CachedPrunedMessage.
+func (c *CachedPrunedMessage) String() string {
+	return stringify.Struct("CachedPrunedMessage",
+		stringify.StructField("CachedObject", c.Unwrap()),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region messageIssuingTime ///////////////////////////////////////////////////////////////////////////////////////////
+
+// messageIssuingTime is an entry of the index of the Messages by their issuing time. Its key starts with the bucket of
+// the issuing time, so that the Messages issued within a time span can be iterated by their prefix.
+type messageIssuingTime struct {
+	objectstorage.StorableObjectFlags
+
+	issuingTime time.Time
+	messageID   MessageID
+}
+
+func newMessageIssuingTime(message *Message) *messageIssuingTime {
+	return &messageIssuingTime{
+		issuingTime: message.IssuingTime(),
+		messageID:   message.ID(),
+	}
+}
+
+func messageIssuingTimeFromBytes(bytes []byte) (result *messageIssuingTime, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	result = &messageIssuingTime{}
+
+	if _, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, errors.Errorf("failed to parse issuing time bucket (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if result.issuingTime, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse issuing time (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if result.messageID, err = MessageIDFromMarshalUtil(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse message ID: %w", err)
+	}
+
+	return result, nil
+}
+
+func messageIssuingTimeFromObjectStorage(key []byte, _ []byte) (result objectstorage.StorableObject, err error) {
+	if result, err = messageIssuingTimeFromBytes(key); err != nil {
+		err = errors.Errorf("failed to parse message issuing time from object storage: %w", err)
+	}
+
+	return
+}
+
+// Update is disabled and panics if it ever gets called - it is required to match the StorableObject interface.
+func (m *messageIssuingTime) Update(objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database.
+func (m *messageIssuingTime) ObjectStorageKey() []byte {
+	return marshalutil.New(marshalutil.Uint64Size + marshalutil.Int64Size + MessageIDLength).
+		WriteBytes(issuingTimeBucketBytes(issuingTimeBucketOf(m.issuingTime))).
+		WriteTime(m.issuingTime).
+		Write(m.messageID).
+		Bytes()
+}
+
+// ObjectStorageValue returns an empty value, as all information is contained in the key.
+func (m *messageIssuingTime) ObjectStorageValue() []byte {
+	return nil
+}
+
+// code contract (make sure the struct implements all required methods)
+var _ objectstorage.StorableObject = &messageIssuingTime{}
+
+// issuingTimeBucketOf returns the bucket of the issuing time index that contains the given time.
+func issuingTimeBucketOf(t time.Time) int64 {
+	if bucket := t.UnixNano() / int64(issuingTimeBucket); bucket > 0 {
+		return bucket
+	}
+
+	return 0
+}
+
+// issuingTimeBucketBytes returns the key prefix of the given bucket of the issuing time index.
+func issuingTimeBucketBytes(bucket int64) []byte {
+	return marshalutil.New(marshalutil.Uint64Size).WriteUint64(uint64(bucket)).Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
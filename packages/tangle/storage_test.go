@@ -4,50 +4,75 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
 func TestStorage_StoreAttachment(t *testing.T) {
-	tangle := newTestTangle()
-	defer tangle.Shutdown()
-
-	transactionID, err := ledgerstate.TransactionIDFromRandomness()
-	assert.NoError(t, err)
-	messageID := randomMessageID()
-	cachedAttachment, stored := tangle.Storage.StoreAttachment(transactionID, messageID)
-	cachedAttachment.Release()
-	assert.True(t, stored)
-	cachedAttachment, stored = tangle.Storage.StoreAttachment(transactionID, randomMessageID())
-	assert.True(t, stored)
-	cachedAttachment.Release()
-	cachedAttachment, stored = tangle.Storage.StoreAttachment(transactionID, messageID)
-	assert.False(t, stored)
-	assert.Nil(t, cachedAttachment)
+	database.ForEachStore(t, func(t *testing.T, store kvstore.KVStore) {
+		tangle := newTestTangle(Store(store))
+		defer tangle.Shutdown()
+
+		transactionID, err := ledgerstate.TransactionIDFromRandomness()
+		assert.NoError(t, err)
+		messageID := randomMessageID()
+		cachedAttachment, stored := tangle.Storage.StoreAttachment(transactionID, messageID)
+		cachedAttachment.Release()
+		assert.True(t, stored)
+		cachedAttachment, stored = tangle.Storage.StoreAttachment(transactionID, randomMessageID())
+		assert.True(t, stored)
+		cachedAttachment.Release()
+		cachedAttachment, stored = tangle.Storage.StoreAttachment(transactionID, messageID)
+		assert.False(t, stored)
+		assert.Nil(t, cachedAttachment)
+	})
 }
 
 func TestStorage_Attachments(t *testing.T) {
-	tangle := newTestTangle()
-	defer tangle.Shutdown()
+	database.ForEachStore(t, func(t *testing.T, store kvstore.KVStore) {
+		tangle := newTestTangle(Store(store))
+		defer tangle.Shutdown()
 
-	attachments := make(map[ledgerstate.TransactionID]int)
-	for i := 0; i < 2; i++ {
-		transactionID, err := ledgerstate.TransactionIDFromRandomness()
-		assert.NoError(t, err)
-		// for every tx, store random number of attachments.
-		for j := 0; j < rand.Intn(5)+1; j++ {
-			attachments[transactionID]++
-			cachedAttachment, _ := tangle.Storage.StoreAttachment(transactionID, randomMessageID())
-			cachedAttachment.Release()
+		attachments := make(map[ledgerstate.TransactionID]int)
+		for i := 0; i < 2; i++ {
+			transactionID, err := ledgerstate.TransactionIDFromRandomness()
+			assert.NoError(t, err)
+			// for every tx, store random number of attachments.
+			for j := 0; j < rand.Intn(5)+1; j++ {
+				attachments[transactionID]++
+				cachedAttachment, _ := tangle.Storage.StoreAttachment(transactionID, randomMessageID())
+				cachedAttachment.Release()
+			}
 		}
-	}
 
-	for transactionID := range attachments {
-		cachedAttachments := tangle.Storage.Attachments(transactionID)
-		assert.Equal(t, attachments[transactionID], len(cachedAttachments))
-		for _, cachedAttachment := range cachedAttachments {
-			cachedAttachment.Release()
+		for transactionID := range attachments {
+			cachedAttachments := tangle.Storage.Attachments(transactionID)
+			assert.Equal(t, attachments[transactionID], len(cachedAttachments))
+			for _, cachedAttachment := range cachedAttachments {
+				cachedAttachment.Release()
+			}
+		}
+	})
+}
+
+func TestStorage_Flush(t *testing.T) {
+	database.ForEachStore(t, func(t *testing.T, store kvstore.KVStore) {
+		tangle := newTestTangle(Store(store))
+		defer tangle.Shutdown()
+
+		message := newTestDataMessage("flush")
+		tangle.Storage.StoreMessage(message)
+		tangle.Storage.Flush()
+
+		for _, prefix := range []byte{PrefixMessage, PrefixMessageMetadata} {
+			has, err := store.Has(byteutils.ConcatBytes([]byte{database.PrefixTangle, prefix}, message.ID().Bytes()))
+			require.NoError(t, err)
+			assert.True(t, has, "prefix %d", prefix)
 		}
-	}
+	})
 }
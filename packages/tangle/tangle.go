@@ -39,6 +39,7 @@ type Tangle struct {
 	Storage               *Storage
 	Solidifier            *Solidifier
 	Scheduler             *Scheduler
+	RateSetter            *RateSetter
 	FIFOScheduler         *FIFOScheduler
 	Orderer               *Orderer
 	Booker                *Booker
@@ -47,9 +48,11 @@ type Tangle struct {
 	ConsensusManager      *ConsensusManager
 	TipManager            *TipManager
 	Requester             *Requester
+	Pruner                *Pruner
 	MessageFactory        *MessageFactory
 	LedgerState           *LedgerState
 	Utils                 *Utils
+	ProcessingGate        *ProcessingGate
 	WeightProvider        WeightProvider
 	Events                *Events
 
@@ -71,18 +74,21 @@ func New(options ...Option) (tangle *Tangle) {
 
 	tangle.Configure(options...)
 
+	tangle.ProcessingGate = NewProcessingGate()
 	tangle.Parser = NewParser()
 	tangle.Storage = NewStorage(tangle)
 	tangle.LedgerState = NewLedgerState(tangle)
 	tangle.Solidifier = NewSolidifier(tangle)
 	tangle.FIFOScheduler = NewFIFOScheduler(tangle)
 	tangle.Scheduler = NewScheduler(tangle)
+	tangle.RateSetter = NewRateSetter(tangle)
 	tangle.Booker = NewBooker(tangle)
 	tangle.ApprovalWeightManager = NewApprovalWeightManager(tangle)
 	tangle.TimeManager = NewTimeManager(tangle)
 	tangle.ConsensusManager = NewConsensusManager(tangle)
 	tangle.Requester = NewRequester(tangle)
 	tangle.TipManager = NewTipManager(tangle)
+	tangle.Pruner = NewPruner(tangle)
 	tangle.MessageFactory = NewMessageFactory(tangle, tangle.TipManager)
 	tangle.Utils = NewUtils(tangle)
 	tangle.Orderer = NewOrderer(tangle)
@@ -118,12 +124,14 @@ func (t *Tangle) Setup() {
 	t.Requester.Setup()
 	t.FIFOScheduler.Setup()
 	t.Scheduler.Setup()
+	t.RateSetter.Setup()
 	t.Orderer.Setup()
 	t.Booker.Setup()
 	t.ApprovalWeightManager.Setup()
 	t.TimeManager.Setup()
 	t.ConsensusManager.Setup()
 	t.TipManager.Setup()
+	t.Pruner.Setup()
 
 	t.MessageFactory.Events.Error.Attach(events.NewClosure(func(err error) {
 		t.Events.Error.Trigger(errors.Errorf("error in MessageFactory: %w", err))
@@ -144,6 +152,7 @@ func (t *Tangle) Setup() {
 	}
 
 	t.TimeManager.Start()
+	t.Pruner.Start()
 
 	// pass solid messages to the scheduler
 	t.Solidifier.Events.MessageSolid.Attach(events.NewClosure(t.schedule))
@@ -202,6 +211,11 @@ func (t *Tangle) IssuePayload(p payload.Payload, parentsCount ...int) (message *
 		}
 	}
 
+	if t.RateSetter.Enabled() && t.RateSetter.Size()+len(p.Bytes()) > MaxLocalQueueSize {
+		err = errors.Errorf("can't issue payload: %w", ErrLocalQueueFull)
+		return
+	}
+
 	return t.MessageFactory.IssuePayload(p, parentsCount...)
 }
 
@@ -216,11 +230,34 @@ func (t *Tangle) Prune() (err error) {
 	return t.Storage.Prune()
 }
 
+// Pause blocks until the Tangle finished processing the work in progress and stops it from processing any further
+// messages until Resume is called.
+func (t *Tangle) Pause() {
+	t.ProcessingGate.Pause()
+}
+
+// Resume continues the processing of messages after the Tangle was paused.
+func (t *Tangle) Resume() {
+	t.ProcessingGate.Resume()
+}
+
+// Flush persists the cached objects of the Tangle without shutting it down (i.e. before a backup of the database is
+// taken). The Tangle needs to be paused while it is flushed, as the objectstorages only empty their caches once no
+// more objects are being used.
+func (t *Tangle) Flush() {
+	t.Storage.Flush()
+	t.LedgerState.Flush()
+	t.Booker.MarkersManager.Flush()
+	t.ConsensusManager.Flush()
+}
+
 // Shutdown marks the tangle as stopped, so it will not accept any new messages (waits for all backgroundTasks to finish).
 func (t *Tangle) Shutdown() {
 	close(t.shutdownSignal)
 
 	t.MessageFactory.Shutdown()
+	t.RateSetter.Shutdown()
+	t.Pruner.Shutdown()
 	t.FIFOScheduler.Shutdown()
 	t.Scheduler.Shutdown()
 	t.Orderer.Shutdown()
@@ -246,6 +283,24 @@ func (t *Tangle) schedule(id MessageID) {
 		return
 	}
 
+	// messages of the local node are handed over to the RateSetter, which submits them to the scheduler at its own rate
+	if t.RateSetter.Enabled() {
+		var issuedLocally bool
+		t.Storage.Message(id).Consume(func(message *Message) {
+			if identity.NewID(message.IssuerPublicKey()) != t.Options.Identity.ID() {
+				return
+			}
+			issuedLocally = true
+
+			if err := t.RateSetter.Issue(message); err != nil {
+				t.Events.Error.Trigger(errors.Errorf("failed to submit to rate setter: %w", err))
+			}
+		})
+		if issuedLocally {
+			return
+		}
+	}
+
 	if err := t.Scheduler.SubmitAndReady(id); err != nil {
 		t.Events.Error.Trigger(errors.Errorf("failed to submit to scheduler: %w", err))
 	}
@@ -295,6 +350,9 @@ type Options struct {
 	GenesisNode                  *ed25519.PublicKey
 	SchedulerParams              SchedulerParams
 	RateSetterParams             RateSetterParams
+	PruningParams                PruningParams
+	TipSelectionParams           TipSelectionParams
+	AddressHistory               bool
 	WeightProvider               WeightProvider
 	SyncTimeWindow               time.Duration
 	StartSynced                  bool
@@ -366,6 +424,28 @@ func RateSetterConfig(params RateSetterParams) Option {
 	}
 }
 
+// PruningConfig is an Option for the Tangle that allows to configure the pruning of old Messages.
+func PruningConfig(params PruningParams) Option {
+	return func(options *Options) {
+		options.PruningParams = params
+	}
+}
+
+// TipSelectionConfig is an Option for the Tangle that allows to configure how the TipManager selects strong tips.
+func TipSelectionConfig(params TipSelectionParams) Option {
+	return func(options *Options) {
+		options.TipSelectionParams = params
+	}
+}
+
+// AddressHistory is an Option for the Tangle that allows to define whether the ledger state maintains the history of
+// the Addresses.
+func AddressHistory(enabled bool) Option {
+	return func(options *Options) {
+		options.AddressHistory = enabled
+	}
+}
+
 // ApprovalWeights is an Option for the Tangle that allows to define how the approval weights of Messages is determined.
 func ApprovalWeights(weightProvider WeightProvider) Option {
 	return func(options *Options) {
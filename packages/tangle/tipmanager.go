@@ -130,23 +130,31 @@ const tipLifeGracePeriod = maxParentsTimeDifference - 1*time.Minute
 
 // TipManager manages a map of tips and emits events for their removal and addition.
 type TipManager struct {
-	tangle      *Tangle
-	strongTips  *randommap.RandomMap
-	weakTips    *randommap.RandomMap
-	tipsCleaner *TimedTaskExecutor
-	Events      *TipManagerEvents
+	tangle               *Tangle
+	strongTips           *randommap.RandomMap
+	weakTips             *randommap.RandomMap
+	tipSelectionStrategy TipSelectionStrategy
+	tipsCleaner          *TimedTaskExecutor
+	Events               *TipManagerEvents
 }
 
 // NewTipManager creates a new tip-selector.
 func NewTipManager(tangle *Tangle, tips ...MessageID) *TipManager {
+	tipSelectionStrategy, err := NewTipSelectionStrategy(tangle.Options.TipSelectionParams.Strategy, tangle)
+	if err != nil {
+		panic(err)
+	}
+
 	tipSelector := &TipManager{
-		tangle:      tangle,
-		strongTips:  randommap.New(),
-		weakTips:    randommap.New(),
-		tipsCleaner: NewTimedTaskExecutor(1),
+		tangle:               tangle,
+		strongTips:           randommap.New(),
+		weakTips:             randommap.New(),
+		tipSelectionStrategy: tipSelectionStrategy,
+		tipsCleaner:          NewTimedTaskExecutor(1),
 		Events: &TipManagerEvents{
 			TipAdded:   events.NewEvent(tipEventHandler),
 			TipRemoved: events.NewEvent(tipEventHandler),
+			TipExpired: events.NewEvent(tipEventHandler),
 		},
 	}
 
@@ -168,10 +176,11 @@ func (t *TipManager) Setup() {
 	}))
 }
 
-// Set adds the given messageIDs as tips.
+// Set adds the given messageIDs as tips. As their metadata is unknown, the strategies treat them like old tips of an
+// unknown branch.
 func (t *TipManager) Set(tips ...MessageID) {
 	for _, messageID := range tips {
-		t.strongTips.Set(messageID, messageID)
+		t.strongTips.Set(messageID, &Tip{MessageID: messageID})
 	}
 }
 
@@ -213,16 +222,22 @@ func (t *TipManager) AddTip(message *Message) {
 		panic(err)
 	}
 
+	tip := NewTip(message, messageMetadata, messageBranchID)
 	t.tangle.LedgerState.BranchDAG.Branch(messageBranchID).Consume(func(branch ledgerstate.Branch) {
 		if branch.MonotonicallyLiked() {
-			if t.strongTips.Set(messageID, messageID) {
+			if t.strongTips.Set(messageID, tip) {
 				t.Events.TipAdded.Trigger(&TipEvent{
 					MessageID: messageID,
 					TipType:   StrongTip,
 				})
 
 				t.tipsCleaner.ExecuteAt(messageID, func() {
-					t.strongTips.Delete(messageID)
+					if _, deleted := t.strongTips.Delete(messageID); deleted {
+						t.Events.TipExpired.Trigger(&TipEvent{
+							MessageID: messageID,
+							TipType:   StrongTip,
+						})
+					}
 				}, message.IssuingTime().Add(tipLifeGracePeriod))
 			}
 
@@ -250,14 +265,19 @@ func (t *TipManager) AddTip(message *Message) {
 				}
 			})
 		} else {
-			if t.weakTips.Set(messageID, messageID) {
+			if t.weakTips.Set(messageID, tip) {
 				t.Events.TipAdded.Trigger(&TipEvent{
 					MessageID: messageID,
 					TipType:   WeakTip,
 				})
 
 				t.tipsCleaner.ExecuteAt(messageID, func() {
-					t.weakTips.Delete(messageID)
+					if _, deleted := t.weakTips.Delete(messageID); deleted {
+						t.Events.TipExpired.Trigger(&TipEvent{
+							MessageID: messageID,
+							TipType:   WeakTip,
+						})
+					}
 				}, message.IssuingTime().Add(tipLifeGracePeriod))
 			}
 		}
@@ -301,7 +321,7 @@ func (t *TipManager) Tips(p payload.Payload, countStrongParents, countWeakParent
 }
 
 // selectStrongTips returns a list of strong parents. In case of a transaction, it references young enough attachments
-// of consumed transactions directly. Otherwise/additionally count tips are randomly selected.
+// of consumed transactions directly. Otherwise/additionally count tips are selected by the TipSelectionStrategy.
 func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents MessageIDs) {
 	parents = make([]MessageID, 0, MaxParentsCount)
 	parentsMap := make(map[MessageID]types.Empty)
@@ -351,7 +371,7 @@ func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents Mes
 		count = MaxParentsCount - len(parents)
 	}
 
-	tips := t.strongTips.RandomUniqueEntries(count)
+	tips := t.tipSelectionStrategy.SelectTips(t.strongTips, count)
 	// count is invalid or there are no tips
 	if len(tips) == 0 {
 		// only add genesis if no tip was found and not previously referenced (in case of a transaction)
@@ -361,9 +381,7 @@ func (t *TipManager) selectStrongTips(p payload.Payload, count int) (parents Mes
 		return
 	}
 	// at least one tip is returned
-	for _, tip := range tips {
-		messageID := tip.(MessageID)
-
+	for _, messageID := range tips {
 		if _, ok := parentsMap[messageID]; !ok {
 			parentsMap[messageID] = types.Void
 			parents = append(parents, messageID)
@@ -384,14 +402,17 @@ func (t *TipManager) selectWeakTips(count int) (parents MessageIDs) {
 	}
 	// at least one tip is returned
 	for _, tip := range tips {
-		messageID := tip.(MessageID)
-
-		parents = append(parents, messageID)
+		parents = append(parents, tip.(*Tip).MessageID)
 	}
 
 	return
 }
 
+// TipSelectionStrategy returns the TipSelectionStrategy that is used to select strong tips.
+func (t *TipManager) TipSelectionStrategy() TipSelectionStrategy {
+	return t.tipSelectionStrategy
+}
+
 // AllWeakTips returns a list of all weak tips that are stored in the TipManger.
 func (t *TipManager) AllWeakTips() MessageIDs {
 	return retrieveAllTips(t.weakTips)
@@ -437,6 +458,9 @@ type TipManagerEvents struct {
 
 	// Fired when a tip is removed.
 	TipRemoved *events.Event
+
+	// Fired when a tip is removed because it became too old without being referenced (i.e. it got orphaned).
+	TipExpired *events.Event
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
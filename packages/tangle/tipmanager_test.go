@@ -899,3 +899,5 @@ func (m *mockConsensusProvider) SetTransactionLiked(transactionID ledgerstate.Tr
 }
 
 func (m *mockConsensusProvider) Shutdown() {}
+
+func (m *mockConsensusProvider) Flush() {}
@@ -0,0 +1,457 @@
+package tangle
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/datastructure/randommap"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+const (
+	// UniformTipSelection is the name of the TipSelectionStrategy that selects tips uniformly at random.
+	UniformTipSelection = "uniform"
+
+	// AgeRestrictedTipSelection is the name of the TipSelectionStrategy that only selects tips that are younger than a
+	// maximum age.
+	AgeRestrictedTipSelection = "ageRestricted"
+
+	// ManaWeightedTipSelection is the name of the TipSelectionStrategy that selects tips with a probability proportional
+	// to the access mana of their issuer.
+	ManaWeightedTipSelection = "manaWeighted"
+
+	// LikedBranchTipSelection is the name of the TipSelectionStrategy that prefers tips that are booked into a liked
+	// branch.
+	LikedBranchTipSelection = "likedBranch"
+
+	// LazyTipAvoidanceTipSelection is the name of the TipSelectionStrategy that avoids lazy tips, i.e. tips whose past
+	// cone is considerably shallower than the one of the deepest tip.
+	LazyTipAvoidanceTipSelection = "lazyTipAvoidance"
+
+	// DefaultMaxTipAge is the default maximum age of the tips that are selected by the age restricted tip selection.
+	DefaultMaxTipAge = 1 * time.Minute
+
+	// DefaultMaxDepthGap is the default maximum difference between the past cone depth of a tip and the deepest tip
+	// before the tip is considered to be lazy.
+	DefaultMaxDepthGap = 10
+)
+
+// ErrUnknownTipSelectionStrategy is returned if a TipSelectionStrategy is requested that was never registered.
+var ErrUnknownTipSelectionStrategy = errors.New("unknown tip selection strategy")
+
+// region TipSelectionParams ///////////////////////////////////////////////////////////////////////////////////////////
+
+// TipSelectionParams represents the parameters for the tip selection of the TipManager.
+type TipSelectionParams struct {
+	// Strategy defines the name of the TipSelectionStrategy that is used to select strong tips.
+	Strategy string
+
+	// MaxTipAge defines the maximum age of the tips that are selected by the age restricted tip selection.
+	MaxTipAge time.Duration
+
+	// MaxDepthGap defines the maximum difference between the past cone depth of a tip and the deepest tip before the
+	// tip is avoided by the lazy tip avoidance.
+	MaxDepthGap uint64
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Tip /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Tip is an entry of the tip pool. It caches the information about the tip that the TipSelectionStrategies need, so
+// that selecting tips does not have to load anything from the storage.
+type Tip struct {
+	// MessageID is the identifier of the tip.
+	MessageID MessageID
+
+	// IssuerID is the identifier of the node that issued the tip.
+	IssuerID identity.ID
+
+	// IssuingTime is the time when the tip was issued.
+	IssuingTime time.Time
+
+	// BranchID is the identifier of the branch that the tip is booked into.
+	BranchID ledgerstate.BranchID
+
+	// PastConeDepth is an approximation of the depth of the past cone of the tip (the highest past marker index plus the
+	// gap to it).
+	PastConeDepth uint64
+}
+
+// NewTip creates a Tip from the given booked Message.
+func NewTip(message *Message, messageMetadata *MessageMetadata, branchID ledgerstate.BranchID) (tip *Tip) {
+	tip = &Tip{
+		MessageID:   message.ID(),
+		IssuerID:    identity.NewID(message.IssuerPublicKey()),
+		IssuingTime: message.IssuingTime(),
+		BranchID:    branchID,
+	}
+	if structureDetails := messageMetadata.StructureDetails(); structureDetails != nil {
+		tip.PastConeDepth = uint64(structureDetails.PastMarkers.HighestIndex()) + structureDetails.PastMarkerGap
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TipSelectionStrategy /////////////////////////////////////////////////////////////////////////////////////////
+
+// TipSelectionStrategy is the interface for the different ways of selecting strong tips from the tip pool.
+type TipSelectionStrategy interface {
+	// Name returns the name that the strategy is registered with.
+	Name() string
+
+	// SelectTips returns up to count unique tips from the given pool of tips, which maps the MessageIDs of the tips to
+	// their *Tip entries.
+	SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs)
+}
+
+// TipSelectionStrategyFactory creates a TipSelectionStrategy for the given Tangle.
+type TipSelectionStrategyFactory func(tangle *Tangle) TipSelectionStrategy
+
+var (
+	tipSelectionStrategies = map[string]TipSelectionStrategyFactory{
+		UniformTipSelection: func(*Tangle) TipSelectionStrategy {
+			return &UniformTipSelectionStrategy{}
+		},
+		AgeRestrictedTipSelection: func(tangle *Tangle) TipSelectionStrategy {
+			return NewAgeRestrictedTipSelectionStrategy(tangle)
+		},
+		ManaWeightedTipSelection: func(tangle *Tangle) TipSelectionStrategy {
+			return NewManaWeightedTipSelectionStrategy(tangle)
+		},
+		LikedBranchTipSelection: func(tangle *Tangle) TipSelectionStrategy {
+			return NewLikedBranchTipSelectionStrategy(tangle)
+		},
+		LazyTipAvoidanceTipSelection: func(tangle *Tangle) TipSelectionStrategy {
+			return NewLazyTipAvoidanceTipSelectionStrategy(tangle)
+		},
+	}
+	tipSelectionStrategiesMutex sync.RWMutex
+)
+
+// RegisterTipSelectionStrategy registers a TipSelectionStrategy under the given name, so that it can be selected by
+// the TipSelectionConfig Option. Existing strategies with the same name are replaced.
+func RegisterTipSelectionStrategy(name string, factory TipSelectionStrategyFactory) {
+	tipSelectionStrategiesMutex.Lock()
+	defer tipSelectionStrategiesMutex.Unlock()
+
+	tipSelectionStrategies[name] = factory
+}
+
+// TipSelectionStrategies returns the sorted names of all registered TipSelectionStrategies.
+func TipSelectionStrategies() (names []string) {
+	tipSelectionStrategiesMutex.RLock()
+	defer tipSelectionStrategiesMutex.RUnlock()
+
+	names = make([]string, 0, len(tipSelectionStrategies))
+	for name := range tipSelectionStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return
+}
+
+// NewTipSelectionStrategy creates the TipSelectionStrategy that is registered under the given name. An empty name
+// results in the UniformTipSelectionStrategy.
+func NewTipSelectionStrategy(name string, tangle *Tangle) (strategy TipSelectionStrategy, err error) {
+	if name == "" {
+		name = UniformTipSelection
+	}
+
+	tipSelectionStrategiesMutex.RLock()
+	factory, exists := tipSelectionStrategies[name]
+	tipSelectionStrategiesMutex.RUnlock()
+	if !exists {
+		return nil, errors.Errorf("failed to create tip selection strategy '%s' (available: %v): %w", name, TipSelectionStrategies(), ErrUnknownTipSelectionStrategy)
+	}
+
+	return factory(tangle), nil
+}
+
+// selectUniformly returns up to count randomly chosen elements of the given candidates.
+func selectUniformly(candidates MessageIDs, count int) (selectedTips MessageIDs) {
+	if count > len(candidates) {
+		count = len(candidates)
+	}
+	if count <= 0 {
+		return MessageIDs{}
+	}
+
+	shuffled := make(MessageIDs, len(candidates))
+	copy(shuffled, candidates)
+	for i := 0; i < count; i++ {
+		j := i + rand.Intn(len(shuffled)-i)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+
+	return shuffled[:count]
+}
+
+// retrieveTipEntries returns the *Tip entries of the given pool of tips.
+func retrieveTipEntries(tips *randommap.RandomMap) (entries []*Tip) {
+	entries = make([]*Tip, 0, tips.Size())
+	tips.ForEach(func(_ interface{}, entry interface{}) {
+		entries = append(entries, entry.(*Tip))
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region UniformTipSelectionStrategy //////////////////////////////////////////////////////////////////////////////////
+
+// UniformTipSelectionStrategy is a TipSelectionStrategy that selects tips uniformly at random.
+type UniformTipSelectionStrategy struct{}
+
+// Name returns the name that the strategy is registered with.
+func (u *UniformTipSelectionStrategy) Name() string {
+	return UniformTipSelection
+}
+
+// SelectTips returns up to count unique tips from the given pool of tips.
+func (u *UniformTipSelectionStrategy) SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs) {
+	entries := tips.RandomUniqueEntries(count)
+	selectedTips = make(MessageIDs, len(entries))
+	for i, entry := range entries {
+		selectedTips[i] = entry.(*Tip).MessageID
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AgeRestrictedTipSelectionStrategy ////////////////////////////////////////////////////////////////////////////
+
+// AgeRestrictedTipSelectionStrategy is a TipSelectionStrategy that selects uniformly among the tips that are younger
+// than TipSelectionParams.MaxTipAge. If there are no such tips, it falls back to all tips.
+type AgeRestrictedTipSelectionStrategy struct {
+	maxAge time.Duration
+}
+
+// NewAgeRestrictedTipSelectionStrategy is the constructor of the AgeRestrictedTipSelectionStrategy.
+func NewAgeRestrictedTipSelectionStrategy(tangle *Tangle) *AgeRestrictedTipSelectionStrategy {
+	maxAge := tangle.Options.TipSelectionParams.MaxTipAge
+	if maxAge <= 0 {
+		maxAge = DefaultMaxTipAge
+	}
+
+	return &AgeRestrictedTipSelectionStrategy{
+		maxAge: maxAge,
+	}
+}
+
+// Name returns the name that the strategy is registered with.
+func (a *AgeRestrictedTipSelectionStrategy) Name() string {
+	return AgeRestrictedTipSelection
+}
+
+// SelectTips returns up to count unique tips from the given pool of tips.
+func (a *AgeRestrictedTipSelectionStrategy) SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs) {
+	tipEntries := retrieveTipEntries(tips)
+	allTips := make(MessageIDs, 0, len(tipEntries))
+	youngTips := make(MessageIDs, 0, len(tipEntries))
+	for _, tip := range tipEntries {
+		allTips = append(allTips, tip.MessageID)
+		if clock.Since(tip.IssuingTime) <= a.maxAge {
+			youngTips = append(youngTips, tip.MessageID)
+		}
+	}
+
+	if len(youngTips) == 0 {
+		return selectUniformly(allTips, count)
+	}
+
+	return selectUniformly(youngTips, count)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ManaWeightedTipSelectionStrategy /////////////////////////////////////////////////////////////////////////////
+
+// ManaWeightedTipSelectionStrategy is a TipSelectionStrategy that selects tips with a probability that is proportional
+// to the access mana of their issuer (every issuer has at least MinMana, so that no tip is starved completely).
+type ManaWeightedTipSelectionStrategy struct {
+	tangle *Tangle
+}
+
+// NewManaWeightedTipSelectionStrategy is the constructor of the ManaWeightedTipSelectionStrategy.
+func NewManaWeightedTipSelectionStrategy(tangle *Tangle) *ManaWeightedTipSelectionStrategy {
+	return &ManaWeightedTipSelectionStrategy{
+		tangle: tangle,
+	}
+}
+
+// Name returns the name that the strategy is registered with.
+func (m *ManaWeightedTipSelectionStrategy) Name() string {
+	return ManaWeightedTipSelection
+}
+
+// SelectTips returns up to count unique tips from the given pool of tips. It uses weighted random sampling without
+// replacement (every tip gets the key u^(1/weight) and the tips with the highest keys are selected). The keys are
+// compared in log space, as u^(1/weight) is indistinguishable from 1 for large weights.
+func (m *ManaWeightedTipSelectionStrategy) SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs) {
+	accessManaRetriever := m.tangle.Options.SchedulerParams.AccessManaRetrieveFunc
+	if accessManaRetriever == nil {
+		return (&UniformTipSelectionStrategy{}).SelectTips(tips, count)
+	}
+
+	type weightedTip struct {
+		messageID MessageID
+		key       float64
+	}
+
+	tipEntries := retrieveTipEntries(tips)
+	weightedTips := make([]weightedTip, 0, len(tipEntries))
+	manaByIssuer := make(map[identity.ID]float64)
+	for _, tip := range tipEntries {
+		weight, cached := manaByIssuer[tip.IssuerID]
+		if !cached {
+			weight = math.Max(accessManaRetriever(tip.IssuerID), MinMana)
+			manaByIssuer[tip.IssuerID] = weight
+		}
+
+		weightedTips = append(weightedTips, weightedTip{
+			messageID: tip.MessageID,
+			key:       math.Log(rand.Float64()) / weight,
+		})
+	}
+
+	sort.Slice(weightedTips, func(i, j int) bool {
+		return weightedTips[i].key > weightedTips[j].key
+	})
+	if count > len(weightedTips) {
+		count = len(weightedTips)
+	}
+
+	selectedTips = make(MessageIDs, 0, count)
+	for _, tip := range weightedTips[:count] {
+		selectedTips = append(selectedTips, tip.messageID)
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region LikedBranchTipSelectionStrategy //////////////////////////////////////////////////////////////////////////////
+
+// LikedBranchTipSelectionStrategy is a TipSelectionStrategy that selects uniformly among the tips that are booked into a
+// currently liked branch and only fills up the remaining parents with the other tips.
+type LikedBranchTipSelectionStrategy struct {
+	tangle *Tangle
+}
+
+// NewLikedBranchTipSelectionStrategy is the constructor of the LikedBranchTipSelectionStrategy.
+func NewLikedBranchTipSelectionStrategy(tangle *Tangle) *LikedBranchTipSelectionStrategy {
+	return &LikedBranchTipSelectionStrategy{
+		tangle: tangle,
+	}
+}
+
+// Name returns the name that the strategy is registered with.
+func (l *LikedBranchTipSelectionStrategy) Name() string {
+	return LikedBranchTipSelection
+}
+
+// SelectTips returns up to count unique tips from the given pool of tips.
+func (l *LikedBranchTipSelectionStrategy) SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs) {
+	tipEntries := retrieveTipEntries(tips)
+	likedTips := make(MessageIDs, 0, len(tipEntries))
+	otherTips := make(MessageIDs, 0)
+	likedByBranch := make(map[ledgerstate.BranchID]bool)
+	for _, tip := range tipEntries {
+		liked, cached := likedByBranch[tip.BranchID]
+		if !cached {
+			liked = l.branchLiked(tip.BranchID)
+			likedByBranch[tip.BranchID] = liked
+		}
+
+		if liked {
+			likedTips = append(likedTips, tip.MessageID)
+			continue
+		}
+		otherTips = append(otherTips, tip.MessageID)
+	}
+
+	selectedTips = selectUniformly(likedTips, count)
+	if len(selectedTips) < count {
+		selectedTips = append(selectedTips, selectUniformly(otherTips, count-len(selectedTips))...)
+	}
+
+	return
+}
+
+// branchLiked returns true if the given branch is currently liked.
+func (l *LikedBranchTipSelectionStrategy) branchLiked(branchID ledgerstate.BranchID) (liked bool) {
+	if branchID == ledgerstate.MasterBranchID {
+		return true
+	}
+
+	l.tangle.LedgerState.BranchDAG.Branch(branchID).Consume(func(branch ledgerstate.Branch) {
+		liked = branch.Liked()
+	})
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region LazyTipAvoidanceTipSelectionStrategy /////////////////////////////////////////////////////////////////////////
+
+// LazyTipAvoidanceTipSelectionStrategy is a TipSelectionStrategy that selects uniformly among the tips whose past cone
+// depth (see Tip.PastConeDepth) is at most TipSelectionParams.MaxDepthGap
+// below the one of the deepest tip.
+type LazyTipAvoidanceTipSelectionStrategy struct {
+	maxDepthGap uint64
+}
+
+// NewLazyTipAvoidanceTipSelectionStrategy is the constructor of the LazyTipAvoidanceTipSelectionStrategy.
+func NewLazyTipAvoidanceTipSelectionStrategy(tangle *Tangle) *LazyTipAvoidanceTipSelectionStrategy {
+	maxDepthGap := tangle.Options.TipSelectionParams.MaxDepthGap
+	if maxDepthGap == 0 {
+		maxDepthGap = DefaultMaxDepthGap
+	}
+
+	return &LazyTipAvoidanceTipSelectionStrategy{
+		maxDepthGap: maxDepthGap,
+	}
+}
+
+// Name returns the name that the strategy is registered with.
+func (l *LazyTipAvoidanceTipSelectionStrategy) Name() string {
+	return LazyTipAvoidanceTipSelection
+}
+
+// SelectTips returns up to count unique tips from the given pool of tips.
+func (l *LazyTipAvoidanceTipSelectionStrategy) SelectTips(tips *randommap.RandomMap, count int) (selectedTips MessageIDs) {
+	tipEntries := retrieveTipEntries(tips)
+	maxDepth := uint64(0)
+	for _, tip := range tipEntries {
+		if tip.PastConeDepth > maxDepth {
+			maxDepth = tip.PastConeDepth
+		}
+	}
+
+	activeTips := make(MessageIDs, 0, len(tipEntries))
+	for _, tip := range tipEntries {
+		if tip.PastConeDepth+l.maxDepthGap >= maxDepth {
+			activeTips = append(activeTips, tip.MessageID)
+		}
+	}
+
+	return selectUniformly(activeTips, count)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,163 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/datastructure/randommap"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/markers"
+)
+
+type fixedTipSelectionStrategy struct {
+	tip MessageID
+}
+
+func (f *fixedTipSelectionStrategy) Name() string {
+	return "fixed"
+}
+
+func (f *fixedTipSelectionStrategy) SelectTips(*randommap.RandomMap, int) MessageIDs {
+	return MessageIDs{f.tip}
+}
+
+func TestTipSelectionStrategy_Register(t *testing.T) {
+	fixedTip := randomMessageID()
+	RegisterTipSelectionStrategy("fixed", func(*Tangle) TipSelectionStrategy {
+		return &fixedTipSelectionStrategy{tip: fixedTip}
+	})
+	// the registry is global, so the strategy must not leak into other tests
+	t.Cleanup(func() {
+		tipSelectionStrategiesMutex.Lock()
+		defer tipSelectionStrategiesMutex.Unlock()
+
+		delete(tipSelectionStrategies, "fixed")
+	})
+	assert.Contains(t, TipSelectionStrategies(), "fixed")
+
+	tangle := newTestTangle(TipSelectionConfig(TipSelectionParams{Strategy: "fixed"}))
+	defer tangle.Shutdown()
+	tangle.TipManager.Set(randomMessageID(), randomMessageID())
+
+	assert.Equal(t, "fixed", tangle.TipManager.TipSelectionStrategy().Name())
+	strongParents, _, err := tangle.TipManager.Tips(nil, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, MessageIDs{fixedTip}, strongParents)
+
+	_, err = NewTipSelectionStrategy("unknown", tangle)
+	assert.ErrorIs(t, err, ErrUnknownTipSelectionStrategy)
+}
+
+func TestAgeRestrictedTipSelectionStrategy(t *testing.T) {
+	tangle := newTestTangle(TipSelectionConfig(TipSelectionParams{
+		Strategy:  AgeRestrictedTipSelection,
+		MaxTipAge: time.Minute,
+	}))
+	defer tangle.Shutdown()
+
+	issuer := identity.GenerateIdentity().PublicKey()
+	oldTip := newMessageWithParentsAndTime(issuer, MessageIDs{EmptyMessageID}, time.Now().Add(-5*time.Minute))
+	youngTip := newMessageWithParentsAndTime(issuer, MessageIDs{EmptyMessageID}, time.Now())
+	tips := randommap.New()
+	for _, message := range []*Message{oldTip, youngTip} {
+		tips.Set(message.ID(), &Tip{MessageID: message.ID(), IssuingTime: message.IssuingTime()})
+	}
+
+	strategy := tangle.TipManager.TipSelectionStrategy()
+	assert.Equal(t, MessageIDs{youngTip.ID()}, strategy.SelectTips(tips, 2))
+
+	// without any young tip, the strategy falls back to all tips
+	tips.Delete(youngTip.ID())
+	assert.Equal(t, MessageIDs{oldTip.ID()}, strategy.SelectTips(tips, 2))
+}
+
+func TestManaWeightedTipSelectionStrategy(t *testing.T) {
+	tangle := newTestTangle(TipSelectionConfig(TipSelectionParams{Strategy: ManaWeightedTipSelection}))
+	defer tangle.Shutdown()
+
+	richIssuer := identity.GenerateIdentity().PublicKey()
+	poorIssuer := identity.GenerateIdentity().PublicKey()
+	tangle.Options.SchedulerParams.AccessManaRetrieveFunc = func(nodeID identity.ID) float64 {
+		if nodeID == identity.NewID(richIssuer) {
+			return 1e9
+		}
+		return 0
+	}
+
+	richTip := newMessageWithParentsAndTime(richIssuer, MessageIDs{EmptyMessageID}, time.Now())
+	poorTip := newMessageWithParentsAndTime(poorIssuer, MessageIDs{EmptyMessageID}, time.Now())
+	tips := randommap.New()
+	for _, message := range []*Message{richTip, poorTip} {
+		tips.Set(message.ID(), &Tip{MessageID: message.ID(), IssuerID: identity.NewID(message.IssuerPublicKey())})
+	}
+
+	strategy := tangle.TipManager.TipSelectionStrategy()
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, MessageIDs{richTip.ID()}, strategy.SelectTips(tips, 1))
+	}
+	assert.ElementsMatch(t, MessageIDs{richTip.ID(), poorTip.ID()}, strategy.SelectTips(tips, 2))
+}
+
+func TestLikedBranchTipSelectionStrategy(t *testing.T) {
+	tangle := newTestTangle(TipSelectionConfig(TipSelectionParams{Strategy: LikedBranchTipSelection}))
+	defer tangle.Shutdown()
+
+	issuer := identity.GenerateIdentity().PublicKey()
+	likedTip := newMessageWithParentsAndTime(issuer, MessageIDs{EmptyMessageID}, time.Now())
+	otherTip := newMessageWithParentsAndTime(issuer, MessageIDs{EmptyMessageID}, time.Now())
+	tips := randommap.New()
+	for message, branchID := range map[*Message]ledgerstate.BranchID{
+		likedTip: ledgerstate.MasterBranchID,
+		// a branch that is unknown to the BranchDAG is not liked
+		otherTip: ledgerstate.BranchID{2},
+	} {
+		tips.Set(message.ID(), &Tip{MessageID: message.ID(), BranchID: branchID})
+	}
+
+	strategy := tangle.TipManager.TipSelectionStrategy()
+	assert.Equal(t, LikedBranchTipSelection, strategy.Name())
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, MessageIDs{likedTip.ID()}, strategy.SelectTips(tips, 1))
+	}
+
+	// the remaining parents are filled up with the other tips
+	assert.ElementsMatch(t, MessageIDs{likedTip.ID(), otherTip.ID()}, strategy.SelectTips(tips, 2))
+}
+
+func TestLazyTipAvoidanceTipSelectionStrategy(t *testing.T) {
+	tangle := newTestTangle(TipSelectionConfig(TipSelectionParams{
+		Strategy:    LazyTipAvoidanceTipSelection,
+		MaxDepthGap: 5,
+	}))
+	defer tangle.Shutdown()
+
+	issuer := identity.GenerateIdentity().PublicKey()
+	deepTip := newMessageWithParentsAndTime(issuer, MessageIDs{EmptyMessageID}, time.Now())
+	activeTip := newMessageWithParentsAndTime(issuer, MessageIDs{EmptyMessageID}, time.Now())
+	lazyTip := newMessageWithParentsAndTime(issuer, MessageIDs{EmptyMessageID}, time.Now())
+	tips := randommap.New()
+	for message, depth := range map[*Message]markers.Index{
+		deepTip:   100,
+		activeTip: 95,
+		lazyTip:   10,
+	} {
+		tangle.Storage.StoreMessage(message)
+		require.True(t, tangle.Storage.MessageMetadata(message.ID()).Consume(func(messageMetadata *MessageMetadata) {
+			messageMetadata.SetStructureDetails(&markers.StructureDetails{
+				PastMarkers:   markers.NewMarkers(markers.NewMarker(1, depth)),
+				FutureMarkers: markers.NewMarkers(),
+			})
+			tips.Set(message.ID(), NewTip(message, messageMetadata, ledgerstate.MasterBranchID))
+		}))
+	}
+
+	strategy := tangle.TipManager.TipSelectionStrategy()
+	assert.Equal(t, LazyTipAvoidanceTipSelection, strategy.Name())
+	for i := 0; i < 10; i++ {
+		assert.ElementsMatch(t, MessageIDs{deepTip.ID(), activeTip.ID()}, strategy.SelectTips(tips, 3))
+	}
+}
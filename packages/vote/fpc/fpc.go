@@ -18,6 +18,9 @@ import (
 )
 
 const (
+	// VoterName is the name under which FPC is registered as a voter.
+	VoterName = "fpc"
+
 	toleranceTotalMana = 0.001
 )
 
@@ -28,6 +31,12 @@ var (
 	ErrNoOpinionGiversAvailable = errors.New("can't perform round as no opinion givers are available")
 )
 
+func init() {
+	vote.RegisterVoter(VoterName, func(opinionGiverFunc opinion.OpinionGiverFunc, ownWeightRetrieverFunc opinion.OwnWeightRetriever) vote.DRNGRoundBasedVoter {
+		return New(opinionGiverFunc, ownWeightRetrieverFunc)
+	})
+}
+
 // New creates a new FPC instance.
 func New(opinionGiverFunc opinion.OpinionGiverFunc, ownWeightRetrieverFunc opinion.OwnWeightRetriever, paras ...*Parameters) *FPC {
 	f := &FPC{
@@ -178,15 +187,10 @@ func (f *FPC) formOpinions(rand float64) {
 		if voteCtx.IsNew() {
 			continue
 		}
-		lowerThreshold, upperThreshold := f.setThreshold(voteCtx)
-
-		eta := f.biasTowardsOwnOpinion(voteCtx)
-
-		if eta >= RandUniformThreshold(rand, lowerThreshold, upperThreshold) {
-			voteCtx.AddOpinion(opinion.Like)
-			continue
-		}
-		voteCtx.AddOpinion(opinion.Dislike)
+		eta, opn := FormOpinion(f.paras, voteCtx, rand)
+		voteCtx.Eta = eta
+		voteCtx.RandUsed = rand
+		voteCtx.AddOpinion(opn)
 	}
 }
 
@@ -271,6 +275,7 @@ func (f *FPC) queryOpinions(delayedRoundStart ...time.Duration) ([]opinion.Queri
 				OpinionGiverID: opinionGiverToQuery.ID().String(),
 				Opinions:       make(map[string]opinion.Opinion),
 				TimesCounted:   selectedCount,
+				Mana:           opinionGiverToQuery.Mana(),
 			}
 
 			// add opinions to vote map
@@ -343,26 +348,40 @@ func (f *FPC) voteContextIDs() (conflictIDs []string, timestampIDs []string) {
 	return conflictIDs, timestampIDs
 }
 
+// FormOpinion forms the opinion on the given vote context in a round that uses the given random number. It returns
+// eta, i.e. the liked proportion biased towards the own opinion, and the opinion resulting from comparing eta against
+// the random threshold of the round. The vote context is not modified.
+func FormOpinion(paras *Parameters, voteCtx *vote.Context, rand float64) (float64, opinion.Opinion) {
+	lowerThreshold, upperThreshold := setThreshold(paras, voteCtx)
+
+	eta := biasTowardsOwnOpinion(voteCtx)
+
+	if eta >= RandUniformThreshold(rand, lowerThreshold, upperThreshold) {
+		return eta, opinion.Like
+	}
+	return eta, opinion.Dislike
+}
+
 // get round boundaries based on the voting stage
-func (f *FPC) setThreshold(voteCtx *vote.Context) (float64, float64) {
-	lowerThreshold := f.paras.SubsequentRoundsLowerBoundThreshold
-	upperThreshold := f.paras.SubsequentRoundsUpperBoundThreshold
+func setThreshold(paras *Parameters, voteCtx *vote.Context) (float64, float64) {
+	lowerThreshold := paras.SubsequentRoundsLowerBoundThreshold
+	upperThreshold := paras.SubsequentRoundsUpperBoundThreshold
 
 	if voteCtx.HadFirstRound() {
-		lowerThreshold = f.paras.FirstRoundLowerBoundThreshold
-		upperThreshold = f.paras.FirstRoundUpperBoundThreshold
+		lowerThreshold = paras.FirstRoundLowerBoundThreshold
+		upperThreshold = paras.FirstRoundUpperBoundThreshold
 	}
 
-	if voteCtx.HadFixedRound(f.paras.TotalRoundsCoolingOffPeriod, f.paras.TotalRoundsFinalization, f.paras.TotalRoundsFixedThreshold) {
-		lowerThreshold = f.paras.EndingRoundsFixedThreshold
-		upperThreshold = f.paras.EndingRoundsFixedThreshold
+	if voteCtx.HadFixedRound(paras.TotalRoundsCoolingOffPeriod, paras.TotalRoundsFinalization, paras.TotalRoundsFixedThreshold) {
+		lowerThreshold = paras.EndingRoundsFixedThreshold
+		upperThreshold = paras.EndingRoundsFixedThreshold
 	}
 
 	return lowerThreshold, upperThreshold
 }
 
 // Node biases the received Liked opinion to its current own opinion using base mana proportions
-func (f *FPC) biasTowardsOwnOpinion(voteCtx *vote.Context) float64 {
+func biasTowardsOwnOpinion(voteCtx *vote.Context) float64 {
 	totalMana := voteCtx.Weights.TotalWeights
 	ownMana := voteCtx.Weights.OwnWeight
 
@@ -377,6 +396,11 @@ func (f *FPC) biasTowardsOwnOpinion(voteCtx *vote.Context) float64 {
 	return eta
 }
 
+// Parameters returns the parameters used by the FPC instance.
+func (f *FPC) Parameters() *Parameters {
+	return f.paras
+}
+
 // SetOpinionGiverRng sets random number generator in the FPC instance
 func (f *FPC) SetOpinionGiverRng(rng *rand.Rand) {
 	f.opinionGiverRng = rng
@@ -0,0 +1,389 @@
+package majority
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+const (
+	// VoterName is the name under which the deterministic majority voter is registered.
+	VoterName = "majority"
+	// SnowballVoterName is the name under which the Snowball-style voter is registered.
+	SnowballVoterName = "snowball"
+)
+
+var (
+	// ErrVoteAlreadyOngoing is returned if a vote is already going on for the given ID.
+	ErrVoteAlreadyOngoing = errors.New("a vote is already ongoing for the given ID")
+	// ErrNoOpinionGiversAvailable is returned if a round cannot be performed as no opinion gives are available.
+	ErrNoOpinionGiversAvailable = errors.New("can't perform round as no opinion givers are available")
+)
+
+func init() {
+	vote.RegisterVoter(VoterName, func(opinionGiverFunc opinion.OpinionGiverFunc, ownWeightRetrieverFunc opinion.OwnWeightRetriever) vote.DRNGRoundBasedVoter {
+		return New(opinionGiverFunc, ownWeightRetrieverFunc)
+	})
+	vote.RegisterVoter(SnowballVoterName, func(opinionGiverFunc opinion.OpinionGiverFunc, ownWeightRetrieverFunc opinion.OwnWeightRetriever) vote.DRNGRoundBasedVoter {
+		return New(opinionGiverFunc, ownWeightRetrieverFunc, SnowballParameters())
+	})
+}
+
+// New creates a new majority voter.
+func New(opinionGiverFunc opinion.OpinionGiverFunc, ownWeightRetrieverFunc opinion.OwnWeightRetriever, paras ...*Parameters) *Voter {
+	v := &Voter{
+		opinionGiverFunc:       opinionGiverFunc,
+		ownWeightRetrieverFunc: ownWeightRetrieverFunc,
+		paras:                  DefaultParameters(),
+		opinionGiverRng:        rand.New(rand.NewSource(clock.SyncedTime().UnixNano())),
+		ctxs:                   make(map[string]*vote.Context),
+		proportionsLiked:       make(map[string]float64),
+		confidences:            make(map[string]int),
+		events: vote.Events{
+			Finalized:     events.NewEvent(vote.OpinionCaller),
+			Failed:        events.NewEvent(vote.OpinionCaller),
+			RoundExecuted: events.NewEvent(vote.RoundStatsCaller),
+			Error:         events.NewEvent(events.ErrorCaller),
+		},
+	}
+	if len(paras) > 0 {
+		v.paras = paras[0]
+	}
+	return v
+}
+
+// Voter is a DRNGRoundBasedVoter which adopts the opinion of the queried opinion givers whose weight exceeds the
+// quorum. An opinion is final once it reached the quorum in TotalRoundsFinalization consecutive rounds.
+// Depending on its parameters, it either deterministically takes the majority of all opinion givers or samples them
+// like Snowball does. In contrast to FPC, the random number of a round is not used.
+type Voter struct {
+	events                 vote.Events
+	opinionGiverFunc       opinion.OpinionGiverFunc
+	ownWeightRetrieverFunc opinion.OwnWeightRetriever
+	// contains the set of current vote contexts.
+	ctxs map[string]*vote.Context
+	// contains the liked proportions of the last round, if enough opinions were received.
+	proportionsLiked map[string]float64
+	// contains the amount of consecutive rounds in which the last opinion reached the quorum.
+	confidences map[string]int
+	ctxsMu      sync.RWMutex
+	// parameters to use within the voter.
+	paras *Parameters
+	// used to randomly select opinion givers.
+	opinionGiverRng *rand.Rand
+}
+
+// queryTarget is an opinion giver that is queried in a round.
+type queryTarget struct {
+	// the amount of times the opinion giver was sampled.
+	timesCounted int
+	// the weight of its opinions.
+	weight float64
+}
+
+// tally sums up the weights of the opinions received for a vote context.
+type tally struct {
+	likedWeight float64
+	totalWeight float64
+	count       int
+}
+
+// Vote sets an initial opinion on the vote context, which is queried in the next round.
+func (v *Voter) Vote(id string, objectType vote.ObjectType, initOpn opinion.Opinion) error {
+	v.ctxsMu.Lock()
+	defer v.ctxsMu.Unlock()
+	if _, alreadyOngoing := v.ctxs[id]; alreadyOngoing {
+		return fmt.Errorf("%w: %s", ErrVoteAlreadyOngoing, id)
+	}
+	v.ctxs[id] = vote.NewContext(id, objectType, initOpn)
+	return nil
+}
+
+// IntermediateOpinion returns the last formed opinion.
+// If the vote is not found for the specified ID, it returns with error ErrVotingNotFound.
+func (v *Voter) IntermediateOpinion(id string) (opinion.Opinion, error) {
+	v.ctxsMu.RLock()
+	defer v.ctxsMu.RUnlock()
+	voteCtx, has := v.ctxs[id]
+	if !has {
+		return opinion.Unknown, fmt.Errorf("%w: %s", vote.ErrVotingNotFound, id)
+	}
+	return voteCtx.LastOpinion(), nil
+}
+
+// Events returns the events which happen on a vote.
+func (v *Voter) Events() vote.Events {
+	return v.events
+}
+
+// Round forms opinions based on the last query, finalizes them and then queries for opinions.
+func (v *Voter) Round(rand float64, delayedRoundStart ...time.Duration) error {
+	start := time.Now()
+	v.formOpinions()
+	v.finalizeOpinions()
+
+	// delayedRoundStart gives the time that has elapsed since the start of the current round.
+	delay := time.Duration(0)
+	if len(delayedRoundStart) != 0 {
+		delay = delayedRoundStart[0]
+	}
+	queriedOpinions, err := v.queryOpinions(delay)
+	if err != nil {
+		return err
+	}
+
+	v.events.RoundExecuted.Trigger(&vote.RoundStats{
+		Duration:           time.Since(start),
+		RandUsed:           rand,
+		ActiveVoteContexts: v.activeVoteContexts(),
+		QueriedOpinions:    queriedOpinions,
+	})
+	return nil
+}
+
+// SetOpinionGiverRng sets the random number generator used to sample the opinion givers.
+func (v *Voter) SetOpinionGiverRng(rng *rand.Rand) {
+	v.opinionGiverRng = rng
+}
+
+// formOpinions adopts the opinion that reached the quorum in the last query. If no opinion reached it, the last
+// opinion is kept, but it needs to reach the quorum again for TotalRoundsFinalization rounds to become final.
+func (v *Voter) formOpinions() {
+	v.ctxsMu.Lock()
+	defer v.ctxsMu.Unlock()
+	for id, voteCtx := range v.ctxs {
+		proportionLiked, queried := v.proportionsLiked[id]
+		if !queried {
+			continue
+		}
+		delete(v.proportionsLiked, id)
+
+		switch {
+		case proportionLiked > v.paras.Quorum:
+			v.adoptOpinion(voteCtx, opinion.Like)
+		case 1-proportionLiked > v.paras.Quorum:
+			v.adoptOpinion(voteCtx, opinion.Dislike)
+		default:
+			v.confidences[id] = 0
+			voteCtx.AddOpinion(voteCtx.LastOpinion())
+		}
+	}
+}
+
+func (v *Voter) adoptOpinion(voteCtx *vote.Context, opn opinion.Opinion) {
+	if opn == voteCtx.LastOpinion() {
+		v.confidences[voteCtx.ID]++
+	} else {
+		v.confidences[voteCtx.ID] = 1
+	}
+	voteCtx.AddOpinion(opn)
+}
+
+// emits a Finalized event for every finalized vote context (or Failed event if failed) and then removes it.
+func (v *Voter) finalizeOpinions() {
+	v.ctxsMu.Lock()
+	defer v.ctxsMu.Unlock()
+	for id, voteCtx := range v.ctxs {
+		switch {
+		case v.confidences[id] >= v.paras.TotalRoundsFinalization:
+			v.events.Finalized.Trigger(&vote.OpinionEvent{ID: id, Opinion: voteCtx.LastOpinion(), Ctx: *voteCtx})
+		case voteCtx.Rounds >= v.paras.MaxRoundsPerVoteContext:
+			v.events.Failed.Trigger(&vote.OpinionEvent{ID: id, Opinion: voteCtx.LastOpinion(), Ctx: *voteCtx})
+		default:
+			voteCtx.Rounds++
+			continue
+		}
+		delete(v.ctxs, id)
+		delete(v.proportionsLiked, id)
+		delete(v.confidences, id)
+	}
+}
+
+// queries the opinions of the opinion givers selected for this round.
+func (v *Voter) queryOpinions(delayedRoundStart time.Duration) ([]opinion.QueriedOpinions, error) {
+	conflictIDs, timestampIDs := v.voteContextIDs()
+
+	// nothing to vote on
+	if len(conflictIDs) == 0 && len(timestampIDs) == 0 {
+		return nil, nil
+	}
+
+	opinionGivers, err := v.opinionGiverFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	// nobody to query
+	if len(opinionGivers) == 0 {
+		return nil, ErrNoOpinionGiversAvailable
+	}
+
+	targets, ownWeight, err := v.queryTargets(opinionGivers)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := append(append([]string{}, conflictIDs...), timestampIDs...)
+	tallies := make(map[string]*tally, len(ids))
+	for _, id := range ids {
+		tallies[id] = &tally{}
+	}
+	var talliesMu sync.Mutex
+
+	// holds queried opinions
+	allQueriedOpinions := []opinion.QueriedOpinions{}
+
+	// send queries
+	var wg sync.WaitGroup
+	for opinionGiverToQuery, target := range targets {
+		wg.Add(1)
+		go func(opinionGiverToQuery opinion.OpinionGiver, target *queryTarget) {
+			defer wg.Done()
+
+			queryCtx, cancel := context.WithTimeout(context.Background(), v.paras.QueryTimeout)
+			defer cancel()
+
+			opinions, err := opinionGiverToQuery.Query(queryCtx, conflictIDs, timestampIDs, delayedRoundStart)
+			if err != nil || len(opinions) != len(ids) {
+				// ignore opinions
+				return
+			}
+
+			queriedOpinions := opinion.QueriedOpinions{
+				OpinionGiverID: opinionGiverToQuery.ID().String(),
+				Opinions:       make(map[string]opinion.Opinion),
+				TimesCounted:   target.timesCounted,
+				Mana:           opinionGiverToQuery.Mana(),
+			}
+
+			talliesMu.Lock()
+			defer talliesMu.Unlock()
+			for i, id := range ids {
+				queriedOpinions.Opinions[id] = opinions[i]
+				tallies[id].add(opinions[i], target.weight)
+			}
+			allQueriedOpinions = append(allQueriedOpinions, queriedOpinions)
+		}(opinionGiverToQuery, target)
+	}
+	wg.Wait()
+
+	v.computeLikeProportions(tallies, ownWeight)
+
+	return allQueriedOpinions, nil
+}
+
+// queryTargets selects the opinion givers to query and returns them together with the weight of the own opinion.
+// The own opinion is only counted if all opinion givers are queried.
+func (v *Voter) queryTargets(opinionGivers []opinion.OpinionGiver) (map[opinion.OpinionGiver]*queryTarget, float64, error) {
+	targets := make(map[opinion.OpinionGiver]*queryTarget)
+
+	if v.paras.QuerySampleSize > 0 {
+		var selected map[opinion.OpinionGiver]int
+		if v.paras.ManaWeighted {
+			selected, _ = fpc.ManaBasedSampling(opinionGivers, v.paras.MaxQuerySampleSize, v.paras.QuerySampleSize, v.opinionGiverRng)
+		} else {
+			selected = fpc.UniformSampling(opinionGivers, v.paras.MaxQuerySampleSize, v.paras.QuerySampleSize, v.opinionGiverRng)
+		}
+		for opinionGiver, selectedCount := range selected {
+			targets[opinionGiver] = &queryTarget{timesCounted: selectedCount, weight: float64(selectedCount)}
+		}
+		return targets, 0, nil
+	}
+
+	ownWeight := 1.0
+	if v.paras.ManaWeighted {
+		ownMana, err := v.ownWeightRetrieverFunc()
+		if err != nil {
+			return nil, 0, err
+		}
+		totalMana := ownMana
+		for _, opinionGiver := range opinionGivers {
+			totalMana += opinionGiver.Mana()
+		}
+		// without any mana, every opinion has the same weight
+		if totalMana > 0 {
+			for _, opinionGiver := range opinionGivers {
+				targets[opinionGiver] = &queryTarget{timesCounted: 1, weight: opinionGiver.Mana()}
+			}
+			return targets, ownMana, nil
+		}
+	}
+
+	for _, opinionGiver := range opinionGivers {
+		targets[opinionGiver] = &queryTarget{timesCounted: 1, weight: 1}
+	}
+	return targets, ownWeight, nil
+}
+
+func (v *Voter) computeLikeProportions(tallies map[string]*tally, ownWeight float64) {
+	v.ctxsMu.Lock()
+	defer v.ctxsMu.Unlock()
+	for id, t := range tallies {
+		voteCtx, exists := v.ctxs[id]
+		if !exists || t.count < v.paras.MinOpinionsReceived {
+			continue
+		}
+		queriedWeight := t.totalWeight
+		if ownWeight > 0 {
+			t.add(voteCtx.LastOpinion(), ownWeight)
+		}
+		if t.totalWeight == 0 {
+			continue
+		}
+
+		voteCtx.Weights = vote.VotingWeights{
+			OwnWeight:    ownWeight,
+			TotalWeights: queriedWeight + ownWeight,
+		}
+		voteCtx.ProportionLiked = t.likedWeight / t.totalWeight
+		v.proportionsLiked[id] = voteCtx.ProportionLiked
+	}
+}
+
+func (v *Voter) voteContextIDs() (conflictIDs []string, timestampIDs []string) {
+	v.ctxsMu.RLock()
+	defer v.ctxsMu.RUnlock()
+	for id, ctx := range v.ctxs {
+		switch ctx.Type {
+		case vote.ConflictType:
+			conflictIDs = append(conflictIDs, id)
+		case vote.TimestampType:
+			timestampIDs = append(timestampIDs, id)
+		}
+	}
+	return conflictIDs, timestampIDs
+}
+
+// activeVoteContexts returns a copy of the set of current vote contexts.
+func (v *Voter) activeVoteContexts() map[string]*vote.Context {
+	v.ctxsMu.RLock()
+	defer v.ctxsMu.RUnlock()
+	ctxs := make(map[string]*vote.Context, len(v.ctxs))
+	for id, voteCtx := range v.ctxs {
+		ctxs[id] = voteCtx
+	}
+	return ctxs
+}
+
+// add adds an opinion with the given weight. Unknown opinions are ignored.
+func (t *tally) add(opn opinion.Opinion, weight float64) {
+	switch opn {
+	case opinion.Like:
+		t.likedWeight += weight
+	case opinion.Dislike:
+	default:
+		return
+	}
+	t.totalWeight += weight
+	t.count++
+}
@@ -0,0 +1,179 @@
+package majority_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/majority"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+type opiniongivermock struct {
+	id      identity.ID
+	opinion opinion.Opinion
+	mana    float64
+}
+
+func newOpinionGiverMock(opn opinion.Opinion, mana float64) *opiniongivermock {
+	return &opiniongivermock{
+		id:      identity.GenerateIdentity().ID(),
+		opinion: opn,
+		mana:    mana,
+	}
+}
+
+func (ogm *opiniongivermock) ID() identity.ID {
+	return ogm.id
+}
+
+func (ogm *opiniongivermock) Query(_ context.Context, conflictIDs, timestampIDs []string, _ ...time.Duration) (opinion.Opinions, error) {
+	opinions := make(opinion.Opinions, len(conflictIDs)+len(timestampIDs))
+	for i := range opinions {
+		opinions[i] = ogm.opinion
+	}
+	return opinions, nil
+}
+
+func (ogm *opiniongivermock) Mana() float64 {
+	return ogm.mana
+}
+
+func opinionGiverFunc(opinionGivers ...opinion.OpinionGiver) opinion.OpinionGiverFunc {
+	return func() ([]opinion.OpinionGiver, error) {
+		return opinionGivers, nil
+	}
+}
+
+func ownWeightRetrieverFunc(weight float64) opinion.OwnWeightRetriever {
+	return func() (float64, error) {
+		return weight, nil
+	}
+}
+
+func TestRegisteredVoters(t *testing.T) {
+	assert.Subset(t, vote.RegisteredVoters(), []string{fpc.VoterName, majority.VoterName, majority.SnowballVoterName})
+
+	voter, err := vote.NewVoter(majority.SnowballVoterName, opinionGiverFunc(), ownWeightRetrieverFunc(0))
+	require.NoError(t, err)
+	assert.IsType(t, &majority.Voter{}, voter)
+
+	_, err = vote.NewVoter("unknown", opinionGiverFunc(), ownWeightRetrieverFunc(0))
+	assert.True(t, errors.Is(err, vote.ErrUnknownVoter))
+}
+
+func TestMajorityPreventSameIDMultipleTimes(t *testing.T) {
+	voter := majority.New(nil, nil)
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+	// can't add the same item twice
+	assert.True(t, errors.Is(voter.Vote("a", vote.ConflictType, opinion.Like), majority.ErrVoteAlreadyOngoing))
+}
+
+func TestMajorityFinalizedEvent(t *testing.T) {
+	voter := majority.New(opinionGiverFunc(
+		newOpinionGiverMock(opinion.Like, 0),
+		newOpinionGiverMock(opinion.Like, 0),
+		newOpinionGiverMock(opinion.Like, 0),
+		newOpinionGiverMock(opinion.Dislike, 0),
+	), ownWeightRetrieverFunc(0))
+
+	var finalizedOpinion *opinion.Opinion
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalizedOpinion = &ev.Opinion
+	}))
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Dislike))
+
+	// the first round only queries, the majority needs to be reached in the following 3 rounds
+	for i := 0; i < 4; i++ {
+		assert.Nil(t, finalizedOpinion, "finalized event should not have been fired in round %d", i)
+		assert.NoError(t, voter.Round(0.5))
+	}
+
+	require.NotNil(t, finalizedOpinion, "finalized event should have been fired")
+	assert.Equal(t, opinion.Like, *finalizedOpinion, "the final opinion should have been 'Like'")
+}
+
+func TestMajorityManaWeighted(t *testing.T) {
+	paras := majority.DefaultParameters()
+	paras.ManaWeighted = true
+	voter := majority.New(opinionGiverFunc(
+		newOpinionGiverMock(opinion.Like, 1),
+		newOpinionGiverMock(opinion.Like, 1),
+		newOpinionGiverMock(opinion.Dislike, 10),
+	), ownWeightRetrieverFunc(1), paras)
+
+	var finalizedOpinion *opinion.Opinion
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalizedOpinion = &ev.Opinion
+	}))
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+
+	require.NotNil(t, finalizedOpinion, "finalized event should have been fired")
+	assert.Equal(t, opinion.Dislike, *finalizedOpinion, "the final opinion should have been 'Dislike'")
+}
+
+func TestMajorityFailedEvent(t *testing.T) {
+	paras := majority.DefaultParameters()
+	paras.Quorum = 0.7
+	paras.MaxRoundsPerVoteContext = 3
+	voter := majority.New(opinionGiverFunc(
+		newOpinionGiverMock(opinion.Like, 0),
+		newOpinionGiverMock(opinion.Dislike, 0),
+	), ownWeightRetrieverFunc(0), paras)
+
+	var failedOpinion *opinion.Opinion
+	voter.Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		failedOpinion = &ev.Opinion
+	}))
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+
+	// the quorum of 0.7 is never reached
+	for i := 0; i < 4; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+
+	require.NotNil(t, failedOpinion, "failed event should have been fired")
+	assert.Equal(t, opinion.Like, *failedOpinion, "the final opinion should have been 'Like'")
+}
+
+func TestSnowballVotingMultipleOpinionGivers(t *testing.T) {
+	opinionGivers := make([]opinion.OpinionGiver, 0, 10)
+	for i := 0; i < 8; i++ {
+		opinionGivers = append(opinionGivers, newOpinionGiverMock(opinion.Dislike, 1))
+	}
+	for i := 0; i < 2; i++ {
+		opinionGivers = append(opinionGivers, newOpinionGiverMock(opinion.Like, 1))
+	}
+
+	paras := majority.SnowballParameters()
+	paras.TotalRoundsFinalization = 2
+	voter := majority.New(opinionGiverFunc(opinionGivers...), ownWeightRetrieverFunc(1), paras)
+
+	var finalizedOpinion *opinion.Opinion
+	var roundsDone int
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		finalizedOpinion = &ev.Opinion
+		roundsDone = ev.Ctx.Rounds
+	}))
+	assert.NoError(t, voter.Vote("a", vote.ConflictType, opinion.Like))
+
+	for i := 0; finalizedOpinion == nil && i < paras.MaxRoundsPerVoteContext; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+
+	require.NotNil(t, finalizedOpinion, "finalized event should have been fired")
+	assert.Equal(t, opinion.Dislike, *finalizedOpinion, "the final opinion should have been 'Dislike'")
+	assert.GreaterOrEqual(t, roundsDone, paras.TotalRoundsFinalization)
+}
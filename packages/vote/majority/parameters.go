@@ -0,0 +1,53 @@
+package majority
+
+import "time"
+
+// Parameters define the parameters of a majority voter.
+type Parameters struct {
+	// The amount of opinions to query on each round for a given vote context. If 0, all opinion givers are queried
+	// and the own opinion is counted as well. Also called 'k'.
+	QuerySampleSize int
+	// The maximum amount of samples to draw on each round. Only used if QuerySampleSize is greater than 0.
+	MaxQuerySampleSize int
+	// Whether opinion givers are sampled and their opinions are weighted by their mana.
+	ManaWeighted bool
+	// The proportion of the received opinions an opinion needs to exceed to be adopted. Also called 'alpha'.
+	Quorum float64
+	// The amount of consecutive rounds the quorum needs to be reached for the same opinion to be considered final.
+	// Also called 'beta'.
+	TotalRoundsFinalization int
+	// The max amount of rounds to execute per vote context before aborting them.
+	MaxRoundsPerVoteContext int
+	// The max amount of time a query is allowed to take.
+	QueryTimeout time.Duration
+	// MinOpinionsReceived defines the minimum amount of opinions to receive in order to consider a round valid.
+	MinOpinionsReceived int
+}
+
+// DefaultParameters returns the parameters of a deterministic majority voter, which queries all opinion givers and
+// adopts the opinion of the simple majority.
+func DefaultParameters() *Parameters {
+	return &Parameters{
+		QuerySampleSize:         0,
+		Quorum:                  0.5,
+		TotalRoundsFinalization: 3,
+		MaxRoundsPerVoteContext: 100,
+		QueryTimeout:            1500 * time.Millisecond,
+		MinOpinionsReceived:     1,
+	}
+}
+
+// SnowballParameters returns the parameters of a Snowball-style voter, which samples the opinion givers by their mana
+// and requires a qualified majority in consecutive rounds.
+func SnowballParameters() *Parameters {
+	return &Parameters{
+		QuerySampleSize:         21,
+		MaxQuerySampleSize:      100,
+		ManaWeighted:            true,
+		Quorum:                  0.7,
+		TotalRoundsFinalization: 10,
+		MaxRoundsPerVoteContext: 100,
+		QueryTimeout:            1500 * time.Millisecond,
+		MinOpinionsReceived:     1,
+	}
+}
@@ -27,6 +27,8 @@ type QueriedOpinions struct {
 	// Usually this number is 1 but due to randomization of the queried opinion givers,
 	// the same opinion giver's opinions might be taken into account multiple times.
 	TimesCounted int `json:"times_counted"`
+	// The mana of the opinion giver at the time it was queried.
+	Mana float64 `json:"mana"`
 }
 
 // OpinionGiverFunc is a function which gives a slice of OpinionGivers or an error.
@@ -0,0 +1,70 @@
+package vote
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// ErrUnknownVoter is returned when a voter with a given name wasn't registered.
+var ErrUnknownVoter = errors.New("unknown voter")
+
+// VoterFactory creates a DRNGRoundBasedVoter which queries the opinion givers returned by opinionGiverFunc and
+// retrieves its own weight using ownWeightRetrieverFunc.
+type VoterFactory func(opinionGiverFunc opinion.OpinionGiverFunc, ownWeightRetrieverFunc opinion.OwnWeightRetriever) DRNGRoundBasedVoter
+
+var (
+	voterFactories      = make(map[string]VoterFactory)
+	voterFactoriesMutex sync.RWMutex
+)
+
+// RegisterVoter registers the factory of a voting protocol under the given name, so that it can be selected by
+// NewVoter. Voting protocols usually register themselves in the init function of their package.
+// It panics if a voter with the same name was already registered.
+func RegisterVoter(name string, factory VoterFactory) {
+	voterFactoriesMutex.Lock()
+	defer voterFactoriesMutex.Unlock()
+
+	if _, exists := voterFactories[name]; exists {
+		panic("voter " + name + " registered twice")
+	}
+	voterFactories[name] = factory
+}
+
+// NewVoter creates a new instance of the voter that was registered under the given name.
+func NewVoter(name string, opinionGiverFunc opinion.OpinionGiverFunc, ownWeightRetrieverFunc opinion.OwnWeightRetriever) (DRNGRoundBasedVoter, error) {
+	voterFactoriesMutex.RLock()
+	factory, exists := voterFactories[name]
+	voterFactoriesMutex.RUnlock()
+
+	if !exists {
+		return nil, errors.Errorf("%w: %s (registered: %s)", ErrUnknownVoter, name, strings.Join(RegisteredVoters(), ", "))
+	}
+	return factory(opinionGiverFunc, ownWeightRetrieverFunc), nil
+}
+
+// VoterRegistered returns true if a voter was registered under the given name.
+func VoterRegistered(name string) bool {
+	voterFactoriesMutex.RLock()
+	defer voterFactoriesMutex.RUnlock()
+
+	_, exists := voterFactories[name]
+	return exists
+}
+
+// RegisteredVoters returns the sorted names of all registered voters.
+func RegisteredVoters() []string {
+	voterFactoriesMutex.RLock()
+	defer voterFactoriesMutex.RUnlock()
+
+	names := make([]string, 0, len(voterFactories))
+	for name := range voterFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,87 @@
+package simulation
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+)
+
+// ErrInvalidConfig is returned if a simulation cannot be run with the given config.
+var ErrInvalidConfig = errors.New("invalid simulation config")
+
+// AdversaryStrategy defines how adversarial nodes reply to queries.
+type AdversaryStrategy string
+
+const (
+	// AdversaryMinority replies with the opinion currently held by the minority of the honest nodes, which tries to
+	// keep the honest nodes split.
+	AdversaryMinority AdversaryStrategy = "minority"
+	// AdversaryRandom replies with a random opinion to every query.
+	AdversaryRandom AdversaryStrategy = "random"
+	// AdversarySilent never replies, like an unreachable node.
+	AdversarySilent AdversaryStrategy = "silent"
+)
+
+// Config defines a simulation.
+type Config struct {
+	// Voter is the name of the registered voter used by the honest nodes.
+	Voter string
+	// Nodes is the amount of nodes in the network, including the adversarial ones.
+	Nodes int
+	// Adversaries is the amount of adversarial nodes, which are randomly chosen in each run.
+	Adversaries int
+	// AdversaryStrategy defines how the adversarial nodes reply to queries.
+	AdversaryStrategy AdversaryStrategy
+	// InitialLikeRatio is the proportion of honest nodes that initially like the conflict.
+	InitialLikeRatio float64
+	// ManaZipf is the exponent of the Zipf distribution of the mana of the nodes. If 0, all nodes have the same mana.
+	ManaZipf float64
+	// MaxRounds is the amount of rounds after which a run is aborted.
+	MaxRounds int
+	// Runs is the amount of independent votes that are simulated.
+	Runs int
+	// RoundInterval is the duration of a round, used to report the finalization time.
+	RoundInterval time.Duration
+	// Seed of the random number generator, which makes the mana, the opinions and the random numbers reproducible.
+	Seed int64
+}
+
+// DefaultConfig returns the config of a network of 100 honest FPC nodes, which are split evenly.
+func DefaultConfig() *Config {
+	return &Config{
+		Voter:             fpc.VoterName,
+		Nodes:             100,
+		Adversaries:       0,
+		AdversaryStrategy: AdversaryMinority,
+		InitialLikeRatio:  0.5,
+		ManaZipf:          0,
+		MaxRounds:         100,
+		Runs:              10,
+		RoundInterval:     10 * time.Second,
+		Seed:              0,
+	}
+}
+
+func (c *Config) validate() error {
+	switch {
+	case c.Nodes < 2:
+		return errors.Errorf("at least 2 nodes are needed: %w", ErrInvalidConfig)
+	case c.Adversaries < 0 || c.Adversaries >= c.Nodes:
+		return errors.Errorf("adversaries must be between 0 and %d: %w", c.Nodes-1, ErrInvalidConfig)
+	case c.InitialLikeRatio < 0 || c.InitialLikeRatio > 1:
+		return errors.Errorf("initial like ratio must be between 0 and 1: %w", ErrInvalidConfig)
+	case c.ManaZipf < 0:
+		return errors.Errorf("mana zipf exponent must not be negative: %w", ErrInvalidConfig)
+	case c.MaxRounds < 1 || c.Runs < 1:
+		return errors.Errorf("at least 1 run with 1 round is needed: %w", ErrInvalidConfig)
+	}
+
+	switch c.AdversaryStrategy {
+	case AdversaryMinority, AdversaryRandom, AdversarySilent:
+		return nil
+	default:
+		return errors.Errorf("unknown adversary strategy %q: %w", c.AdversaryStrategy, ErrInvalidConfig)
+	}
+}
@@ -0,0 +1,351 @@
+// Package simulation runs many voters in-process over a simulated network, to evaluate voting protocols and their
+// parameters. All nodes are queried synchronously: the opinions a node replies with in a round are the ones it held at
+// the end of the previous round.
+package simulation
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	// register the majority and Snowball-style voters
+	_ "github.com/iotaledger/goshimmer/packages/vote/majority"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// conflictID is the ID of the conflict that is voted on in every run.
+const conflictID = "conflict"
+
+var errNoReply = errors.New("adversary does not reply")
+
+// Result summarizes the runs of a simulation.
+type Result struct {
+	// Runs is the amount of simulated votes.
+	Runs int
+	// HonestNodes is the amount of honest nodes in every run.
+	HonestNodes int
+	// FinalizedNodes is the amount of honest nodes that finalized their opinion, summed over all runs.
+	FinalizedNodes int
+	// AgreementFailures is the amount of runs in which honest nodes finalized different opinions.
+	AgreementFailures int
+	// TerminationFailures is the amount of runs in which at least one honest node did not finalize its opinion.
+	TerminationFailures int
+	// LikedRuns is the amount of runs in which all finalized honest nodes liked the conflict.
+	LikedRuns int
+	// MeanFinalizationRounds is the mean round in which the honest nodes finalized their opinion.
+	MeanFinalizationRounds float64
+	// MaxFinalizationRounds is the last round in which an honest node finalized its opinion.
+	MaxFinalizationRounds int
+
+	roundInterval time.Duration
+}
+
+// AgreementFailureRate returns the proportion of runs in which honest nodes finalized different opinions.
+func (r *Result) AgreementFailureRate() float64 {
+	return float64(r.AgreementFailures) / float64(r.Runs)
+}
+
+// TerminationFailureRate returns the proportion of runs in which not all honest nodes finalized their opinion.
+func (r *Result) TerminationFailureRate() float64 {
+	return float64(r.TerminationFailures) / float64(r.Runs)
+}
+
+// MeanFinalizationTime returns the mean time it took the honest nodes to finalize their opinion.
+func (r *Result) MeanFinalizationTime() time.Duration {
+	return time.Duration(r.MeanFinalizationRounds * float64(r.roundInterval))
+}
+
+// MaxFinalizationTime returns the time it took the slowest honest node to finalize its opinion.
+func (r *Result) MaxFinalizationTime() time.Duration {
+	return time.Duration(r.MaxFinalizationRounds) * r.roundInterval
+}
+
+// Run runs the simulation defined by the given config.
+func Run(config *Config) (*Result, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+	result := &Result{
+		Runs:          config.Runs,
+		HonestNodes:   config.Nodes - config.Adversaries,
+		roundInterval: config.RoundInterval,
+	}
+
+	finalizationRoundsSum := 0
+	for i := 0; i < config.Runs; i++ {
+		n, err := newNetwork(config, rng)
+		if err != nil {
+			return nil, err
+		}
+		if err := n.run(); err != nil {
+			return nil, errors.Wrapf(err, "run %d failed", i)
+		}
+
+		finalizedOpinions := make(map[opinion.Opinion]int)
+		for _, h := range n.honest {
+			if !h.finalized {
+				continue
+			}
+			finalizedOpinions[h.opinion]++
+			finalizationRoundsSum += h.finalizationRound
+			if h.finalizationRound > result.MaxFinalizationRounds {
+				result.MaxFinalizationRounds = h.finalizationRound
+			}
+			result.FinalizedNodes++
+		}
+		if len(finalizedOpinions) > 1 {
+			result.AgreementFailures++
+		}
+		if finalizedOpinions[opinion.Like] == len(n.honest) {
+			result.LikedRuns++
+		}
+		if finalizedOpinions[opinion.Like]+finalizedOpinions[opinion.Dislike] < len(n.honest) {
+			result.TerminationFailures++
+		}
+	}
+	if result.FinalizedNodes > 0 {
+		result.MeanFinalizationRounds = float64(finalizationRoundsSum) / float64(result.FinalizedNodes)
+	}
+
+	return result, nil
+}
+
+// region network //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// network is the simulated network of a single run.
+type network struct {
+	config *Config
+	rng    *rand.Rand
+	nodes  []*node
+	honest []*node
+	// the round that is currently executed.
+	round int
+	// the opinion held by the minority of the honest nodes at the end of the last round.
+	minorityOpinion opinion.Opinion
+}
+
+func newNetwork(config *Config, rng *rand.Rand) (*network, error) {
+	n := &network{
+		config: config,
+		rng:    rng,
+		nodes:  make([]*node, config.Nodes),
+	}
+
+	// the mana follows a Zipf distribution over the nodes, scaled to a mean of 1
+	manaSum := 0.0
+	for i := range n.nodes {
+		manaSum += math.Pow(float64(i+1), -config.ManaZipf)
+	}
+	for i := range n.nodes {
+		n.nodes[i] = &node{
+			network: n,
+			id:      identity.GenerateIdentity().ID(),
+			mana:    math.Pow(float64(i+1), -config.ManaZipf) * float64(config.Nodes) / manaSum,
+			rng:     rand.New(rand.NewSource(rng.Int63())),
+		}
+	}
+
+	// choose the adversaries and the initial opinions of the honest nodes randomly
+	likes := int(math.Round(config.InitialLikeRatio * float64(config.Nodes-config.Adversaries)))
+	for i, index := range rng.Perm(config.Nodes) {
+		nd := n.nodes[index]
+		switch {
+		case i < config.Adversaries:
+			nd.adversarial = true
+			continue
+		case len(n.honest) < likes:
+			nd.opinion = opinion.Like
+		default:
+			nd.opinion = opinion.Dislike
+		}
+		n.honest = append(n.honest, nd)
+	}
+
+	for _, h := range n.honest {
+		if err := h.setupVoter(); err != nil {
+			return nil, err
+		}
+	}
+	n.updateMinorityOpinion()
+
+	return n, nil
+}
+
+// run votes on the conflict until all honest nodes finalized their opinion or MaxRounds is reached.
+func (n *network) run() error {
+	for _, h := range n.honest {
+		if err := h.voter.Vote(conflictID, vote.ConflictType, h.opinion); err != nil {
+			return err
+		}
+	}
+
+	for n.round = 1; n.round <= n.config.MaxRounds && !n.done(); n.round++ {
+		// all nodes use the same random number, like the one of the dRNG
+		random := n.rng.Float64()
+		for _, h := range n.honest {
+			if h.done() {
+				continue
+			}
+			if err := h.voter.Round(random); err != nil {
+				return errors.Wrapf(err, "round %d failed", n.round)
+			}
+		}
+
+		// the opinions formed in this round are only replied in the next round
+		for _, h := range n.honest {
+			h.updateOpinion()
+		}
+		n.updateMinorityOpinion()
+	}
+
+	return nil
+}
+
+func (n *network) done() bool {
+	for _, h := range n.honest {
+		if !h.done() {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *network) updateMinorityOpinion() {
+	likes := 0
+	for _, h := range n.honest {
+		if h.opinion == opinion.Like {
+			likes++
+		}
+	}
+	n.minorityOpinion = opinion.Like
+	if 2*likes > len(n.honest) {
+		n.minorityOpinion = opinion.Dislike
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region node /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// node is a simulated node, which is an opinion giver to all other nodes.
+type node struct {
+	network     *network
+	id          identity.ID
+	mana        float64
+	adversarial bool
+	voter       vote.DRNGRoundBasedVoter
+
+	// the opinion the node replies with in the current round.
+	opinion           opinion.Opinion
+	finalized         bool
+	failed            bool
+	finalizationRound int
+	// the opinion the node finalized or failed with.
+	lastOpinion opinion.Opinion
+
+	rng   *rand.Rand
+	rngMu sync.Mutex
+}
+
+func (nd *node) setupVoter() (err error) {
+	opinionGivers := make([]opinion.OpinionGiver, 0, len(nd.network.nodes)-1)
+	for _, other := range nd.network.nodes {
+		if other != nd {
+			opinionGivers = append(opinionGivers, other)
+		}
+	}
+
+	nd.voter, err = vote.NewVoter(nd.network.config.Voter, func() ([]opinion.OpinionGiver, error) {
+		return opinionGivers, nil
+	}, func() (float64, error) {
+		return nd.mana, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// make the sampling of the opinion givers reproducible
+	if voter, ok := nd.voter.(interface{ SetOpinionGiverRng(*rand.Rand) }); ok {
+		voter.SetOpinionGiverRng(rand.New(rand.NewSource(nd.rng.Int63())))
+	}
+
+	nd.voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		nd.finalized = true
+		nd.finalizationRound = nd.network.round
+		nd.lastOpinion = ev.Opinion
+	}))
+	nd.voter.Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		nd.failed = true
+		nd.lastOpinion = ev.Opinion
+	}))
+
+	return nil
+}
+
+func (nd *node) done() bool {
+	return nd.finalized || nd.failed
+}
+
+func (nd *node) updateOpinion() {
+	if nd.done() {
+		nd.opinion = nd.lastOpinion
+		return
+	}
+	if o, err := nd.voter.IntermediateOpinion(conflictID); err == nil {
+		nd.opinion = o
+	}
+}
+
+// Query returns the opinions of the node. Honest nodes reply with their current opinion.
+func (nd *node) Query(_ context.Context, conflictIDs []string, timestampIDs []string, _ ...time.Duration) (opinion.Opinions, error) {
+	if nd.adversarial && nd.network.config.AdversaryStrategy == AdversarySilent {
+		return nil, errNoReply
+	}
+
+	opinions := make(opinion.Opinions, len(conflictIDs)+len(timestampIDs))
+	for i := range opinions {
+		opinions[i] = nd.reply()
+	}
+	return opinions, nil
+}
+
+func (nd *node) reply() opinion.Opinion {
+	if !nd.adversarial {
+		return nd.opinion
+	}
+
+	switch nd.network.config.AdversaryStrategy {
+	case AdversaryMinority:
+		return nd.network.minorityOpinion
+	case AdversaryRandom:
+		nd.rngMu.Lock()
+		defer nd.rngMu.Unlock()
+		if nd.rng.Intn(2) == 0 {
+			return opinion.Like
+		}
+		return opinion.Dislike
+	default:
+		return opinion.Unknown
+	}
+}
+
+// ID returns the ID of the node.
+func (nd *node) ID() identity.ID {
+	return nd.id
+}
+
+// Mana returns the mana of the node.
+func (nd *node) Mana() float64 {
+	return nd.mana
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
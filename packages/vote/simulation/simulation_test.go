@@ -0,0 +1,70 @@
+package simulation_test
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/majority"
+	"github.com/iotaledger/goshimmer/packages/vote/simulation"
+)
+
+func TestRun_Majority(t *testing.T) {
+	config := simulation.DefaultConfig()
+	config.Voter = majority.VoterName
+	config.Nodes = 20
+	config.InitialLikeRatio = 0.8
+	config.Runs = 3
+
+	result, err := simulation.Run(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3*20, result.FinalizedNodes)
+	assert.Equal(t, 3, result.LikedRuns)
+	assert.Zero(t, result.AgreementFailures)
+	assert.Zero(t, result.TerminationFailures)
+	// the first round only queries, the majority needs to be reached in the following 3 rounds
+	assert.Equal(t, 4.0, result.MeanFinalizationRounds)
+	assert.Equal(t, 4, result.MaxFinalizationRounds)
+	assert.Equal(t, 4*config.RoundInterval, result.MaxFinalizationTime())
+}
+
+func TestRun_Adversaries(t *testing.T) {
+	for _, strategy := range []simulation.AdversaryStrategy{simulation.AdversaryMinority, simulation.AdversaryRandom, simulation.AdversarySilent} {
+		t.Run(string(strategy), func(t *testing.T) {
+			config := simulation.DefaultConfig()
+			config.Voter = fpc.VoterName
+			config.Nodes = 30
+			config.Adversaries = 3
+			config.AdversaryStrategy = strategy
+			config.InitialLikeRatio = 0.9
+			config.ManaZipf = 0.9
+			config.Runs = 2
+
+			result, err := simulation.Run(config)
+			require.NoError(t, err)
+
+			assert.Equal(t, 27, result.HonestNodes)
+			assert.Equal(t, 2*27, result.FinalizedNodes)
+			assert.Zero(t, result.AgreementFailureRate())
+			assert.Zero(t, result.TerminationFailureRate())
+			assert.Greater(t, result.MeanFinalizationRounds, float64(fpc.DefaultParameters().TotalRoundsFinalization))
+		})
+	}
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	config := simulation.DefaultConfig()
+	config.Adversaries = config.Nodes
+	_, err := simulation.Run(config)
+	assert.True(t, errors.Is(err, simulation.ErrInvalidConfig))
+
+	config = simulation.DefaultConfig()
+	config.Voter = "unknown"
+	_, err = simulation.Run(config)
+	assert.True(t, errors.Is(err, vote.ErrUnknownVoter))
+}
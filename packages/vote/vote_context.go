@@ -39,6 +39,10 @@ type Context struct {
 	Opinions []opinion.Opinion
 	// Weights used for voting
 	Weights VotingWeights
+	// The liked proportion biased towards the own opinion, which was compared against the threshold in the last round.
+	Eta float64
+	// The random number which was used to form the last opinion.
+	RandUsed float64
 	// ConflictCreationTime points to time when the context has been created
 	ConflictCreationTime time.Time
 }
@@ -0,0 +1,229 @@
+package votelog
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+)
+
+// ErrTraceNotFound is returned if no vote was logged for an ID.
+var ErrTraceNotFound = errors.New("vote trace not found")
+
+// staleTraceRounds is the amount of executed rounds after which the trace of a vote context that is no longer active is
+// dropped from memory, although neither its finalization nor its failure was recorded.
+const staleTraceRounds = 3
+
+const (
+	// traceKeyPrefix is the prefix of the keys of the stored traces.
+	traceKeyPrefix byte = iota
+	// startIndexKeyPrefix is the prefix of the keys that index the stored traces by the start of their vote.
+	startIndexKeyPrefix
+)
+
+// Log records the votes of an FPC voter, so that every decision can be audited and replayed afterwards. It is fed by
+// the RoundExecuted, Finalized and Failed events of the voter and persists the trace of a vote after every round.
+type Log struct {
+	store      kvstore.KVStore
+	parameters *fpc.Parameters
+	// the traces of the ongoing votes.
+	traces map[string]*ongoingTrace
+	// the amount of rounds that were recorded.
+	rounds uint64
+	mutex  sync.Mutex
+}
+
+// ongoingTrace is the trace of an ongoing vote together with the last round in which its vote context was active.
+type ongoingTrace struct {
+	*Trace
+	lastActiveRound uint64
+}
+
+// NewLog creates a log that persists the traces of the votes of a voter with the given parameters in the given store.
+func NewLog(store kvstore.KVStore, parameters *fpc.Parameters) *Log {
+	return &Log{
+		store:      store,
+		parameters: parameters,
+		traces:     make(map[string]*ongoingTrace),
+	}
+}
+
+// RecordRound records an executed round for all active vote contexts.
+func (l *Log) RecordRound(roundStats *vote.RoundStats) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := clock.SyncedTime()
+	l.rounds++
+	for id, voteCtx := range roundStats.ActiveVoteContexts {
+		trace := l.trace(voteCtx)
+		l.traces[voteCtx.ID].lastActiveRound = l.rounds
+		round := &Round{
+			Number:          voteCtx.Rounds,
+			Time:            now,
+			RandUsed:        roundStats.RandUsed,
+			OpinionFormed:   len(voteCtx.Opinions) > trace.opinionsCount(),
+			Eta:             voteCtx.Eta,
+			Opinion:         voteCtx.LastOpinion(),
+			ProportionLiked: voteCtx.ProportionLiked,
+			OwnWeight:       voteCtx.Weights.OwnWeight,
+			TotalWeight:     voteCtx.Weights.TotalWeights,
+		}
+		for _, queriedOpinions := range roundStats.QueriedOpinions {
+			opn, ok := queriedOpinions.Opinions[id]
+			if !ok {
+				continue
+			}
+			round.QueriedOpinions = append(round.QueriedOpinions, &QueriedOpinion{
+				OpinionGiverID: queriedOpinions.OpinionGiverID,
+				Opinion:        opn,
+				TimesCounted:   queriedOpinions.TimesCounted,
+				Mana:           queriedOpinions.Mana,
+			})
+		}
+		trace.Rounds = append(trace.Rounds, round)
+
+		if err := l.persist(trace); err != nil {
+			return err
+		}
+	}
+
+	for id, trace := range l.traces {
+		if l.rounds-trace.lastActiveRound >= staleTraceRounds {
+			delete(l.traces, id)
+		}
+	}
+	return nil
+}
+
+// RecordFinalized records the last round of a vote whose opinion was finalized.
+func (l *Log) RecordFinalized(ev *vote.OpinionEvent) error {
+	return l.recordOutcome(ev, Finalized)
+}
+
+// RecordFailed records the last round of a vote whose opinion couldn't be finalized.
+func (l *Log) RecordFailed(ev *vote.OpinionEvent) error {
+	return l.recordOutcome(ev, Failed)
+}
+
+// Trace returns the trace of the vote on the given ID.
+func (l *Log) Trace(id string) (*Trace, error) {
+	value, err := l.store.Get(traceKey(id))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errors.Errorf("%w: %s", ErrTraceNotFound, id)
+		}
+		return nil, errors.Wrapf(err, "failed to load trace %s", id)
+	}
+	return TraceFromBytes(value)
+}
+
+// Prune removes the stored traces of the votes that started before the given threshold and returns their amount.
+func (l *Log) Prune(threshold time.Time) (pruned int, err error) {
+	batch := l.store.Batched()
+	if iterateErr := l.store.IterateKeys(kvstore.KeyPrefix{startIndexKeyPrefix}, func(key kvstore.Key) bool {
+		if len(key) < 1+marshalutil.Int64Size || !time.Unix(0, int64(binary.BigEndian.Uint64(key[1:]))).Before(threshold) {
+			return true
+		}
+
+		if err = batch.Delete(byteutils.ConcatBytes(key)); err == nil {
+			err = batch.Delete(traceKey(string(key[1+marshalutil.Int64Size:])))
+		}
+		pruned++
+		return err == nil
+	}); err == nil {
+		err = iterateErr
+	}
+	if err != nil {
+		batch.Cancel()
+		return 0, errors.Wrap(err, "failed to prune traces")
+	}
+
+	if err = batch.Commit(); err != nil {
+		return 0, errors.Wrap(err, "failed to prune traces")
+	}
+	return pruned, nil
+}
+
+// recordOutcome records the last round of a vote, in which the vote context was finalized or failed. In this round no
+// opinion givers were queried anymore.
+func (l *Log) recordOutcome(ev *vote.OpinionEvent, outcome Outcome) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	trace := l.trace(&ev.Ctx)
+	trace.Rounds = append(trace.Rounds, &Round{
+		Number:          ev.Ctx.Rounds + 1,
+		Time:            clock.SyncedTime(),
+		RandUsed:        ev.Ctx.RandUsed,
+		OpinionFormed:   len(ev.Ctx.Opinions) > trace.opinionsCount(),
+		Eta:             ev.Ctx.Eta,
+		Opinion:         ev.Opinion,
+		ProportionLiked: ev.Ctx.ProportionLiked,
+		OwnWeight:       ev.Ctx.Weights.OwnWeight,
+		TotalWeight:     ev.Ctx.Weights.TotalWeights,
+	})
+	trace.Outcome = outcome
+	trace.FinalOpinion = ev.Opinion
+	delete(l.traces, trace.ID)
+
+	return l.persist(trace)
+}
+
+// trace returns the trace of the ongoing vote of the given vote context, which is created if it doesn't exist yet.
+func (l *Log) trace(voteCtx *vote.Context) *Trace {
+	trace, exists := l.traces[voteCtx.ID]
+	if !exists {
+		trace = &ongoingTrace{
+			Trace: &Trace{
+				ID:             voteCtx.ID,
+				Type:           voteCtx.Type,
+				InitialOpinion: voteCtx.Opinions[0],
+				Start:          voteCtx.ConflictCreationTime,
+				Parameters:     l.parameters,
+			},
+			lastActiveRound: l.rounds,
+		}
+		l.traces[voteCtx.ID] = trace
+	}
+	return trace.Trace
+}
+
+// persist stores the trace together with its entry in the start index.
+func (l *Log) persist(trace *Trace) error {
+	batch := l.store.Batched()
+	err := batch.Set(traceKey(trace.ID), trace.Bytes())
+	if err == nil {
+		err = batch.Set(startIndexKey(trace), []byte{})
+	}
+	if err == nil {
+		err = batch.Commit()
+	} else {
+		batch.Cancel()
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to store trace %s", trace.ID)
+	}
+	return nil
+}
+
+// traceKey returns the key of the trace of the vote on the given ID.
+func traceKey(id string) []byte {
+	return byteutils.ConcatBytes([]byte{traceKeyPrefix}, []byte(id))
+}
+
+// startIndexKey returns the key that indexes the given trace by the start of its vote.
+func startIndexKey(trace *Trace) []byte {
+	start := make([]byte, marshalutil.Int64Size)
+	binary.BigEndian.PutUint64(start, uint64(trace.Start.UnixNano()))
+
+	return byteutils.ConcatBytes([]byte{startIndexKeyPrefix}, start, []byte(trace.ID))
+}
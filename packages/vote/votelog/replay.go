@@ -0,0 +1,136 @@
+package votelog
+
+import (
+	"math"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+const toleranceProportion = 1e-9
+
+var (
+	// ErrReplayMismatch is returned if the replay of a vote leads to a different decision than the logged one.
+	ErrReplayMismatch = errors.New("replay does not match the logged vote")
+	// ErrIncompleteTrace is returned if a trace misses rounds and can therefore not be replayed.
+	ErrIncompleteTrace = errors.New("trace is incomplete")
+)
+
+// Replay re-executes the logged vote with the logged parameters, random numbers and queried opinions and verifies that
+// it leads to the logged liked proportions, etas, opinions and outcome.
+func Replay(trace *Trace) error {
+	if trace.Parameters == nil {
+		return errors.Errorf("no parameters: %w", ErrIncompleteTrace)
+	}
+
+	voteCtx := vote.NewContext(trace.ID, trace.Type, trace.InitialOpinion)
+	lastNumber := 0
+	for i, round := range trace.Rounds {
+		if round.Number != lastNumber+1 {
+			return errors.Errorf("round %d follows round %d: %w", round.Number, lastNumber, ErrIncompleteTrace)
+		}
+		lastNumber = round.Number
+		voteCtx.Rounds = round.Number - 1
+		lastRound := i == len(trace.Rounds)-1 && trace.Outcome != Ongoing
+
+		if round.OpinionFormed {
+			if voteCtx.IsNew() {
+				return errors.Errorf("round %d formed an opinion before any query: %w", round.Number, ErrReplayMismatch)
+			}
+			eta, opn := fpc.FormOpinion(trace.Parameters, voteCtx, round.RandUsed)
+			if math.Abs(eta-round.Eta) > toleranceProportion {
+				return errors.Errorf("round %d: eta is %f instead of %f: %w", round.Number, eta, round.Eta, ErrReplayMismatch)
+			}
+			voteCtx.AddOpinion(opn)
+		} else if !voteCtx.IsNew() {
+			return errors.Errorf("round %d should have formed an opinion: %w", round.Number, ErrReplayMismatch)
+		}
+		if voteCtx.LastOpinion() != round.Opinion {
+			return errors.Errorf("round %d: opinion is %s instead of %s: %w", round.Number, voteCtx.LastOpinion(), round.Opinion, ErrReplayMismatch)
+		}
+
+		finalized := voteCtx.IsFinalized(trace.Parameters.TotalRoundsCoolingOffPeriod, trace.Parameters.TotalRoundsFinalization)
+		failed := !finalized && voteCtx.Rounds >= trace.Parameters.MaxRoundsPerVoteContext
+		if lastRound {
+			return verifyOutcome(trace, voteCtx, finalized, failed)
+		}
+		if finalized || failed {
+			return errors.Errorf("round %d should have ended the vote: %w", round.Number, ErrReplayMismatch)
+		}
+
+		if err := replayQuery(trace.Parameters, voteCtx, round); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replayQuery computes the liked proportion of the queried opinions of the round. The logged own and total weights are
+// trusted inputs: the total weight includes the mana of all opinion givers the sample was drawn from, which is not part
+// of the trace, so it can only be checked for consistency with the logged mana of the queried opinion givers.
+func replayQuery(paras *fpc.Parameters, voteCtx *vote.Context, round *Round) error {
+	var likedSum float64
+	votedCount := 0
+	for _, queriedOpinion := range round.QueriedOpinions {
+		switch queriedOpinion.Opinion {
+		case opinion.Like:
+			likedSum += float64(queriedOpinion.TimesCounted)
+		case opinion.Unknown:
+			continue
+		}
+		votedCount += queriedOpinion.TimesCounted
+	}
+
+	// too few opinions keep the liked proportion of the previous query
+	if votedCount >= paras.MinOpinionsReceived && votedCount > 0 {
+		if err := verifyWeights(round); err != nil {
+			return err
+		}
+		voteCtx.ProportionLiked = likedSum / float64(votedCount)
+		voteCtx.Weights = vote.VotingWeights{
+			OwnWeight:    round.OwnWeight,
+			TotalWeights: round.TotalWeight,
+		}
+	}
+
+	if math.Abs(voteCtx.ProportionLiked-round.ProportionLiked) > toleranceProportion {
+		return errors.Errorf("round %d: liked proportion is %f instead of %f: %w", round.Number, voteCtx.ProportionLiked, round.ProportionLiked, ErrReplayMismatch)
+	}
+	return nil
+}
+
+// verifyWeights checks that the logged weights of the round are consistent with the logged mana of the queried opinion
+// givers, i.e. that the total weight covers the own weight and the mana of every queried opinion giver.
+func verifyWeights(round *Round) error {
+	if round.OwnWeight < 0 || round.TotalWeight < round.OwnWeight {
+		return errors.Errorf("round %d: own weight %f does not fit the total weight %f: %w", round.Number, round.OwnWeight, round.TotalWeight, ErrReplayMismatch)
+	}
+
+	queriedMana := 0.0
+	for _, queriedOpinion := range round.QueriedOpinions {
+		if queriedOpinion.Mana < 0 {
+			return errors.Errorf("round %d: opinion giver %s has negative mana %f: %w", round.Number, queriedOpinion.OpinionGiverID, queriedOpinion.Mana, ErrReplayMismatch)
+		}
+		queriedMana += queriedOpinion.Mana
+	}
+	if round.OwnWeight+queriedMana > round.TotalWeight+toleranceProportion*math.Max(round.TotalWeight, 1) {
+		return errors.Errorf("round %d: total weight %f is less than the own weight and the mana of the queried opinion givers %f: %w", round.Number, round.TotalWeight, round.OwnWeight+queriedMana, ErrReplayMismatch)
+	}
+	return nil
+}
+
+func verifyOutcome(trace *Trace, voteCtx *vote.Context, finalized, failed bool) error {
+	switch {
+	case trace.Outcome == Finalized && !finalized:
+		return errors.Errorf("vote was not finalized after %d rounds: %w", len(trace.Rounds), ErrReplayMismatch)
+	case trace.Outcome == Failed && !failed:
+		return errors.Errorf("vote did not fail after %d rounds: %w", len(trace.Rounds), ErrReplayMismatch)
+	case voteCtx.LastOpinion() != trace.FinalOpinion:
+		return errors.Errorf("final opinion is %s instead of %s: %w", voteCtx.LastOpinion(), trace.FinalOpinion, ErrReplayMismatch)
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+package votelog
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+)
+
+// region Outcome //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Outcome is the outcome of a vote.
+type Outcome uint8
+
+const (
+	// Ongoing is the outcome of a vote which has not been finalized yet.
+	Ongoing Outcome = iota
+	// Finalized is the outcome of a vote whose opinion was finalized.
+	Finalized
+	// Failed is the outcome of a vote whose opinion couldn't be finalized.
+	Failed
+)
+
+// String returns a human readable version of the Outcome.
+func (o Outcome) String() string {
+	switch o {
+	case Ongoing:
+		return "Ongoing"
+	case Finalized:
+		return "Finalized"
+	case Failed:
+		return "Failed"
+	default:
+		return "Unknown"
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Trace ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Trace is the log of a single vote on a conflict or timestamp.
+type Trace struct {
+	// ID of the voted object, i.e. a transaction or message ID.
+	ID string
+	// Type of the voted object.
+	Type vote.ObjectType
+	// InitialOpinion is the opinion the vote was started with.
+	InitialOpinion opinion.Opinion
+	// Start is the time the vote context was created.
+	Start time.Time
+	// Parameters are the FPC parameters of the voter.
+	Parameters *fpc.Parameters
+	// Rounds contains the rounds of the vote in the order they were executed.
+	Rounds []*Round
+	// Outcome of the vote.
+	Outcome Outcome
+	// FinalOpinion is the opinion the vote was finalized or failed with.
+	FinalOpinion opinion.Opinion
+}
+
+// Round is a single round of a vote. In a round, an opinion is formed based on the query of the previous round and then
+// the opinion givers are queried again. The last round of a finalized or failed vote does not query anymore.
+type Round struct {
+	// Number is the number of the round within the vote, starting with 1.
+	Number int
+	// Time the round was logged.
+	Time time.Time
+	// RandUsed is the random number of the round.
+	RandUsed float64
+	// OpinionFormed tells whether an opinion was formed in the round.
+	OpinionFormed bool
+	// Eta is the liked proportion biased towards the own opinion, which was compared against the threshold.
+	Eta float64
+	// Opinion is the opinion at the end of the round.
+	Opinion opinion.Opinion
+	// ProportionLiked is the proportion of the queried opinions which liked the object.
+	ProportionLiked float64
+	// OwnWeight is the own mana at the time of the query.
+	OwnWeight float64
+	// TotalWeight is the total mana of all opinion givers the sample was drawn from and the own mana.
+	TotalWeight float64
+	// QueriedOpinions are the opinions received in the query of the round.
+	QueriedOpinions []*QueriedOpinion
+}
+
+// QueriedOpinion is the opinion of a single opinion giver on the voted object.
+type QueriedOpinion struct {
+	// OpinionGiverID is the shortened ID of the opinion giver.
+	OpinionGiverID string
+	// Opinion of the opinion giver.
+	Opinion opinion.Opinion
+	// TimesCounted is the amount of times the opinion giver was sampled.
+	TimesCounted int
+	// Mana of the opinion giver.
+	Mana float64
+}
+
+// opinionsCount returns the amount of opinions of the vote context, including the initial opinion.
+func (t *Trace) opinionsCount() (count int) {
+	count = 1
+	for _, round := range t.Rounds {
+		if round.OpinionFormed {
+			count++
+		}
+	}
+	return count
+}
+
+// Bytes returns a marshaled version of the Trace.
+func (t *Trace) Bytes() []byte {
+	marshalUtil := marshalutil.New().
+		WriteUint16(uint16(len(t.ID))).
+		WriteBytes([]byte(t.ID)).
+		WriteUint8(uint8(t.Type)).
+		WriteByte(byte(t.InitialOpinion)).
+		WriteTime(t.Start).
+		WriteUint8(uint8(t.Outcome)).
+		WriteByte(byte(t.FinalOpinion))
+	writeParameters(marshalUtil, t.Parameters)
+
+	marshalUtil.WriteUint32(uint32(len(t.Rounds)))
+	for _, round := range t.Rounds {
+		marshalUtil.
+			WriteUint32(uint32(round.Number)).
+			WriteTime(round.Time).
+			WriteFloat64(round.RandUsed).
+			WriteBool(round.OpinionFormed).
+			WriteFloat64(round.Eta).
+			WriteByte(byte(round.Opinion)).
+			WriteFloat64(round.ProportionLiked).
+			WriteFloat64(round.OwnWeight).
+			WriteFloat64(round.TotalWeight).
+			WriteUint32(uint32(len(round.QueriedOpinions)))
+		for _, queriedOpinion := range round.QueriedOpinions {
+			marshalUtil.
+				WriteUint16(uint16(len(queriedOpinion.OpinionGiverID))).
+				WriteBytes([]byte(queriedOpinion.OpinionGiverID)).
+				WriteByte(byte(queriedOpinion.Opinion)).
+				WriteUint32(uint32(queriedOpinion.TimesCounted)).
+				WriteFloat64(queriedOpinion.Mana)
+		}
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the Trace.
+func (t *Trace) String() string {
+	return stringify.Struct("Trace",
+		stringify.StructField("id", t.ID),
+		stringify.StructField("type", uint8(t.Type)),
+		stringify.StructField("initialOpinion", t.InitialOpinion.String()),
+		stringify.StructField("start", t.Start),
+		stringify.StructField("rounds", len(t.Rounds)),
+		stringify.StructField("outcome", t.Outcome.String()),
+		stringify.StructField("finalOpinion", t.FinalOpinion.String()),
+	)
+}
+
+// TraceFromBytes unmarshals a Trace from a sequence of bytes.
+func TraceFromBytes(bytes []byte) (trace *Trace, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	trace = &Trace{}
+
+	if trace.ID, err = readString(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse ID of trace: %w", err)
+	}
+	objectType, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse type of trace %s: %w", trace.ID, err)
+	}
+	trace.Type = vote.ObjectType(objectType)
+	if trace.InitialOpinion, err = readOpinion(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse initial opinion of trace %s: %w", trace.ID, err)
+	}
+	if trace.Start, err = marshalUtil.ReadTime(); err != nil {
+		return nil, errors.Errorf("failed to parse start of trace %s: %w", trace.ID, err)
+	}
+	outcome, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse outcome of trace %s: %w", trace.ID, err)
+	}
+	trace.Outcome = Outcome(outcome)
+	if trace.FinalOpinion, err = readOpinion(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse final opinion of trace %s: %w", trace.ID, err)
+	}
+	if trace.Parameters, err = readParameters(marshalUtil); err != nil {
+		return nil, errors.Errorf("failed to parse parameters of trace %s: %w", trace.ID, err)
+	}
+
+	roundsCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Errorf("failed to parse rounds count of trace %s: %w", trace.ID, err)
+	}
+	trace.Rounds = make([]*Round, roundsCount)
+	for i := range trace.Rounds {
+		if trace.Rounds[i], err = readRound(marshalUtil); err != nil {
+			return nil, errors.Errorf("failed to parse round %d of trace %s: %w", i, trace.ID, err)
+		}
+	}
+
+	return trace, nil
+}
+
+func readRound(marshalUtil *marshalutil.MarshalUtil) (round *Round, err error) {
+	round = &Round{}
+	number, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	round.Number = int(number)
+	if round.Time, err = marshalUtil.ReadTime(); err != nil {
+		return nil, err
+	}
+	if round.RandUsed, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, err
+	}
+	if round.OpinionFormed, err = marshalUtil.ReadBool(); err != nil {
+		return nil, err
+	}
+	if round.Eta, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, err
+	}
+	if round.Opinion, err = readOpinion(marshalUtil); err != nil {
+		return nil, err
+	}
+	if round.ProportionLiked, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, err
+	}
+	if round.OwnWeight, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, err
+	}
+	if round.TotalWeight, err = marshalUtil.ReadFloat64(); err != nil {
+		return nil, err
+	}
+
+	queriedOpinionsCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	round.QueriedOpinions = make([]*QueriedOpinion, queriedOpinionsCount)
+	for i := range round.QueriedOpinions {
+		queriedOpinion := &QueriedOpinion{}
+		if queriedOpinion.OpinionGiverID, err = readString(marshalUtil); err != nil {
+			return nil, err
+		}
+		if queriedOpinion.Opinion, err = readOpinion(marshalUtil); err != nil {
+			return nil, err
+		}
+		timesCounted, err := marshalUtil.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		queriedOpinion.TimesCounted = int(timesCounted)
+		if queriedOpinion.Mana, err = marshalUtil.ReadFloat64(); err != nil {
+			return nil, err
+		}
+		round.QueriedOpinions[i] = queriedOpinion
+	}
+
+	return round, nil
+}
+
+func writeParameters(marshalUtil *marshalutil.MarshalUtil, paras *fpc.Parameters) {
+	marshalUtil.
+		WriteFloat64(paras.FirstRoundLowerBoundThreshold).
+		WriteFloat64(paras.FirstRoundUpperBoundThreshold).
+		WriteFloat64(paras.SubsequentRoundsLowerBoundThreshold).
+		WriteFloat64(paras.SubsequentRoundsUpperBoundThreshold).
+		WriteFloat64(paras.EndingRoundsFixedThreshold).
+		WriteUint32(uint32(paras.QuerySampleSize)).
+		WriteUint32(uint32(paras.MaxQuerySampleSize)).
+		WriteUint32(uint32(paras.TotalRoundsFinalization)).
+		WriteUint32(uint32(paras.TotalRoundsFixedThreshold)).
+		WriteUint32(uint32(paras.TotalRoundsCoolingOffPeriod)).
+		WriteUint32(uint32(paras.MaxRoundsPerVoteContext)).
+		WriteInt64(int64(paras.QueryTimeout)).
+		WriteUint32(uint32(paras.MinOpinionsReceived))
+}
+
+func readParameters(marshalUtil *marshalutil.MarshalUtil) (paras *fpc.Parameters, err error) {
+	paras = &fpc.Parameters{}
+	for _, field := range []*float64{
+		&paras.FirstRoundLowerBoundThreshold,
+		&paras.FirstRoundUpperBoundThreshold,
+		&paras.SubsequentRoundsLowerBoundThreshold,
+		&paras.SubsequentRoundsUpperBoundThreshold,
+		&paras.EndingRoundsFixedThreshold,
+	} {
+		if *field, err = marshalUtil.ReadFloat64(); err != nil {
+			return nil, err
+		}
+	}
+	for _, field := range []*int{
+		&paras.QuerySampleSize,
+		&paras.MaxQuerySampleSize,
+		&paras.TotalRoundsFinalization,
+		&paras.TotalRoundsFixedThreshold,
+		&paras.TotalRoundsCoolingOffPeriod,
+		&paras.MaxRoundsPerVoteContext,
+	} {
+		value, err := marshalUtil.ReadUint32()
+		if err != nil {
+			return nil, err
+		}
+		*field = int(value)
+	}
+	queryTimeout, err := marshalUtil.ReadInt64()
+	if err != nil {
+		return nil, err
+	}
+	paras.QueryTimeout = time.Duration(queryTimeout)
+	minOpinionsReceived, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	paras.MinOpinionsReceived = int(minOpinionsReceived)
+
+	return paras, nil
+}
+
+func readString(marshalUtil *marshalutil.MarshalUtil) (string, error) {
+	length, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return "", err
+	}
+	bytes, err := marshalUtil.ReadBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
+
+func readOpinion(marshalUtil *marshalutil.MarshalUtil) (opinion.Opinion, error) {
+	b, err := marshalUtil.ReadByte()
+	return opinion.Opinion(b), err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,202 @@
+package votelog_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/opinion"
+	"github.com/iotaledger/goshimmer/packages/vote/votelog"
+)
+
+type opiniongivermock struct {
+	id      identity.ID
+	opinion opinion.Opinion
+	mana    float64
+}
+
+func (ogm *opiniongivermock) ID() identity.ID {
+	return ogm.id
+}
+
+func (ogm *opiniongivermock) Query(_ context.Context, conflictIDs, timestampIDs []string, _ ...time.Duration) (opinion.Opinions, error) {
+	opinions := make(opinion.Opinions, len(conflictIDs)+len(timestampIDs))
+	for i := range opinions {
+		opinions[i] = ogm.opinion
+	}
+	return opinions, nil
+}
+
+func (ogm *opiniongivermock) Mana() float64 {
+	return ogm.mana
+}
+
+// voteAndLog votes on the given ID until the vote is finalized and returns its logged trace.
+func voteAndLog(t *testing.T, id string) *votelog.Trace {
+	opinionGivers := make([]opinion.OpinionGiver, 0, 10)
+	for i := 0; i < 10; i++ {
+		opn := opinion.Like
+		if i%3 == 0 {
+			opn = opinion.Dislike
+		}
+		opinionGivers = append(opinionGivers, &opiniongivermock{
+			id:      identity.GenerateIdentity().ID(),
+			opinion: opn,
+			mana:    float64(i + 1),
+		})
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.QuerySampleSize = 5
+	paras.TotalRoundsFinalization = 5
+	voter := fpc.New(func() ([]opinion.OpinionGiver, error) {
+		return opinionGivers, nil
+	}, func() (float64, error) {
+		return 10, nil
+	}, paras)
+
+	log := votelog.NewLog(mapdb.NewMapDB(), voter.Parameters())
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		assert.NoError(t, log.RecordRound(roundStats))
+	}))
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		assert.NoError(t, log.RecordFinalized(ev))
+	}))
+	voter.Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		assert.NoError(t, log.RecordFailed(ev))
+	}))
+
+	require.NoError(t, voter.Vote(id, vote.ConflictType, opinion.Dislike))
+	for i := 0; i < paras.MaxRoundsPerVoteContext+2; i++ {
+		require.NoError(t, voter.Round(rand.Float64()))
+	}
+
+	trace, err := log.Trace(id)
+	require.NoError(t, err)
+	return trace
+}
+
+func TestLog(t *testing.T) {
+	trace := voteAndLog(t, "a")
+
+	assert.Equal(t, "a", trace.ID)
+	assert.Equal(t, opinion.Dislike, trace.InitialOpinion)
+	assert.Equal(t, votelog.Finalized, trace.Outcome)
+	assert.Equal(t, trace.Rounds[len(trace.Rounds)-1].Opinion, trace.FinalOpinion)
+	assert.Equal(t, fpc.DefaultParameters().FirstRoundLowerBoundThreshold, trace.Parameters.FirstRoundLowerBoundThreshold)
+	require.Greater(t, len(trace.Rounds), 5)
+	assert.False(t, trace.Rounds[0].OpinionFormed)
+	assert.NotEmpty(t, trace.Rounds[0].QueriedOpinions)
+	assert.Empty(t, trace.Rounds[len(trace.Rounds)-1].QueriedOpinions)
+
+	restored, err := votelog.TraceFromBytes(trace.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, trace.Bytes(), restored.Bytes())
+
+	_, err = votelog.NewLog(mapdb.NewMapDB(), fpc.DefaultParameters()).Trace("a")
+	assert.True(t, errors.Is(err, votelog.ErrTraceNotFound))
+}
+
+func TestReplay(t *testing.T) {
+	trace := voteAndLog(t, "a")
+	require.NoError(t, votelog.Replay(trace))
+
+	// a different eta
+	tampered, err := votelog.TraceFromBytes(trace.Bytes())
+	require.NoError(t, err)
+	for _, round := range tampered.Rounds {
+		if round.OpinionFormed {
+			round.Eta = 1 - round.Eta
+			break
+		}
+	}
+	assert.True(t, errors.Is(votelog.Replay(tampered), votelog.ErrReplayMismatch))
+
+	// a different queried opinion changes the liked proportion
+	tampered, err = votelog.TraceFromBytes(trace.Bytes())
+	require.NoError(t, err)
+	additionalOpinion := &votelog.QueriedOpinion{OpinionGiverID: "x", Opinion: opinion.Like, TimesCounted: 100}
+	if tampered.Rounds[0].ProportionLiked == 1 {
+		additionalOpinion.Opinion = opinion.Dislike
+	}
+	tampered.Rounds[0].QueriedOpinions = append(tampered.Rounds[0].QueriedOpinions, additionalOpinion)
+	assert.True(t, errors.Is(votelog.Replay(tampered), votelog.ErrReplayMismatch))
+
+	// a different outcome
+	tampered, err = votelog.TraceFromBytes(trace.Bytes())
+	require.NoError(t, err)
+	tampered.Outcome = votelog.Failed
+	assert.True(t, errors.Is(votelog.Replay(tampered), votelog.ErrReplayMismatch))
+
+	// a total weight that doesn't cover the mana of the queried opinion givers
+	tampered, err = votelog.TraceFromBytes(trace.Bytes())
+	require.NoError(t, err)
+	tampered.Rounds[0].TotalWeight = tampered.Rounds[0].OwnWeight
+	assert.True(t, errors.Is(votelog.Replay(tampered), votelog.ErrReplayMismatch))
+
+	// a missing round
+	tampered, err = votelog.TraceFromBytes(trace.Bytes())
+	require.NoError(t, err)
+	tampered.Rounds = append(tampered.Rounds[:1], tampered.Rounds[2:]...)
+	assert.True(t, errors.Is(votelog.Replay(tampered), votelog.ErrIncompleteTrace))
+}
+
+func TestLog_StaleTraces(t *testing.T) {
+	log := votelog.NewLog(mapdb.NewMapDB(), fpc.DefaultParameters())
+	voteCtx := vote.NewContext("a", vote.ConflictType, opinion.Like)
+	active := &vote.RoundStats{ActiveVoteContexts: map[string]*vote.Context{"a": voteCtx}}
+	idle := &vote.RoundStats{ActiveVoteContexts: map[string]*vote.Context{}}
+
+	require.NoError(t, log.RecordRound(active))
+	require.NoError(t, log.RecordRound(active))
+	trace, err := log.Trace("a")
+	require.NoError(t, err)
+	assert.Len(t, trace.Rounds, 2)
+
+	// the trace of a vote context that is no longer active is dropped from memory, but remains stored
+	for i := 0; i < 3; i++ {
+		require.NoError(t, log.RecordRound(idle))
+	}
+	trace, err = log.Trace("a")
+	require.NoError(t, err)
+	assert.Len(t, trace.Rounds, 2)
+
+	require.NoError(t, log.RecordRound(active))
+	trace, err = log.Trace("a")
+	require.NoError(t, err)
+	assert.Len(t, trace.Rounds, 1)
+}
+
+func TestLog_Prune(t *testing.T) {
+	log := votelog.NewLog(mapdb.NewMapDB(), fpc.DefaultParameters())
+	oldCtx := vote.NewContext("old", vote.ConflictType, opinion.Like)
+	oldCtx.ConflictCreationTime = time.Now().Add(-2 * time.Hour)
+	newCtx := vote.NewContext("new", vote.ConflictType, opinion.Like)
+	require.NoError(t, log.RecordRound(&vote.RoundStats{ActiveVoteContexts: map[string]*vote.Context{
+		"old": oldCtx,
+		"new": newCtx,
+	}}))
+
+	pruned, err := log.Prune(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pruned)
+
+	_, err = log.Trace("old")
+	assert.True(t, errors.Is(err, votelog.ErrTraceNotFound))
+	_, err = log.Trace("new")
+	assert.NoError(t, err)
+
+	pruned, err = log.Prune(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, pruned)
+}
@@ -29,6 +29,11 @@ var (
 	nodeOnce sync.Once
 )
 
+// FilePath returns the path of the config file.
+func FilePath() string {
+	return *configFilePath
+}
+
 // Init triggers the Init event.
 func Init() {
 	plugin.Events.Init.Trigger(plugin)
@@ -86,18 +91,7 @@ func fetch(printConfig bool, ignoreSettingsAtPrint ...[]string) error {
 		fmt.Printf("No config file found via '%s'. Loading default settings.", *configFilePath)
 	}
 
-	if err := _node.LoadFlagSet(flag.CommandLine); err != nil {
-		return err
-	}
-
-	// read in ENV variables
-	// load the env vars after default values from flags were set (otherwise the env vars are not added because the keys don't exist)
-	if err := _node.LoadEnvironmentVars(""); err != nil {
-		return err
-	}
-
-	// load the flags again to overwrite env vars that were also set via command line
-	if err := _node.LoadFlagSet(flag.CommandLine); err != nil {
+	if err := loadFlagsAndEnvironment(_node); err != nil {
 		return err
 	}
 
@@ -118,6 +112,36 @@ func fetch(printConfig bool, ignoreSettingsAtPrint ...[]string) error {
 	return nil
 }
 
+// Reload reads the config file into a new configuration and applies the command line flags and the environment
+// variables in the same order as at startup, so that the values that were not set in the file are preserved.
+func Reload() (*configuration.Configuration, error) {
+	reloadedConfig := configuration.New()
+	if err := reloadedConfig.LoadFile(*configFilePath); err != nil {
+		return nil, err
+	}
+	if err := loadFlagsAndEnvironment(reloadedConfig); err != nil {
+		return nil, err
+	}
+
+	return reloadedConfig, nil
+}
+
+// loadFlagsAndEnvironment loads the command line flags and the environment variables into the configuration.
+func loadFlagsAndEnvironment(cfg *configuration.Configuration) error {
+	if err := cfg.LoadFlagSet(flag.CommandLine); err != nil {
+		return err
+	}
+
+	// read in ENV variables
+	// load the env vars after default values from flags were set (otherwise the env vars are not added because the keys don't exist)
+	if err := cfg.LoadEnvironmentVars(""); err != nil {
+		return err
+	}
+
+	// load the flags again to overwrite env vars that were also set via command line
+	return cfg.LoadFlagSet(flag.CommandLine)
+}
+
 // PrintConfig prints the config.
 func PrintConfig(ignoreSettingsAtPrint ...[]string) {
 	_node.Print(ignoreSettingsAtPrint...)
@@ -44,6 +44,7 @@ var Core = node.Plugins(
 	autopeering.Plugin(),
 	manualpeering.Plugin(),
 	drng.Plugin(),
+	drng.ProducerPlugin(),
 	faucet.Plugin(),
 	messagelayer.ConsensusPlugin(),
 	metrics.Plugin(),
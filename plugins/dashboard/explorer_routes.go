@@ -112,6 +112,7 @@ func createExplorerMessage(msg *tangle.Message) *ExplorerMessage {
 // ExplorerAddress defines the struct of the ExplorerAddress.
 type ExplorerAddress struct {
 	Address         string           `json:"address"`
+	Bech32          string           `json:"bech32"`
 	ExplorerOutputs []ExplorerOutput `json:"explorerOutputs"`
 }
 
@@ -181,6 +182,15 @@ func setupExplorerRoutes(routeGroup *echo.Group) {
 		search := c.Param("search")
 		result := &SearchResult{}
 
+		// bech32 encoded addresses can not be identified by the length of their decoded bytes
+		if _, err := ledgerstate.AddressFromBech32EncodedString(search); err == nil {
+			if addr, err := findAddress(search); err == nil {
+				result.Address = addr
+			}
+
+			return c.JSON(http.StatusOK, result)
+		}
+
 		searchInByte, err := base58.Decode(search)
 		if err != nil {
 			return fmt.Errorf("%w: search ID %s", ErrInvalidParameter, search)
@@ -223,7 +233,7 @@ func findMessage(messageID tangle.MessageID) (explorerMsg *ExplorerMessage, err
 }
 
 func findAddress(strAddress string) (*ExplorerAddress, error) {
-	address, err := ledgerstate.AddressFromBase58EncodedString(strAddress)
+	address, err := ledgerstate.AddressFromString(strAddress)
 	if err != nil {
 		return nil, fmt.Errorf("%w: address %s", ErrNotFound, strAddress)
 	}
@@ -277,7 +287,8 @@ func findAddress(strAddress string) (*ExplorerAddress, error) {
 	}
 
 	return &ExplorerAddress{
-		Address:         strAddress,
+		Address:         address.Base58(),
+		Bech32:          address.Bech32(),
 		ExplorerOutputs: outputs,
 	}, nil
 }
@@ -105,7 +105,8 @@ export class ExplorerAddressQueryResult extends React.Component<Props, any> {
         }
         return (
             <Container>
-                <h3 style={{marginBottom: "40px"}}>Address <strong>{id}</strong> {addr !== null && <span>({addr.explorerOutputs.length} Outputs)</span>}</h3>
+                <h3 style={{marginBottom: addr !== null ? "10px" : "40px"}}>Address <strong>{addr !== null ? addr.bech32 : id}</strong> {addr !== null && <span>({addr.explorerOutputs.length} Outputs)</span>}</h3>
+                {addr !== null && <p style={{marginBottom: "30px"}}>Base58: {addr.address}</p>}
                 {
                     addr !== null ?
                         <React.Fragment>
@@ -86,6 +86,13 @@ export class Neighbor extends React.Component<Props, any> {
                                         </ListGroup.Item>
                                     </ListGroup>
                                 </Col>
+                                <Col>
+                                    <ListGroup variant={"flush"} as={"small"}>
+                                        <ListGroup.Item>
+                                            Score: {last.score.toFixed(2)}
+                                        </ListGroup.Item>
+                                    </ListGroup>
+                                </Col>
                             </Row>
                             <Row className={"mb-3"}>
                                 <Col>
@@ -48,6 +48,7 @@ export class Message {
 
 export class AddressResult {
     address: string;
+    bech32: string;
     explorerOutputs: Array<ExplorerOutput>;
 }
 
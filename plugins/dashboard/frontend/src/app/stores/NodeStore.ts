@@ -116,6 +116,7 @@ class NeighborMetric {
     connection_origin: number;
     bytes_read: number;
     bytes_written: number;
+    score: number;
     ts: number;
 }
 
@@ -229,11 +229,12 @@ type memmetrics struct {
 }
 
 type neighbormetric struct {
-	ID               string `json:"id"`
-	Address          string `json:"address"`
-	ConnectionOrigin string `json:"connection_origin"`
-	BytesRead        uint64 `json:"bytes_read"`
-	BytesWritten     uint64 `json:"bytes_written"`
+	ID               string  `json:"id"`
+	Address          string  `json:"address"`
+	ConnectionOrigin string  `json:"connection_origin"`
+	BytesRead        uint64  `json:"bytes_read"`
+	BytesWritten     uint64  `json:"bytes_written"`
+	Score            float64 `json:"score"`
 }
 
 type tipsInfo struct {
@@ -274,6 +275,7 @@ func neighborMetrics() []neighbormetric {
 			Address:          net.JoinHostPort(host, strconv.Itoa(port)),
 			BytesRead:        neighbor.BytesRead(),
 			BytesWritten:     neighbor.BytesWritten(),
+			Score:            neighbor.Score(),
 			ConnectionOrigin: origin,
 		})
 	}
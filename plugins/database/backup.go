@@ -0,0 +1,121 @@
+package database
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+	"go.uber.org/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+// backupFileExtension is the extension of the backup files.
+const backupFileExtension = ".gsdb.gz"
+
+var (
+	// ErrBackupInProgress is returned if a backup is requested while another backup is being written.
+	ErrBackupInProgress = errors.New("backup in progress")
+
+	// backupInProgress is set while a backup is being written.
+	backupInProgress atomic.Bool
+)
+
+// CreateBackup writes a backup of all realms of the database to a new file in the backup directory and returns its
+// path. The dirty flag and the backups of migrations are not part of the backup. The BackupStarted event is triggered
+// before the objects are read, so that the plugins can pause their processing and persist the objects that they cache
+// in memory. The objects of all realms are then read with a single iteration, before the BackupFinished event lets the
+// plugins continue.
+func CreateBackup() (path string, info *database.BackupInfo, err error) {
+	if !backupInProgress.CAS(false, true) {
+		return "", nil, ErrBackupInProgress
+	}
+	defer backupInProgress.Store(false)
+
+	if err = os.MkdirAll(BackupParameters.Directory, 0o755); err != nil {
+		return "", nil, errors.Errorf("failed to create backup directory: %w", err)
+	}
+
+	path = filepath.Join(BackupParameters.Directory, fmt.Sprintf("backup-%s%s", time.Now().UTC().Format("20060102-150405"), backupFileExtension))
+	file, err := os.CreateTemp(BackupParameters.Directory, "backup-*.tmp")
+	if err != nil {
+		return "", nil, errors.Errorf("failed to create backup file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	log.Infof("Writing database backup to %s ...", path)
+	startTime := time.Now()
+	Events.BackupStarted.Trigger()
+	info, err = database.WriteBackup(Store(), file, backupExcludedPrefixes()...)
+	Events.BackupFinished.Trigger()
+	if err != nil {
+		_ = file.Close()
+		return "", nil, err
+	}
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+		return "", nil, errors.Errorf("failed to sync backup file: %w", err)
+	}
+	if err = file.Close(); err != nil {
+		return "", nil, errors.Errorf("failed to close backup file: %w", err)
+	}
+	if err = os.Rename(file.Name(), path); err != nil {
+		return "", nil, errors.Errorf("failed to rename backup file: %w", err)
+	}
+	log.Infof("Writing database backup to %s ... done, %d objects, took %v", path, info.TotalObjects(), time.Since(startTime))
+
+	return path, info, nil
+}
+
+// restoreBackup verifies the backup at the given path and restores it into the empty store.
+func restoreBackup(store kvstore.KVStore, path string) (err error) {
+	log.Infof("Verifying database backup %s ...", path)
+	info, err := readBackupFile(path, database.VerifyBackup)
+	if err != nil {
+		return err
+	}
+	if info.DatabaseVersion > DBVersion {
+		return errors.Errorf("%w: supported version: %d, version of backup: %d", ErrDBVersionIncompatible, DBVersion, info.DatabaseVersion)
+	}
+	log.Infof("Verifying database backup %s ... done, %s", path, info)
+
+	log.Infof("Restoring database backup %s ...", path)
+	if info, err = readBackupFile(path, func(reader io.Reader) (*database.BackupInfo, error) {
+		return database.RestoreBackup(store, reader)
+	}); err != nil {
+		return err
+	}
+	if err = store.Flush(); err != nil {
+		return errors.Errorf("failed to flush restored database: %w", err)
+	}
+	log.Infof("Restoring database backup %s ... done, %d objects", path, info.TotalObjects())
+
+	return nil
+}
+
+// readBackupFile opens the backup file at the given path and passes it to the reader.
+func readBackupFile(path string, reader func(io.Reader) (*database.BackupInfo, error)) (info *database.BackupInfo, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Errorf("failed to open backup: %w", err)
+	}
+	defer file.Close()
+
+	return reader(file)
+}
+
+// backupExcludedPrefixes returns the keys that are not part of a backup.
+func backupExcludedPrefixes() []kvstore.KeyPrefix {
+	return []kvstore.KeyPrefix{
+		append([]byte{database.PrefixHealth}, healthKey...),
+		{database.PrefixMigrationBackup},
+	}
+}
@@ -0,0 +1,20 @@
+package database
+
+import (
+	"github.com/iotaledger/hive.go/events"
+)
+
+// Events defines the events of the plugin.
+var Events = pluginEvents{
+	BackupStarted:  events.NewEvent(events.VoidCaller),
+	BackupFinished: events.NewEvent(events.VoidCaller),
+}
+
+type pluginEvents struct {
+	// Fired before the objects of the database are written to a backup. The plugins pause the processing that modifies
+	// their objects and persist the objects that they cache in memory when the event is triggered, so the backup
+	// contains a consistent state of all of them.
+	BackupStarted *events.Event
+	// Fired after the backup was written (or failed), so the plugins can continue their processing.
+	BackupFinished *events.Event
+}
@@ -5,15 +5,21 @@ import (
 
 	"github.com/iotaledger/hive.go/configuration"
 	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/goshimmer/packages/database"
 )
 
 const (
 	// CfgDatabaseDir defines the directory of the database.
 	CfgDatabaseDir = "database.directory"
+	// CfgDatabaseEngine defines the storage engine of the persisted database.
+	CfgDatabaseEngine = "database.engine"
 	// CfgDatabaseInMemory defines whether to use an in-memory database.
 	CfgDatabaseInMemory = "database.inMemory"
 	// CfgDatabaseDirty defines whether to override the database dirty flag.
 	CfgDatabaseDirty = "database.dirty"
+	// CfgDatabaseRestore defines the backup that is restored into the (empty) database at startup.
+	CfgDatabaseRestore = "restore"
 )
 
 // Parameters contains configuration parameters used by the storage layer.
@@ -22,10 +28,30 @@ var Parameters = struct {
 	ForceCacheTime time.Duration `default:"-1s" usage:"interval of time for which objects should remain in memory. Zero time means no caching, negative value means use defaults"`
 }{}
 
+// MigrationParameters contains the configuration parameters of the database migrations.
+var MigrationParameters = struct {
+	// DryRun defines whether the migrations are only executed on an in-memory copy of the database.
+	DryRun bool `default:"false" usage:"execute the migrations on an in-memory copy of the affected data and exit without modifying the database"`
+	// Backup defines whether the affected data is backed up before it is migrated.
+	Backup bool `default:"true" usage:"back up the affected data before migrating it, so failed migrations are rolled back and the last migration can be undone"`
+	// Rollback defines whether the backup of the last migration is restored.
+	Rollback bool `default:"false" usage:"restore the backup of the last migration and exit"`
+}{}
+
+// BackupParameters contains the configuration parameters of the database backups.
+var BackupParameters = struct {
+	// Directory defines the directory that the backups are written to.
+	Directory string `default:"backups" usage:"the directory that database backups are written to"`
+}{}
+
 func init() {
 	flag.String(CfgDatabaseDir, "mainnetdb", "path to the database folder")
+	flag.String(CfgDatabaseEngine, database.EngineRocksDB.String(), "the storage engine of the persisted database (rocksdb, pebble)")
 	flag.Bool(CfgDatabaseInMemory, false, "whether the database is only kept in memory and not persisted")
 	flag.String(CfgDatabaseDirty, "", "set the dirty flag of the database")
+	flag.String(CfgDatabaseRestore, "", "path of a database backup that is verified and restored into the empty database at startup")
 
 	configuration.BindParameters(&Parameters, "database")
+	configuration.BindParameters(&MigrationParameters, "database.migration")
+	configuration.BindParameters(&BackupParameters, "database.backup")
 }
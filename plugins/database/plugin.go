@@ -1,7 +1,8 @@
-// Package database is a plugin that manages the RocksDB database (e.g. garbage collection).
+// Package database is a plugin that manages the RocksDB or Pebble database (e.g. garbage collection).
 package database
 
 import (
+	"os"
 	"strconv"
 	"sync"
 	"time"
@@ -33,6 +34,9 @@ var (
 	cacheProviderOnce sync.Once
 )
 
+// ErrDBEngineMismatch is returned when the database was created by a different engine than the configured one.
+var ErrDBEngineMismatch = errors.New("database was created by a different engine")
+
 // Plugin gets the plugin instance.
 func Plugin() *node.Plugin {
 	pluginOnce.Do(func() {
@@ -69,8 +73,10 @@ func createStore() {
 	if config.Node().Bool(CfgDatabaseInMemory) {
 		db, err = database.NewMemDB()
 	} else {
-		dbDir := config.Node().String(CfgDatabaseDir)
-		db, err = database.NewDB(dbDir)
+		db, err = openDB(config.Node().String(CfgDatabaseDir), database.Engine(config.Node().String(CfgDatabaseEngine)))
+	}
+	if errors.Is(err, ErrDBEngineMismatch) {
+		log.Fatalf("Unable to open the database, please convert it with the db-convert tool or change %s. Error: %s", CfgDatabaseEngine, err)
 	}
 	if err != nil {
 		log.Fatal("Unable to open the database, please delete the database folder. Error: %s", err)
@@ -79,16 +85,31 @@ func createStore() {
 	store = db.NewStore()
 }
 
+// openDB opens the persisted database with the given engine, unless the database was created by a different engine.
+func openDB(dbDir string, engine database.Engine) (database.DB, error) {
+	existingEngine, exists, err := database.DetectEngine(dbDir)
+	if err != nil {
+		return nil, err
+	}
+	if exists && existingEngine != engine {
+		return nil, errors.Errorf("%w: configured engine: %s, engine of database: %s", ErrDBEngineMismatch, engine, existingEngine)
+	}
+
+	return database.NewDBWithEngine(engine, dbDir)
+}
+
 func configure(_ *node.Plugin) {
 	// assure that the store is initialized
 	store := Store()
 	configureHealthStore(store)
 
-	if err := checkDatabaseVersion(healthStore); err != nil {
-		if errors.Is(err, ErrDBVersionIncompatible) {
-			log.Fatalf("The database scheme was updated. Please delete the database folder. %s", err)
+	if path := config.Node().String(CfgDatabaseRestore); path != "" {
+		if err := restoreBackup(store, path); err != nil {
+			if errors.Is(err, database.ErrDatabaseNotEmpty) {
+				log.Fatalf("Failed to restore database backup: the database must be empty, please delete the database folder or remove the --%s flag. %s", CfgDatabaseRestore, err)
+			}
+			log.Fatalf("Failed to restore database backup: %s", err)
 		}
-		log.Fatalf("Failed to check database version: %s", err)
 	}
 
 	if str := config.Node().String(CfgDatabaseDirty); str != "" {
@@ -106,6 +127,26 @@ func configure(_ *node.Plugin) {
 		log.Fatal("The database is marked as not properly shutdown/corrupted, please delete the database folder and restart.")
 	}
 
+	migrator := newMigrator(store)
+	if MigrationParameters.Rollback {
+		if err := rollbackMigration(migrator); err != nil {
+			log.Fatalf("Failed to roll back database migration: %s", err)
+		}
+		closeAndExit()
+	}
+	if MigrationParameters.DryRun {
+		if err := dryRunMigration(migrator); err != nil {
+			log.Fatalf("Failed to migrate database: %s", err)
+		}
+		closeAndExit()
+	}
+	if err := checkDatabaseVersion(migrator); err != nil {
+		if errors.Is(err, ErrDBVersionIncompatible) {
+			log.Fatalf("The database scheme was updated. Please delete the database folder. %s", err)
+		}
+		log.Fatalf("Failed to check database version: %s", err)
+	}
+
 	// we open the database in the configure, so we must also make sure it's closed here
 	if err := daemon.BackgroundWorker(PluginName, manageDBLifetime, shutdown.PriorityDatabase); err != nil {
 		log.Fatalf("Failed to start as daemon: %s", err)
@@ -136,6 +177,14 @@ func manageDBLifetime(shutdownSignal <-chan struct{}) {
 	log.Infof("Syncing database to disk... done")
 }
 
+// closeAndExit closes the database and exits the node (i.e. after a rollback or dry run of a migration).
+func closeAndExit() {
+	if err := db.Close(); err != nil {
+		log.Fatalf("Failed to flush the database: %s", err)
+	}
+	os.Exit(0)
+}
+
 func runDatabaseGC() {
 	if !db.RequiresGC() {
 		return
@@ -2,41 +2,114 @@ package database
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/cockroachdb/errors"
-
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/kvstore"
+
+	"github.com/iotaledger/goshimmer/packages/database"
 )
 
 const (
 	// DBVersion defines the version of the database schema this version of GoShimmer supports.
-	// Every time there's a breaking change regarding the stored data, this version flag should be adjusted.
+	// Every time there's a breaking change regarding the stored data, this version flag should be adjusted and a
+	// database.Migration from the previous version should be registered for every affected prefix.
 	DBVersion = 38
-)
 
-var (
-	// ErrDBVersionIncompatible is returned when the database has an unexpected version.
-	ErrDBVersionIncompatible = errors.New("database version is not compatible. please delete your database folder and restart")
-	// the key under which the database is stored
-	dbVersionKey = []byte{0}
+	// migrationProgressLogInterval defines how often the progress of a running migration is logged.
+	migrationProgressLogInterval = 5 * time.Second
 )
 
-// checks whether the database is compatible with the current schema version.
+// ErrDBVersionIncompatible is returned when the database has an unexpected version that can not be migrated.
+var ErrDBVersionIncompatible = errors.New("database version is not compatible. please delete your database folder and restart")
+
+// newMigrator creates a Migrator for the registered migrations that logs its progress.
+func newMigrator(store kvstore.KVStore) *database.Migrator {
+	migrator := database.NewMigrator(store, database.RegisteredMigrations(), database.WithBackup(MigrationParameters.Backup))
+
+	var lastProgressLog time.Time
+	migrator.Events.MigrationStarted.Attach(events.NewClosure(func(event *database.MigrationEvent) {
+		log.Infof("Migrating prefix %d from version %d to %d (%s) ...", event.Migration.Prefix, event.Migration.Version, event.Migration.Version+1, event.Migration.Description)
+		lastProgressLog = time.Now()
+	}))
+	migrator.Events.MigrationProgress.Attach(events.NewClosure(func(event *database.MigrationEvent) {
+		if time.Since(lastProgressLog) < migrationProgressLogInterval && event.MigratedObjects != event.TotalObjects {
+			return
+		}
+		log.Infof("Migrating prefix %d from version %d to %d: %d/%d objects (%v)", event.Migration.Prefix, event.Migration.Version, event.Migration.Version+1, event.MigratedObjects, event.TotalObjects, event.Duration)
+		lastProgressLog = time.Now()
+	}))
+	migrator.Events.MigrationFinished.Attach(events.NewClosure(func(event *database.MigrationEvent) {
+		log.Infof("Migrating prefix %d from version %d to %d (%s) ... done, took %v", event.Migration.Prefix, event.Migration.Version, event.Migration.Version+1, event.Migration.Description, event.Duration)
+	}))
+
+	return migrator
+}
+
+// checks whether the database is compatible with the current schema version and migrates it if it is older.
 // also automatically sets the version if the database is new.
-func checkDatabaseVersion(store kvstore.KVStore) error {
-	entry, err := store.Get(dbVersionKey)
-	if errors.Is(err, kvstore.ErrKeyNotFound) {
+func checkDatabaseVersion(migrator *database.Migrator) error {
+	version, exists, err := migrator.Version()
+	if err != nil {
+		return err
+	}
+	if !exists {
 		// set the version in an empty DB
-		return store.Set(dbVersionKey, []byte{DBVersion})
+		return migrator.SetVersion(DBVersion)
+	}
+	if version == DBVersion {
+		return nil
+	}
+	if version > DBVersion {
+		return fmt.Errorf("%w: supported version: %d, version of database: %d", ErrDBVersionIncompatible, DBVersion, version)
+	}
+
+	if _, err = migrator.Plan(version, DBVersion); err != nil {
+		return fmt.Errorf("%w: supported version: %d, version of database: %d (%s)", ErrDBVersionIncompatible, DBVersion, version, err)
+	}
+
+	log.Infof("Migrating database from version %d to %d (backup=%v) ...", version, DBVersion, MigrationParameters.Backup)
+	if err = migrator.Migrate(DBVersion); err != nil {
+		return errors.Errorf("failed to migrate database: %w", err)
 	}
+	log.Infof("Migrating database from version %d to %d ... done", version, DBVersion)
+
+	return nil
+}
+
+// dryRunMigration executes the migrations to the current schema version without modifying the database.
+func dryRunMigration(migrator *database.Migrator) error {
+	version, _, err := migrator.Version()
 	if err != nil {
 		return err
 	}
-	if len(entry) == 0 {
-		return fmt.Errorf("%w: no database version was persisted", ErrDBVersionIncompatible)
+
+	log.Infof("Dry run of the migration from version %d to %d ...", version, DBVersion)
+	plan, err := migrator.DryRun(DBVersion)
+	if err != nil {
+		return errors.Errorf("dry run of the migration failed: %w", err)
 	}
-	if entry[0] != DBVersion {
-		return fmt.Errorf("%w: supported version: %d, version of database: %d", ErrDBVersionIncompatible, DBVersion, entry[0])
+	log.Infof("Dry run of the migration from version %d to %d ... done, %d migration steps would be executed", version, DBVersion, len(plan))
+
+	return nil
+}
+
+// rollbackMigration restores the backup of the last migration.
+func rollbackMigration(migrator *database.Migrator) error {
+	backupVersion, exists, err := migrator.BackupVersion()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.Errorf("failed to roll back migration: %w", database.ErrNoBackup)
 	}
+
+	log.Infof("Rolling back database to version %d ...", backupVersion)
+	if err = migrator.Rollback(); err != nil {
+		return err
+	}
+	log.Infof("Rolling back database to version %d ... done, please start the previous version of GoShimmer", backupVersion)
+
 	return nil
 }
@@ -0,0 +1,24 @@
+package drng
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/drng"
+	databaseplugin "github.com/iotaledger/goshimmer/plugins/database"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+var (
+	history     *drng.History
+	historyOnce sync.Once
+)
+
+// History returns the history of the verified beacons of all dRNG instances.
+func History() *drng.History {
+	historyOnce.Do(func() {
+		history = drng.NewHistory(databaseplugin.StoreRealm([]byte{database.PrefixDRNG}), time.Duration(messagelayer.FPCParameters.RoundInterval)*time.Second)
+	})
+	return history
+}
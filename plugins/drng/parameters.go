@@ -1,6 +1,7 @@
 package drng
 
 import (
+	"github.com/iotaledger/hive.go/configuration"
 	flag "github.com/spf13/pflag"
 )
 
@@ -39,7 +40,19 @@ const (
 	CfgDRNGCustomCommitteeMembers = "drng.custom.committeeMembers"
 )
 
+// ProducerParameters contains the configuration parameters of the local dRNG beacon producer.
+var ProducerParameters = struct {
+	// InstanceID defines the instanceID of the committee whose beacons are produced.
+	InstanceID uint32 `default:"9999" usage:"instance ID of the committee whose beacons are produced by this node"`
+	// KeyShare defines the private BLS key share of this node.
+	KeyShare string `usage:"private BLS key share of this node (hex encoded)"`
+	// Commits defines the commitments of the public polynomial of the committee.
+	Commits []string `usage:"commitments of the public polynomial of the committee (hex encoded)"`
+}{}
+
 func init() {
+	configuration.BindParameters(&ProducerParameters, "drng.producer")
+
 	// Default parameters of GoShimmer dRNG committee.
 	flag.Int(CfgDRNGInstanceID, Pollen, "instance ID of the GoShimmer drng instance")
 	flag.Int(CfgDRNGThreshold, 3, "BLS threshold of the GoShimmer drng")
@@ -69,7 +69,9 @@ func run(*node.Plugin) {
 						plugin.LogDebug(err)
 						return
 					}
-					plugin.LogDebug("New randomness: ", instance.State[parsedPayload.InstanceID].Randomness())
+					if parsedPayload.PayloadType == drng.TypeCollectiveBeacon {
+						plugin.LogDebug("New randomness: ", instance.State[parsedPayload.InstanceID].Randomness())
+					}
 				})
 			}
 		}
@@ -95,6 +97,13 @@ func configureEvents() {
 
 	messagelayer.SetDRNGState(Instance().LoadState(messagelayer.FPCParameters.DRNGInstanceID))
 
+	// persist every verified beacon, so that the randomness of past rounds can be replayed.
+	Instance().Events.VerifiedCollectiveBeacon.Attach(events.NewClosure(func(cb *drng.CollectiveBeaconEvent) {
+		if err := History().Store(drng.NewBeacon(cb)); err != nil {
+			plugin.LogErrorf("Failed to store beacon: %s", err)
+		}
+	}))
+
 	// Section to update the randomness for the dRNG ticker used by FPC.
 	Instance().Events.Randomness.Attach(events.NewClosure(func(state *drng.State) {
 		if state.Committee().InstanceID == messagelayer.FPCParameters.DRNGInstanceID {
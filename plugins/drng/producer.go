@@ -0,0 +1,139 @@
+package drng
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/drng"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// ProducerPluginName is the name of the dRNG producer plugin.
+const ProducerPluginName = "DRNGProducer"
+
+var (
+	// producerPlugin is the plugin instance of the dRNG producer plugin.
+	producerPlugin     *node.Plugin
+	producerPluginOnce sync.Once
+	producer           *drng.Producer
+)
+
+// ProducerPlugin gets the plugin instance of the dRNG producer, that lets the node take part in a committee whose
+// beacons are produced by the nodes of the network themselves. It is disabled by default.
+func ProducerPlugin() *node.Plugin {
+	producerPluginOnce.Do(func() {
+		producerPlugin = node.NewPlugin(ProducerPluginName, node.Disabled, configureProducer, runProducer)
+	})
+	return producerPlugin
+}
+
+func configureProducer(plugin *node.Plugin) {
+	state := Instance().LoadState(ProducerParameters.InstanceID)
+	if state == nil {
+		plugin.LogFatalf("No committee configured for dRNG instance %d", ProducerParameters.InstanceID)
+	}
+	committee := state.Committee()
+	if !isCommitteeMember(committee) {
+		plugin.LogFatalf("This node is not a member of the committee of dRNG instance %d", committee.InstanceID)
+	}
+
+	keyShare, err := parseKeyShare(ProducerParameters.KeyShare, ProducerParameters.Commits)
+	if err != nil {
+		plugin.LogFatalf("Invalid key share: %s", err)
+	}
+	producer, err = drng.NewProducer(committee.InstanceID, int(committee.Threshold), len(committee.Identities), keyShare)
+	if err != nil {
+		plugin.LogFatalf("Failed to create the producer: %s", err)
+	}
+	if len(committee.DistributedPK) != 0 && !bytes.Equal(committee.DistributedPK, producer.DistributedPK()) {
+		plugin.LogFatalf("The key share does not match the distributed public key of dRNG instance %d", committee.InstanceID)
+	}
+
+	Instance().Events.PartialBeacon.Attach(events.NewClosure(func(event *drng.PartialBeaconEvent) {
+		if event.InstanceID != committee.InstanceID {
+			return
+		}
+		beacon, err := producer.ProcessPartialBeacon(event)
+		if err != nil {
+			plugin.LogDebugf("Invalid partial beacon of %s: %s", event.IssuerPublicKey, err)
+			return
+		}
+		if beacon != nil {
+			issueBeaconPayload(plugin, beacon)
+		}
+	}))
+	Instance().Events.CollectiveBeacon.Attach(events.NewClosure(func(event *drng.CollectiveBeaconEvent) {
+		if event.InstanceID != committee.InstanceID {
+			return
+		}
+		if err := producer.ProcessBeacon(event); err != nil {
+			plugin.LogDebugf("Invalid collective beacon of %s: %s", event.IssuerPublicKey, err)
+		}
+	}))
+}
+
+func runProducer(plugin *node.Plugin) {
+	if err := daemon.BackgroundWorker(ProducerPluginName, func(shutdownSignal <-chan struct{}) {
+		// the rounds are aligned with the ticker of FPC, that consumes the randomness
+		interval := messagelayer.FPCParameters.RoundInterval
+		for {
+			now := clock.SyncedTime()
+			timePoint := drng.ResolveNextTimePoint(now.Unix(), interval)
+
+			select {
+			case <-shutdownSignal:
+				plugin.LogInfof("Stopping %s ... done", ProducerPluginName)
+				return
+			case <-time.After(time.Unix(timePoint, 0).Sub(now)):
+			}
+
+			partial, err := producer.Sign(uint64(timePoint / interval))
+			if err != nil {
+				plugin.LogWarnf("Failed to sign round %d: %s", timePoint/interval, err)
+				continue
+			}
+			issueBeaconPayload(plugin, partial)
+		}
+	}, shutdown.PriorityFPC); err != nil {
+		plugin.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+func issueBeaconPayload(plugin *node.Plugin, beaconPayload payload.Payload) {
+	if _, err := messagelayer.Tangle().IssuePayload(beaconPayload); err != nil {
+		plugin.LogWarnf("Failed to issue %s: %s", beaconPayload, err)
+	}
+}
+
+func isCommitteeMember(committee drng.Committee) bool {
+	for _, member := range committee.Identities {
+		if member == local.GetInstance().PublicKey() {
+			return true
+		}
+	}
+	return false
+}
+
+func parseKeyShare(keyShare string, commits []string) (*drng.KeyShare, error) {
+	priShareBytes, err := hex.DecodeString(keyShare)
+	if err != nil {
+		return nil, err
+	}
+	commitsBytes := make([][]byte, len(commits))
+	for i, commit := range commits {
+		if commitsBytes[i], err = hex.DecodeString(commit); err != nil {
+			return nil, err
+		}
+	}
+	return drng.KeyShareFromBytes(priShareBytes, commitsBytes)
+}
@@ -1,29 +1,29 @@
 package faucet
 
 import (
-	"runtime"
 	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/daemon"
-	"github.com/iotaledger/hive.go/datastructure/orderedmap"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
-	"github.com/iotaledger/hive.go/workerpool"
 	"github.com/mr-tron/base58"
 	flag "github.com/spf13/pflag"
 	"go.uber.org/atomic"
 
 	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/faucet"
-	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
 	"github.com/iotaledger/goshimmer/packages/pow"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/config"
+	databaseplugin "github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
 
@@ -40,9 +40,21 @@ const (
 	CfgFaucetMaxTransactionBookedAwaitTimeSeconds = "faucet.maxTransactionBookedAwaitTimeSeconds"
 	// CfgFaucetPoWDifficulty defines the PoW difficulty for faucet payloads.
 	CfgFaucetPoWDifficulty = "faucet.powDifficulty"
-	// CfgFaucetBlacklistCapacity holds the maximum amount the address blacklist holds.
-	// An address for which a funding was done in the past is added to the blacklist and eventually is removed from it.
-	CfgFaucetBlacklistCapacity = "faucet.blacklistCapacity"
+	// CfgFaucetQueueCapacity defines the maximum number of requests that wait to be funded.
+	CfgFaucetQueueCapacity = "faucet.queueCapacity"
+	// CfgFaucetAddressQuota defines how many times an address is funded within the address quota window.
+	CfgFaucetAddressQuota = "faucet.addressQuota"
+	// CfgFaucetAddressQuotaWindow defines the sliding window of the address quota.
+	CfgFaucetAddressQuotaWindow = "faucet.addressQuotaWindow"
+	// CfgFaucetNodeQuota defines how many requests may pledge mana to the same node within the node quota window.
+	CfgFaucetNodeQuota = "faucet.nodeQuota"
+	// CfgFaucetNodeQuotaWindow defines the sliding window of the node quota.
+	CfgFaucetNodeQuotaWindow = "faucet.nodeQuotaWindow"
+	// CfgFaucetPriorityAccessManaThreshold defines the access mana below which the requests of a mana pledge node are
+	// funded before all other requests.
+	CfgFaucetPriorityAccessManaThreshold = "faucet.priorityAccessManaThreshold"
+	// CfgFaucetRequestRetention defines how long the state of a processed request is kept.
+	CfgFaucetRequestRetention = "faucet.requestRetention"
 	// CfgFaucetPreparedOutputsCount is the number of outputs the faucet prepares for requests.
 	CfgFaucetPreparedOutputsCount = "faucet.preparedOutputsCount"
 	// CfgFaucetStartIndex defines from which address index the faucet should start gathering outputs.
@@ -54,32 +66,34 @@ func init() {
 	flag.Int(CfgFaucetTokensPerRequest, 1000000, "the amount of tokens the faucet should send for each request")
 	flag.Int(CfgFaucetMaxTransactionBookedAwaitTimeSeconds, 5, "the max amount of time for a funding transaction to become booked in the value layer")
 	flag.Int(CfgFaucetPoWDifficulty, 22, "defines the PoW difficulty for faucet payloads")
-	flag.Int(CfgFaucetBlacklistCapacity, 10000, "holds the maximum amount the address blacklist holds")
+	flag.Int(CfgFaucetQueueCapacity, faucet.DefaultQueueCapacity, "the maximum number of requests that wait to be funded")
+	flag.Int(CfgFaucetAddressQuota, faucet.DefaultAddressQuota, "how many times an address is funded within the address quota window (0 to disable)")
+	flag.Duration(CfgFaucetAddressQuotaWindow, faucet.DefaultAddressQuotaWindow, "the sliding window of the address quota")
+	flag.Int(CfgFaucetNodeQuota, faucet.DefaultNodeQuota, "how many requests may pledge mana to the same node within the node quota window (0 to disable)")
+	flag.Duration(CfgFaucetNodeQuotaWindow, faucet.DefaultNodeQuotaWindow, "the sliding window of the node quota")
+	flag.Float64(CfgFaucetPriorityAccessManaThreshold, tangle.MinMana, "requests pledging to nodes with less access mana are funded first (0 to disable)")
+	flag.Duration(CfgFaucetRequestRetention, 48*time.Hour, "how long the state of a processed request is kept")
 	flag.Int(CfgFaucetPreparedOutputsCount, 126, "number of outputs the faucet prepares")
 	flag.Int(CfgFaucetStartIndex, 0, "address index to start faucet with")
 }
 
 var (
 	// Plugin is the "plugin" instance of the faucet application.
-	plugin                 *node.Plugin
-	pluginOnce             sync.Once
-	_faucet                *StateManager
-	faucetOnce             sync.Once
-	log                    *logger.Logger
-	powVerifier            = pow.New()
-	fundingWorkerPool      *workerpool.NonBlockingQueuedWorkerPool
-	fundingWorkerCount     = runtime.GOMAXPROCS(0)
-	fundingWorkerQueueSize = 500
-	targetPoWDifficulty    int
-	startIndex             int
-	// blacklist makes sure that an address might only request tokens once.
-	blacklist         *orderedmap.OrderedMap
-	blacklistCapacity int
-	blackListMutex    sync.RWMutex
+	plugin              *node.Plugin
+	pluginOnce          sync.Once
+	_faucet             *StateManager
+	faucetOnce          sync.Once
+	requestQueue        *faucet.Queue
+	requestQueueOnce    sync.Once
+	log                 *logger.Logger
+	powVerifier         = pow.New()
+	targetPoWDifficulty int
+	startIndex          int
 	// signals that the faucet has initialized itself and can start funding requests
 	initDone atomic.Bool
 
-	waitForManaWindow = 5 * time.Second
+	waitForManaWindow    = 5 * time.Second
+	requestPruneInterval = time.Hour
 )
 
 // Plugin returns the plugin instance of the faucet plugin.
@@ -123,24 +137,29 @@ func Faucet() *StateManager {
 	return _faucet
 }
 
+// Queue gets the persistent queue of the funding requests.
+func Queue() *faucet.Queue {
+	requestQueueOnce.Do(func() {
+		queue, err := faucet.NewQueue(databaseplugin.StoreRealm([]byte{database.PrefixFaucet}),
+			faucet.WithCapacity(config.Node().Int(CfgFaucetQueueCapacity)),
+			faucet.WithAddressQuota(config.Node().Int(CfgFaucetAddressQuota), config.Node().Duration(CfgFaucetAddressQuotaWindow)),
+			faucet.WithNodeQuota(config.Node().Int(CfgFaucetNodeQuota), config.Node().Duration(CfgFaucetNodeQuotaWindow)),
+			faucet.WithExemptNodes(messagelayer.Tangle().Options.Identity.ID()),
+		)
+		if err != nil {
+			log.Fatalf("failed to load the faucet request queue: %s", err)
+		}
+		requestQueue = queue
+	})
+	return requestQueue
+}
+
 func configure(*node.Plugin) {
 	log = logger.NewLogger(PluginName)
 	targetPoWDifficulty = config.Node().Int(CfgFaucetPoWDifficulty)
 	startIndex = config.Node().Int(CfgFaucetStartIndex)
-	blacklist = orderedmap.New()
-	blacklistCapacity = config.Node().Int(CfgFaucetBlacklistCapacity)
 	Faucet()
-
-	fundingWorkerPool = workerpool.NewNonBlockingQueuedWorkerPool(func(task workerpool.Task) {
-		msg := task.Param(0).(*tangle.Message)
-		addr := msg.Payload().(*faucet.Request).Address()
-		msg, txID, err := Faucet().FulFillFundingRequest(msg)
-		if err != nil {
-			log.Warnf("couldn't fulfill funding request to %s: %s", addr.Base58(), err)
-			return
-		}
-		log.Infof("sent funds to address %s via tx %s and msg %s", addr.Base58(), txID, msg.ID())
-	}, workerpool.WorkerCount(fundingWorkerCount), workerpool.QueueSize(fundingWorkerQueueSize))
+	Queue()
 
 	configureEvents()
 }
@@ -170,16 +189,68 @@ func run(*node.Plugin) {
 		}
 		log.Info("Deriving faucet state from the ledger... done")
 
-		defer fundingWorkerPool.Stop()
 		initDone.Store(true)
-
-		<-shutdownSignal
+		processRequests(shutdownSignal)
 		log.Infof("Stopping %s ...", PluginName)
 	}, shutdown.PriorityFaucet); err != nil {
 		log.Panicf("Failed to start daemon: %s", err)
 	}
 }
 
+// processRequests funds the queued requests one after the other until the shutdown signal is received.
+func processRequests(shutdownSignal <-chan struct{}) {
+	pruneTicker := time.NewTicker(requestPruneInterval)
+	defer pruneTicker.Stop()
+
+	for {
+		request, ok := Queue().Dequeue()
+		if !ok {
+			select {
+			case <-Queue().Ready():
+			case <-pruneTicker.C:
+				pruneRequests()
+			case <-shutdownSignal:
+				return
+			}
+			continue
+		}
+
+		msg, txID, err := Faucet().FulFillFundingRequest(request)
+		if err != nil {
+			log.Warnf("couldn't fulfill funding request to %s: %s", request.Address.Base58(), err)
+			if err := Queue().Failed(request); err != nil {
+				log.Errorf("failed to update funding request %s: %s", request.MessageID, err)
+			}
+			continue
+		}
+		if err := Queue().Fulfilled(request, txID); err != nil {
+			log.Errorf("failed to update funding request %s: %s", request.MessageID, err)
+		}
+		log.Infof("sent funds to address %s via tx %s and msg %s", request.Address.Base58(), txID.Base58(), msg.ID())
+
+		select {
+		case <-pruneTicker.C:
+			pruneRequests()
+		case <-shutdownSignal:
+			return
+		default:
+		}
+	}
+}
+
+// pruneRequests deletes the processed requests that are older than the retention and all the quota windows.
+func pruneRequests() {
+	retention := config.Node().Duration(CfgFaucetRequestRetention)
+	for _, window := range []time.Duration{config.Node().Duration(CfgFaucetAddressQuotaWindow), config.Node().Duration(CfgFaucetNodeQuotaWindow)} {
+		if window > retention {
+			retention = window
+		}
+	}
+	if err := Queue().Prune(time.Now().Add(-retention)); err != nil {
+		log.Errorf("failed to prune funding requests: %s", err)
+	}
+}
+
 func waitUntilSynced(shutdownSignal <-chan struct{}) bool {
 	synced := make(chan struct{}, 1)
 	closure := events.NewClosure(func(e *tangle.SyncChangedEvent) {
@@ -244,69 +315,64 @@ func configureEvents() {
 			if !faucet.IsFaucetReq(message) {
 				return
 			}
-			fundingRequest := message.Payload().(*faucet.Request)
-			addr := fundingRequest.Address()
+			request := faucet.NewQueuedRequest(message, clock.SyncedTime())
+			addr := request.Address.Base58()
 
-			// verify PoW
-			leadingZeroes, err := powVerifier.LeadingZeros(fundingRequest.Bytes())
-			if err != nil {
-				log.Infof("couldn't verify PoW of funding request for address %s", addr.Base58())
-				return
-			}
-
-			if leadingZeroes < targetPoWDifficulty {
-				log.Infof("funding request for address %s doesn't fulfill PoW requirement %d vs. %d", addr.Base58(), targetPoWDifficulty, leadingZeroes)
-				return
-			}
-
-			if IsAddressBlackListed(addr) {
-				log.Infof("can't fund address %s since it is blacklisted", addr.Base58())
+			// requests without sufficient PoW are cheap to spam, so their refusal is only logged and not stored
+			if err := checkPoW(message.Payload().(*faucet.Request)); err != nil {
+				log.Infof("refused funding request for address %s: %s", addr, err)
 				return
 			}
 
 			// finally add it to the faucet to be processed
-			_, added := fundingWorkerPool.TrySubmit(message)
-			if !added {
-				RemoveAddressFromBlacklist(addr)
-				log.Info("dropped funding request for address %s as queue is full", addr.Base58())
+			request.Prioritized = isPrioritized(request.AccessManaPledgeID)
+			if err := Queue().Enqueue(request); err != nil {
+				log.Infof("refused funding request for address %s: %s", addr, err)
 				return
 			}
-			log.Infof("enqueued funding request for address %s", addr.Base58())
+			log.Infof("enqueued funding request for address %s", addr)
 		})
 	}))
 }
 
-// IsAddressBlackListed returns if an address is blacklisted.
-// adds the given address to the blacklist and removes the oldest blacklist entry if it would go over capacity.
-func IsAddressBlackListed(address ledgerstate.Address) bool {
-	blackListMutex.Lock()
-	defer blackListMutex.Unlock()
+// CheckRequest returns a faucet.RefusalError if the faucet would refuse the given request issued by the given node.
+// It's used to refuse requests before they are issued.
+func CheckRequest(request *faucet.Request, issuerID identity.ID) error {
+	if err := checkPoW(request); err != nil {
+		return err
+	}
 
-	// see if it was already blacklisted
-	_, blacklisted := blacklist.Get(address.Base58())
+	accessManaPledgeID, consensusManaPledgeID := request.ManaPledgeIDs(issuerID)
+	return Queue().Check(request.Address(), issuerID, accessManaPledgeID, consensusManaPledgeID, clock.SyncedTime())
+}
 
-	if blacklisted {
-		return true
+// checkPoW returns a faucet.RefusalError if the PoW of the request doesn't fulfill the target difficulty.
+func checkPoW(request *faucet.Request) error {
+	leadingZeroes, err := powVerifier.LeadingZeros(request.Bytes())
+	if err != nil {
+		log.Debugf("couldn't verify PoW of funding request for address %s: %s", request.Address().Base58(), err)
+		return faucet.NewRefusalError(faucet.RefusalInsufficientPoW, 0)
 	}
-
-	// add it to the blacklist
-	blacklist.Set(address.Base58(), true)
-	if blacklist.Size() > blacklistCapacity {
-		var headKey interface{}
-		blacklist.ForEach(func(key, value interface{}) bool {
-			headKey = key
-			return false
-		})
-		blacklist.Delete(headKey)
+	if leadingZeroes < targetPoWDifficulty {
+		log.Debugf("funding request for address %s doesn't fulfill PoW requirement %d vs. %d", request.Address().Base58(), targetPoWDifficulty, leadingZeroes)
+		return faucet.NewRefusalError(faucet.RefusalInsufficientPoW, 0)
 	}
-
-	return false
+	return nil
 }
 
-// RemoveAddressFromBlacklist removes an address from the blacklist.
-func RemoveAddressFromBlacklist(address ledgerstate.Address) {
-	blackListMutex.Lock()
-	defer blackListMutex.Unlock()
-
-	blacklist.Delete(address.Base58())
+// isPrioritized returns true if the given node has less access mana than the priority threshold. Nodes that are missing
+// in the access mana vector are not prioritized, as anyone can create them to skip the queue.
+func isPrioritized(nodeID identity.ID) bool {
+	threshold := config.Node().Float64(CfgFaucetPriorityAccessManaThreshold)
+	if threshold <= 0 {
+		return false
+	}
+	aMana, _, err := messagelayer.GetAccessMana(nodeID)
+	if err != nil {
+		if !errors.Is(err, mana.ErrNodeNotFoundInBaseManaVector) {
+			log.Debugf("failed to get access mana of %s: %s", nodeID, err)
+		}
+		return false
+	}
+	return aMana < threshold
 }
@@ -115,8 +115,8 @@ func (s *StateManager) FundingOutputsCount() int {
 }
 
 // DeriveStateFromTangle derives the faucet state from a synchronized Tangle.
-//  - startIndex defines from which address index to start look for prepared outputs.
-//  - remainder output should always sit on address 0.
+//   - startIndex defines from which address index to start look for prepared outputs.
+//   - remainder output should always sit on address 0.
 //   - if no funding outputs are found, the faucet creates them from the remainder output.
 func (s *StateManager) DeriveStateFromTangle(startIndex int) (err error) {
 	s.Lock()
@@ -182,14 +182,12 @@ func (s *StateManager) DeriveStateFromTangle(startIndex int) (err error) {
 	return err
 }
 
-// FulFillFundingRequest fulfills a faucet request by spending the next funding output to the requested address.
-// Mana of the transaction is pledged to the requesting node.
-func (s *StateManager) FulFillFundingRequest(requestMsg *tangle.Message) (m *tangle.Message, txID string, err error) {
+// FulFillFundingRequest fulfills a queued faucet request by spending the next funding output to the requested address.
+// Mana of the transaction is pledged to the mana pledge nodes of the request.
+func (s *StateManager) FulFillFundingRequest(request *faucet.QueuedRequest) (m *tangle.Message, txID ledgerstate.TransactionID, err error) {
 	s.Lock()
 	defer s.Unlock()
 
-	faucetReq := requestMsg.Payload().(*faucet.Request)
-
 	// get an output that we can spend
 	fundingOutput, fErr := s.getFundingOutput()
 	// we don't have funding outputs
@@ -214,24 +212,14 @@ func (s *StateManager) FulFillFundingRequest(requestMsg *tangle.Message) (m *tan
 	}
 
 	// prepare funding tx, pledge mana to requester
-	emptyID := identity.ID{}
-	accessManaPledgeID := identity.NewID(requestMsg.IssuerPublicKey())
-	consensusManaPledgeID := identity.NewID(requestMsg.IssuerPublicKey())
-	if faucetReq.AccessManaPledgeID() != emptyID {
-		accessManaPledgeID = faucetReq.AccessManaPledgeID()
-	}
-	if faucetReq.ConsensusManaPledgeID() != emptyID {
-		consensusManaPledgeID = faucetReq.ConsensusManaPledgeID()
-	}
-
-	tx := s.prepareFaucetTransaction(faucetReq.Address(), fundingOutput, accessManaPledgeID, consensusManaPledgeID)
+	tx := s.prepareFaucetTransaction(request.Address, fundingOutput, request.AccessManaPledgeID, request.ConsensusManaPledgeID)
 
 	// issue funding request
 	m, err = s.issueTX(tx)
 	if err != nil {
 		return
 	}
-	txID = tx.ID().Base58()
+	txID = tx.ID()
 
 	return m, txID, err
 }
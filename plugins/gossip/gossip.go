@@ -51,7 +51,14 @@ func createManager() {
 	if err := lPeer.UpdateService(service.GossipKey, "tcp", gossipPort); err != nil {
 		log.Fatalf("could not update services: %s", err)
 	}
-	mgr = gossip.NewManager(lPeer, loadMessage, log)
+	mgrOpts := []gossip.ManagerOption{gossip.WithBatching(BatchingParameters.FlushInterval, BatchingParameters.MaxSize)}
+	if RequestParameters.Interval > 0 {
+		mgrOpts = append(mgrOpts, gossip.WithRequestScheduling(RequestParameters.Interval, RequestParameters.MaxQueued, RequestParameters.MaxInFlight, RequestParameters.Timeout))
+	}
+	if ScoringParameters.Enabled {
+		mgrOpts = append(mgrOpts, gossip.WithScoring(ScoringParameters.HalfLife, ScoringParameters.Threshold, ScoringParameters.BanDuration))
+	}
+	mgr = gossip.NewManager(lPeer, loadMessage, log, mgrOpts...)
 }
 
 func start(shutdownSignal <-chan struct{}) {
@@ -75,13 +82,17 @@ func start(shutdownSignal <-chan struct{}) {
 	}
 	defer listener.Close()
 
-	srv := server.ServeTCP(lPeer, listener, log)
+	var srvOpts []server.Option
+	if Parameters.Compression {
+		srvOpts = append(srvOpts, server.WithCompression(gossip.SupportedCompressions...))
+	}
+	srv := server.ServeTCP(lPeer, listener, log, srvOpts...)
 	defer srv.Close()
 
 	mgr.Start(srv)
 	defer mgr.Stop()
 
-	log.Infof("%s started: bind-address=%s", PluginName, localAddr.String())
+	log.Infof("%s started: bind-address=%s compression=%v batching=%v", PluginName, localAddr.String(), Parameters.Compression, BatchingParameters.FlushInterval)
 
 	<-shutdownSignal
 	log.Info("Stopping " + PluginName + " ...")
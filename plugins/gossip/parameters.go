@@ -1,6 +1,8 @@
 package gossip
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/configuration"
 )
 
@@ -8,8 +10,55 @@ import (
 var Parameters = struct {
 	// NetworkVersion defines the config flag of the network version.
 	Port int `default:"14666" usage:"tcp port for gossip connection"`
+	// Compression defines whether the packets are compressed on the connections to neighbors that support it.
+	Compression bool `default:"false" usage:"compress the gossip packets on the connections to neighbors that support it"`
+}{}
+
+// BatchingParameters contains the configuration parameters of the batching of gossip packets.
+var BatchingParameters = struct {
+	// FlushInterval defines how long the packets are collected before they are sent as a single batch.
+	FlushInterval time.Duration `default:"0s" usage:"interval for which gossip packets are collected and sent as a single batch, 0 disables batching"`
+	// MaxSize defines the maximum size of a batch.
+	MaxSize int `default:"16384" usage:"maximum size of a batch of gossip packets [bytes]"`
+}{}
+
+// RequestParameters contains the configuration parameters of the scheduling of message requests.
+var RequestParameters = struct {
+	// Interval defines how often the queued message requests are sent to a neighbor.
+	Interval time.Duration `default:"100ms" usage:"interval in which the queued message requests are sent to a neighbor, 0 sends every request immediately"`
+	// MaxQueued defines the maximum amount of queued message requests per neighbor.
+	MaxQueued int `default:"10000" usage:"maximum amount of queued message requests per neighbor, further requests are only sent with the retries to all neighbors"`
+	// MaxInFlight defines the maximum amount of unanswered message requests per neighbor.
+	MaxInFlight int `default:"500" usage:"maximum amount of unanswered message requests per neighbor"`
+	// Timeout defines after which time an unanswered message request no longer counts towards MaxInFlight.
+	Timeout time.Duration `default:"5s" usage:"time after which an unanswered message request no longer counts towards the maximum"`
+}{}
+
+// ScoringParameters contains the configuration parameters of the scoring of the neighbors.
+var ScoringParameters = struct {
+	// Enabled defines whether neighbors whose score falls below the threshold are dropped and banned.
+	Enabled bool `default:"true" usage:"drop and ban neighbors whose score falls below the threshold"`
+	// Threshold defines the score below which a neighbor is dropped and banned.
+	Threshold float64 `default:"-100" usage:"score below which a neighbor is dropped and banned"`
+	// HalfLife defines the time after which the penalties of a neighbor are halved.
+	HalfLife time.Duration `default:"10m" usage:"time after which the penalties of a neighbor are halved"`
+	// BanDuration defines how long a dropped neighbor is banned.
+	BanDuration time.Duration `default:"1h" usage:"duration for which a dropped neighbor is banned"`
+	// InvalidMessagePenalty defines the penalty for a message that could not be parsed or has an invalid signature.
+	InvalidMessagePenalty float64 `default:"10" usage:"penalty for an invalid message"`
+	// InvalidPoWPenalty defines the penalty for a message with insufficient PoW.
+	InvalidPoWPenalty float64 `default:"20" usage:"penalty for a message with insufficient PoW"`
+	// RequestTimeoutPenalty defines the penalty for a message whose parents could not be requested.
+	RequestTimeoutPenalty float64 `default:"5" usage:"penalty for a message whose parents could not be requested"`
+	// DuplicateFloodThreshold defines how many duplicate messages per second are accepted from a neighbor.
+	DuplicateFloodThreshold int `default:"1000" usage:"amount of duplicate messages per second that are accepted from a neighbor"`
+	// DuplicateFloodPenalty defines the penalty for every second in which a neighbor exceeds the DuplicateFloodThreshold.
+	DuplicateFloodPenalty float64 `default:"10" usage:"penalty for every second in which a neighbor sends too many duplicate messages"`
 }{}
 
 func init() {
 	configuration.BindParameters(&Parameters, "gossip")
+	configuration.BindParameters(&BatchingParameters, "gossip.batching")
+	configuration.BindParameters(&RequestParameters, "gossip.requests")
+	configuration.BindParameters(&ScoringParameters, "gossip.scoring")
 }
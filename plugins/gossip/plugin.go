@@ -40,6 +40,7 @@ func configure(*node.Plugin) {
 
 	configureLogging()
 	configureMessageLayer()
+	configureScoring()
 }
 
 func run(*node.Plugin) {
@@ -87,6 +88,13 @@ func configureMessageLayer() {
 
 	// request missing messages
 	messagelayer.Tangle().Requester.Events.SendRequest.Attach(events.NewClosure(func(sendRequest *tangle.SendRequestEvent) {
-		mgr.RequestMessage(sendRequest.ID[:])
+		if sendRequest.Peer == nil {
+			mgr.RequestMessage(sendRequest.ID[:])
+			return
+		}
+		mgr.RequestMessage(sendRequest.ID[:], sendRequest.Peer.ID())
+	}))
+	messagelayer.Tangle().Storage.Events.MissingMessageStored.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		mgr.StopMessageRequest(messageID[:])
 	}))
 }
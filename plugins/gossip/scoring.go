@@ -0,0 +1,148 @@
+package gossip
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+func configureScoring() {
+	if !ScoringParameters.Enabled {
+		return
+	}
+
+	// assure that the Manager is instantiated
+	mgr := Manager()
+
+	for _, group := range []gossip.NeighborsGroup{gossip.NeighborsGroupAuto, gossip.NeighborsGroupManual} {
+		mgr.NeighborsEvents(group).NeighborBanned.Attach(events.NewClosure(func(n *gossip.Neighbor) {
+			log.Warnf("Neighbor banned for %v: %s / %s (score=%.2f)", ScoringParameters.BanDuration, gossip.GetAddress(n.Peer), n.ID(), n.Score())
+		}))
+	}
+
+	// penalize invalid messages and duplicate floods
+	duplicates := newDuplicateCounter(ScoringParameters.DuplicateFloodThreshold)
+	messagelayer.Tangle().Parser.Events.BytesRejected.Attach(events.NewClosure(func(event *tangle.BytesRejectedEvent, err error) {
+		switch {
+		case errors.Is(err, tangle.ErrReceivedDuplicateBytes):
+			if duplicates.Increase(event.Peer.ID()) {
+				mgr.Penalize(event.Peer.ID(), ScoringParameters.DuplicateFloodPenalty)
+			}
+		case errors.Is(err, tangle.ErrInvalidPOWDifficultly):
+			mgr.Penalize(event.Peer.ID(), ScoringParameters.InvalidPoWPenalty)
+		default:
+			mgr.Penalize(event.Peer.ID(), ScoringParameters.InvalidMessagePenalty)
+		}
+	}))
+	messagelayer.Tangle().Parser.Events.MessageRejected.Attach(events.NewClosure(func(event *tangle.MessageRejectedEvent, _ error) {
+		mgr.Penalize(event.Peer.ID(), ScoringParameters.InvalidMessagePenalty)
+	}))
+
+	// penalize the senders of messages whose parents could not be requested
+	senders := newMissingParentSenders()
+	messagelayer.Tangle().Solidifier.Events.MessageMissing.Attach(events.NewClosure(senders.AddMissing))
+	// the message is stored (and its missing parents are marked) before the closures attached after are executed
+	messagelayer.Tangle().Parser.Events.MessageParsed.AttachAfter(events.NewClosure(func(event *tangle.MessageParsedEvent) {
+		senders.AddSender(event.Message, event.Peer.ID())
+	}))
+	messagelayer.Tangle().Storage.Events.MissingMessageStored.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		senders.Remove(messageID)
+	}))
+	messagelayer.Tangle().Requester.Events.RequestFailed.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		for _, id := range senders.Remove(messageID) {
+			mgr.Penalize(id, ScoringParameters.RequestTimeoutPenalty)
+		}
+	}))
+}
+
+// region duplicateCounter /////////////////////////////////////////////////////////////////////////////////////////////
+
+// duplicateCounter counts the duplicate messages that were received from the neighbors within the current second.
+type duplicateCounter struct {
+	threshold   int
+	counts      map[identity.ID]int
+	windowStart time.Time
+	mutex       sync.Mutex
+}
+
+func newDuplicateCounter(threshold int) *duplicateCounter {
+	return &duplicateCounter{
+		threshold: threshold,
+		counts:    make(map[identity.ID]int),
+	}
+}
+
+// Increase counts a duplicate message of the given peer and returns true if the peer exceeded the threshold in the
+// current second. It returns true at most once per peer and second.
+func (d *duplicateCounter) Increase(id identity.ID) bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if now := time.Now(); now.Sub(d.windowStart) >= time.Second {
+		d.counts = make(map[identity.ID]int)
+		d.windowStart = now
+	}
+	d.counts[id]++
+
+	return d.counts[id] == d.threshold+1
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region missingParentSenders /////////////////////////////////////////////////////////////////////////////////////////
+
+// missingParentSenders keeps track of the peers that sent messages referencing a missing message.
+type missingParentSenders struct {
+	senders map[tangle.MessageID]map[identity.ID]struct{}
+	mutex   sync.Mutex
+}
+
+func newMissingParentSenders() *missingParentSenders {
+	return &missingParentSenders{
+		senders: make(map[tangle.MessageID]map[identity.ID]struct{}),
+	}
+}
+
+// AddMissing starts tracking the senders of the messages that reference the given missing message.
+func (m *missingParentSenders) AddMissing(messageID tangle.MessageID) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.senders[messageID]; !exists {
+		m.senders[messageID] = make(map[identity.ID]struct{})
+	}
+}
+
+// AddSender remembers the given peer for all the missing parents of the message.
+func (m *missingParentSenders) AddSender(message *tangle.Message, id identity.ID) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	message.ForEachParent(func(parent tangle.Parent) {
+		if senders, exists := m.senders[parent.ID]; exists {
+			senders[id] = struct{}{}
+		}
+	})
+}
+
+// Remove stops tracking the given missing message and returns the peers that sent messages referencing it.
+func (m *missingParentSenders) Remove(messageID tangle.MessageID) (ids []identity.ID) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for id := range m.senders[messageID] {
+		ids = append(ids, id)
+	}
+	delete(m.senders, messageID)
+
+	return ids
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1,4 +1,20 @@
 package manualpeering
 
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
+
 // CfgManualpeeringKnownPeers list of peers that will be used as known peers in the manualpeering.
 const CfgManualpeeringKnownPeers = "manualpeering.knownPeers"
+
+// Parameters contains the configuration parameters of the manualpeering plugin.
+var Parameters = struct {
+	// ReloadInterval defines how often the config file is checked for changes of the known peers.
+	ReloadInterval time.Duration `default:"10s" usage:"interval in which the config file is checked for changes of the known peers, 0 disables the reload"`
+}{}
+
+func init() {
+	configuration.BindParameters(&Parameters, "manualpeering")
+}
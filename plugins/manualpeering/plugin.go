@@ -2,21 +2,23 @@ package manualpeering
 
 import (
 	"encoding/json"
+	"os"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
-
-	"github.com/iotaledger/goshimmer/plugins/config"
-
+	"github.com/iotaledger/hive.go/configuration"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 
+	"github.com/iotaledger/goshimmer/packages/database"
 	"github.com/iotaledger/goshimmer/packages/manualpeering"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	databaseplugin "github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
-
-	"github.com/iotaledger/goshimmer/packages/shutdown"
 )
 
 // PluginName is the name of the manualpeering plugin.
@@ -42,7 +44,9 @@ func Plugin() *node.Plugin {
 func Manager() *manualpeering.Manager {
 	managerOnce.Do(func() {
 		lPeer := local.GetInstance()
-		manager = manualpeering.NewManager(gossip.Manager(), lPeer, logger.NewLogger(PluginName))
+		manager = manualpeering.NewManager(gossip.Manager(), lPeer, logger.NewLogger(PluginName),
+			manualpeering.WithStore(databaseplugin.StoreRealm([]byte{database.PrefixManualPeering})),
+		)
 	})
 	return manager
 }
@@ -59,31 +63,64 @@ func runPlugin(*node.Plugin) {
 
 func startManager(shutdownSignal <-chan struct{}) {
 	mgr := Manager()
-	mgr.Start()
+	if err := mgr.Start(); err != nil {
+		plugin.Logger().Errorw("Failed to restore some of the stored known peers", "err", err)
+	}
 	defer func() {
 		if err := mgr.Stop(); err != nil {
 			plugin.Logger().Errorw("Failed to stop the manager", "err", err)
 		}
 	}()
-	addPeersFromConfigToManager(mgr)
-	<-shutdownSignal
+	setConfigPeers(mgr, config.Node())
+
+	if Parameters.ReloadInterval <= 0 {
+		<-shutdownSignal
+		return
+	}
+
+	ticker := time.NewTicker(Parameters.ReloadInterval)
+	defer ticker.Stop()
+	lastModified := configFileModTime()
+	for {
+		select {
+		case <-ticker.C:
+			if modified := configFileModTime(); !modified.Equal(lastModified) {
+				lastModified = modified
+				reloadConfigPeers(mgr)
+			}
+		case <-shutdownSignal:
+			return
+		}
+	}
+}
+
+// reloadConfigPeers reads the known peers from the changed config file (merged with the command line flags and the
+// environment variables like at startup) and passes them to the manager.
+func reloadConfigPeers(mgr *manualpeering.Manager) {
+	reloadedConfig, err := config.Reload()
+	if err != nil {
+		plugin.Logger().Errorw("Failed to reload the config file, keeping the current known peers", "err", err)
+		return
+	}
+	plugin.Logger().Infow("Config file changed, reloading known peers")
+	setConfigPeers(mgr, reloadedConfig)
 }
 
-func addPeersFromConfigToManager(mgr *manualpeering.Manager) {
-	peers, err := getKnownPeersFromConfig()
+func setConfigPeers(mgr *manualpeering.Manager, cfg *configuration.Configuration) {
+	peers, err := getKnownPeersFromConfig(cfg)
 	if err != nil {
 		plugin.Logger().Errorw("Failed to get known peers from the config file, continuing without them...", "err", err)
-	} else if len(peers) != 0 {
-		plugin.Logger().Infow("Pass known peers list from the config file to the manager", "peers", peers)
-		if err := mgr.AddPeer(peers...); err != nil {
-			plugin.Logger().Infow("Failed to pass known peers list from the config file to the manager",
-				"peers", peers, "err", err)
-		}
+		return
+	}
+	plugin.Logger().Infow("Pass known peers list from the config file to the manager", "peers", peers)
+	if err := mgr.SetConfigPeers(peers); err != nil {
+		plugin.Logger().Infow("Failed to pass known peers list from the config file to the manager",
+			"peers", peers, "err", err)
 	}
 }
 
-func getKnownPeersFromConfig() ([]*manualpeering.KnownPeerToAdd, error) {
-	rawMap := config.Node().Get(CfgManualpeeringKnownPeers)
+func getKnownPeersFromConfig(cfg *configuration.Configuration) ([]*manualpeering.KnownPeerToAdd, error) {
+	rawMap := cfg.Get(CfgManualpeeringKnownPeers)
 	// This is a hack to transform a map from config into peer.Peer struct.
 	jsonData, err := json.Marshal(rawMap)
 	if err != nil {
@@ -95,3 +132,12 @@ func getKnownPeersFromConfig() ([]*manualpeering.KnownPeerToAdd, error) {
 	}
 	return peers, nil
 }
+
+// configFileModTime returns the modification time of the config file or the zero time if it does not exist.
+func configFileModTime() time.Time {
+	info, err := os.Stat(config.FilePath())
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,7 +29,9 @@ import (
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/tangle/payload"
 	"github.com/iotaledger/goshimmer/packages/vote"
-	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	// register the voting protocols that can be selected by config
+	_ "github.com/iotaledger/goshimmer/packages/vote/fpc"
+	_ "github.com/iotaledger/goshimmer/packages/vote/majority"
 	votenet "github.com/iotaledger/goshimmer/packages/vote/net"
 	"github.com/iotaledger/goshimmer/packages/vote/opinion"
 	"github.com/iotaledger/goshimmer/packages/vote/statement"
@@ -42,7 +45,7 @@ var (
 	// plugin is the plugin instance of the statement plugin.
 	consensusPlugin     *node.Plugin
 	consensusPluginOnce sync.Once
-	voter               *fpc.FPC
+	voter               vote.DRNGRoundBasedVoter
 	voterOnce           sync.Once
 	voterServer         *votenet.VoterServer
 	registry            *statement.Registry
@@ -69,6 +72,10 @@ func ConsensusPlugin() *node.Plugin {
 }
 
 func configureConsensusPlugin(plugin *node.Plugin) {
+	if !vote.VoterRegistered(FPCParameters.Voter) {
+		plugin.LogFatalf("unknown voter '%s' (registered: %s)", FPCParameters.Voter, strings.Join(vote.RegisteredVoters(), ", "))
+	}
+
 	configureFPC(plugin)
 
 	// subscribe to FCOB events
@@ -92,7 +99,11 @@ func runConsensusPlugin(plugin *node.Plugin) {
 // Voter returns the DRNGRoundBasedVoter instance used by the FPC plugin.
 func Voter() vote.DRNGRoundBasedVoter {
 	voterOnce.Do(func() {
-		voter = fpc.New(OpinionGiverFunc, OwnManaRetriever)
+		var err error
+		voter, err = vote.NewVoter(FPCParameters.Voter, OpinionGiverFunc, OwnManaRetriever)
+		if err != nil {
+			panic(err)
+		}
 	})
 	return voter
 }
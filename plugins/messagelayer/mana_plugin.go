@@ -3,15 +3,16 @@ package messagelayer
 import (
 	"fmt"
 	"math"
-	"os"
 	"sort"
 	"sync"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/datastructure/set"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/iotaledger/hive.go/objectstorage"
@@ -54,6 +55,14 @@ var (
 	// debuggingEnabled              bool
 )
 
+// manaVectorPrefixes contains the storage prefixes of the persisted mana vectors.
+var manaVectorPrefixes = map[mana.Type]byte{
+	mana.AccessMana:        mana.PrefixAccess,
+	mana.ConsensusMana:     mana.PrefixConsensus,
+	mana.ResearchAccess:    mana.PrefixAccessResearch,
+	mana.ResearchConsensus: mana.PrefixConsensusResearch,
+}
+
 // ManaPlugin gets the plugin instance.
 func ManaPlugin() *node.Plugin {
 	once.Do(func() {
@@ -78,11 +87,11 @@ func configureManaPlugin(*node.Plugin) {
 	storages = make(map[mana.Type]*objectstorage.ObjectStorage)
 	store := database.Store()
 	osFactory = objectstorage.NewFactory(store, db_pkg.PrefixMana)
-	storages[mana.AccessMana] = osFactory.New(mana.PrefixAccess, mana.FromObjectStorage)
-	storages[mana.ConsensusMana] = osFactory.New(mana.PrefixConsensus, mana.FromObjectStorage)
+	storages[mana.AccessMana] = osFactory.New(manaVectorPrefixes[mana.AccessMana], mana.FromObjectStorage)
+	storages[mana.ConsensusMana] = osFactory.New(manaVectorPrefixes[mana.ConsensusMana], mana.FromObjectStorage)
 	if ManaParameters.EnableResearchVectors {
-		storages[mana.ResearchAccess] = osFactory.New(mana.PrefixAccessResearch, mana.FromObjectStorage)
-		storages[mana.ResearchConsensus] = osFactory.New(mana.PrefixConsensusResearch, mana.FromObjectStorage)
+		storages[mana.ResearchAccess] = osFactory.New(manaVectorPrefixes[mana.ResearchAccess], mana.FromObjectStorage)
+		storages[mana.ResearchConsensus] = osFactory.New(manaVectorPrefixes[mana.ResearchConsensus], mana.FromObjectStorage)
 	}
 	// consensusEventsLogStorage = osFactory.New(mana.PrefixEventStorage, mana.FromEventObjectStorage)
 	// consensusEventsLogsStorageSize.Store(getConsensusEventLogsStorageSize())
@@ -205,19 +214,16 @@ func runManaPlugin(_ *node.Plugin) {
 		if !readStoredManaVectors() {
 			// read snapshot file
 			if Parameters.Snapshot.File != "" {
-				snapshot := &ledgerstate.Snapshot{}
-				f, err := os.Open(Parameters.Snapshot.File)
+				snapshot, err := ReadSnapshot()
 				if err != nil {
-					plugin.Panic("can not open snapshot file:", err)
-				}
-				if _, err := snapshot.ReadFrom(f); err != nil {
 					plugin.Panic("could not read snapshot file in Mana Plugin:", err)
 				}
-				loadSnapshot(snapshot)
+				if err = loadSnapshot(snapshot); err != nil {
+					plugin.Panic("could not load snapshot file in Mana Plugin:", err)
+				}
 				plugin.LogInfof("MANA: read snapshot from %s", Parameters.Snapshot.File)
 			}
 		}
-		pruneStorages()
 		for {
 			select {
 			case <-shutdownSignal:
@@ -225,7 +231,7 @@ func runManaPlugin(_ *node.Plugin) {
 				// mana.Events().Pledged.Detach(onPledgeEventClosure)
 				// mana.Events().Pledged.Detach(onRevokeEventClosure)
 				Tangle().LedgerState.UTXODAG.Events.TransactionConfirmed.Detach(onTransactionConfirmedClosure)
-				storeManaVectors()
+				persistManaVectors()
 				shutdownStorages()
 				return
 			// case <-ticker.C:
@@ -256,21 +262,46 @@ func readStoredManaVectors() (read bool) {
 	return
 }
 
-func storeManaVectors() {
+// persistManaVectors replaces the stored mana vectors with their current state. All vectors are written in a single
+// batch, so the database never contains a partially written state (i.e. if the node crashes while they are persisted).
+func persistManaVectors() {
+	batch := database.Store().Batched()
 	for vectorType, baseManaVector := range baseManaVectors {
-		persitables := baseManaVector.ToPersistables()
-		for _, p := range persitables {
-			storages[vectorType].Store(p).Release()
+		store := manaVectorStore(vectorType)
+
+		persistables := make(map[string]*mana.PersistableBaseMana)
+		for _, persistable := range baseManaVector.ToPersistables() {
+			persistables[string(persistable.ObjectStorageKey())] = persistable
+		}
+
+		if err := store.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+			if _, exists := persistables[string(key)]; !exists {
+				if err := batch.Delete(append(store.Realm(), key...)); err != nil {
+					manaLogger.Errorf("failed to delete stored %s mana of node %x: %s", vectorType.String(), key, err)
+				}
+			}
+			return true
+		}); err != nil {
+			manaLogger.Errorf("failed to iterate stored %s mana vector: %s", vectorType.String(), err)
+		}
+
+		for key, persistable := range persistables {
+			if err := batch.Set(append(store.Realm(), key...), persistable.ObjectStorageValue()); err != nil {
+				manaLogger.Errorf("failed to store %s mana of node %x: %s", vectorType.String(), key, err)
+			}
 		}
 	}
-}
 
-func pruneStorages() {
-	for vectorType := range baseManaVectors {
-		_ = storages[vectorType].Prune()
+	if err := batch.Commit(); err != nil {
+		manaLogger.Errorf("failed to persist mana vectors: %s", err)
 	}
 }
 
+// manaVectorStore returns the store that contains the persisted mana vector of the given type.
+func manaVectorStore(vectorType mana.Type) kvstore.KVStore {
+	return database.Store().WithRealm(kvstore.Realm{db_pkg.PrefixMana, manaVectorPrefixes[vectorType]})
+}
+
 func shutdownStorages() {
 	for vectorType := range baseManaVectors {
 		storages[vectorType].Shutdown()
@@ -791,7 +822,9 @@ func QueryAllowed() (allowed bool) {
 }
 
 // loadSnapshot loads the tx snapshot and the access mana snapshot, sorts it and loads it into the various mana versions
-func loadSnapshot(snapshot *ledgerstate.Snapshot) {
+// loadSnapshot loads the mana vectors from the given snapshot. It returns an error if the consensus mana of the snapshot
+// does not match the pledges of its transactions.
+func loadSnapshot(snapshot *ledgerstate.Snapshot) (err error) {
 	txSnapshotByNode := make(map[identity.ID]mana.SortedTxSnapshot)
 
 	// load txSnapshot into SnapshotInfoVec
@@ -824,6 +857,17 @@ func loadSnapshot(snapshot *ledgerstate.Snapshot) {
 		SnapshotByNode[nodeID] = snapshotNode
 	}
 
+	// the consensus mana is derived from the pledges of the snapshotted transactions, so it has to match the snapshot
+	for nodeID, consensusMana := range snapshot.ConsensusManaByNode {
+		var derivedConsensusMana float64
+		for _, txSnapshot := range SnapshotByNode[nodeID].SortedTxSnapshot {
+			derivedConsensusMana += txSnapshot.Value
+		}
+		if derivedConsensusMana != consensusMana {
+			return errors.Errorf("consensus mana of %s in snapshot (%f) does not match its transactions (%f)", nodeID, consensusMana, derivedConsensusMana)
+		}
+	}
+
 	// determine addTime if snapshot should be updated for the difference to now
 	var addTime time.Duration
 	// for certain applications (e.g. docker-network) update all timestamps, to have large enough aMana
@@ -853,4 +897,6 @@ func loadSnapshot(snapshot *ledgerstate.Snapshot) {
 
 	baseManaVectors[mana.ConsensusMana].LoadSnapshot(SnapshotByNode)
 	baseManaVectors[mana.AccessMana].LoadSnapshot(SnapshotByNode)
+
+	return nil
 }
@@ -15,6 +15,7 @@ var Parameters = struct {
 	Snapshot struct {
 		// File is the path to the snapshot file.
 		File        string `default:"./snapshot.bin" usage:"the path to the snapshot file"`
+		DeltaFile   string `usage:"the path to a delta snapshot file that is applied on top of the snapshot file"`
 		GenesisNode string `default:"Gm7W191NDnqyF7KJycZqK7V6ENLwqxTwoKQN4SmpkB24" usage:"the node (base58 public key) that is allowed to attach to the genesis message"`
 	}
 
@@ -28,6 +29,12 @@ var Parameters = struct {
 
 	// StartSynced defines if the node should start as synced.
 	StartSynced bool `default:"false" usage:"start as synced"`
+
+	// AddressHistory defines if the ledger state keeps an index of the outputs that were created and consumed on each address.
+	AddressHistory bool `default:"false" usage:"if the ledger state keeps the history of the addresses"`
+
+	// Bech32HRP defines the human-readable part of bech32 encoded addresses, which identifies the network.
+	Bech32HRP string `default:"atoi" usage:"the human-readable part of bech32 encoded addresses"`
 }{}
 
 // FPCParameters contains the configuration parameters used by the FPC consensus.
@@ -38,6 +45,9 @@ var FPCParameters = struct {
 	// Listen defines if the FPC service should listen.
 	Listen bool `default:"true" usage:"if the FPC service should listen"`
 
+	// Voter defines the voting protocol that is used to resolve conflicts.
+	Voter string `default:"fpc" usage:"the voting protocol used to resolve conflicts (fpc, majority or snowball)"`
+
 	// RoundInterval defines how long a round lasts (in seconds).
 	RoundInterval int64 `default:"10" usage:"FPC round interval [s]"`
 
@@ -109,10 +119,34 @@ var ManaParameters = struct {
 
 // RateSetterParameters contains the configuration parameters used by the Rate Setter.
 var RateSetterParameters = struct {
+	// Enabled defines whether messages issued by the node pass through the rate setter before being scheduled.
+	Enabled bool `default:"true" usage:"if messages issued by the node are rate limited by the rate setter"`
 	// Initial defines the initial rate of rate setting.
 	Initial float64 `default:"100000" usage:"the initial rate of rate setting"`
 }{}
 
+// PruningParameters contains the configuration parameters used by the Pruner.
+var PruningParameters = struct {
+	// Enabled defines whether old messages are pruned from the database.
+	Enabled bool `default:"false" usage:"if old messages should be pruned from the database"`
+	// Horizon defines how far behind the TangleTime a message has to be to get pruned.
+	Horizon time.Duration `default:"24h" usage:"how far behind the TangleTime a message has to be to get pruned"`
+	// Interval defines the interval in which the pruning is executed.
+	Interval time.Duration `default:"10m" usage:"the interval in which old messages are pruned"`
+	// BatchSize defines the number of messages that are pruned at once.
+	BatchSize int `default:"1000" usage:"the number of messages that are pruned at once"`
+}{}
+
+// TipSelectionParameters contains the configuration parameters used by the tip selection.
+var TipSelectionParameters = struct {
+	// Strategy defines the name of the strategy that is used to select strong tips.
+	Strategy string `default:"uniform" usage:"the strategy used to select strong tips (uniform, ageRestricted, manaWeighted, likedBranch, lazyTipAvoidance)"`
+	// MaxTipAge defines the maximum age of the tips that are selected by the ageRestricted strategy.
+	MaxTipAge time.Duration `default:"1m" usage:"the maximum age of the tips selected by the ageRestricted strategy"`
+	// MaxDepthGap defines how far the past cone depth of a tip can be behind the deepest tip for the lazyTipAvoidance strategy.
+	MaxDepthGap uint64 `default:"10" usage:"the maximum past cone depth gap to the deepest tip before a tip is avoided by the lazyTipAvoidance strategy"`
+}{}
+
 // SchedulerParameters contains the configuration parameters used by the Scheduler.
 var SchedulerParameters = struct {
 	// MaxBufferSize defines the maximum buffer size (in bytes).
@@ -128,4 +162,6 @@ func init() {
 	configuration.BindParameters(&ManaParameters, "mana")
 	configuration.BindParameters(&RateSetterParameters, "rateSetter")
 	configuration.BindParameters(&SchedulerParameters, "scheduler")
+	configuration.BindParameters(&PruningParameters, "pruning")
+	configuration.BindParameters(&TipSelectionParameters, "tipSelection")
 }
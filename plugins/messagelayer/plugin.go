@@ -1,7 +1,6 @@
 package messagelayer
 
 import (
-	"os"
 	"sync"
 	"time"
 
@@ -46,6 +45,8 @@ func Plugin() *node.Plugin {
 }
 
 func configure(plugin *node.Plugin) {
+	ledgerstate.SetBech32HRP(Parameters.Bech32HRP)
+
 	Tangle().Events.Error.Attach(events.NewClosure(func(err error) {
 		plugin.LogError(err)
 	}))
@@ -81,6 +82,14 @@ func configure(plugin *node.Plugin) {
 		plugin.LogInfof("node %s is blacklisted in Scheduler", nodeID.String())
 	}))
 
+	Tangle().RateSetter.Events.MessageDiscarded.Attach(events.NewClosure(func(messageID tangle.MessageID) {
+		plugin.LogInfof("message discarded in RateSetter: %s", messageID.Base58())
+	}))
+
+	Tangle().Pruner.Events.PruningCompleted.Attach(events.NewClosure(func(ev *tangle.PruningCompletedEvent) {
+		plugin.LogInfof("pruned %d messages older than %v in %v", ev.PrunedMessages, ev.Threshold, ev.Duration)
+	}))
+
 	Tangle().TimeManager.Events.SyncChanged.Attach(events.NewClosure(func(ev *tangle.SyncChangedEvent) {
 		plugin.LogInfo("Sync changed: ", ev.Synced)
 		if ev.Synced {
@@ -97,18 +106,31 @@ func configure(plugin *node.Plugin) {
 		}
 	}))
 
+	// pause the processing and persist the cached objects, so the backup contains a consistent state of the Tangle,
+	// the consensus and the mana vectors
+	database.Events.BackupStarted.Attach(events.NewClosure(func() {
+		Tangle().Pause()
+		Tangle().Flush()
+		if !node.IsSkipped(ManaPlugin()) {
+			persistManaVectors()
+		}
+	}))
+	database.Events.BackupFinished.Attach(events.NewClosure(func() {
+		Tangle().Resume()
+	}))
+
 	// read snapshot file
 	if Parameters.Snapshot.File != "" {
-		snapshot := &ledgerstate.Snapshot{}
-		f, err := os.Open(Parameters.Snapshot.File)
+		snapshot, err := ReadSnapshot()
 		if err != nil {
-			plugin.Panic("can not open snapshot file:", err)
-		}
-		if _, err := snapshot.ReadFrom(f); err != nil {
 			plugin.Panic("could not read snapshot file in message layer plugin:", err)
 		}
 		Tangle().LedgerState.LoadSnapshot(snapshot)
-		plugin.LogInfof("read snapshot from %s", Parameters.Snapshot.File)
+		if snapshot.Header != nil {
+			plugin.LogInfof("read snapshot from %s: %s", Parameters.Snapshot.File, snapshot.Header)
+		} else {
+			plugin.LogInfof("read snapshot from %s", Parameters.Snapshot.File)
+		}
 	}
 
 	fcob.LikedThreshold = time.Duration(Parameters.FCOB.QuarantineTime) * time.Second
@@ -151,10 +173,23 @@ func Tangle() *tangle.Tangle {
 				TotalAccessManaRetrieveFunc: totalAccessManaRetriever,
 			}),
 			tangle.RateSetterConfig(tangle.RateSetterParams{
+				Enabled: RateSetterParameters.Enabled,
 				Initial: &RateSetterParameters.Initial,
 			}),
+			tangle.PruningConfig(tangle.PruningParams{
+				Enabled:   PruningParameters.Enabled,
+				Horizon:   PruningParameters.Horizon,
+				Interval:  PruningParameters.Interval,
+				BatchSize: PruningParameters.BatchSize,
+			}),
+			tangle.TipSelectionConfig(tangle.TipSelectionParams{
+				Strategy:    TipSelectionParameters.Strategy,
+				MaxTipAge:   TipSelectionParameters.MaxTipAge,
+				MaxDepthGap: TipSelectionParameters.MaxDepthGap,
+			}),
 			tangle.SyncTimeWindow(Parameters.TangleTimeWindow),
 			tangle.StartSynced(Parameters.StartSynced),
+			tangle.AddressHistory(Parameters.AddressHistory),
 			tangle.CacheTimeProvider(database.CacheTimeProvider()),
 		)
 
@@ -0,0 +1,53 @@
+package messagelayer
+
+import (
+	"os"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
+)
+
+// ErrSnapshotNetworkMismatch is returned if the configured snapshot was created for a different network.
+var ErrSnapshotNetworkMismatch = errors.New("snapshot was created for a different network")
+
+// ReadSnapshot reads the configured snapshot file and applies the configured delta snapshot (if any) on top of it.
+func ReadSnapshot() (snapshot *ledgerstate.Snapshot, err error) {
+	if snapshot, err = readSnapshotFile(Parameters.Snapshot.File); err != nil {
+		return nil, err
+	}
+
+	if Parameters.Snapshot.DeltaFile != "" {
+		delta, deltaErr := readSnapshotFile(Parameters.Snapshot.DeltaFile)
+		if deltaErr != nil {
+			return nil, deltaErr
+		}
+		if err = snapshot.ApplyDelta(delta); err != nil {
+			return nil, errors.Errorf("failed to apply delta snapshot %s: %w", Parameters.Snapshot.DeltaFile, err)
+		}
+	}
+
+	return snapshot, nil
+}
+
+// readSnapshotFile reads the snapshot from the given file and checks that it belongs to the network of the node.
+func readSnapshotFile(fileName string) (snapshot *ledgerstate.Snapshot, err error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, errors.Errorf("failed to open snapshot file %s: %w", fileName, err)
+	}
+	defer f.Close()
+
+	snapshot = &ledgerstate.Snapshot{}
+	if _, err = snapshot.ReadFrom(f); err != nil {
+		return nil, errors.Errorf("failed to read snapshot file %s: %w", fileName, err)
+	}
+
+	// snapshots in the legacy format or without a network id can be loaded by every network
+	if snapshot.Header != nil && snapshot.Header.NetworkID != 0 && snapshot.Header.NetworkID != uint32(discovery.Parameters.NetworkVersion) {
+		return nil, errors.Errorf("failed to load snapshot file %s of network %d: %w", fileName, snapshot.Header.NetworkID, ErrSnapshotNetworkMismatch)
+	}
+
+	return snapshot, nil
+}
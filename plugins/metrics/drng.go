@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"sync"
+
+	drngpkg "github.com/iotaledger/goshimmer/packages/drng"
+)
+
+var (
+	// drngLastRounds holds the round of the last verified beacon of every dRNG instance.
+	drngLastRounds = make(map[uint32]uint64)
+	// drngMissedRounds holds the number of rounds of every dRNG instance that were skipped by the verified beacons.
+	drngMissedRounds = make(map[uint32]uint64)
+	drngMutex        sync.RWMutex
+)
+
+// DRNGLastRounds returns the round of the last verified beacon of every dRNG instance.
+func DRNGLastRounds() map[uint32]uint64 {
+	drngMutex.RLock()
+	defer drngMutex.RUnlock()
+
+	result := make(map[uint32]uint64, len(drngLastRounds))
+	for instanceID, round := range drngLastRounds {
+		result[instanceID] = round
+	}
+	return result
+}
+
+// DRNGMissedRounds returns the number of rounds of every dRNG instance for which no beacon was received since the start
+// of the node.
+func DRNGMissedRounds() map[uint32]uint64 {
+	drngMutex.RLock()
+	defer drngMutex.RUnlock()
+
+	result := make(map[uint32]uint64, len(drngMissedRounds))
+	for instanceID, missed := range drngMissedRounds {
+		result[instanceID] = missed
+	}
+	return result
+}
+
+func processVerifiedBeacon(cb *drngpkg.CollectiveBeaconEvent) {
+	drngMutex.Lock()
+	defer drngMutex.Unlock()
+
+	// the first beacon after the start of the node does not count any missed rounds
+	if lastRound, ok := drngLastRounds[cb.InstanceID]; ok && cb.Round > lastRound+1 {
+		drngMissedRounds[cb.InstanceID] += cb.Round - lastRound - 1
+	}
+	if _, ok := drngMissedRounds[cb.InstanceID]; !ok {
+		drngMissedRounds[cb.InstanceID] = 0
+	}
+	drngLastRounds[cb.InstanceID] = cb.Round
+}
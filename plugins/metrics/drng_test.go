@@ -0,0 +1,21 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	drngpkg "github.com/iotaledger/goshimmer/packages/drng"
+)
+
+func TestDRNGMissedRounds(t *testing.T) {
+	// the first beacon does not count any missed rounds
+	processVerifiedBeacon(&drngpkg.CollectiveBeaconEvent{InstanceID: 1, Round: 10})
+	assert.Equal(t, map[uint32]uint64{1: 0}, DRNGMissedRounds())
+
+	processVerifiedBeacon(&drngpkg.CollectiveBeaconEvent{InstanceID: 1, Round: 11})
+	processVerifiedBeacon(&drngpkg.CollectiveBeaconEvent{InstanceID: 1, Round: 14})
+	processVerifiedBeacon(&drngpkg.CollectiveBeaconEvent{InstanceID: 2, Round: 3})
+	assert.Equal(t, map[uint32]uint64{1: 2, 2: 0}, DRNGMissedRounds())
+	assert.Equal(t, map[uint32]uint64{1: 14, 2: 3}, DRNGLastRounds())
+}
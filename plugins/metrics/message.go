@@ -95,6 +95,18 @@ var (
 
 	// number of messages being requested by the message layer.
 	requestQueueSize atomic.Int64
+
+	// number of messages removed by the pruner since start of the node.
+	messagePrunedCount atomic.Uint64
+
+	// threshold (unix time in seconds) of the most recent pruning.
+	lastPruningThreshold atomic.Int64
+
+	// number of tips per tip selection strategy that expired without being referenced since start of the node.
+	orphanedTipsCountPerStrategy = make(map[string]uint64)
+
+	// protect map from concurrent read/write.
+	orphanedTipsCountPerStrategyMutex syncutils.RWMutex
 )
 
 ////// Exported functions to obtain metrics from outside //////
@@ -187,8 +199,45 @@ func ReceivedMessagesPerSecond() uint64 {
 	return measuredReceivedMPS.Load()
 }
 
+// MessagePrunedCount returns the number of messages that were pruned since the start of the node.
+func MessagePrunedCount() uint64 {
+	return messagePrunedCount.Load()
+}
+
+// LastPruningThreshold returns the threshold (unix time in seconds) of the most recently completed pruning.
+func LastPruningThreshold() int64 {
+	return lastPruningThreshold.Load()
+}
+
+// OrphanedTipsCountPerStrategy returns a map of tip selection strategies and the number of tips that expired without
+// being referenced while the strategy was used since the start of the node.
+func OrphanedTipsCountPerStrategy() map[string]uint64 {
+	orphanedTipsCountPerStrategyMutex.RLock()
+	defer orphanedTipsCountPerStrategyMutex.RUnlock()
+
+	// copy the original map
+	clone := make(map[string]uint64)
+	for key, element := range orphanedTipsCountPerStrategy {
+		clone[key] = element
+	}
+
+	return clone
+}
+
+// TipSelectionStrategy returns the name of the strategy that is used to select strong tips.
+func TipSelectionStrategy() string {
+	return messagelayer.Tangle().TipManager.TipSelectionStrategy().Name()
+}
+
 ////// Handling data updates and measuring //////
 
+func increaseOrphanedTipsCounter(strategy string) {
+	orphanedTipsCountPerStrategyMutex.Lock()
+	defer orphanedTipsCountPerStrategyMutex.Unlock()
+
+	orphanedTipsCountPerStrategy[strategy]++
+}
+
 func increasePerPayloadCounter(p payload.Type) {
 	messageCountPerPayloadMutex.Lock()
 	defer messageCountPerPayloadMutex.Unlock()
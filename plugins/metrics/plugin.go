@@ -19,6 +19,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/analysis/server"
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/drng"
 	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 )
@@ -135,6 +136,18 @@ func registerLocalMetrics() {
 		messageTotalCountDB.Dec()
 	}))
 
+	messagelayer.Tangle().Pruner.Events.MessagePruned.Attach(events.NewClosure(func(tangle.MessageID) {
+		messagePrunedCount.Inc()
+	}))
+
+	messagelayer.Tangle().Pruner.Events.PruningCompleted.Attach(events.NewClosure(func(ev *tangle.PruningCompletedEvent) {
+		lastPruningThreshold.Store(ev.Threshold.Unix())
+	}))
+
+	messagelayer.Tangle().TipManager.Events.TipExpired.Attach(events.NewClosure(func(*tangle.TipEvent) {
+		increaseOrphanedTipsCounter(TipSelectionStrategy())
+	}))
+
 	// messages can only become solid once, then they stay like that, hence no .Dec() part
 	messagelayer.Tangle().Solidifier.Events.MessageSolid.Attach(events.NewClosure(func(messageID tangle.MessageID) {
 		increasePerComponentCounter(Solidifier)
@@ -193,6 +206,9 @@ func registerLocalMetrics() {
 		processFailed(ev.Ctx)
 	}))
 
+	// a new beacon of a dRNG instance has been verified
+	drng.Instance().Events.VerifiedCollectiveBeacon.Attach(events.NewClosure(processVerifiedBeacon))
+
 	//// Events coming from metrics package ////
 
 	metrics.Events().FPCInboundBytes.Attach(events.NewClosure(func(amountBytes uint64) {
@@ -0,0 +1,48 @@
+package prometheus
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/goshimmer/plugins/metrics"
+)
+
+var (
+	drngLastRound    *prometheus.GaugeVec
+	drngMissedRounds *prometheus.GaugeVec
+)
+
+func registerDRNGMetrics() {
+	drngLastRound = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "drng_last_round",
+			Help: "round of the last verified beacon of the dRNG instance",
+		},
+		[]string{
+			"instanceID",
+		})
+
+	drngMissedRounds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "drng_missed_rounds",
+			Help: "number of rounds of the dRNG instance without a beacon since the start of the node",
+		},
+		[]string{
+			"instanceID",
+		})
+
+	registry.MustRegister(drngLastRound)
+	registry.MustRegister(drngMissedRounds)
+
+	addCollect(collectDRNGMetrics)
+}
+
+func collectDRNGMetrics() {
+	for instanceID, round := range metrics.DRNGLastRounds() {
+		drngLastRound.WithLabelValues(strconv.FormatUint(uint64(instanceID), 10)).Set(float64(round))
+	}
+	for instanceID, missed := range metrics.DRNGMissedRounds() {
+		drngMissedRounds.WithLabelValues(strconv.FormatUint(uint64(instanceID), 10)).Set(float64(missed))
+	}
+}
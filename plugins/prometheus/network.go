@@ -5,6 +5,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
+	"github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
 )
 
@@ -14,6 +15,8 @@ var (
 	analysisOutboundBytes    prometheus.Gauge
 	gossipInboundBytes       prometheus.Gauge
 	gossipOutboundBytes      prometheus.Gauge
+	gossipNeighborBytes      *prometheus.GaugeVec
+	gossipNeighborSavedBytes *prometheus.GaugeVec
 	autopeeringInboundBytes  prometheus.Gauge
 	autopeeringOutboundBytes prometheus.Gauge
 )
@@ -43,6 +46,14 @@ func registerNetworkMetrics() {
 		Name: "traffic_gossip_outbound_bytes",
 		Help: "traffic_gossip TX network traffic [bytes].",
 	})
+	gossipNeighborBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "traffic_gossip_neighbor_outbound_bytes",
+		Help: "traffic_gossip TX network traffic per neighbor [bytes].",
+	}, []string{"neighbor_id", "compression"})
+	gossipNeighborSavedBytes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "traffic_gossip_neighbor_saved_bytes",
+		Help: "traffic_gossip TX network traffic per neighbor saved by batching and compression [bytes].",
+	}, []string{"neighbor_id", "compression"})
 	analysisOutboundBytes = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "traffic_analysis_outbound_bytes",
 		Help: "traffic_Analysis client TX network traffic [bytes].",
@@ -57,6 +68,8 @@ func registerNetworkMetrics() {
 	registry.MustRegister(analysisOutboundBytes)
 	registry.MustRegister(gossipInboundBytes)
 	registry.MustRegister(gossipOutboundBytes)
+	registry.MustRegister(gossipNeighborBytes)
+	registry.MustRegister(gossipNeighborSavedBytes)
 
 	addCollect(collectNetworkMetrics)
 }
@@ -71,4 +84,12 @@ func collectNetworkMetrics() {
 	analysisOutboundBytes.Set(float64(metrics.AnalysisOutboundBytes()))
 	gossipInboundBytes.Set(float64(metrics.GossipInboundBytes()))
 	gossipOutboundBytes.Set(float64(metrics.GossipOutboundBytes()))
+
+	// only report the currently connected neighbors
+	gossipNeighborBytes.Reset()
+	gossipNeighborSavedBytes.Reset()
+	for _, neighbor := range gossip.Manager().AllNeighbors() {
+		gossipNeighborBytes.WithLabelValues(neighbor.ID().String(), neighbor.Compression().String()).Set(float64(neighbor.BytesWritten()))
+		gossipNeighborSavedBytes.WithLabelValues(neighbor.ID().String(), neighbor.Compression().String()).Set(float64(neighbor.SavedBytes()))
+	}
 }
@@ -18,6 +18,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/metrics"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
 )
 
 // PluginName is the name of the prometheus plugin.
@@ -62,11 +63,15 @@ func configure(plugin *node.Plugin) {
 		}
 		registerDBMetrics()
 		registerFPCMetrics()
+		registerDRNGMetrics()
 		registerInfoMetrics()
 		registerNetworkMetrics()
 		registerProcessMetrics()
 		registerTangleMetrics()
 		registerManaMetrics()
+		if !node.IsSkipped(webapi.Plugin()) {
+			registerWebAPIMetrics()
+		}
 	}
 
 	if config.Node().Bool(metrics.CfgMetricsGlobal) {
@@ -16,6 +16,9 @@ var (
 	avgSolidificationTime    prometheus.Gauge
 	messageMissingCountDB    prometheus.Gauge
 	messageRequestCount      prometheus.Gauge
+	messagePrunedCount       prometheus.Gauge
+	lastPruningThreshold     prometheus.Gauge
+	orphanedTipsCount        *prometheus.GaugeVec
 
 	transactionCounter prometheus.Gauge
 )
@@ -77,6 +80,24 @@ func registerTangleMetrics() {
 		Help: "current number requested messages by the message tangle",
 	})
 
+	messagePrunedCount = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_message_pruned_count",
+		Help: "number of messages pruned from the node's database since the start of the node",
+	})
+
+	lastPruningThreshold = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "tangle_last_pruning_threshold",
+		Help: "unix time of the threshold used by the most recently completed pruning",
+	})
+
+	orphanedTipsCount = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "tangle_orphaned_tips_count",
+			Help: "number of tips that expired without being referenced since the start of the node per tip selection strategy",
+		}, []string{
+			"strategy",
+		})
+
 	registry.MustRegister(messageTips)
 	registry.MustRegister(messagePerTypeCount)
 	registry.MustRegister(messagePerComponentCount)
@@ -87,6 +108,9 @@ func registerTangleMetrics() {
 	registry.MustRegister(messageMissingCountDB)
 	registry.MustRegister(messageRequestCount)
 	registry.MustRegister(transactionCounter)
+	registry.MustRegister(messagePrunedCount)
+	registry.MustRegister(lastPruningThreshold)
+	registry.MustRegister(orphanedTipsCount)
 
 	addCollect(collectTangleMetrics)
 }
@@ -107,5 +131,10 @@ func collectTangleMetrics() {
 	avgSolidificationTime.Set(metrics.AvgSolidificationTime())
 	messageMissingCountDB.Set(float64(metrics.MessageMissingCountDB()))
 	messageRequestCount.Set(float64(metrics.MessageRequestQueueSize()))
+	messagePrunedCount.Set(float64(metrics.MessagePrunedCount()))
+	lastPruningThreshold.Set(float64(metrics.LastPruningThreshold()))
+	for strategy, count := range metrics.OrphanedTipsCountPerStrategy() {
+		orphanedTipsCount.WithLabelValues(strategy).Set(float64(count))
+	}
 	// transactionCounter.Set(float64(metrics.ValueTransactionCounter()))
 }
@@ -0,0 +1,47 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+var (
+	webAPIRejectedRequests    *prometheus.GaugeVec
+	webAPIRateLimitedRequests *prometheus.GaugeVec
+)
+
+func registerWebAPIMetrics() {
+	webAPIRejectedRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "webapi_rejected_requests",
+			Help: "Number of web API requests that were rejected by the token auth.",
+		},
+		[]string{
+			"reason",
+		},
+	)
+
+	webAPIRateLimitedRequests = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "webapi_rate_limited_requests",
+			Help: "Number of web API requests that were rejected by the rate limit.",
+		},
+		[]string{
+			"class",
+		},
+	)
+
+	registry.MustRegister(webAPIRejectedRequests)
+	registry.MustRegister(webAPIRateLimitedRequests)
+
+	addCollect(collectWebAPIMetrics)
+}
+
+func collectWebAPIMetrics() {
+	webAPIRejectedRequests.WithLabelValues("unauthenticated").Set(float64(webapi.UnauthenticatedRequests()))
+	webAPIRejectedRequests.WithLabelValues("forbidden").Set(float64(webapi.ForbiddenRequests()))
+	for _, class := range []webapi.RateLimitClass{webapi.ReadRateLimitClass, webapi.DiagnosticsRateLimitClass, webapi.IssuanceRateLimitClass} {
+		webAPIRateLimitedRequests.WithLabelValues(class.String()).Set(float64(webapi.RateLimitedRequests(class)))
+	}
+}
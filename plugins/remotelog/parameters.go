@@ -0,0 +1,55 @@
+package remotelog
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// CfgLoggerRemotelogServerAddress defines the config flag of the server address.
+	CfgLoggerRemotelogServerAddress = "logger.remotelog.serverAddress"
+	// CfgDisableEvents defines the config flag for disabling logger events.
+	CfgDisableEvents = "logger.disableEvents"
+)
+
+// Parameters contains the configuration parameters of the remote logger.
+var Parameters = struct {
+	// Sinks defines the URLs of the sinks that the records are sent to.
+	Sinks []string `usage:"URLs of the sinks that the records are sent to (udp://host:port, tcp://host:port or file:path), the server address is used as UDP sink if empty"`
+	// Level defines the minimum level of the log messages that are sent.
+	Level string `default:"debug" usage:"minimum level of the log messages that are sent"`
+	// Loggers defines the names of the loggers whose messages are sent.
+	Loggers []string `usage:"names of the loggers whose messages are sent, all loggers if empty"`
+	// ExcludedLoggers defines the names of the loggers whose messages are not sent.
+	ExcludedLoggers []string `usage:"names of the loggers whose messages are not sent"`
+	// Types defines the types of the records that are sent.
+	Types []string `usage:"types of the records that are sent (e.g. log, sync or drng), all types if empty"`
+	// ReconnectInterval defines how long a TCP sink waits before it reconnects.
+	ReconnectInterval time.Duration `default:"5s" usage:"time a TCP sink waits before it reconnects after the connection was lost"`
+}{}
+
+// FileParameters contains the configuration parameters of the file sinks.
+var FileParameters = struct {
+	// MaxSize defines the size at which the file of a file sink is rotated.
+	MaxSize int `default:"100" usage:"size at which the file of a file sink is rotated [MB]"`
+	// MaxFiles defines how many rotated files of a file sink are kept.
+	MaxFiles int `default:"5" usage:"amount of rotated files of a file sink that are kept"`
+}{}
+
+// BufferParameters contains the configuration parameters of the buffer of the UDP and TCP sinks.
+var BufferParameters = struct {
+	// Directory defines the directory of the buffers.
+	Directory string `usage:"directory in which the records are buffered while a UDP or TCP sink is unavailable, the records are not buffered if empty"`
+	// MaxSize defines the maximum size of the buffer of a sink.
+	MaxSize int `default:"100" usage:"maximum size of the buffer of a sink [MB]"`
+}{}
+
+func init() {
+	flag.String(CfgLoggerRemotelogServerAddress, "ressims.iota.cafe:5213", "RemoteLog server address")
+
+	configuration.BindParameters(&Parameters, "logger.remotelog")
+	configuration.BindParameters(&FileParameters, "logger.remotelog.file")
+	configuration.BindParameters(&BufferParameters, "logger.remotelog.buffer")
+}
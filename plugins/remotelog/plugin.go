@@ -1,25 +1,27 @@
-// Package remotelog is a plugin that enables log messages being sent via UDP to a central ELK stack for debugging.
+// Package remotelog is a plugin that enables log messages being sent to a central ELK stack for debugging.
 // It is disabled by default and when enabled, additionally, logger.disableEvents=false in config.json needs to be set.
-// The destination can be set via logger.remotelog.serverAddress.
-// All events according to logger.level in config.json are sent.
+// The destinations can be set via logger.remotelog.sinks (UDP, TCP or local files), if no sink is set, the messages are
+// sent via UDP to logger.remotelog.serverAddress. While a UDP or TCP sink is unavailable, the messages are buffered on
+// disk. All events according to logger.level in config.json are sent, unless they are filtered by logger.remotelog.level,
+// logger.remotelog.loggers, logger.remotelog.excludedLoggers or logger.remotelog.types.
 package remotelog
 
 import (
 	"os"
 	"path/filepath"
-	"runtime"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
-	"github.com/iotaledger/hive.go/workerpool"
-	flag "github.com/spf13/pflag"
 	"gopkg.in/src-d/go-git.v4"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/remotelog"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/banner"
@@ -27,18 +29,10 @@ import (
 )
 
 const (
-	// CfgLoggerRemotelogServerAddress defines the config flag of the server address.
-	CfgLoggerRemotelogServerAddress = "logger.remotelog.serverAddress"
-	// CfgDisableEvents defines the config flag for disabling logger events.
-	CfgDisableEvents = "logger.disableEvents"
 	// PluginName is the name of the remote log plugin.
 	PluginName = "RemoteLog"
 
 	remoteLogType = "log"
-
-	levelIndex   = 0
-	nameIndex    = 1
-	messageIndex = 2
 )
 
 var (
@@ -48,7 +42,6 @@ var (
 	myID        string
 	myGitHead   string
 	myGitBranch string
-	workerPool  *workerpool.NonBlockingQueuedWorkerPool
 
 	remoteLogger     *RemoteLoggerConn
 	remoteLoggerOnce sync.Once
@@ -62,10 +55,6 @@ func Plugin() *node.Plugin {
 	return plugin
 }
 
-func init() {
-	flag.String(CfgLoggerRemotelogServerAddress, "ressims.iota.cafe:5213", "RemoteLog server address")
-}
-
 func configure(plugin *node.Plugin) {
 	if config.Node().Bool(CfgDisableEvents) {
 		plugin.LogFatalf("%s in config.json needs to be false so that events can be captured!", CfgDisableEvents)
@@ -80,17 +69,14 @@ func configure(plugin *node.Plugin) {
 	}
 
 	getGitInfo()
-
-	workerPool = workerpool.NewNonBlockingQueuedWorkerPool(func(task workerpool.Task) {
-		SendLogMsg(task.Param(levelIndex).(logger.Level), task.Param(nameIndex).(string), task.Param(messageIndex).(string))
-
-		task.Return(nil)
-	}, workerpool.WorkerCount(runtime.GOMAXPROCS(0)), workerpool.QueueSize(1000))
 }
 
 func run(plugin *node.Plugin) {
 	logEvent := events.NewClosure(func(level logger.Level, name string, msg string) {
-		workerPool.TrySubmit(level, name, msg)
+		if !RemoteLogger().filter.AllowsLog(level, name) {
+			return
+		}
+		SendAsync(newLogMessage(level, name, msg), nil)
 	})
 
 	if err := daemon.BackgroundWorker(PluginName, func(shutdownSignal <-chan struct{}) {
@@ -98,7 +84,9 @@ func run(plugin *node.Plugin) {
 		<-shutdownSignal
 		plugin.LogInfof("Stopping %s ...", PluginName)
 		logger.Events.AnyMsg.Detach(logEvent)
-		workerPool.Stop()
+		if err := RemoteLogger().Close(); err != nil {
+			plugin.LogErrorf("Failed to close the sinks: %s", err)
+		}
 		plugin.LogInfof("Stopping %s ... done", PluginName)
 	}, shutdown.PriorityRemoteLog); err != nil {
 		plugin.Panicf("Failed to start as daemon: %s", err)
@@ -107,7 +95,18 @@ func run(plugin *node.Plugin) {
 
 // SendLogMsg sends log message to the remote logger.
 func SendLogMsg(level logger.Level, name, msg string) {
-	m := logMessage{
+	_ = RemoteLogger().Send(newLogMessage(level, name, msg))
+}
+
+// SendAsync queues the record for the sinks of the remote logger, so that the caller is not blocked by slow sinks. Every
+// sink receives the records in the order they were queued. The record is dropped for the sinks whose queue is full. If
+// sending fails, the error is passed to onError (if set).
+func SendAsync(record interface{}, onError func(err error)) {
+	RemoteLogger().SendAsync(record, onError)
+}
+
+func newLogMessage(level logger.Level, name, msg string) logMessage {
+	return logMessage{
 		banner.AppVersion,
 		myGitHead,
 		myGitBranch,
@@ -118,8 +117,6 @@ func SendLogMsg(level logger.Level, name, msg string) {
 		clock.SyncedTime(),
 		remoteLogType,
 	}
-
-	_ = RemoteLogger().Send(m)
 }
 
 func getGitInfo() {
@@ -160,21 +157,73 @@ func getGitDir() string {
 	return gitDir
 }
 
-// RemoteLogger represents a connection to our remote log server.
+// RemoteLogger represents the connection to the sinks of the remote logger.
 func RemoteLogger() *RemoteLoggerConn {
 	remoteLoggerOnce.Do(func() {
-		r, err := newRemoteLoggerConn(config.Node().String(CfgLoggerRemotelogServerAddress))
+		var level logger.Level
+		if err := level.UnmarshalText([]byte(Parameters.Level)); err != nil {
+			plugin.LogFatalf("Invalid logger.remotelog.level: %s", err)
+			return
+		}
+		filter := remotelog.NewFilter(level, Parameters.Loggers, Parameters.ExcludedLoggers, Parameters.Types)
+
+		sinks, err := createSinks()
 		if err != nil {
 			plugin.LogFatal(err)
 			return
 		}
 
-		remoteLogger = r
+		remoteLogger = newRemoteLoggerConn(sinks, filter)
 	})
 
 	return remoteLogger
 }
 
+// createSinks creates the configured sinks. The UDP and TCP sinks are buffered, if a buffer directory is configured.
+func createSinks() (sinks []remotelog.Sink, err error) {
+	sinkURLs := Parameters.Sinks
+	if len(sinkURLs) == 0 {
+		sinkURLs = []string{"udp://" + config.Node().String(CfgLoggerRemotelogServerAddress)}
+	}
+
+	for _, sinkURL := range sinkURLs {
+		sink, sinkErr := remotelog.NewSink(sinkURL,
+			remotelog.WithReconnectInterval(Parameters.ReconnectInterval),
+			remotelog.WithFileRotation(int64(FileParameters.MaxSize)*1024*1024, FileParameters.MaxFiles),
+		)
+		if sinkErr != nil {
+			err = sinkErr
+			break
+		}
+		if _, isFile := sink.(*remotelog.FileSink); !isFile && BufferParameters.Directory != "" {
+			bufferPath := filepath.Join(BufferParameters.Directory, bufferFileName(sinkURL))
+			if sink, sinkErr = remotelog.NewBufferedSink(sink, bufferPath, int64(BufferParameters.MaxSize)*1024*1024, Parameters.ReconnectInterval); sinkErr != nil {
+				err = sinkErr
+				break
+			}
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if err != nil {
+		for _, sink := range sinks {
+			_ = sink.Close()
+		}
+		return nil, err
+	}
+	return sinks, nil
+}
+
+// bufferFileName returns the name of the buffer file of the sink with the given URL.
+func bufferFileName(sinkURL string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '-' {
+			return r
+		}
+		return '_'
+	}, sinkURL) + ".buffer"
+}
+
 type logMessage struct {
 	Version   string    `json:"version"`
 	GitHead   string    `json:"gitHead,omitempty"`
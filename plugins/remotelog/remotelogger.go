@@ -2,34 +2,114 @@ package remotelog
 
 import (
 	"encoding/json"
-	"fmt"
-	"net"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/workerpool"
+
+	"github.com/iotaledger/goshimmer/packages/remotelog"
+)
+
+const (
+	recordIndex  = 0
+	onErrorIndex = 1
+
+	// sendQueueSize is the amount of records per sink that are queued by SendAsync before new records are dropped.
+	sendQueueSize = 1000
 )
 
-// RemoteLoggerConn is a wrapper for a connection to our RemoteLog server.
+// RemoteLoggerConn is a wrapper for the connections to the sinks of the remote logger.
 type RemoteLoggerConn struct {
-	conn net.Conn
+	sinks  []remotelog.Sink
+	filter *remotelog.Filter
+
+	// sendWorkerPools contains a worker pool with a single worker for every sink, so that the records of SendAsync
+	// reach every sink in order and a slow sink does not delay the others.
+	sendWorkerPools []*workerpool.NonBlockingQueuedWorkerPool
 }
 
-func newRemoteLoggerConn(address string) (*RemoteLoggerConn, error) {
-	c, err := net.Dial("udp", address)
-	if err != nil {
-		return nil, fmt.Errorf("could not create UDP socket to '%s'. %v", address, err)
+func newRemoteLoggerConn(sinks []remotelog.Sink, filter *remotelog.Filter) *RemoteLoggerConn {
+	r := &RemoteLoggerConn{
+		sinks:           sinks,
+		filter:          filter,
+		sendWorkerPools: make([]*workerpool.NonBlockingQueuedWorkerPool, len(sinks)),
+	}
+	for i, sink := range sinks {
+		r.sendWorkerPools[i] = newSendWorkerPool(sink)
 	}
 
-	return &RemoteLoggerConn{conn: c}, nil
+	return r
 }
 
-// Send sends a message on the RemoteLoggers connection.
+// Send sends a message to all sinks of the RemoteLogger, unless its type is filtered.
 func (r *RemoteLoggerConn) Send(msg interface{}) error {
-	b, err := json.Marshal(msg)
-	if err != nil {
+	b, err := r.marshal(msg)
+	if err != nil || b == nil {
 		return err
 	}
-	_, err = r.conn.Write(b)
+
+	for _, sink := range r.sinks {
+		if sinkErr := sink.Send(b); sinkErr != nil {
+			err = errors.CombineErrors(err, sinkErr)
+		}
+	}
+
+	return err
+}
+
+// SendAsync queues a message for all sinks of the RemoteLogger, unless its type is filtered, so that the caller is not
+// blocked by slow sinks. The message is dropped for the sinks whose queue is full. If sending fails, the error is
+// passed to onError (if set).
+func (r *RemoteLoggerConn) SendAsync(msg interface{}, onError func(err error)) {
+	b, err := r.marshal(msg)
 	if err != nil {
-		return err
+		if onError != nil {
+			onError(err)
+		}
+		return
+	}
+	if b == nil {
+		return
 	}
 
-	return nil
+	for _, sendWorkerPool := range r.sendWorkerPools {
+		sendWorkerPool.TrySubmit(b, onError)
+	}
+}
+
+// Close stops sending the queued messages and closes all sinks of the RemoteLogger.
+func (r *RemoteLoggerConn) Close() (err error) {
+	for _, sendWorkerPool := range r.sendWorkerPools {
+		sendWorkerPool.StopAndWait()
+	}
+	for _, sink := range r.sinks {
+		err = errors.CombineErrors(err, sink.Close())
+	}
+
+	return err
+}
+
+// marshal returns the JSON encoding of the message or nil if its type is filtered.
+func (r *RemoteLoggerConn) marshal(msg interface{}) ([]byte, error) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if !r.filter.AllowsRecord(b) {
+		return nil, nil
+	}
+
+	return b, nil
+}
+
+// newSendWorkerPool creates the worker pool that sends the records of SendAsync to the given sink.
+func newSendWorkerPool(sink remotelog.Sink) *workerpool.NonBlockingQueuedWorkerPool {
+	return workerpool.NewNonBlockingQueuedWorkerPool(func(task workerpool.Task) {
+		if err := sink.Send(task.Param(recordIndex).([]byte)); err != nil {
+			if onError := task.Param(onErrorIndex).(func(error)); onError != nil {
+				onError(err)
+			}
+		}
+
+		task.Return(nil)
+	}, workerpool.WorkerCount(1), workerpool.QueueSize(sendQueueSize))
 }
@@ -6,7 +6,6 @@ import (
 	"github.com/iotaledger/goshimmer/packages/remotelogmetrics"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
-	"github.com/iotaledger/goshimmer/plugins/remotelog"
 )
 
 func onRandomnessReceived(state *drng.State) {
@@ -29,7 +28,5 @@ func onRandomnessReceived(state *drng.State) {
 		DeltaReceived:     clock.Since(state.Randomness().Timestamp).Nanoseconds(),
 	}
 
-	if err := remotelog.RemoteLogger().Send(record); err != nil {
-		plugin.Logger().Errorw("Failed to send Randomness record", "err", err)
-	}
+	sendRecord(record, "Failed to send Randomness record")
 }
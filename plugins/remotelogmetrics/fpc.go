@@ -6,7 +6,6 @@ import (
 	"github.com/iotaledger/goshimmer/packages/vote"
 	"github.com/iotaledger/goshimmer/packages/vote/opinion"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
-	"github.com/iotaledger/goshimmer/plugins/remotelog"
 )
 
 func onVoteFinalized(ev *vote.OpinionEvent) {
@@ -25,9 +24,7 @@ func onVoteFinalized(ev *vote.OpinionEvent) {
 		ConflictCreationTime: ev.Ctx.ConflictCreationTime,
 		Delta:                clock.Since(ev.Ctx.ConflictCreationTime).Nanoseconds(),
 	}
-	if err := remotelog.RemoteLogger().Send(record); err != nil {
-		plugin.Logger().Errorw("Failed to send FPC conflict record on vote finalized event", "err", err)
-	}
+	sendRecord(record, "Failed to send FPC conflict record on vote finalized event")
 }
 
 func onVoteRoundExecuted(roundStats *vote.RoundStats) {
@@ -46,8 +43,6 @@ func onVoteRoundExecuted(roundStats *vote.RoundStats) {
 			ConflictCreationTime: conflictContext.ConflictCreationTime,
 			Delta:                clock.Since(conflictContext.ConflictCreationTime).Nanoseconds(),
 		}
-		if err := remotelog.RemoteLogger().Send(record); err != nil {
-			plugin.Logger().Errorw("Failed to send FPC conflict record on round executed event", "err", err)
-		}
+		sendRecord(record, "Failed to send FPC conflict record on round executed event")
 	}
 }
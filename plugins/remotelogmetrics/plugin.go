@@ -1,6 +1,6 @@
 // Package remotelogmetrics is a plugin that enables log metrics too complex for Prometheus, but still interesting in terms of analysis and debugging.
 // It is enabled by default.
-// The destination can be set via logger.remotelog.serverAddress.
+// The records are sent to the sinks of the remote logger, which can be set via logger.remotelog.sinks.
 package remotelogmetrics
 
 import (
@@ -69,10 +69,14 @@ func configureSyncMetrics() {
 }
 
 func sendSyncStatusChangedEvent(syncUpdate remotelogmetrics.SyncStatusChangedEvent) {
-	err := remotelog.RemoteLogger().Send(syncUpdate)
-	if err != nil {
-		plugin.Logger().Errorw("Failed to send sync status changed record on sync change event.", "err", err)
-	}
+	sendRecord(syncUpdate, "Failed to send sync status changed record on sync change event.")
+}
+
+// sendRecord sends the record to the remote logger without blocking the caller and logs the given message if it fails.
+func sendRecord(record interface{}, errorMessage string) {
+	remotelog.SendAsync(record, func(err error) {
+		plugin.Logger().Errorw(errorMessage, "err", err)
+	})
 }
 
 func configureFPCConflictsMetrics() {
@@ -9,7 +9,6 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/clock"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
-	"github.com/iotaledger/goshimmer/plugins/remotelog"
 )
 
 func onStatementReceived(msg *tangle.Message) {
@@ -40,8 +39,6 @@ func onStatementReceived(msg *tangle.Message) {
 			Sync:         messagelayer.Tangle().Synced(),
 			Type:         "statement",
 		}
-		if err := remotelog.RemoteLogger().Send(m); err != nil {
-			plugin.Logger().Errorw("Failed to send statement metrics", "err", err)
-		}
+		sendRecord(m, "Failed to send statement metrics")
 	})
 }
@@ -7,7 +7,6 @@ import (
 	"github.com/iotaledger/goshimmer/packages/tangle"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
-	"github.com/iotaledger/goshimmer/plugins/remotelog"
 )
 
 // Evaluate evaluates the opinion of the given messageID.
@@ -30,9 +29,7 @@ func onTransactionOpinionFormed(messageID tangle.MessageID) {
 				Timestamp:        opinion.Timestamp(),
 				MessageID:        messageID.Base58(),
 			}
-			if err := remotelog.RemoteLogger().Send(record); err != nil {
-				plugin.Logger().Errorw("Failed to send Transaction record", "err", err)
-			}
+			sendRecord(record, "Failed to send Transaction record")
 		})
 	})
 }
@@ -76,7 +73,5 @@ func onTransactionConfirmed(transactionID ledgerstate.TransactionID) {
 		record.DeltaSolid = transactionMetadata.SolidificationTime().Sub(record.IssuedTimestamp).Nanoseconds()
 	})
 
-	if err := remotelog.RemoteLogger().Send(record); err != nil {
-		plugin.Logger().Errorw("Failed to send Transaction record", "err", err)
-	}
+	sendRecord(record, "Failed to send Transaction record")
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/remotelog"
 	"github.com/iotaledger/goshimmer/plugins/remotelogmetrics"
 	"github.com/iotaledger/goshimmer/plugins/txstream"
+	"github.com/iotaledger/goshimmer/plugins/votelog"
 )
 
 // Research contains research plugins of a GoShimmer node.
@@ -27,4 +28,5 @@ var Research = node.Plugins(
 	txstream.Plugin(),
 	activity.Plugin(),
 	chat.App(),
+	votelog.Plugin(),
 )
@@ -0,0 +1,19 @@
+package votelog
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+// Parameters contains the configuration parameters of the votelog plugin.
+var Parameters = struct {
+	// Retention defines how long the traces of the votes are kept.
+	Retention time.Duration `default:"168h" usage:"how long the traces of the votes are kept after the vote started"`
+	// PruningInterval defines how often the traces that exceeded the retention are removed.
+	PruningInterval time.Duration `default:"1h" usage:"how often the traces that exceeded the retention are removed"`
+}{}
+
+func init() {
+	configuration.BindParameters(&Parameters, "votelog")
+}
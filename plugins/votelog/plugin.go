@@ -0,0 +1,85 @@
+// Package votelog is a plugin that persists the trace of every FPC vote of the node, i.e. the initial opinion, the
+// queried opinions, random numbers and etas of each round and the final outcome. The traces can be fetched via the
+// web API and replayed to verify the decisions. The traces are kept for votelog.retention. It is disabled by default.
+package votelog
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/iotaledger/goshimmer/packages/clock"
+	"github.com/iotaledger/goshimmer/packages/database"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+	"github.com/iotaledger/goshimmer/packages/vote/votelog"
+	databaseplugin "github.com/iotaledger/goshimmer/plugins/database"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// PluginName is the name of the votelog plugin.
+const PluginName = "VoteLog"
+
+var (
+	// plugin is the plugin instance of the votelog plugin.
+	plugin     *node.Plugin
+	pluginOnce sync.Once
+	log        *votelog.Log
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	pluginOnce.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Disabled, configure, run)
+	})
+	return plugin
+}
+
+func configure(plugin *node.Plugin) {
+	voter, ok := messagelayer.Voter().(*fpc.FPC)
+	if !ok {
+		plugin.LogFatalf("the vote log only supports the %s voter, but the node uses the %s voter", fpc.VoterName, messagelayer.FPCParameters.Voter)
+	}
+	log = votelog.NewLog(databaseplugin.StoreRealm([]byte{database.PrefixVoteLog}), voter.Parameters())
+
+	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		if err := log.RecordRound(roundStats); err != nil {
+			plugin.LogErrorf("failed to log round: %s", err)
+		}
+	}))
+	voter.Events().Finalized.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		if err := log.RecordFinalized(ev); err != nil {
+			plugin.LogErrorf("failed to log finalized vote: %s", err)
+		}
+	}))
+	voter.Events().Failed.Attach(events.NewClosure(func(ev *vote.OpinionEvent) {
+		if err := log.RecordFailed(ev); err != nil {
+			plugin.LogErrorf("failed to log failed vote: %s", err)
+		}
+	}))
+
+	configureWebAPI()
+}
+
+func run(plugin *node.Plugin) {
+	if err := daemon.BackgroundWorker("VoteLog-Pruning", func(shutdownSignal <-chan struct{}) {
+		prune := func() {
+			pruned, err := log.Prune(clock.SyncedTime().Add(-Parameters.Retention))
+			if err != nil {
+				plugin.LogErrorf("failed to prune vote traces: %s", err)
+				return
+			}
+			plugin.LogDebugf("pruned %d vote traces", pruned)
+		}
+
+		prune()
+		timeutil.NewTicker(prune, Parameters.PruningInterval, shutdownSignal)
+		<-shutdownSignal
+	}, shutdown.PriorityFPC); err != nil {
+		plugin.Panicf("Failed to start as daemon: %s", err)
+	}
+}
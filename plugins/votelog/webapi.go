@@ -0,0 +1,37 @@
+package votelog
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote/votelog"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+func configureWebAPI() {
+	webapi.Server().GET("votelog/:id", traceHandler)
+}
+
+// traceHandler returns the trace of the vote on the given transaction or message ID.
+func traceHandler(c echo.Context) error {
+	id := c.Param("id")
+	if _, err := ledgerstate.TransactionIDFromBase58(id); err != nil {
+		if _, err := tangle.NewMessageID(id); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.VoteTraceResponse{Error: errors.Errorf("invalid transaction or message ID: %w", err).Error()})
+		}
+	}
+
+	trace, err := log.Trace(id)
+	if err != nil {
+		if errors.Is(err, votelog.ErrTraceNotFound) {
+			return c.JSON(http.StatusNotFound, jsonmodels.VoteTraceResponse{Error: err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, jsonmodels.VoteTraceResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusOK, jsonmodels.VoteTraceResponse{Trace: jsonmodels.NewVoteTrace(trace)})
+}
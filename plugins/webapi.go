@@ -6,6 +6,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/webapi"
 	"github.com/iotaledger/goshimmer/plugins/webapi/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/webapi/data"
+	"github.com/iotaledger/goshimmer/plugins/webapi/database"
 	"github.com/iotaledger/goshimmer/plugins/webapi/drng"
 	"github.com/iotaledger/goshimmer/plugins/webapi/faucet"
 	"github.com/iotaledger/goshimmer/plugins/webapi/healthz"
@@ -14,6 +15,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/webapi/mana"
 	"github.com/iotaledger/goshimmer/plugins/webapi/message"
 	"github.com/iotaledger/goshimmer/plugins/webapi/snapshot"
+	"github.com/iotaledger/goshimmer/plugins/webapi/subscriptions"
 	"github.com/iotaledger/goshimmer/plugins/webapi/tools"
 	"github.com/iotaledger/goshimmer/plugins/webapi/weightprovider"
 )
@@ -32,5 +34,7 @@ var WebAPI = node.Plugins(
 	mana.Plugin(),
 	ledgerstate.Plugin(),
 	snapshot.Plugin(),
+	database.Plugin(),
+	subscriptions.Plugin(),
 	weightprovider.Plugin(),
 )
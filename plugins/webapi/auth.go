@@ -0,0 +1,329 @@
+package webapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+	"go.uber.org/atomic"
+)
+
+// region Permission ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// PublicPermission is required by routes that can be accessed without a token.
+	PublicPermission Permission = iota
+
+	// ReadPermission is required by routes that only read the state of the node.
+	ReadPermission
+
+	// IssuePermission is required by routes that issue messages or transactions.
+	IssuePermission
+
+	// AdminPermission is required by routes that change the behavior of the node or that are expensive to serve.
+	AdminPermission
+)
+
+// Permission represents the permission that is required to access a route of the web API.
+type Permission uint8
+
+// permissionNames contains the human readable representations of the Permissions.
+var permissionNames = [...]string{
+	"PublicPermission",
+	"ReadPermission",
+	"IssuePermission",
+	"AdminPermission",
+}
+
+// String returns a human readable representation of the Permission.
+func (p Permission) String() string {
+	if int(p) >= len(permissionNames) {
+		return fmt.Sprintf("Permission(%d)", uint8(p))
+	}
+
+	return permissionNames[p]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Role /////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// ReadOnlyRole grants access to the routes that read the state of the node.
+	ReadOnlyRole Role = iota
+
+	// IssuerRole additionally grants access to the routes that issue messages or transactions.
+	IssuerRole
+
+	// AdminRole grants access to all routes.
+	AdminRole
+)
+
+// Role represents a named set of permissions that is assigned to an API token.
+type Role uint8
+
+// RoleFromString returns the Role with the given name.
+func RoleFromString(name string) (role Role, err error) {
+	switch name {
+	case "read-only":
+		return ReadOnlyRole, nil
+	case "issuer":
+		return IssuerRole, nil
+	case "admin":
+		return AdminRole, nil
+	default:
+		return 0, errors.Errorf("unknown role '%s' (expected read-only, issuer or admin)", name)
+	}
+}
+
+// Allows returns true if the Role grants the given Permission. Roles are hierarchical, so every Role grants the
+// permissions of the Roles below it.
+func (r Role) Allows(permission Permission) bool {
+	return permission <= Permission(r)+ReadPermission
+}
+
+// roleNames contains the names of the Roles.
+var roleNames = [...]string{
+	"read-only",
+	"issuer",
+	"admin",
+}
+
+// String returns the name of the Role.
+func (r Role) String() string {
+	if int(r) >= len(roleNames) {
+		return fmt.Sprintf("Role(%d)", uint8(r))
+	}
+
+	return roleNames[r]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Route permissions ////////////////////////////////////////////////////////////////////////////////////////////
+
+// routePermissions contains the Permission that is required for every route that is registered via Server(). Routes that
+// are missing from the table require the AdminPermission.
+var routePermissions = map[string]Permission{
+	"GET /":        ReadPermission,
+	"GET /healthz": PublicPermission,
+	"GET /info":    ReadPermission,
+
+	"GET /autopeering/neighbors":  ReadPermission,
+	"GET /manualpeering/peers":    AdminPermission,
+	"POST /manualpeering/peers":   AdminPermission,
+	"DELETE /manualpeering/peers": AdminPermission,
+
+	"GET /messages/:messageID":           ReadPermission,
+	"GET /messages/:messageID/metadata":  ReadPermission,
+	"GET /messages/:messageID/consensus": ReadPermission,
+	"POST /messages/payload":             IssuePermission,
+	"POST /data":                         IssuePermission,
+	"POST /chat":                         IssuePermission,
+	"POST /faucet":                       IssuePermission,
+	"GET /faucet/:messageID":             ReadPermission,
+
+	"GET /ledgerstate/addresses/:address":                         ReadPermission,
+	"GET /ledgerstate/addresses/:address/unspentOutputs":          ReadPermission,
+	"GET /ledgerstate/addresses/:address/history":                 ReadPermission,
+	"POST /ledgerstate/addresses/unspentOutputs":                  ReadPermission,
+	"GET /ledgerstate/branches/:branchID":                         ReadPermission,
+	"GET /ledgerstate/branches/:branchID/children":                ReadPermission,
+	"GET /ledgerstate/branches/:branchID/conflicts":               ReadPermission,
+	"GET /ledgerstate/outputs/:outputID":                          ReadPermission,
+	"GET /ledgerstate/outputs/:outputID/consumers":                ReadPermission,
+	"GET /ledgerstate/outputs/:outputID/metadata":                 ReadPermission,
+	"GET /ledgerstate/transactions/:transactionID":                ReadPermission,
+	"GET /ledgerstate/transactions/:transactionID/metadata":       ReadPermission,
+	"GET /ledgerstate/transactions/:transactionID/inclusionState": ReadPermission,
+	"GET /ledgerstate/transactions/:transactionID/consensus":      ReadPermission,
+	"GET /ledgerstate/transactions/:transactionID/attachments":    ReadPermission,
+	"POST /ledgerstate/transactions":                              IssuePermission,
+
+	"GET /mana":                            ReadPermission,
+	"GET /mana/all":                        ReadPermission,
+	"GET /mana/percentile":                 ReadPermission,
+	"GET /mana/access/online":              ReadPermission,
+	"GET /mana/consensus/online":           ReadPermission,
+	"GET /mana/access/nhighest":            ReadPermission,
+	"GET /mana/consensus/nhighest":         ReadPermission,
+	"GET /mana/pending":                    ReadPermission,
+	"GET /mana/allowedManaPledge":          ReadPermission,
+	"GET /mana/delegated":                  ReadPermission,
+	"GET /mana/delegated/outputs":          ReadPermission,
+	"GET /weightprovider/activenodes":      ReadPermission,
+	"GET /weightprovider/weights":          ReadPermission,
+	"GET /drng/info/committee":             ReadPermission,
+	"GET /drng/info/randomness":            ReadPermission,
+	"GET /drng/history/:instanceID":        ReadPermission,
+	"GET /drng/history/:instanceID/:round": ReadPermission,
+	"POST /drng/collectiveBeacon":          IssuePermission,
+	"GET /subscriptions":                   ReadPermission,
+	"GET /votelog/:id":                     ReadPermission,
+	"GET /snapshot":                        AdminPermission,
+	"POST /database/backup":                AdminPermission,
+	"GET /spammer":                         AdminPermission,
+	"POST /networkdelay":                   AdminPermission,
+	"GET /tools/message/pastcone":          AdminPermission,
+	"GET /tools/message/missing":           AdminPermission,
+	"GET /tools/message/approval":          AdminPermission,
+	"GET /tools/message/orphanage":         AdminPermission,
+	"GET /tools/diagnostic/messages":       AdminPermission,
+	"GET /tools/diagnostic/utxodag":        AdminPermission,
+	"GET /tools/diagnostic/branches":       AdminPermission,
+	"GET /tools/diagnostic/tips":           AdminPermission,
+	"GET /tools/diagnostic/tips/strong":    AdminPermission,
+	"GET /tools/diagnostic/tips/weak":      AdminPermission,
+	"GET /tools/diagnostic/drng":           AdminPermission,
+
+	"GET /tools/diagnostic/messages/firstweakreferences": AdminPermission,
+	"GET /tools/diagnostic/messages/rank/:rank":          AdminPermission,
+	"GET /tools/diagnostic/branches/lazybooked":          AdminPermission,
+	"GET /tools/diagnostic/branches/invalid":             AdminPermission,
+}
+
+// RoutePermission returns the Permission that is required to access the route with the given method and path (as it
+// was registered).
+func RoutePermission(method, path string) (permission Permission, exists bool) {
+	permission, exists = routePermissions[routeKey(method, path)]
+	if !exists {
+		return AdminPermission, false
+	}
+
+	return permission, true
+}
+
+// routeKey returns the key of a route in the routePermissions table.
+func routeKey(method, path string) string {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return method + " " + path
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region API tokens ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// APIToken represents a token that grants access to the web API. Only the hash of the token is kept in the config.
+type APIToken struct {
+	Name string
+	Role Role
+}
+
+// APITokens maps the SHA-256 hashes of the tokens to the corresponding APIToken.
+type APITokens map[[sha256.Size]byte]*APIToken
+
+// APITokensFromStrings parses the configured tokens. Every entry has the format "name:role:hash" where hash is the hex
+// encoded SHA-256 hash of the token.
+func APITokensFromStrings(entries []string) (apiTokens APITokens, err error) {
+	apiTokens = make(APITokens, len(entries))
+	names := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, errors.Errorf("invalid API token entry '%s' (expected name:role:hash)", entry)
+		}
+
+		name := parts[0]
+		if name == "" || names[name] {
+			return nil, errors.Errorf("API token names must be unique and not empty: '%s'", name)
+		}
+		names[name] = true
+
+		role, roleErr := RoleFromString(parts[1])
+		if roleErr != nil {
+			return nil, errors.Errorf("invalid role of API token '%s': %w", name, roleErr)
+		}
+
+		hashBytes, hashErr := hex.DecodeString(parts[2])
+		if hashErr != nil || len(hashBytes) != sha256.Size {
+			return nil, errors.Errorf("invalid hash of API token '%s' (expected hex encoded SHA-256 hash)", name)
+		}
+		var hash [sha256.Size]byte
+		copy(hash[:], hashBytes)
+		apiTokens[hash] = &APIToken{Name: name, Role: role}
+	}
+
+	return apiTokens, nil
+}
+
+// Authenticate returns the APIToken that belongs to the given token.
+func (a APITokens) Authenticate(token string) (apiToken *APIToken, exists bool) {
+	apiToken, exists = a[sha256.Sum256([]byte(token))]
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Middleware ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// unauthenticatedRequests counts the requests that were rejected due to a missing or unknown token.
+	unauthenticatedRequests atomic.Uint64
+
+	// forbiddenRequests counts the requests that were rejected because the role of the token was insufficient.
+	forbiddenRequests atomic.Uint64
+)
+
+// UnauthenticatedRequests returns the amount of requests that were rejected due to a missing or unknown token.
+func UnauthenticatedRequests() uint64 {
+	return unauthenticatedRequests.Load()
+}
+
+// ForbiddenRequests returns the amount of requests that were rejected because the role of the token was insufficient.
+func ForbiddenRequests() uint64 {
+	return forbiddenRequests.Load()
+}
+
+// apiTokenContextKey is the key under which tokenAuth stores the APIToken of an authenticated request in the context.
+const apiTokenContextKey = "apiToken"
+
+// tokenAuth returns a middleware that checks the bearer token of every request against the Permission of its route.
+func tokenAuth(apiTokens APITokens) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			permission, _ := RoutePermission(c.Request().Method, c.Path())
+			if permission == PublicPermission {
+				return next(c)
+			}
+
+			authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+			if !strings.HasPrefix(authorization, "Bearer ") {
+				unauthenticatedRequests.Inc()
+				return errors.Errorf("missing API token: %w", echo.ErrUnauthorized)
+			}
+
+			apiToken, exists := apiTokens.Authenticate(strings.TrimPrefix(authorization, "Bearer "))
+			if !exists {
+				unauthenticatedRequests.Inc()
+				return errors.Errorf("unknown API token: %w", echo.ErrUnauthorized)
+			}
+
+			if !apiToken.Role.Allows(permission) {
+				forbiddenRequests.Inc()
+				return errors.Errorf("API token '%s' with role %s can not access %s %s: %w", apiToken.Name, apiToken.Role, c.Request().Method, c.Path(), echo.ErrForbidden)
+			}
+			c.Set(apiTokenContextKey, apiToken)
+
+			return next(c)
+		}
+	}
+}
+
+// checkRoutePermissions logs the routes of the server that are missing from the routePermissions table.
+func checkRoutePermissions() {
+	for _, route := range server.Routes() {
+		if _, exists := RoutePermission(route.Method, route.Path); !exists && route.Method != http.MethodOptions {
+			log.Warnf("route %s %s has no permission assigned and requires the %s", route.Method, route.Path, AdminPermission)
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,93 @@
+package webapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPITokensFromStrings(t *testing.T) {
+	hash := sha256.Sum256([]byte("secret"))
+
+	apiTokens, err := APITokensFromStrings([]string{"explorer:read-only:" + hex.EncodeToString(hash[:])})
+	require.NoError(t, err)
+	apiToken, exists := apiTokens.Authenticate("secret")
+	require.True(t, exists)
+	assert.Equal(t, "explorer", apiToken.Name)
+	assert.Equal(t, ReadOnlyRole, apiToken.Role)
+	_, exists = apiTokens.Authenticate("wrong")
+	assert.False(t, exists)
+
+	for _, invalidEntry := range []string{
+		"explorer:read-only",
+		"explorer:superuser:" + hex.EncodeToString(hash[:]),
+		"explorer:admin:1234",
+		":admin:" + hex.EncodeToString(hash[:]),
+	} {
+		_, err = APITokensFromStrings([]string{invalidEntry})
+		assert.Error(t, err, invalidEntry)
+	}
+}
+
+func TestRole_Allows(t *testing.T) {
+	assert.True(t, ReadOnlyRole.Allows(ReadPermission))
+	assert.False(t, ReadOnlyRole.Allows(IssuePermission))
+	assert.True(t, IssuerRole.Allows(IssuePermission))
+	assert.False(t, IssuerRole.Allows(AdminPermission))
+	assert.True(t, AdminRole.Allows(AdminPermission))
+}
+
+func TestPermissionAndRole_String(t *testing.T) {
+	assert.Equal(t, "IssuePermission", IssuePermission.String())
+	assert.Equal(t, "Permission(42)", Permission(42).String())
+	assert.Equal(t, "admin", AdminRole.String())
+	assert.Equal(t, "Role(42)", Role(42).String())
+}
+
+func TestTokenAuth(t *testing.T) {
+	readHash := sha256.Sum256([]byte("reader"))
+	adminHash := sha256.Sum256([]byte("admin"))
+	apiTokens, err := APITokensFromStrings([]string{
+		"reader:read-only:" + hex.EncodeToString(readHash[:]),
+		"admin:admin:" + hex.EncodeToString(adminHash[:]),
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("healthz", ok)
+	e.GET("info", ok)
+	e.POST("ledgerstate/transactions", ok)
+	e.GET("unlisted", ok)
+
+	request := func(method, path, token string) error {
+		c := e.NewContext(httptest.NewRequest(method, "/"+path, nil), httptest.NewRecorder())
+		if token != "" {
+			c.Request().Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		e.Router().Find(method, "/"+path, c)
+
+		return tokenAuth(apiTokens)(c.Handler())(c)
+	}
+
+	unauthenticatedBefore, forbiddenBefore := UnauthenticatedRequests(), ForbiddenRequests()
+
+	assert.NoError(t, request(http.MethodGet, "healthz", ""))
+	assert.True(t, errors.Is(request(http.MethodGet, "info", ""), echo.ErrUnauthorized))
+	assert.True(t, errors.Is(request(http.MethodGet, "info", "wrong"), echo.ErrUnauthorized))
+	assert.NoError(t, request(http.MethodGet, "info", "reader"))
+	assert.True(t, errors.Is(request(http.MethodPost, "ledgerstate/transactions", "reader"), echo.ErrForbidden))
+	assert.NoError(t, request(http.MethodPost, "ledgerstate/transactions", "admin"))
+	assert.True(t, errors.Is(request(http.MethodGet, "unlisted", "reader"), echo.ErrForbidden))
+	assert.NoError(t, request(http.MethodGet, "unlisted", "admin"))
+
+	assert.Equal(t, unauthenticatedBefore+2, UnauthenticatedRequests())
+	assert.Equal(t, forbiddenBefore+2, ForbiddenRequests())
+}
@@ -8,12 +8,14 @@ import (
 
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
 
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
+	gossipplugin "github.com/iotaledger/goshimmer/plugins/gossip"
 	"github.com/iotaledger/goshimmer/plugins/webapi"
 )
 
@@ -50,11 +52,20 @@ func getNeighbors(c echo.Context) error {
 		}
 	}
 
+	scores := make(map[identity.ID]float64)
+	for _, n := range gossipplugin.Manager().AllNeighbors() {
+		scores[n.ID()] = n.Score()
+	}
+
 	for _, p := range autopeering.Selection().GetOutgoingNeighbors() {
-		chosen = append(chosen, createNeighborFromPeer(p))
+		n := createNeighborFromPeer(p)
+		n.Score = scores[p.ID()]
+		chosen = append(chosen, n)
 	}
 	for _, p := range autopeering.Selection().GetIncomingNeighbors() {
-		accepted = append(accepted, createNeighborFromPeer(p))
+		n := createNeighborFromPeer(p)
+		n.Score = scores[p.ID()]
+		accepted = append(accepted, n)
 	}
 
 	return c.JSON(http.StatusOK, jsonmodels.GetNeighborsResponse{KnownPeers: knownPeers, Chosen: chosen, Accepted: accepted})
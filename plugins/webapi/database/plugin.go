@@ -0,0 +1,55 @@
+package database
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/plugins/database"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+// region Plugin ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+var (
+	// plugin holds the singleton instance of the plugin.
+	plugin *node.Plugin
+
+	// pluginOnce is used to ensure that the plugin is a singleton.
+	once sync.Once
+)
+
+// Plugin returns the plugin as a singleton.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin("WebAPI database Endpoint", node.Enabled, func(*node.Plugin) {
+			webapi.Server().POST("database/backup", CreateBackup)
+		})
+	})
+
+	return plugin
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CreateBackup /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CreateBackup writes a backup of the database to the backup directory of the node.
+func CreateBackup(c echo.Context) error {
+	path, info, err := database.CreateBackup()
+	if err != nil {
+		if errors.Is(err, database.ErrBackupInProgress) {
+			return c.JSON(http.StatusConflict, jsonmodels.NewErrorResponse(err))
+		}
+		plugin.LogErrorf("unable to create database backup: %s", err)
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	return c.JSON(http.StatusOK, jsonmodels.NewDatabaseBackupResponse(path, info))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,95 @@
+package drng
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+
+	drngpkg "github.com/iotaledger/goshimmer/packages/drng"
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/plugins/drng"
+)
+
+// maxBeaconsLimit defines the maximum amount of beacons that are returned by a single history request.
+const maxBeaconsLimit = 1000
+
+// beaconHandler returns the beacon of the given instance and round.
+func beaconHandler(c echo.Context) error {
+	instanceID, err := strconv.ParseUint(c.Param("instanceID"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.BeaconResponse{Error: errors.Errorf("invalid instanceID: %w", err).Error()})
+	}
+	round, err := strconv.ParseUint(c.Param("round"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.BeaconResponse{Error: errors.Errorf("invalid round: %w", err).Error()})
+	}
+
+	beacon, err := drng.History().Beacon(uint32(instanceID), round)
+	if err != nil {
+		return c.JSON(historyErrorStatus(err), jsonmodels.BeaconResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusOK, jsonmodels.BeaconResponse{Beacon: beaconToJSON(beacon)})
+}
+
+// beaconsHandler returns the beacons of the given instance. The rounds are either selected by the from and to query
+// parameters, or the time query parameter selects the beacon whose randomness was the current one at that time.
+func beaconsHandler(c echo.Context) error {
+	instanceID, err := strconv.ParseUint(c.Param("instanceID"), 10, 32)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.BeaconsResponse{Error: errors.Errorf("invalid instanceID: %w", err).Error()})
+	}
+
+	if c.QueryParam("time") != "" {
+		t, err := time.Parse(time.RFC3339, c.QueryParam("time"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.BeaconsResponse{Error: errors.Errorf("invalid time: %w", err).Error()})
+		}
+		beacon, err := drng.History().BeaconAt(uint32(instanceID), t)
+		if err != nil {
+			return c.JSON(historyErrorStatus(err), jsonmodels.BeaconsResponse{Error: err.Error()})
+		}
+		return c.JSON(http.StatusOK, jsonmodels.BeaconsResponse{Beacons: []*jsonmodels.Beacon{beaconToJSON(beacon)}})
+	}
+
+	from, err := strconv.ParseUint(c.QueryParam("from"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.BeaconsResponse{Error: errors.Errorf("invalid from: %w", err).Error()})
+	}
+	to, err := strconv.ParseUint(c.QueryParam("to"), 10, 64)
+	if err != nil || to < from {
+		return c.JSON(http.StatusBadRequest, jsonmodels.BeaconsResponse{Error: "to must be a round greater than or equal to from"})
+	}
+
+	beacons, err := drng.History().Beacons(uint32(instanceID), from, to, maxBeaconsLimit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, jsonmodels.BeaconsResponse{Error: err.Error()})
+	}
+	response := jsonmodels.BeaconsResponse{Beacons: make([]*jsonmodels.Beacon, len(beacons))}
+	for i, beacon := range beacons {
+		response.Beacons[i] = beaconToJSON(beacon)
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+func historyErrorStatus(err error) int {
+	if errors.Is(err, drngpkg.ErrBeaconNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func beaconToJSON(beacon *drngpkg.Beacon) *jsonmodels.Beacon {
+	// the signature of a stored beacon has been verified already, so the randomness can always be extracted
+	randomness, _ := beacon.Randomness()
+	return &jsonmodels.Beacon{
+		InstanceID:    beacon.InstanceID,
+		Round:         beacon.Round,
+		Timestamp:     beacon.Timestamp,
+		PrevSignature: beacon.PrevSignature,
+		Signature:     beacon.Signature,
+		Randomness:    randomness,
+	}
+}
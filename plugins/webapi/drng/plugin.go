@@ -29,4 +29,6 @@ func configure(_ *node.Plugin) {
 	webapi.Server().POST("drng/collectiveBeacon", collectiveBeaconHandler)
 	webapi.Server().GET("drng/info/committee", committeeHandler)
 	webapi.Server().GET("drng/info/randomness", randomnessHandler)
+	webapi.Server().GET("drng/history/:instanceID", beaconsHandler)
+	webapi.Server().GET("drng/history/:instanceID/:round", beaconHandler)
 }
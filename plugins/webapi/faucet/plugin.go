@@ -3,8 +3,10 @@ package faucet
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 
+	"github.com/cockroachdb/errors"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
@@ -13,6 +15,8 @@ import (
 	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/faucet"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 	"github.com/iotaledger/goshimmer/plugins/webapi"
 )
@@ -33,10 +37,12 @@ func Plugin() *node.Plugin {
 
 func configure(_ *node.Plugin) {
 	webapi.Server().POST("faucet", requestFunds)
+	webapi.Server().GET("faucet/:messageID", requestStatus)
 }
 
 // requestFunds creates a faucet request (0-value) message with the given destination address and
-// broadcasts it to the node's neighbors. It returns the message ID if successful.
+// broadcasts it to the node's neighbors. It returns the message ID if successful. If the faucet is running on this node,
+// requests that it would refuse are not issued, and the reason of the refusal is returned instead.
 func requestFunds(c echo.Context) error {
 	var request jsonmodels.FaucetRequest
 	if err := c.Bind(&request); err != nil {
@@ -47,7 +53,7 @@ func requestFunds(c echo.Context) error {
 	plugin.LogInfo("Received - address:", request.Address)
 	plugin.LogDebug(request)
 
-	addr, err := ledgerstate.AddressFromBase58EncodedString(request.Address)
+	addr, err := ledgerstate.AddressFromString(request.Address)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.FaucetResponse{Error: "Invalid address"})
 	}
@@ -70,6 +76,14 @@ func requestFunds(c echo.Context) error {
 
 	faucetPayload := faucetpkg.NewRequest(addr, accessManaPledgeID, consensusManaPledgeID, request.Nonce)
 
+	// the faucet exempts its own identity from the node quota, so that its web API clients don't share a single quota
+	if !node.IsSkipped(faucet.Plugin()) {
+		var refusalErr *faucetpkg.RefusalError
+		if err := faucet.CheckRequest(faucetPayload, messagelayer.Tangle().Options.Identity.ID()); errors.As(err, &refusalErr) {
+			return refuse(c, refusalErr)
+		}
+	}
+
 	msg, err := messagelayer.Tangle().MessageFactory.IssuePayload(faucetPayload)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, jsonmodels.FaucetResponse{Error: fmt.Sprintf("Failed to send faucetrequest: %s", err.Error())})
@@ -77,3 +91,37 @@ func requestFunds(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, jsonmodels.FaucetResponse{ID: msg.ID().Base58()})
 }
+
+// requestStatus returns the processing state of the funding request contained in the message with the given ID. It is
+// only available on the faucet node.
+func requestStatus(c echo.Context) error {
+	if node.IsSkipped(faucet.Plugin()) {
+		return c.JSON(http.StatusNotImplemented, jsonmodels.FaucetRequestStatusResponse{Error: "the faucet is not enabled on this node"})
+	}
+
+	messageID, err := tangle.NewMessageID(c.Param("messageID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.FaucetRequestStatusResponse{Error: err.Error()})
+	}
+
+	request, position, err := faucet.Queue().Request(messageID)
+	if err != nil {
+		if errors.Is(err, faucetpkg.ErrRequestNotFound) {
+			return c.JSON(http.StatusNotFound, jsonmodels.FaucetRequestStatusResponse{Error: err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, jsonmodels.FaucetRequestStatusResponse{Error: err.Error()})
+	}
+	return c.JSON(http.StatusOK, jsonmodels.NewFaucetRequestStatusResponse(request, position))
+}
+
+// refuse responds with the reason why the faucet refused a request.
+func refuse(c echo.Context, refusalErr *faucetpkg.RefusalError) error {
+	response := jsonmodels.NewFaucetRefusalResponse(refusalErr)
+	if refusalErr.Reason == faucetpkg.RefusalInsufficientPoW {
+		return c.JSON(http.StatusBadRequest, response)
+	}
+	if response.RetryAfter > 0 {
+		c.Response().Header().Set("Retry-After", strconv.FormatInt(response.RetryAfter, 10))
+	}
+	return c.JSON(http.StatusTooManyRequests, response)
+}
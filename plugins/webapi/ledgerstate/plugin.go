@@ -3,6 +3,7 @@ package ledgerstate
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
+	"github.com/mr-tron/base58"
 
 	"github.com/iotaledger/goshimmer/packages/clock"
 	"github.com/iotaledger/goshimmer/packages/consensus/fcob"
@@ -30,6 +32,12 @@ import (
 const (
 	PluginName                       = "WebAPI ledgerstate Endpoint"
 	DoubleSpendFilterCleanupInterval = 10 * time.Second
+
+	// defaultAddressHistoryLimit is the amount of history entries that are returned if no limit is given.
+	defaultAddressHistoryLimit = 100
+
+	// maxAddressHistoryLimit is the maximum amount of history entries that are returned at once.
+	maxAddressHistoryLimit = 1000
 )
 
 var (
@@ -86,6 +94,7 @@ func run(*node.Plugin) {
 	// register endpoints
 	webapi.Server().GET("ledgerstate/addresses/:address", GetAddress)
 	webapi.Server().GET("ledgerstate/addresses/:address/unspentOutputs", GetAddressUnspentOutputs)
+	webapi.Server().GET("ledgerstate/addresses/:address/history", GetAddressHistory)
 	webapi.Server().POST("ledgerstate/addresses/unspentOutputs", PostAddressUnspentOutputs)
 	webapi.Server().GET("ledgerstate/branches/:branchID", GetBranch)
 	webapi.Server().GET("ledgerstate/branches/:branchID/children", GetBranchChildren)
@@ -125,7 +134,7 @@ func worker(shutdownSignal <-chan struct{}) {
 
 // GetAddress is the handler for the /ledgerstate/addresses/:address endpoint.
 func GetAddress(c echo.Context) error {
-	address, err := ledgerstate.AddressFromBase58EncodedString(c.Param("address"))
+	address, err := ledgerstate.AddressFromString(c.Param("address"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
@@ -142,7 +151,7 @@ func GetAddress(c echo.Context) error {
 
 // GetAddressUnspentOutputs is the handler for the /ledgerstate/addresses/:address/unspentOutputs endpoint.
 func GetAddressUnspentOutputs(c echo.Context) error {
-	address, err := ledgerstate.AddressFromBase58EncodedString(c.Param("address"))
+	address, err := ledgerstate.AddressFromString(c.Param("address"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
@@ -161,6 +170,58 @@ func GetAddressUnspentOutputs(c echo.Context) error {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region GetAddressHistory ////////////////////////////////////////////////////////////////////////////////////////////
+
+// GetAddressHistory is the handler for the /ledgerstate/addresses/:address/history endpoint. It returns the entries in
+// chronological order and supports pagination with the limit and cursor query parameters.
+func GetAddressHistory(c echo.Context) error {
+	address, err := ledgerstate.AddressFromString(c.Param("address"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	limit := defaultAddressHistoryLimit
+	if c.QueryParam("limit") != "" {
+		if limit, err = strconv.Atoi(c.QueryParam("limit")); err != nil || limit <= 0 || limit > maxAddressHistoryLimit {
+			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("limit must be a number between 1 and %d", maxAddressHistoryLimit)))
+		}
+	}
+
+	var cursor []byte
+	if c.QueryParam("cursor") != "" {
+		if cursor, err = base58.Decode(c.QueryParam("cursor")); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("failed to decode cursor: %w", err)))
+		}
+	}
+
+	addressHistoryEntries, nextCursor, err := messagelayer.Tangle().LedgerState.AddressHistory(address, cursor, limit)
+	if err != nil {
+		if errors.Is(err, ledgerstate.ErrAddressHistoryDisabled) {
+			return c.JSON(http.StatusServiceUnavailable, jsonmodels.NewErrorResponse(err))
+		}
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+	}
+
+	response := &jsonmodels.GetAddressHistoryResponse{
+		Address: jsonmodels.NewAddress(address),
+		Entries: make([]*jsonmodels.AddressHistoryEntry, 0),
+	}
+	if nextCursor != nil {
+		response.NextCursor = base58.Encode(nextCursor)
+	}
+	for _, addressHistoryEntry := range addressHistoryEntries {
+		inclusionState, err := messagelayer.Tangle().LedgerState.TransactionInclusionState(addressHistoryEntry.TransactionID())
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
+		}
+		response.Entries = append(response.Entries, jsonmodels.NewAddressHistoryEntry(addressHistoryEntry, inclusionState))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region PostAddressUnspentOutputs /////////////////////////////////////////////////////////////////////////////////////
 
 // PostAddressUnspentOutputs is the handler for the /ledgerstate/addresses/unspentOutputs endpoint.
@@ -172,7 +233,7 @@ func PostAddressUnspentOutputs(c echo.Context) error {
 	addresses := make([]ledgerstate.Address, len(req.Addresses))
 	for i, addressString := range req.Addresses {
 		var err error
-		addresses[i], err = ledgerstate.AddressFromBase58EncodedString(addressString)
+		addresses[i], err = ledgerstate.AddressFromString(addressString)
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 		}
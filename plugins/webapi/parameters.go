@@ -13,6 +13,30 @@ const (
 	CfgBasicAuthUsername = "webapi.basic_auth.username"
 	// CfgBasicAuthPassword defines the config flag of the webapi basic auth password.
 	CfgBasicAuthPassword = "webapi.basic_auth.password"
+	// CfgAuthEnabled defines the config flag of the webapi token auth enabler.
+	CfgAuthEnabled = "webapi.auth.enabled"
+	// CfgAuthTokens defines the config flag of the webapi API tokens.
+	CfgAuthTokens = "webapi.auth.tokens"
+	// CfgRateLimitEnabled defines the config flag of the webapi rate limit enabler.
+	CfgRateLimitEnabled = "webapi.rateLimit.enabled"
+	// CfgRateLimitReadRate defines the config flag of the requests per second of a caller to the read routes.
+	CfgRateLimitReadRate = "webapi.rateLimit.read.rate"
+	// CfgRateLimitReadBurst defines the config flag of the burst of a caller to the read routes.
+	CfgRateLimitReadBurst = "webapi.rateLimit.read.burst"
+	// CfgRateLimitDiagnosticsRate defines the config flag of the requests per second of a caller to the tools routes.
+	CfgRateLimitDiagnosticsRate = "webapi.rateLimit.diagnostics.rate"
+	// CfgRateLimitDiagnosticsBurst defines the config flag of the burst of a caller to the tools routes.
+	CfgRateLimitDiagnosticsBurst = "webapi.rateLimit.diagnostics.burst"
+	// CfgRateLimitIssuanceRate defines the config flag of the requests per second of a caller to the issuance routes.
+	CfgRateLimitIssuanceRate = "webapi.rateLimit.issuance.rate"
+	// CfgRateLimitIssuanceBurst defines the config flag of the burst of a caller to the issuance routes.
+	CfgRateLimitIssuanceBurst = "webapi.rateLimit.issuance.burst"
+	// CfgRateLimitAllowedIPs defines the config flag of the IPs and CIDRs that are exempt from the rate limit.
+	CfgRateLimitAllowedIPs = "webapi.rateLimit.allowedIPs"
+	// CfgRateLimitAllowedTokens defines the config flag of the API token names that are exempt from the rate limit.
+	CfgRateLimitAllowedTokens = "webapi.rateLimit.allowedTokens"
+	// CfgRateLimitTrustedProxies defines the config flag of the IPs and CIDRs of the proxies whose headers are trusted.
+	CfgRateLimitTrustedProxies = "webapi.rateLimit.trustedProxies"
 )
 
 func init() {
@@ -20,4 +44,16 @@ func init() {
 	flag.Bool(CfgBasicAuthEnabled, false, "whether to enable HTTP basic auth")
 	flag.String(CfgBasicAuthUsername, "goshimmer", "HTTP basic auth username")
 	flag.String(CfgBasicAuthPassword, "goshimmer", "HTTP basic auth password")
+	flag.Bool(CfgAuthEnabled, false, "whether to require API tokens (replaces HTTP basic auth)")
+	flag.StringSlice(CfgAuthTokens, []string{}, "the API tokens in the format name:role:sha256(token) with the roles read-only, issuer or admin")
+	flag.Bool(CfgRateLimitEnabled, false, "whether to limit the requests per caller")
+	flag.Float64(CfgRateLimitReadRate, 20, "the requests per second of a caller to the read routes (0 disables the limit)")
+	flag.Int(CfgRateLimitReadBurst, 40, "the amount of requests a caller can burst to the read routes")
+	flag.Float64(CfgRateLimitDiagnosticsRate, 0.1, "the requests per second of a caller to the tools routes (0 disables the limit)")
+	flag.Int(CfgRateLimitDiagnosticsBurst, 2, "the amount of requests a caller can burst to the tools routes")
+	flag.Float64(CfgRateLimitIssuanceRate, 2, "the requests per second of a caller to the issuance routes (0 disables the limit)")
+	flag.Int(CfgRateLimitIssuanceBurst, 10, "the amount of requests a caller can burst to the issuance routes")
+	flag.StringSlice(CfgRateLimitAllowedIPs, []string{"127.0.0.1", "::1"}, "the IPs and CIDRs that are exempt from the rate limit")
+	flag.StringSlice(CfgRateLimitAllowedTokens, []string{}, "the names of the API tokens that are exempt from the rate limit")
+	flag.StringSlice(CfgRateLimitTrustedProxies, []string{}, "the IPs and CIDRs of the reverse proxies whose X-Forwarded-For and X-Real-IP headers identify the caller")
 }
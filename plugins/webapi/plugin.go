@@ -50,8 +50,14 @@ func Server() *echo.Echo {
 			AllowMethods: []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete},
 		}))
 
-		// if enabled, configure basic-auth
-		if config.Node().Bool(CfgBasicAuthEnabled) {
+		// if enabled, configure token auth (which replaces basic-auth) or basic-auth
+		if config.Node().Bool(CfgAuthEnabled) {
+			apiTokens, err := APITokensFromStrings(config.Node().Strings(CfgAuthTokens))
+			if err != nil {
+				panic(fmt.Sprintf("failed to parse %s: %s", CfgAuthTokens, err))
+			}
+			server.Use(tokenAuth(apiTokens))
+		} else if config.Node().Bool(CfgBasicAuthEnabled) {
 			server.Use(middleware.BasicAuth(func(username, password string, c echo.Context) (bool, error) {
 				if username == config.Node().String(CfgBasicAuthUsername) &&
 					password == config.Node().String(CfgBasicAuthPassword) {
@@ -61,30 +67,46 @@ func Server() *echo.Echo {
 			}))
 		}
 
+		// if enabled, limit the requests per caller (after the auth, so that callers can be identified by their token)
+		if config.Node().Bool(CfgRateLimitEnabled) {
+			var err error
+			if rateLimiter, err = NewRateLimiter(
+				RateLimit{Rate: config.Node().Float64(CfgRateLimitReadRate), Burst: float64(config.Node().Int(CfgRateLimitReadBurst))},
+				RateLimit{Rate: config.Node().Float64(CfgRateLimitDiagnosticsRate), Burst: float64(config.Node().Int(CfgRateLimitDiagnosticsBurst))},
+				RateLimit{Rate: config.Node().Float64(CfgRateLimitIssuanceRate), Burst: float64(config.Node().Int(CfgRateLimitIssuanceBurst))},
+				config.Node().Strings(CfgRateLimitAllowedIPs),
+				config.Node().Strings(CfgRateLimitAllowedTokens),
+				config.Node().Strings(CfgRateLimitTrustedProxies),
+			); err != nil {
+				panic(fmt.Sprintf("failed to configure the rate limit: %s", err))
+			}
+			server.Use(rateLimiter.Middleware())
+		}
+
 		server.HTTPErrorHandler = func(err error, c echo.Context) {
 			log.Warnf("Request failed: %s", err)
 
 			var statusCode int
 			var message string
 
-			switch errors.Unwrap(err) {
-			case echo.ErrUnauthorized:
+			switch {
+			case errors.Is(err, echo.ErrUnauthorized):
 				statusCode = http.StatusUnauthorized
 				message = "unauthorized"
 
-			case echo.ErrForbidden:
+			case errors.Is(err, echo.ErrForbidden):
 				statusCode = http.StatusForbidden
 				message = "access forbidden"
 
-			case echo.ErrInternalServerError:
+			case errors.Is(err, echo.ErrInternalServerError):
 				statusCode = http.StatusInternalServerError
 				message = "internal server error"
 
-			case echo.ErrNotFound:
+			case errors.Is(err, echo.ErrNotFound):
 				statusCode = http.StatusNotFound
 				message = "not found"
 
-			case echo.ErrBadRequest:
+			case errors.Is(err, echo.ErrBadRequest):
 				statusCode = http.StatusBadRequest
 				message = "bad request"
 
@@ -110,6 +132,10 @@ func configure(*node.Plugin) {
 }
 
 func run(*node.Plugin) {
+	if config.Node().Bool(CfgAuthEnabled) {
+		checkRoutePermissions()
+	}
+
 	log.Infof("Starting %s ...", PluginName)
 	if err := daemon.BackgroundWorker("WebAPI server", worker, shutdown.PriorityWebAPI); err != nil {
 		log.Panicf("Failed to start as daemon: %s", err)
@@ -122,7 +148,7 @@ func worker(shutdownSignal <-chan struct{}) {
 	stopped := make(chan struct{})
 	bindAddr := config.Node().String(CfgBindAddress)
 	go func() {
-		log.Infof("%s started, bind-address=%s, basic-auth=%v", PluginName, bindAddr, config.Node().Bool(CfgBasicAuthEnabled))
+		log.Infof("%s started, bind-address=%s, basic-auth=%v, token-auth=%v, rate-limit=%v", PluginName, bindAddr, config.Node().Bool(CfgBasicAuthEnabled) && !config.Node().Bool(CfgAuthEnabled), config.Node().Bool(CfgAuthEnabled), config.Node().Bool(CfgRateLimitEnabled))
 		if err := server.Start(bindAddr); err != nil {
 			if !errors.Is(err, http.ErrServerClosed) {
 				log.Errorf("Error serving: %s", err)
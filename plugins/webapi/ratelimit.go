@@ -0,0 +1,349 @@
+package webapi
+
+import (
+	"container/list"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/labstack/echo"
+	"go.uber.org/atomic"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+)
+
+// region RateLimitClass ///////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// ReadRateLimitClass contains the routes that are cheap to serve.
+	ReadRateLimitClass RateLimitClass = iota
+
+	// DiagnosticsRateLimitClass contains the routes that are expensive to serve (i.e. tools and diagnostics).
+	DiagnosticsRateLimitClass
+
+	// IssuanceRateLimitClass contains the routes that issue messages or transactions.
+	IssuanceRateLimitClass
+
+	// rateLimitClassCount contains the amount of RateLimitClasses.
+	rateLimitClassCount
+)
+
+// RateLimitClass represents a group of routes that share the same rate limit budget.
+type RateLimitClass uint8
+
+// RouteRateLimitClass returns the RateLimitClass of the route with the given method and path (as it was registered).
+func RouteRateLimitClass(method, path string) RateLimitClass {
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if strings.HasPrefix(path, "/tools/") || path == "/snapshot" || path == "/database/backup" {
+		return DiagnosticsRateLimitClass
+	}
+	if permission, _ := RoutePermission(method, path); permission == IssuePermission {
+		return IssuanceRateLimitClass
+	}
+
+	return ReadRateLimitClass
+}
+
+// String returns the name of the RateLimitClass.
+func (r RateLimitClass) String() string {
+	return [...]string{
+		"read",
+		"diagnostics",
+		"issuance",
+	}[r]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RateLimit ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RateLimit defines the budget of a RateLimitClass as a token bucket that is refilled with Rate requests per second and
+// that holds up to Burst requests (at least 1). A Rate of 0 disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst float64
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RateLimiter //////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// rateLimiterMaxBuckets defines the amount of buckets after which the least recently used bucket is removed.
+	rateLimiterMaxBuckets = 100000
+
+	// rateLimiterIPv6PrefixLength defines the prefix length of the IPv6 networks that share a bucket, as a single
+	// client usually controls a complete /64 network.
+	rateLimiterIPv6PrefixLength = 64
+
+	// headerRetryAfter is the header that tells rate limited callers how many seconds to wait before retrying.
+	headerRetryAfter = "Retry-After"
+)
+
+// RateLimiter limits the requests of every caller (identified by its API token, its IPv4 address or its IPv6 /64
+// network) separately for every RateLimitClass. The buckets are kept in the order of their last use, so idle buckets
+// and (if there are too many callers) the least recently used buckets can be removed in constant time.
+type RateLimiter struct {
+	limits         [rateLimitClassCount]RateLimit
+	allowedIPs     []*net.IPNet
+	trustedProxies []*net.IPNet
+	allowedTokens  map[string]bool
+	buckets        map[rateLimitBucketKey]*list.Element
+	recentlyUsed   *list.List
+	maxBuckets     int
+	rejected       [rateLimitClassCount]atomic.Uint64
+	mutex          sync.Mutex
+}
+
+// NewRateLimiter creates a new RateLimiter with the given limits for the read, diagnostics and issuance routes. Callers
+// whose IP is contained in one of the allowedIPs (IPs or CIDRs) or who use an API token with one of the allowedTokens
+// names are not limited. The proxy headers that contain the IP of the caller are only honored for requests that are
+// received from one of the trustedProxies (IPs or CIDRs).
+func NewRateLimiter(read, diagnostics, issuance RateLimit, allowedIPs, allowedTokens, trustedProxies []string) (rateLimiter *RateLimiter, err error) {
+	rateLimiter = &RateLimiter{
+		limits:        [rateLimitClassCount]RateLimit{read, diagnostics, issuance},
+		allowedTokens: make(map[string]bool, len(allowedTokens)),
+		buckets:       make(map[rateLimitBucketKey]*list.Element),
+		recentlyUsed:  list.New(),
+		maxBuckets:    rateLimiterMaxBuckets,
+	}
+
+	for class, limit := range rateLimiter.limits {
+		if limit.Rate > 0 && limit.Burst < 1 {
+			return nil, errors.Errorf("burst of %s requests needs to be at least 1 (got %v)", RateLimitClass(class), limit.Burst)
+		}
+	}
+
+	if rateLimiter.allowedIPs, err = parseIPNets(allowedIPs); err != nil {
+		return nil, errors.Errorf("failed to parse allowed IPs: %w", err)
+	}
+	if rateLimiter.trustedProxies, err = parseIPNets(trustedProxies); err != nil {
+		return nil, errors.Errorf("failed to parse trusted proxies: %w", err)
+	}
+	for _, allowedToken := range allowedTokens {
+		rateLimiter.allowedTokens[allowedToken] = true
+	}
+
+	return rateLimiter, nil
+}
+
+// Allow consumes a request of the given caller from the budget of the RateLimitClass. If the budget is exhausted, it
+// returns false together with the time after which the next request will be allowed.
+func (r *RateLimiter) Allow(class RateLimitClass, caller string, now time.Time) (allowed bool, retryAfter time.Duration) {
+	limit := r.limits[class]
+	if limit.Rate <= 0 {
+		return true, 0
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.removeIdleBuckets(now)
+
+	key := rateLimitBucketKey{class: class, caller: caller}
+	element, exists := r.buckets[key]
+	if exists {
+		r.recentlyUsed.MoveToFront(element)
+	} else {
+		if len(r.buckets) >= r.maxBuckets {
+			r.removeBucket(r.recentlyUsed.Back())
+		}
+		element = r.recentlyUsed.PushFront(&rateLimitBucket{key: key, tokens: limit.Burst, lastUpdate: now})
+		r.buckets[key] = element
+	}
+	bucket := element.Value.(*rateLimitBucket)
+	bucket.refill(limit, now)
+
+	if bucket.tokens < 1 {
+		r.rejected[class].Inc()
+		return false, time.Duration((1 - bucket.tokens) / limit.Rate * float64(time.Second))
+	}
+	bucket.tokens--
+
+	return true, 0
+}
+
+// Allowed returns true if the caller with the given IP and API token (may be nil) is exempt from the limits.
+func (r *RateLimiter) Allowed(ip net.IP, apiToken *APIToken) bool {
+	if apiToken != nil && r.allowedTokens[apiToken.Name] {
+		return true
+	}
+
+	return containsIP(r.allowedIPs, ip)
+}
+
+// CallerIP returns the IP of the caller of the given request. The X-Forwarded-For and X-Real-IP headers can be set by
+// anyone, so they are only honored if the request was received from one of the trusted proxies.
+func (r *RateLimiter) CallerIP(request *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		host = request.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if !containsIP(r.trustedProxies, ip) {
+		return ip
+	}
+
+	if forwardedFor := request.Header.Get(echo.HeaderXForwardedFor); forwardedFor != "" {
+		// every proxy appends the IP it received the request from, so we walk the chain backwards until we reach the
+		// first hop that is not one of our proxies
+		forwardedIPs := strings.Split(forwardedFor, ",")
+		for i := len(forwardedIPs) - 1; i >= 0; i-- {
+			forwardedIP := net.ParseIP(strings.TrimSpace(forwardedIPs[i]))
+			if forwardedIP == nil {
+				break
+			}
+			if ip = forwardedIP; !containsIP(r.trustedProxies, ip) {
+				break
+			}
+		}
+
+		return ip
+	}
+	if realIP := net.ParseIP(strings.TrimSpace(request.Header.Get(echo.HeaderXRealIP))); realIP != nil {
+		return realIP
+	}
+
+	return ip
+}
+
+// RejectedRequests returns the amount of requests of the given RateLimitClass that were rejected.
+func (r *RateLimiter) RejectedRequests(class RateLimitClass) uint64 {
+	return r.rejected[class].Load()
+}
+
+// Middleware returns a middleware that rejects requests that exceed the budget of their caller with 429 Too Many
+// Requests.
+func (r *RateLimiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ip := r.CallerIP(c.Request())
+			apiToken, _ := c.Get(apiTokenContextKey).(*APIToken)
+			if r.Allowed(ip, apiToken) {
+				return next(c)
+			}
+
+			caller := "ip:" + rateLimitIP(ip)
+			if apiToken != nil {
+				caller = "token:" + apiToken.Name
+			}
+
+			class := RouteRateLimitClass(c.Request().Method, c.Path())
+			if allowed, retryAfter := r.Allow(class, caller, time.Now()); !allowed {
+				c.Response().Header().Set(headerRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				return c.JSON(http.StatusTooManyRequests, jsonmodels.NewErrorResponse(errors.Errorf("rate limit of %s requests exceeded", class)))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// removeIdleBuckets removes the least recently used buckets as long as they were refilled completely, as their callers
+// have been idle.
+func (r *RateLimiter) removeIdleBuckets(now time.Time) {
+	for element := r.recentlyUsed.Back(); element != nil; element = r.recentlyUsed.Back() {
+		bucket := element.Value.(*rateLimitBucket)
+		if bucket.refill(r.limits[bucket.key.class], now); bucket.tokens < r.limits[bucket.key.class].Burst {
+			return
+		}
+		r.removeBucket(element)
+	}
+}
+
+// removeBucket removes the bucket of the given element.
+func (r *RateLimiter) removeBucket(element *list.Element) {
+	delete(r.buckets, r.recentlyUsed.Remove(element).(*rateLimitBucket).key)
+}
+
+// rateLimitIP returns the part of the IP that identifies a caller: IPv4 addresses are used as they are, IPv6 addresses
+// are reduced to their /64 network.
+func rateLimitIP(ip net.IP) string {
+	if ip == nil || ip.To4() != nil {
+		return ip.String()
+	}
+
+	mask := net.CIDRMask(rateLimiterIPv6PrefixLength, 8*net.IPv6len)
+
+	return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+}
+
+// parseIPNets parses the given IPs and CIDRs.
+func parseIPNets(ips []string) (ipNets []*net.IPNet, err error) {
+	for _, ip := range ips {
+		if !strings.Contains(ip, "/") {
+			if strings.Contains(ip, ":") {
+				ip += "/128"
+			} else {
+				ip += "/32"
+			}
+		}
+		_, ipNet, parseErr := net.ParseCIDR(ip)
+		if parseErr != nil {
+			return nil, errors.Errorf("failed to parse IP '%s': %w", ip, parseErr)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+
+	return ipNets, nil
+}
+
+// containsIP returns true if the IP is contained in one of the given IPNets.
+func containsIP(ipNets []*net.IPNet, ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rateLimitBucketKey identifies the bucket of a caller for a RateLimitClass.
+type rateLimitBucketKey struct {
+	class  RateLimitClass
+	caller string
+}
+
+// rateLimitBucket is a token bucket that holds the remaining budget of a caller.
+type rateLimitBucket struct {
+	key        rateLimitBucketKey
+	tokens     float64
+	lastUpdate time.Time
+}
+
+// refill adds the tokens that accumulated since the last update.
+func (r *rateLimitBucket) refill(limit RateLimit, now time.Time) {
+	if elapsed := now.Sub(r.lastUpdate); elapsed > 0 {
+		r.tokens = math.Min(limit.Burst, r.tokens+elapsed.Seconds()*limit.Rate)
+		r.lastUpdate = now
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Metrics //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// rateLimiter contains the RateLimiter of the server (nil if rate limiting is disabled).
+var rateLimiter *RateLimiter
+
+// RateLimitedRequests returns the amount of requests of the given RateLimitClass that were rejected by the rate limiter.
+func RateLimitedRequests(class RateLimitClass) uint64 {
+	if rateLimiter == nil {
+		return 0
+	}
+
+	return rateLimiter.RejectedRequests(class)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,149 @@
+package webapi
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteRateLimitClass(t *testing.T) {
+	assert.Equal(t, ReadRateLimitClass, RouteRateLimitClass(http.MethodGet, "/info"))
+	assert.Equal(t, ReadRateLimitClass, RouteRateLimitClass(http.MethodGet, "ledgerstate/addresses/:address"))
+	assert.Equal(t, DiagnosticsRateLimitClass, RouteRateLimitClass(http.MethodGet, "tools/message/pastcone"))
+	assert.Equal(t, DiagnosticsRateLimitClass, RouteRateLimitClass(http.MethodGet, "/tools/diagnostic/messages"))
+	assert.Equal(t, IssuanceRateLimitClass, RouteRateLimitClass(http.MethodPost, "messages/payload"))
+	assert.Equal(t, IssuanceRateLimitClass, RouteRateLimitClass(http.MethodPost, "/ledgerstate/transactions"))
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	rateLimiter, err := NewRateLimiter(RateLimit{Rate: 1, Burst: 2}, RateLimit{Rate: 0.5, Burst: 1}, RateLimit{}, nil, nil, nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for i := 0; i < 2; i++ {
+		allowed, _ := rateLimiter.Allow(ReadRateLimitClass, "ip:1.2.3.4", now)
+		assert.True(t, allowed)
+	}
+	allowed, retryAfter := rateLimiter.Allow(ReadRateLimitClass, "ip:1.2.3.4", now)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, retryAfter)
+
+	// other callers and classes have separate budgets
+	allowed, _ = rateLimiter.Allow(ReadRateLimitClass, "ip:5.6.7.8", now)
+	assert.True(t, allowed)
+	allowed, _ = rateLimiter.Allow(DiagnosticsRateLimitClass, "ip:1.2.3.4", now)
+	assert.True(t, allowed)
+	allowed, retryAfter = rateLimiter.Allow(DiagnosticsRateLimitClass, "ip:1.2.3.4", now)
+	assert.False(t, allowed)
+	assert.Equal(t, 2*time.Second, retryAfter)
+
+	// a rate of 0 disables the limit
+	for i := 0; i < 100; i++ {
+		allowed, _ = rateLimiter.Allow(IssuanceRateLimitClass, "ip:1.2.3.4", now)
+		assert.True(t, allowed)
+	}
+
+	// the bucket is refilled over time
+	allowed, _ = rateLimiter.Allow(ReadRateLimitClass, "ip:1.2.3.4", now.Add(500*time.Millisecond))
+	assert.False(t, allowed)
+	allowed, _ = rateLimiter.Allow(ReadRateLimitClass, "ip:1.2.3.4", now.Add(time.Second))
+	assert.True(t, allowed)
+
+	assert.Equal(t, uint64(2), rateLimiter.RejectedRequests(ReadRateLimitClass))
+	assert.Equal(t, uint64(1), rateLimiter.RejectedRequests(DiagnosticsRateLimitClass))
+	assert.Equal(t, uint64(0), rateLimiter.RejectedRequests(IssuanceRateLimitClass))
+
+	// the buckets of idle callers are removed
+	rateLimiter.Allow(ReadRateLimitClass, "ip:9.9.9.9", now.Add(time.Minute))
+	assert.Len(t, rateLimiter.buckets, 1)
+
+	// the least recently used buckets are removed if there are too many callers
+	rateLimiter.maxBuckets = 2
+	rateLimiter.Allow(ReadRateLimitClass, "ip:1.1.1.1", now.Add(time.Minute))
+	rateLimiter.Allow(ReadRateLimitClass, "ip:2.2.2.2", now.Add(time.Minute))
+	assert.Len(t, rateLimiter.buckets, 2)
+	assert.NotContains(t, rateLimiter.buckets, rateLimitBucketKey{class: ReadRateLimitClass, caller: "ip:9.9.9.9"})
+
+	// a burst below 1 would reject all requests
+	_, err = NewRateLimiter(RateLimit{Rate: 1, Burst: 0.5}, RateLimit{}, RateLimit{}, nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestRateLimitIP(t *testing.T) {
+	assert.Equal(t, "1.2.3.4", rateLimitIP(net.ParseIP("1.2.3.4")))
+	assert.Equal(t, "2001:db8:1:2::/64", rateLimitIP(net.ParseIP("2001:db8:1:2:3:4:5:6")))
+	assert.Equal(t, rateLimitIP(net.ParseIP("2001:db8:1:2::1")), rateLimitIP(net.ParseIP("2001:db8:1:2:ffff::1")))
+}
+
+func TestRateLimiter_CallerIP(t *testing.T) {
+	rateLimiter, err := NewRateLimiter(RateLimit{}, RateLimit{}, RateLimit{}, []string{"127.0.0.1"}, nil, []string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	newRequest := func(remoteAddr, forwardedFor, realIP string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/info", nil)
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		}
+		if realIP != "" {
+			req.Header.Set(echo.HeaderXRealIP, realIP)
+		}
+
+		return req
+	}
+
+	// the headers of untrusted peers are ignored, so they can't pretend to be allowed
+	assert.Equal(t, "1.2.3.4", rateLimiter.CallerIP(newRequest("1.2.3.4:1234", "127.0.0.1", "127.0.0.1")).String())
+	assert.False(t, rateLimiter.Allowed(rateLimiter.CallerIP(newRequest("1.2.3.4:1234", "127.0.0.1", "")), nil))
+
+	// trusted proxies are skipped, but a spoofed entry in front of the caller is not used
+	assert.Equal(t, "5.6.7.8", rateLimiter.CallerIP(newRequest("10.0.0.1:1234", "127.0.0.1, 5.6.7.8, 10.0.0.2", "")).String())
+	assert.Equal(t, "5.6.7.8", rateLimiter.CallerIP(newRequest("10.0.0.1:1234", "", "5.6.7.8")).String())
+	assert.Equal(t, "10.0.0.1", rateLimiter.CallerIP(newRequest("10.0.0.1:1234", "", "")).String())
+	assert.Equal(t, "10.0.0.2", rateLimiter.CallerIP(newRequest("10.0.0.1:1234", "not-an-ip, 10.0.0.2", "")).String())
+}
+
+func TestRateLimiter_Allowed(t *testing.T) {
+	rateLimiter, err := NewRateLimiter(RateLimit{}, RateLimit{}, RateLimit{}, []string{"127.0.0.1", "::1", "10.0.0.0/8"}, []string{"dashboard"}, nil)
+	require.NoError(t, err)
+
+	assert.True(t, rateLimiter.Allowed(net.ParseIP("127.0.0.1"), nil))
+	assert.True(t, rateLimiter.Allowed(net.ParseIP("::1"), nil))
+	assert.True(t, rateLimiter.Allowed(net.ParseIP("10.1.2.3"), nil))
+	assert.True(t, rateLimiter.Allowed(net.ParseIP("1.2.3.4"), &APIToken{Name: "dashboard"}))
+	assert.False(t, rateLimiter.Allowed(net.ParseIP("1.2.3.4"), &APIToken{Name: "explorer"}))
+	assert.False(t, rateLimiter.Allowed(nil, nil))
+
+	_, err = NewRateLimiter(RateLimit{}, RateLimit{}, RateLimit{}, []string{"not-an-ip"}, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestRateLimiter_Middleware(t *testing.T) {
+	rateLimiter, err := NewRateLimiter(RateLimit{Rate: 0.25, Burst: 1}, RateLimit{}, RateLimit{}, nil, nil, nil)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.GET("/info", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	request := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/info", nil)
+		req.RemoteAddr = "1.2.3.4:1234"
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		e.Router().Find(http.MethodGet, "/info", c)
+		require.NoError(t, rateLimiter.Middleware()(c.Handler())(c))
+
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, request().Code)
+	rec := request()
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "4", rec.Header().Get("Retry-After"))
+}
@@ -1,11 +1,20 @@
 package snapshot
 
 import (
+	"bytes"
+	"io"
+	"net/http"
 	"os"
+	"sort"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 	"github.com/iotaledger/goshimmer/packages/mana"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/discovery"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
 	"github.com/iotaledger/goshimmer/plugins/webapi"
 
@@ -17,7 +26,8 @@ import (
 // region Plugin ///////////////////////////////////////////////////////////////////////////////////////////////////////
 
 const (
-	snapshotFileName = "snapshot.bin"
+	snapshotFileName      = "snapshot.bin"
+	deltaSnapshotFileName = "snapshot-delta.bin"
 )
 
 var (
@@ -43,60 +53,98 @@ func Plugin() *node.Plugin {
 
 // region DumpCurrentLedger ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-// DumpCurrentLedger dumps a snapshot (all unspent UTXO and all of the access mana) from now.
+// DumpCurrentLedger dumps a snapshot (all unspent UTXO and all of the access and consensus mana) from now. If the query
+// parameter "delta" is set to true, only the changes since the snapshot that the node was started from are dumped.
 func DumpCurrentLedger(c echo.Context) (err error) {
-	snapshot := messagelayer.Tangle().LedgerState.SnapshotUTXO()
-
-	aMana, err := snapshotAccessMana()
-	if err != nil {
-		return err
+	fileName := snapshotFileName
+	var base *ledgerstate.Snapshot
+	if delta, _ := strconv.ParseBool(c.QueryParam("delta")); delta {
+		if base, err = messagelayer.ReadSnapshot(); err != nil {
+			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+		}
+		fileName = deltaSnapshotFileName
 	}
-	snapshot.AccessManaByNode = aMana
 
-	f, err := os.OpenFile(snapshotFileName, os.O_RDWR|os.O_CREATE, 0666)
+	f, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
 	if err != nil {
 		plugin.LogErrorf("unable to create snapshot file %s", err)
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
 	}
+	defer f.Close()
 
-	n, err := snapshot.WriteTo(f)
+	header, err := writeSnapshot(f, base)
 	if err != nil {
 		plugin.LogErrorf("unable to write snapshot content to file %s", err)
+		return c.JSON(http.StatusInternalServerError, jsonmodels.NewErrorResponse(err))
 	}
+	plugin.LogInfof("Snapshot written to %s: %s", fileName, header)
 
-	// plugin.LogInfo(snapshot)
-	plugin.LogInfo("Snapshot information: ")
-	plugin.LogInfo("     Number of snapshotted transactions: ", len(snapshot.Transactions))
-	plugin.LogInfo("          inputs, outputs, txID, unspentOutputs")
-	for key, tx := range snapshot.Transactions {
-		plugin.LogInfo("          ", len(tx.Essence.Inputs()), len(tx.Essence.Outputs()), key)
-		plugin.LogInfo("          ", tx.UnspentOutputs)
-	}
-	plugin.LogInfo("     Number of snapshotted accessManaEntries: ", len(snapshot.AccessManaByNode))
-	plugin.LogInfo("          nodeID, aMana, timestamp")
-	for nodeID, accessMana := range snapshot.AccessManaByNode {
-		plugin.LogInfo("          ", nodeID, accessMana.Value, accessMana.Timestamp)
+	return c.Attachment(fileName, fileName)
+}
+
+// writeSnapshot streams the current ledger state (or the delta to the given base) and mana to the given writer.
+func writeSnapshot(w io.Writer, base *ledgerstate.Snapshot) (header *ledgerstate.SnapshotHeader, err error) {
+	lastConfirmedMessage := messagelayer.Tangle().TimeManager.LastConfirmedMessage()
+	header = &ledgerstate.SnapshotHeader{
+		NetworkID:                discovery.NetworkVersion(),
+		GenesisTime:              time.Unix(tangle.DefaultGenesisTime, 0),
+		SnapshotTime:             time.Now(),
+		LastConfirmedMessageID:   lastConfirmedMessage.MessageID,
+		LastConfirmedMessageTime: lastConfirmedMessage.Time,
 	}
 
-	plugin.LogInfof("Bytes written %d", n)
-	f.Close()
+	var snapshotWriter *ledgerstate.SnapshotWriter
+	if base != nil {
+		snapshotWriter, err = ledgerstate.NewDeltaSnapshotWriter(w, header, base)
+	} else {
+		snapshotWriter, err = ledgerstate.NewSnapshotWriter(w, header)
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	return c.Attachment(snapshotFileName, snapshotFileName)
-}
+	if err = messagelayer.Tangle().LedgerState.WriteSnapshot(snapshotWriter); err != nil {
+		return nil, err
+	}
 
-// snapshotAccessMana returns snapshot of the current access mana.
-func snapshotAccessMana() (aManaSnapshot map[identity.ID]ledgerstate.AccessMana, err error) {
-	aManaSnapshot = make(map[identity.ID]ledgerstate.AccessMana)
+	accessMana, manaTime, err := messagelayer.GetManaMap(mana.AccessMana)
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeID := range sortedNodeIDs(accessMana) {
+		if err = snapshotWriter.WriteAccessMana(nodeID, ledgerstate.AccessMana{Value: accessMana[nodeID], Timestamp: manaTime}); err != nil {
+			return nil, err
+		}
+	}
 
-	m, t, err := messagelayer.GetManaMap(mana.AccessMana)
+	consensusMana, _, err := messagelayer.GetManaMap(mana.ConsensusMana)
 	if err != nil {
 		return nil, err
 	}
-	for nodeID, aMana := range m {
-		aManaSnapshot[nodeID] = ledgerstate.AccessMana{
-			Value:     aMana,
-			Timestamp: t,
+	for _, nodeID := range sortedNodeIDs(consensusMana) {
+		if err = snapshotWriter.WriteConsensusMana(nodeID, consensusMana[nodeID]); err != nil {
+			return nil, err
 		}
 	}
+
+	if err = snapshotWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return snapshotWriter.Header(), nil
+}
+
+// sortedNodeIDs returns the ids of the nodes in the given NodeMap in a deterministic order, so that writing the same
+// state results in the same snapshot.
+func sortedNodeIDs(nodeMap mana.NodeMap) (nodeIDs []identity.ID) {
+	nodeIDs = make([]identity.ID, 0, len(nodeMap))
+	for nodeID := range nodeMap {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool {
+		return bytes.Compare(nodeIDs[i].Bytes(), nodeIDs[j].Bytes()) < 0
+	})
+
 	return
 }
 
@@ -0,0 +1,186 @@
+package subscriptions
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/workerpool"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// publishWorkerCount is the amount of workers that publish the events (a single worker keeps the events in order).
+const publishWorkerCount = 1
+
+var (
+	// errMaxClientsReached is returned if a client tries to connect while the maximum amount of clients is connected.
+	errMaxClientsReached = errors.New("maximum amount of subscription clients reached")
+
+	// publishWorkerPool publishes the events outside of the event handlers of the Tangle.
+	publishWorkerPool *workerpool.NonBlockingQueuedWorkerPool
+)
+
+// submitPublish hands the publishing of an event over to the publishWorkerPool, so that loading the affected objects
+// and delivering them to the clients does not delay the components that trigger the events. Events are dropped if the
+// queue of the publishWorkerPool is full.
+func submitPublish(publish func()) {
+	if !hasClients() {
+		return
+	}
+
+	publishWorkerPool.TrySubmit(publish)
+}
+
+// publishMessage delivers the stored Message to the subscribers of the MessagesTopic.
+func publishMessage(messageID tangle.MessageID) {
+	if !hasClients() {
+		return
+	}
+
+	messagelayer.Tangle().Storage.Message(messageID).Consume(func(message *tangle.Message) {
+		payloadType := message.Payload().Type()
+		event := &jsonmodels.SubscribedMessage{
+			ID:              messageID.Base58(),
+			PayloadType:     uint32(payloadType),
+			IssuerPublicKey: message.IssuerPublicKey().String(),
+			IssuingTime:     message.IssuingTime().Unix(),
+		}
+
+		forEachClient(func(client *wsClient) {
+			client.forEachSubscription(jsonmodels.MessagesTopic, func(s *subscription) {
+				if s.payloadType != nil && *s.payloadType != payloadType {
+					return
+				}
+				client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionEvent, SubscriptionID: s.id, Topic: s.topic, Message: event})
+			})
+		})
+	})
+}
+
+// publishTransaction delivers the Transaction of a booked Message to the subscribers of the TransactionsTopic that watch
+// one of the addresses that it touches. It also reports the inclusion state of newly booked Transactions.
+func publishTransaction(messageID tangle.MessageID) {
+	if !hasClients() {
+		return
+	}
+
+	messagelayer.Tangle().Storage.Message(messageID).Consume(func(message *tangle.Message) {
+		transaction, isTransaction := message.Payload().(*ledgerstate.Transaction)
+		if !isTransaction {
+			return
+		}
+
+		touchedAddresses := make(map[string]bool)
+		for _, output := range transaction.Essence().Outputs() {
+			touchedAddresses[output.Address().Base58()] = true
+		}
+		messagelayer.Tangle().LedgerState.ConsumedOutputs(transaction).Consume(func(output ledgerstate.Output) {
+			touchedAddresses[output.Address().Base58()] = true
+		})
+
+		forEachClient(func(client *wsClient) {
+			client.forEachSubscription(jsonmodels.TransactionsTopic, func(s *subscription) {
+				matchedAddresses := make([]string, 0)
+				for address := range touchedAddresses {
+					if s.addresses[address] {
+						matchedAddresses = append(matchedAddresses, address)
+					}
+				}
+				if len(matchedAddresses) == 0 {
+					return
+				}
+
+				client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionEvent, SubscriptionID: s.id, Topic: s.topic, Transaction: &jsonmodels.SubscribedTransaction{
+					ID:        transaction.ID().Base58(),
+					MessageID: messageID.Base58(),
+					Addresses: matchedAddresses,
+				}})
+			})
+		})
+
+		transactionID := transaction.ID()
+		publishInclusionStates(func(subscribedTransactionID ledgerstate.TransactionID) bool {
+			return subscribedTransactionID == transactionID
+		})
+	})
+}
+
+// publishInclusionStates reports the inclusion states of the Transactions that match the filter to the subscribers of
+// the InclusionStateTopic.
+func publishInclusionStates(filter func(transactionID ledgerstate.TransactionID) bool) {
+	if !hasClients() {
+		return
+	}
+
+	forEachClient(func(client *wsClient) {
+		client.forEachSubscription(jsonmodels.InclusionStateTopic, func(s *subscription) {
+			if filter(s.transactionID) {
+				publishInclusionState(client, s)
+			}
+		})
+	})
+}
+
+// publishInclusionState reports the inclusion state of the subscribed Transaction if it changed since the last report.
+func publishInclusionState(client *wsClient, s *subscription) {
+	inclusionState, err := messagelayer.Tangle().LedgerState.TransactionInclusionState(s.transactionID)
+	if err != nil {
+		// the transaction is not known, yet
+		return
+	}
+	if s.inclusionStateReported && s.lastInclusionState == inclusionState {
+		return
+	}
+
+	if client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionEvent, SubscriptionID: s.id, Topic: s.topic, InclusionState: &jsonmodels.SubscribedInclusionState{
+		TransactionID:  s.transactionID.Base58(),
+		InclusionState: inclusionState.String(),
+	}}) {
+		s.lastInclusionState = inclusionState
+		s.inclusionStateReported = true
+	}
+}
+
+// publishBranch delivers the confirmation or rejection of a Branch to the subscribers of the BranchConfirmationTopic.
+// Since the inclusion state of Transactions depends on their Branch, the inclusion states are re-evaluated as well.
+func publishBranch(branchID ledgerstate.BranchID, inclusionState ledgerstate.InclusionState) {
+	if !hasClients() {
+		return
+	}
+
+	event := &jsonmodels.SubscribedBranch{
+		ID:             branchID.Base58(),
+		InclusionState: inclusionState.String(),
+	}
+	forEachClient(func(client *wsClient) {
+		client.forEachSubscription(jsonmodels.BranchConfirmationTopic, func(s *subscription) {
+			if s.branchID != nil && *s.branchID != branchID {
+				return
+			}
+			client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionEvent, SubscriptionID: s.id, Topic: s.topic, Branch: event})
+		})
+	})
+
+	publishInclusionStates(func(ledgerstate.TransactionID) bool {
+		return true
+	})
+}
+
+// publishTip delivers added and removed tips to the subscribers of the TipsTopic.
+func publishTip(tipEvent *tangle.TipEvent, added bool) {
+	if !hasClients() {
+		return
+	}
+
+	event := &jsonmodels.SubscribedTip{
+		MessageID: tipEvent.MessageID.Base58(),
+		TipType:   tipEvent.TipType.String(),
+		Added:     added,
+	}
+	forEachClient(func(client *wsClient) {
+		client.forEachSubscription(jsonmodels.TipsTopic, func(s *subscription) {
+			client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionEvent, SubscriptionID: s.id, Topic: s.topic, Tip: event})
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package subscriptions
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/configuration"
+)
+
+// Parameters contains the configuration parameters of the web API subscriptions endpoint.
+var Parameters = struct {
+	// MaxClients is the maximum amount of clients that can be connected at the same time.
+	MaxClients int `default:"100" usage:"the maximum amount of clients that can be connected to the subscriptions endpoint at the same time"`
+	// MaxSubscriptionsPerClient is the maximum amount of subscriptions a single client can hold.
+	MaxSubscriptionsPerClient int `default:"32" usage:"the maximum amount of subscriptions of a single client"`
+	// MaxAddressesPerSubscription is the maximum amount of addresses a single transactions subscription can watch.
+	MaxAddressesPerSubscription int `default:"100" usage:"the maximum amount of addresses that a single transactions subscription can watch"`
+	// SendQueueSize is the amount of events that are buffered for a client before events get dropped.
+	SendQueueSize int `default:"1024" usage:"the amount of events that are buffered for a client before events get dropped"`
+	// MaxDroppedEvents is the amount of consecutively dropped events after which a slow client gets disconnected.
+	MaxDroppedEvents int `default:"10000" usage:"the amount of consecutively dropped events after which a slow client gets disconnected"`
+	// WriteTimeout is the timeout for writing a single message to a client.
+	WriteTimeout time.Duration `default:"5s" usage:"the timeout for writing a single message to a client"`
+	// PublishQueueSize is the amount of events that are buffered before they are published to the clients.
+	PublishQueueSize int `default:"10000" usage:"the amount of events that are buffered before they are published to the clients (further events get dropped)"`
+	// AllowedOrigins contains the origins of the web pages that are allowed to connect in addition to the node's own.
+	AllowedOrigins []string `usage:"the origins of the web pages that are allowed to connect to the subscriptions endpoint in addition to the node's own (* allows all)"`
+}{}
+
+func init() {
+	configuration.BindParameters(&Parameters, "webapi.subscriptions")
+}
@@ -0,0 +1,279 @@
+package subscriptions
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/workerpool"
+	"github.com/labstack/echo"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+)
+
+// PluginName is the name of the web API subscriptions endpoint plugin.
+const PluginName = "WebAPI subscriptions Endpoint"
+
+var (
+	// plugin is the plugin instance of the web API subscriptions endpoint plugin.
+	plugin *node.Plugin
+	once   sync.Once
+	log    *logger.Logger
+
+	clients      = make(map[uint64]*wsClient)
+	clientsMutex sync.RWMutex
+	nextClientID uint64
+
+	upgrader = websocket.Upgrader{
+		CheckOrigin:       checkOrigin,
+		EnableCompression: true,
+	}
+)
+
+// Plugin gets the plugin instance.
+func Plugin() *node.Plugin {
+	once.Do(func() {
+		plugin = node.NewPlugin(PluginName, node.Enabled, configure, run)
+	})
+	return plugin
+}
+
+func configure(_ *node.Plugin) {
+	log = logger.NewLogger(PluginName)
+	upgrader.HandshakeTimeout = Parameters.WriteTimeout
+	publishWorkerPool = workerpool.NewNonBlockingQueuedWorkerPool(func(task workerpool.Task) {
+		task.Param(0).(func())()
+
+		task.Return(nil)
+	}, workerpool.WorkerCount(publishWorkerCount), workerpool.QueueSize(Parameters.PublishQueueSize))
+
+	webapi.Server().GET("subscriptions", handleSubscriptions)
+}
+
+func run(*node.Plugin) {
+	onMessageStored := events.NewClosure(func(messageID tangle.MessageID) {
+		submitPublish(func() { publishMessage(messageID) })
+	})
+	onMessageBooked := events.NewClosure(func(messageID tangle.MessageID) {
+		submitPublish(func() { publishTransaction(messageID) })
+	})
+	onTransactionConfirmed := events.NewClosure(func(transactionID ledgerstate.TransactionID) {
+		submitPublish(func() {
+			publishInclusionStates(func(subscribedTransactionID ledgerstate.TransactionID) bool {
+				return subscribedTransactionID == transactionID
+			})
+		})
+	})
+	onBranchConfirmed := events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		branchID := branchDAGEvent.Branch.ID()
+		submitPublish(func() { publishBranch(branchID, ledgerstate.Confirmed) })
+	})
+	onBranchRejected := events.NewClosure(func(branchDAGEvent *ledgerstate.BranchDAGEvent) {
+		defer branchDAGEvent.Release()
+		branchID := branchDAGEvent.Branch.ID()
+		submitPublish(func() { publishBranch(branchID, ledgerstate.Rejected) })
+	})
+	onTipAdded := events.NewClosure(func(tipEvent *tangle.TipEvent) {
+		submitPublish(func() { publishTip(tipEvent, true) })
+	})
+	onTipRemoved := events.NewClosure(func(tipEvent *tangle.TipEvent) {
+		submitPublish(func() { publishTip(tipEvent, false) })
+	})
+
+	if err := daemon.BackgroundWorker("WebAPI subscriptions", func(shutdownSignal <-chan struct{}) {
+		messagelayer.Tangle().Storage.Events.MessageStored.Attach(onMessageStored)
+		messagelayer.Tangle().Booker.Events.MessageBooked.Attach(onMessageBooked)
+		messagelayer.Tangle().LedgerState.UTXODAG.Events.TransactionConfirmed.Attach(onTransactionConfirmed)
+		messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchConfirmed.Attach(onBranchConfirmed)
+		messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchRejected.Attach(onBranchRejected)
+		messagelayer.Tangle().TipManager.Events.TipAdded.Attach(onTipAdded)
+		messagelayer.Tangle().TipManager.Events.TipRemoved.Attach(onTipRemoved)
+
+		<-shutdownSignal
+
+		log.Info("Stopping WebAPI subscriptions ...")
+		messagelayer.Tangle().Storage.Events.MessageStored.Detach(onMessageStored)
+		messagelayer.Tangle().Booker.Events.MessageBooked.Detach(onMessageBooked)
+		messagelayer.Tangle().LedgerState.UTXODAG.Events.TransactionConfirmed.Detach(onTransactionConfirmed)
+		messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchConfirmed.Detach(onBranchConfirmed)
+		messagelayer.Tangle().LedgerState.BranchDAG.Events.BranchRejected.Detach(onBranchRejected)
+		messagelayer.Tangle().TipManager.Events.TipAdded.Detach(onTipAdded)
+		messagelayer.Tangle().TipManager.Events.TipRemoved.Detach(onTipRemoved)
+		publishWorkerPool.Stop()
+
+		clientsMutex.RLock()
+		for _, client := range clients {
+			client.close()
+		}
+		clientsMutex.RUnlock()
+		log.Info("Stopping WebAPI subscriptions ... done")
+	}, shutdown.PriorityWebAPI); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// region connection handling //////////////////////////////////////////////////////////////////////////////////////////
+
+// handleSubscriptions is the handler for the /subscriptions endpoint. It upgrades the connection to a WebSocket and
+// processes the SubscriptionRequests of the client until it disconnects.
+func handleSubscriptions(c echo.Context) error {
+	client, err := registerClient()
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, jsonmodels.NewErrorResponse(err))
+	}
+	defer unregisterClient(client)
+
+	ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	// read the requests of the client in a separate goroutine and disconnect if the connection breaks
+	go func() {
+		defer client.close()
+		for {
+			request := &jsonmodels.SubscriptionRequest{}
+			if readErr := ws.ReadJSON(request); readErr != nil {
+				return
+			}
+			handleRequest(client, request)
+		}
+	}()
+
+	for {
+		select {
+		case response := <-client.sendQueue:
+			if dropped := client.takeDroppedEvents(); dropped > 0 {
+				if err = writeResponse(ws, &jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionDropped, Dropped: dropped}); err != nil {
+					return nil
+				}
+			}
+			if err = writeResponse(ws, response); err != nil {
+				return nil
+			}
+		case <-client.disconnect:
+			return nil
+		}
+	}
+}
+
+// handleRequest processes a single SubscriptionRequest of the client.
+func handleRequest(client *wsClient, request *jsonmodels.SubscriptionRequest) {
+	switch request.Action {
+	case jsonmodels.SubscribeAction:
+		s, err := client.subscribe(request)
+		if err != nil {
+			client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionError, Topic: request.Topic, Error: err.Error()})
+			return
+		}
+		client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionSubscribed, SubscriptionID: s.id, Topic: s.topic})
+
+		// report the current inclusion state right away, so clients do not miss changes that happened before
+		if s.topic == jsonmodels.InclusionStateTopic {
+			client.forEachSubscription(jsonmodels.InclusionStateTopic, func(subscription *subscription) {
+				if subscription == s {
+					publishInclusionState(client, s)
+				}
+			})
+		}
+	case jsonmodels.UnsubscribeAction:
+		s, err := client.unsubscribe(request.SubscriptionID)
+		if err != nil {
+			client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionError, SubscriptionID: request.SubscriptionID, Error: err.Error()})
+			return
+		}
+		client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionUnsubscribed, SubscriptionID: s.id, Topic: s.topic})
+	default:
+		client.send(&jsonmodels.SubscriptionResponse{Type: jsonmodels.SubscriptionError, Error: "unknown action '" + string(request.Action) + "'"})
+	}
+}
+
+// writeResponse writes a single response to the WebSocket connection.
+func writeResponse(ws *websocket.Conn, response *jsonmodels.SubscriptionResponse) error {
+	if err := ws.SetWriteDeadline(time.Now().Add(Parameters.WriteTimeout)); err != nil {
+		return err
+	}
+
+	return ws.WriteJSON(response)
+}
+
+// registerClient registers a new client if the maximum amount of clients was not reached, yet.
+func registerClient() (client *wsClient, err error) {
+	clientsMutex.Lock()
+	defer clientsMutex.Unlock()
+
+	if len(clients) >= Parameters.MaxClients {
+		return nil, errMaxClientsReached
+	}
+
+	client = newWSClient(nextClientID)
+	clients[client.id] = client
+	nextClientID++
+
+	return client, nil
+}
+
+// unregisterClient removes the client from the set of connected clients.
+func unregisterClient(client *wsClient) {
+	clientsMutex.Lock()
+	defer clientsMutex.Unlock()
+
+	client.close()
+	delete(clients, client.id)
+}
+
+// forEachClient calls the consumer for all connected clients.
+func forEachClient(consumer func(client *wsClient)) {
+	clientsMutex.RLock()
+	defer clientsMutex.RUnlock()
+
+	for _, client := range clients {
+		consumer(client)
+	}
+}
+
+// checkOrigin allows WebSocket connections from the node's own origin, from the configured AllowedOrigins and from
+// clients that do not send an Origin header (i.e. clients that are not browsers).
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, allowedOrigin := range Parameters.AllowedOrigins {
+		if allowedOrigin == "*" || strings.EqualFold(allowedOrigin, origin) {
+			return true
+		}
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(originURL.Host, r.Host)
+}
+
+// hasClients returns true if at least one client is connected, so events do not need to be prepared otherwise.
+func hasClients() bool {
+	clientsMutex.RLock()
+	defer clientsMutex.RUnlock()
+
+	return len(clients) != 0
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,30 @@
+package subscriptions
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	originAllowed := func(origin string) bool {
+		r := httptest.NewRequest("GET", "http://node.example:8080/subscriptions", nil)
+		if origin != "" {
+			r.Header.Set("Origin", origin)
+		}
+		return checkOrigin(r)
+	}
+
+	Parameters.AllowedOrigins = nil
+	assert.True(t, originAllowed(""))
+	assert.True(t, originAllowed("http://node.example:8080"))
+	assert.False(t, originAllowed("http://evil.example"))
+
+	Parameters.AllowedOrigins = []string{"http://dashboard.example"}
+	assert.True(t, originAllowed("http://dashboard.example"))
+	assert.False(t, originAllowed("http://evil.example"))
+
+	Parameters.AllowedOrigins = []string{"*"}
+	assert.True(t, originAllowed("http://evil.example"))
+}
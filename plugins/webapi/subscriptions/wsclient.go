@@ -0,0 +1,185 @@
+package subscriptions
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+	"github.com/iotaledger/goshimmer/packages/tangle/payload"
+)
+
+// region subscription /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// subscription represents a single subscription of a client including the filter criteria of its topic.
+type subscription struct {
+	id    uint64
+	topic jsonmodels.SubscriptionTopic
+
+	payloadType *payload.Type
+	addresses   map[string]bool
+
+	transactionID          ledgerstate.TransactionID
+	lastInclusionState     ledgerstate.InclusionState
+	inclusionStateReported bool
+
+	branchID *ledgerstate.BranchID
+}
+
+// newSubscription creates a new subscription from the given request.
+func newSubscription(id uint64, request *jsonmodels.SubscriptionRequest) (s *subscription, err error) {
+	s = &subscription{
+		id:    id,
+		topic: request.Topic,
+	}
+
+	switch request.Topic {
+	case jsonmodels.MessagesTopic:
+		if request.PayloadType != nil {
+			payloadType := payload.Type(*request.PayloadType)
+			s.payloadType = &payloadType
+		}
+	case jsonmodels.TransactionsTopic:
+		if len(request.Addresses) == 0 {
+			return nil, errors.Errorf("at least one address is required to subscribe to %s", request.Topic)
+		}
+		if len(request.Addresses) > Parameters.MaxAddressesPerSubscription {
+			return nil, errors.Errorf("a subscription can watch at most %d addresses", Parameters.MaxAddressesPerSubscription)
+		}
+		s.addresses = make(map[string]bool, len(request.Addresses))
+		for _, addressString := range request.Addresses {
+			address, addressErr := ledgerstate.AddressFromString(addressString)
+			if addressErr != nil {
+				return nil, errors.Errorf("failed to parse address %s: %w", addressString, addressErr)
+			}
+			s.addresses[address.Base58()] = true
+		}
+	case jsonmodels.InclusionStateTopic:
+		if s.transactionID, err = ledgerstate.TransactionIDFromBase58(request.TransactionID); err != nil {
+			return nil, errors.Errorf("failed to parse transaction ID %s: %w", request.TransactionID, err)
+		}
+	case jsonmodels.BranchConfirmationTopic:
+		if request.BranchID != "" {
+			branchID, branchIDErr := ledgerstate.BranchIDFromBase58(request.BranchID)
+			if branchIDErr != nil {
+				return nil, errors.Errorf("failed to parse branch ID %s: %w", request.BranchID, branchIDErr)
+			}
+			s.branchID = &branchID
+		}
+	case jsonmodels.TipsTopic:
+	default:
+		return nil, errors.Errorf("unknown topic '%s'", request.Topic)
+	}
+
+	return s, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region wsClient /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// wsClient represents a connected client of the subscriptions endpoint. Events are delivered through a bounded queue, so
+// a slow client never blocks the event processing of the node - if the queue is full, events are dropped and the client
+// is informed about the amount of dropped events with the next message it receives.
+type wsClient struct {
+	id uint64
+
+	subscriptions      map[uint64]*subscription
+	subscriptionsMutex sync.RWMutex
+	nextSubscriptionID uint64
+
+	sendQueue        chan *jsonmodels.SubscriptionResponse
+	droppedEvents    uint64
+	consecutiveDrops uint64
+	disconnect       chan struct{}
+	disconnectOnce   sync.Once
+}
+
+// newWSClient creates a new wsClient with the given ID.
+func newWSClient(id uint64) *wsClient {
+	return &wsClient{
+		id:                 id,
+		subscriptions:      make(map[uint64]*subscription),
+		nextSubscriptionID: 1,
+		sendQueue:          make(chan *jsonmodels.SubscriptionResponse, Parameters.SendQueueSize),
+		disconnect:         make(chan struct{}),
+	}
+}
+
+// subscribe adds a new subscription to the client.
+func (c *wsClient) subscribe(request *jsonmodels.SubscriptionRequest) (s *subscription, err error) {
+	c.subscriptionsMutex.Lock()
+	defer c.subscriptionsMutex.Unlock()
+
+	if len(c.subscriptions) >= Parameters.MaxSubscriptionsPerClient {
+		return nil, errors.Errorf("maximum amount of subscriptions (%d) reached", Parameters.MaxSubscriptionsPerClient)
+	}
+
+	if s, err = newSubscription(c.nextSubscriptionID, request); err != nil {
+		return nil, err
+	}
+	c.subscriptions[s.id] = s
+	c.nextSubscriptionID++
+
+	return s, nil
+}
+
+// unsubscribe removes the subscription with the given ID.
+func (c *wsClient) unsubscribe(subscriptionID uint64) (s *subscription, err error) {
+	c.subscriptionsMutex.Lock()
+	defer c.subscriptionsMutex.Unlock()
+
+	s, exists := c.subscriptions[subscriptionID]
+	if !exists {
+		return nil, errors.Errorf("unknown subscription %d", subscriptionID)
+	}
+	delete(c.subscriptions, subscriptionID)
+
+	return s, nil
+}
+
+// forEachSubscription calls the consumer for all subscriptions of the given topic. The consumer may modify the
+// subscription as it is called while holding the write lock.
+func (c *wsClient) forEachSubscription(topic jsonmodels.SubscriptionTopic, consumer func(s *subscription)) {
+	c.subscriptionsMutex.Lock()
+	defer c.subscriptionsMutex.Unlock()
+
+	for _, s := range c.subscriptions {
+		if s.topic == topic {
+			consumer(s)
+		}
+	}
+}
+
+// send queues the response for delivery without blocking. It returns false if the response was dropped.
+func (c *wsClient) send(response *jsonmodels.SubscriptionResponse) bool {
+	select {
+	case c.sendQueue <- response:
+		atomic.StoreUint64(&c.consecutiveDrops, 0)
+		return true
+	case <-c.disconnect:
+		return false
+	default:
+		atomic.AddUint64(&c.droppedEvents, 1)
+		if atomic.AddUint64(&c.consecutiveDrops, 1) >= uint64(Parameters.MaxDroppedEvents) {
+			c.close()
+		}
+		return false
+	}
+}
+
+// takeDroppedEvents returns the amount of events that were dropped since the last call.
+func (c *wsClient) takeDroppedEvents() uint64 {
+	return atomic.SwapUint64(&c.droppedEvents, 0)
+}
+
+// close signals the connection handler to disconnect the client.
+func (c *wsClient) close() {
+	c.disconnectOnce.Do(func() {
+		close(c.disconnect)
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
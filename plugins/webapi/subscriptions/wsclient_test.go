@@ -0,0 +1,93 @@
+package subscriptions
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func TestNewSubscription(t *testing.T) {
+	Parameters.MaxAddressesPerSubscription = 2
+
+	address := ledgerstate.NewED25519Address(ed25519.GenerateKeyPair().PublicKey).Base58()
+
+	_, err := newSubscription(1, &jsonmodels.SubscriptionRequest{Topic: jsonmodels.TransactionsTopic})
+	assert.Error(t, err)
+	_, err = newSubscription(1, &jsonmodels.SubscriptionRequest{Topic: jsonmodels.TransactionsTopic, Addresses: []string{"invalid"}})
+	assert.Error(t, err)
+	_, err = newSubscription(1, &jsonmodels.SubscriptionRequest{Topic: jsonmodels.TransactionsTopic, Addresses: []string{address, address, address}})
+	assert.Error(t, err)
+	s, err := newSubscription(1, &jsonmodels.SubscriptionRequest{Topic: jsonmodels.TransactionsTopic, Addresses: []string{address}})
+	require.NoError(t, err)
+	assert.True(t, s.addresses[address])
+
+	_, err = newSubscription(1, &jsonmodels.SubscriptionRequest{Topic: jsonmodels.InclusionStateTopic, TransactionID: "invalid"})
+	assert.Error(t, err)
+	s, err = newSubscription(1, &jsonmodels.SubscriptionRequest{Topic: jsonmodels.InclusionStateTopic, TransactionID: ledgerstate.GenesisTransactionID.Base58()})
+	require.NoError(t, err)
+	assert.Equal(t, ledgerstate.GenesisTransactionID, s.transactionID)
+
+	_, err = newSubscription(1, &jsonmodels.SubscriptionRequest{Topic: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestWSClient_Subscribe(t *testing.T) {
+	Parameters.MaxSubscriptionsPerClient = 2
+	Parameters.SendQueueSize = 1
+
+	client := newWSClient(0)
+	first, err := client.subscribe(&jsonmodels.SubscriptionRequest{Topic: jsonmodels.TipsTopic})
+	require.NoError(t, err)
+	second, err := client.subscribe(&jsonmodels.SubscriptionRequest{Topic: jsonmodels.MessagesTopic})
+	require.NoError(t, err)
+	assert.NotEqual(t, first.id, second.id)
+
+	_, err = client.subscribe(&jsonmodels.SubscriptionRequest{Topic: jsonmodels.TipsTopic})
+	assert.Error(t, err)
+
+	_, err = client.unsubscribe(first.id)
+	require.NoError(t, err)
+	_, err = client.unsubscribe(first.id)
+	assert.Error(t, err)
+
+	subscriptionCount := 0
+	client.forEachSubscription(jsonmodels.MessagesTopic, func(s *subscription) {
+		subscriptionCount++
+	})
+	assert.Equal(t, 1, subscriptionCount)
+}
+
+func TestWSClient_Send(t *testing.T) {
+	Parameters.SendQueueSize = 2
+	Parameters.MaxDroppedEvents = 3
+
+	client := newWSClient(0)
+	assert.True(t, client.send(&jsonmodels.SubscriptionResponse{}))
+	assert.True(t, client.send(&jsonmodels.SubscriptionResponse{}))
+
+	// the queue is full, so events are dropped instead of blocking the caller
+	assert.False(t, client.send(&jsonmodels.SubscriptionResponse{}))
+	assert.False(t, client.send(&jsonmodels.SubscriptionResponse{}))
+	assert.EqualValues(t, 2, client.takeDroppedEvents())
+	assert.EqualValues(t, 0, client.takeDroppedEvents())
+
+	// a successful send resets the consecutive drops
+	<-client.sendQueue
+	assert.True(t, client.send(&jsonmodels.SubscriptionResponse{}))
+	assert.False(t, client.send(&jsonmodels.SubscriptionResponse{}))
+	assert.False(t, client.send(&jsonmodels.SubscriptionResponse{}))
+	select {
+	case <-client.disconnect:
+		t.Fatal("client should not be disconnected")
+	default:
+	}
+
+	// slow clients get disconnected
+	assert.False(t, client.send(&jsonmodels.SubscriptionResponse{}))
+	<-client.disconnect
+}
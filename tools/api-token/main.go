@@ -0,0 +1,50 @@
+// Package main generates API tokens for the web API. The token is only printed once, while the node config only
+// contains its SHA-256 hash in the "webapi.auth.tokens" list.
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mr-tron/base58"
+)
+
+func main() {
+	namePtr := flag.String("name", "", "name of the token (e.g. the user or service that uses it)")
+	rolePtr := flag.String("role", "read-only", "role of the token (read-only, issuer or admin)")
+	tokenPtr := flag.String("token", "", "(optional) existing token to create the config entry for instead of generating a new one")
+	flag.Parse()
+
+	if *namePtr == "" || strings.Contains(*namePtr, ":") {
+		fmt.Fprintln(os.Stderr, "name has to be set and must not contain ':'")
+		os.Exit(1)
+	}
+	switch *rolePtr {
+	case "read-only", "issuer", "admin":
+	default:
+		fmt.Fprintf(os.Stderr, "unknown role '%s' (expected read-only, issuer or admin)\n", *rolePtr)
+		os.Exit(1)
+	}
+
+	token := *tokenPtr
+	if token == "" {
+		tokenBytes := make([]byte, 32)
+		if _, err := rand.Read(tokenBytes); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		token = base58.Encode(tokenBytes)
+	}
+	hash := sha256.Sum256([]byte(token))
+
+	fmt.Println("Token (pass it to the client, it is not stored by the node):")
+	fmt.Println(token)
+	fmt.Println()
+	fmt.Println("Add this entry to webapi.auth.tokens in the config of the node:")
+	fmt.Printf("%s:%s:%s\n", *namePtr, *rolePtr, hex.EncodeToString(hash[:]))
+}
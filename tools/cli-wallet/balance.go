@@ -76,6 +76,18 @@ func execBalanceCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printTimedBalance(header, timeTitle, cliWallet, confirmedConditional, pendingConditional)
 	}
 
+	// fetch hash time locked balances
+	confirmedHashTimeLocked, pendingHashTimeLocked, err := cliWallet.HashTimeLockedBalances(false)
+	if err != nil {
+		printUsage(nil, err.Error())
+	}
+
+	if len(confirmedHashTimeLocked) > 0 || len(pendingHashTimeLocked) > 0 {
+		header := "Hash Time Locked Token Balances - execute `claim-htlc` command to claim or refund these funds"
+		timeTitle := "TIMEOUT"
+		printTimedBalance(header, timeTitle, cliWallet, confirmedHashTimeLocked, pendingHashTimeLocked)
+	}
+
 	// fetch balances from wallet
 	confirmedGovAliasBalance, confirmedStateAliasBalance, pendingGovAliasBalance, pendingStateAliasBalance, err := cliWallet.AliasBalance(false)
 	if err != nil {
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/htlcoptions"
+)
+
+func execClaimHTLCCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	preimagePtr := command.String("preimage", "", "secret that unlocks the hash lock of the funds that are supposed to be claimed")
+	refundPtr := command.Bool("refund", false, "refund expired hash time locked funds that were sent by the wallet")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+	if (*preimagePtr == "") == !*refundPtr {
+		printUsage(command, "please provide either the preimage or the refund argument")
+	}
+
+	options := []htlcoptions.HashTimeLockedFundsOption{
+		htlcoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		htlcoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+	}
+
+	if *refundPtr {
+		fmt.Println("Refunding hash time locked funds... [this might take a while]")
+		_, err = cliWallet.RefundHashTimeLockedFunds(options...)
+		if err != nil {
+			printUsage(command, err.Error())
+		}
+
+		fmt.Println()
+		fmt.Println("Refunding hash time locked funds... [DONE]")
+		return
+	}
+
+	fmt.Println("Claiming hash time locked funds... [this might take a while]")
+	_, err = cliWallet.ClaimHashTimeLockedFunds(append(options, htlcoptions.Preimage([]byte(*preimagePtr)))...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Claiming hash time locked funds... [DONE]")
+}
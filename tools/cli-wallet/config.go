@@ -5,15 +5,18 @@ import (
 	"os"
 
 	"github.com/iotaledger/goshimmer/client"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
 )
 
 // config type that defines the config structure
 type configuration struct {
 	WebAPI               string           `json:"WebAPI,omitempty"`
 	BasicAuth            client.BasicAuth `json:"basic_auth,omitempty"`
+	APIToken             string           `json:"api_token,omitempty"`
 	ReuseAddresses       bool             `json:"reuse_addresses"`
 	FaucetPowDifficulty  int              `json:"faucetPowDifficulty"`
 	AssetRegistryNetwork string           `json:"assetRegistryNetwork"`
+	Bech32HRP            string           `json:"bech32HRP,omitempty"`
 }
 
 // internal variable that holds the config
@@ -28,7 +31,8 @@ var configJSON = `{
 	},
 	"reuse_addresses": false,
 	"faucetPowDifficulty": 25,
-	"assetRegistryNetwork": "nectar"
+	"assetRegistryNetwork": "nectar",
+	"bech32HRP": "atoi"
 }`
 
 // load the config file
@@ -53,4 +57,9 @@ func loadConfig() {
 	if err = json.NewDecoder(file).Decode(&config); err != nil {
 		panic(err)
 	}
+
+	// addresses of the wallet are displayed and parsed using the human-readable part of the network
+	if config.Bech32HRP != "" {
+		ledgerstate.SetBech32HRP(config.Bech32HRP)
+	}
 }
@@ -50,13 +50,13 @@ func execDelegateFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		if statusErr != nil {
 			printUsage(command, fmt.Sprintf("failed to get delegation address from connected node: %s", statusErr.Error()))
 		}
-		delegationAddress, err = ledgerstate.AddressFromBase58EncodedString(status.DelegationAddress)
+		delegationAddress, err = ledgerstate.AddressFromString(status.DelegationAddress)
 		if err != nil {
 			printUsage(command, fmt.Sprintf("failed to parse connected node's delegation adddress: %s", err.Error()))
 		}
 		delegateToConnectedNode = true
 	} else {
-		delegationAddress, err = ledgerstate.AddressFromBase58EncodedString(*delegationAddressPtr)
+		delegationAddress, err = ledgerstate.AddressFromString(*delegationAddressPtr)
 		if err != nil {
 			printUsage(command, fmt.Sprintf("provided delelegation address %s is not a valid IOTA address: %s", *delegationAddressPtr, err.Error()))
 		}
@@ -118,9 +118,9 @@ func execDelegateFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 
 	fmt.Println()
 	if delegateToConnectedNode {
-		fmt.Printf("\nDelegating to node %s, delegation address %s\n", status.ID, delegationAddress.Base58())
+		fmt.Printf("\nDelegating to node %s, delegation address %s\n", status.ID, delegationAddress.Bech32())
 	} else {
-		fmt.Printf("\nDelegating to address %s\n", delegationAddress.Base58())
+		fmt.Printf("\nDelegating to address %s\n", delegationAddress.Bech32())
 	}
 	for _, id := range delegationIDs {
 		fmt.Println("Delegation ID is: ", id.Base58())
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execHistoryCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "display this help screen")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	fmt.Println("Fetching transaction history...")
+	history, err := cliWallet.TransactionHistory()
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	// initialize tab writer
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	// print header
+	fmt.Println()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "STATUS", "TIME", "TYPE", "INDEX", "ADDRESS", "TRANSACTION ID")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "------", "-------------------", "--------", "-----", "--------------------------------------------", "--------------------------------------------")
+
+	if len(history) == 0 {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>", "<EMPTY>")
+	}
+	for _, entry := range history {
+		status := "[PEND]"
+		switch {
+		case entry.InclusionState.Confirmed:
+			status = "[ OK ]"
+		case entry.InclusionState.Rejected:
+			status = "[ REJ]"
+		}
+
+		direction := "received"
+		if entry.Type == ledgerstate.ConsumedAddressHistoryEntryType {
+			direction = "spent"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", status, entry.Timestamp.Format("2006-01-02 15:04:05"), direction, entry.Address.Index, entry.Address.Address().Bech32(), entry.TransactionID.Base58())
+	}
+	_ = w.Flush()
+}
@@ -33,6 +33,9 @@ func loadWallet() *wallet.Wallet {
 	if config.BasicAuth.IsEnabled() {
 		options = append(options, client.WithBasicAuth(config.BasicAuth.Credentials()))
 	}
+	if config.APIToken != "" {
+		options = append(options, client.WithToken(config.APIToken))
+	}
 
 	if assetRegistry != nil {
 		// we do have an asset registry parsed
@@ -154,12 +157,30 @@ func printUsage(command *flag.FlagSet, optionalErrorMessage ...string) {
 		fmt.Println("COMMANDS:")
 		fmt.Println("  balance")
 		fmt.Println("        show the balances held by this wallet")
+		fmt.Println("  history")
+		fmt.Println("        show the transactions that created or spent outputs on the addresses of this wallet")
 		fmt.Println("  send-funds")
 		fmt.Println("        initiate a value transfer")
+		fmt.Println("  prepare")
+		fmt.Println("        prepare an unsigned value transfer that can be signed on an offline machine")
+		fmt.Println("  sign")
+		fmt.Println("        sign a prepared transfer using only the seed of the wallet (works offline)")
+		fmt.Println("  submit")
+		fmt.Println("        assemble a prepared transfer with its signatures and submit it")
 		fmt.Println("  consolidate-funds")
 		fmt.Println("        consolidate available funds under one wallet address")
 		fmt.Println("  claim-conditional")
 		fmt.Println("        claim (move) conditionally owned funds into the wallet")
+		fmt.Println("  claim-htlc")
+		fmt.Println("        claim hash time locked funds by revealing the preimage or refund expired ones")
+		fmt.Println("  multisig-address")
+		fmt.Println("        show the public key of the wallet or derive a multisig address from a set of public keys")
+		fmt.Println("  multisig-prepare")
+		fmt.Println("        prepare an unsigned transaction that spends funds of a multisig address")
+		fmt.Println("  multisig-sign")
+		fmt.Println("        add the signature of the wallet to a prepared multisig transaction")
+		fmt.Println("  multisig-submit")
+		fmt.Println("        submit a multisig transaction that collected enough signatures")
 		fmt.Println("  request-funds")
 		fmt.Println("        request funds from the testnet-faucet")
 		fmt.Println("  create-asset")
@@ -24,6 +24,12 @@ func main() {
 		printUsage(nil)
 	}
 
+	// the sign command runs on offline machines, so it only reads the seed and does not connect to a node
+	if len(os.Args) >= 2 && os.Args[1] == "sign" {
+		execSignCommand(flag.NewFlagSet("sign", flag.ExitOnError))
+		return
+	}
+
 	// load wallet
 	wallet := loadWallet()
 	defer writeWalletStateFile(wallet, "wallet.dat")
@@ -35,9 +41,17 @@ func main() {
 
 	// define sub commands
 	balanceCommand := flag.NewFlagSet("balance", flag.ExitOnError)
+	historyCommand := flag.NewFlagSet("history", flag.ExitOnError)
 	sendFundsCommand := flag.NewFlagSet("send-funds", flag.ExitOnError)
+	prepareCommand := flag.NewFlagSet("prepare", flag.ExitOnError)
+	submitCommand := flag.NewFlagSet("submit", flag.ExitOnError)
 	consolidateFundsCommand := flag.NewFlagSet("consolidate-funds", flag.ExitOnError)
 	claimConditionalFundsCommand := flag.NewFlagSet("claim-conditional", flag.ExitOnError)
+	claimHTLCCommand := flag.NewFlagSet("claim-htlc", flag.ExitOnError)
+	multisigAddressCommand := flag.NewFlagSet("multisig-address", flag.ExitOnError)
+	multisigPrepareCommand := flag.NewFlagSet("multisig-prepare", flag.ExitOnError)
+	multisigSignCommand := flag.NewFlagSet("multisig-sign", flag.ExitOnError)
+	multisigSubmitCommand := flag.NewFlagSet("multisig-submit", flag.ExitOnError)
 	createAssetCommand := flag.NewFlagSet("create-asset", flag.ExitOnError)
 	assetInfoCommand := flag.NewFlagSet("asset-info", flag.ExitOnError)
 	delegateFundsCommand := flag.NewFlagSet("delegate-funds", flag.ExitOnError)
@@ -59,14 +73,30 @@ func main() {
 	switch os.Args[1] {
 	case "balance":
 		execBalanceCommand(balanceCommand, wallet)
+	case "history":
+		execHistoryCommand(historyCommand, wallet)
 	case "address":
 		execAddressCommand(addressCommand, wallet)
 	case "send-funds":
 		execSendFundsCommand(sendFundsCommand, wallet)
+	case "prepare":
+		execPrepareCommand(prepareCommand, wallet)
+	case "submit":
+		execSubmitCommand(submitCommand, wallet)
 	case "consolidate-funds":
 		execConsolidateFundsCommand(consolidateFundsCommand, wallet)
 	case "claim-conditional":
 		execClaimConditionalCommand(claimConditionalFundsCommand, wallet)
+	case "claim-htlc":
+		execClaimHTLCCommand(claimHTLCCommand, wallet)
+	case "multisig-address":
+		execMultisigAddressCommand(multisigAddressCommand, wallet)
+	case "multisig-prepare":
+		execMultisigPrepareCommand(multisigPrepareCommand, wallet)
+	case "multisig-sign":
+		execMultisigSignCommand(multisigSignCommand, wallet)
+	case "multisig-submit":
+		execMultisigSubmitCommand(multisigSubmitCommand, wallet)
 	case "create-asset":
 		execCreateAssetCommand(createAssetCommand, wallet)
 	case "asset-info":
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execMultisigAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	thresholdPtr := command.Uint("threshold", 0, "amount of signatures that are required to spend the funds of the multisig address")
+	keysPtr := command.String("keys", "", "comma separated list of base58 encoded public keys of the key holders")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *keysPtr == "" {
+		fmt.Println()
+		fmt.Printf("Public key of this wallet: %s\n", cliWallet.MultisigPublicKey())
+		return
+	}
+
+	publicKeys, err := parseMultisigPublicKeys(*keysPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	multisigAddress, err := ledgerstate.NewMultisigAddress(uint8(*thresholdPtr), publicKeys...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Printf("Multisig address (%d of %d): %s\n", *thresholdPtr, len(publicKeys), multisigAddress.Bech32())
+}
+
+func execMultisigPrepareCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	thresholdPtr := command.Uint("threshold", 0, "amount of signatures that are required to spend the funds of the multisig address")
+	keysPtr := command.String("keys", "", "comma separated list of base58 encoded public keys of the key holders")
+	addressPtr := command.String("dest-addr", "", "destination address for the transfer")
+	amountPtr := command.Int64("amount", 0, "the amount of tokens that are supposed to be sent")
+	colorPtr := command.String("color", "IOTA", "(optional) color of the tokens to transfer")
+	outPtr := command.String("out", "multisig.tx", "(optional) file to write the unsigned transaction to")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *keysPtr == "" {
+		printUsage(command, "keys has to be set")
+	}
+	if *addressPtr == "" {
+		printUsage(command, "dest-addr has to be set")
+	}
+	if *amountPtr <= 0 {
+		printUsage(command, "amount has to be set and be bigger than 0")
+	}
+	if *colorPtr == "" {
+		printUsage(command, "color must be set")
+	}
+
+	publicKeys, err := parseMultisigPublicKeys(*keysPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	destinationAddress, err := ledgerstate.AddressFromString(*addressPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	var color ledgerstate.Color
+	switch *colorPtr {
+	case "IOTA":
+		color = ledgerstate.ColorIOTA
+	default:
+		colorBytes, parseErr := base58.Decode(*colorPtr)
+		if parseErr != nil {
+			printUsage(command, parseErr.Error())
+		}
+
+		color, _, parseErr = ledgerstate.ColorFromBytes(colorBytes)
+		if parseErr != nil {
+			printUsage(command, parseErr.Error())
+		}
+	}
+
+	multisigTransaction, err := cliWallet.PrepareMultisigTransfer(uint8(*thresholdPtr), publicKeys,
+		sendoptions.Destination(address.Address{
+			AddressBytes: destinationAddress.Array(),
+		}, uint64(*amountPtr), color),
+		sendoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		sendoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+	)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if err = os.WriteFile(*outPtr, multisigTransaction.Bytes(), 0o644); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Printf("Unsigned transaction written to %s (requires %d signatures)\n", *outPtr, multisigTransaction.Signature().Threshold())
+}
+
+func execMultisigSignCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	inPtr := command.String("in", "multisig.tx", "(optional) file to read the partially signed transaction from")
+	outPtr := command.String("out", "", "(optional) file to write the signed transaction to (defaults to the input file)")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+	if *outPtr == "" {
+		*outPtr = *inPtr
+	}
+
+	multisigTransaction, err := readMultisigTransaction(*inPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if err = cliWallet.SignMultisigTransaction(multisigTransaction); err != nil {
+		printUsage(command, err.Error())
+	}
+	if err = os.WriteFile(*outPtr, multisigTransaction.Bytes(), 0o644); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Printf("Signed transaction written to %s (%d of %d signatures)\n", *outPtr, len(multisigTransaction.Signature().Signatures()), multisigTransaction.Signature().Threshold())
+}
+
+func execMultisigSubmitCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	inPtr := command.String("in", "multisig.tx", "(optional) file to read the signed transaction from")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	multisigTransaction, err := readMultisigTransaction(*inPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println("Submitting multisig transaction...")
+	tx, err := cliWallet.SubmitMultisigTransaction(multisigTransaction)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Printf("Submitting multisig transaction %s... [DONE]\n", tx.ID().Base58())
+}
+
+// parseMultisigPublicKeys parses a comma separated list of base58 encoded public keys.
+func parseMultisigPublicKeys(keys string) (publicKeys []ed25519.PublicKey, err error) {
+	for _, key := range strings.Split(keys, ",") {
+		publicKey, parseErr := ed25519.PublicKeyFromString(strings.TrimSpace(key))
+		if parseErr != nil {
+			return nil, fmt.Errorf("wrong public key %s: %w", key, parseErr)
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	return
+}
+
+// readMultisigTransaction reads a MultisigTransaction from the given file.
+func readMultisigTransaction(fileName string) (multisigTransaction *wallet.MultisigTransaction, err error) {
+	multisigTransactionBytes, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", fileName, err)
+	}
+
+	return wallet.MultisigTransactionFromBytes(multisigTransactionBytes)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/ledgerstate"
+)
+
+func execPrepareCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	outPtr := command.String("out", "unsigned.tx", "(optional) file to write the unsigned transaction to")
+	options := parseSendFundsOptions(command, cliWallet)
+
+	unsignedTransaction, err := cliWallet.PrepareTransfer(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if err = os.WriteFile(*outPtr, unsignedTransaction.Bytes(), 0o644); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Printf("Unsigned transaction written to %s\n", *outPtr)
+}
+
+func execSignCommand(command *flag.FlagSet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	inPtr := command.String("in", "unsigned.tx", "(optional) file to read the unsigned transaction from")
+	outPtr := command.String("out", "unlockblocks.bin", "(optional) file to write the unlock blocks to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	seed, _, _, _, err := importWalletStateFile("wallet.dat")
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	unsignedTransaction, err := readUnsignedTransaction(*inPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	// show what is about to be signed, as the offline machine can not verify the transfer otherwise
+	fmt.Println()
+	fmt.Println("Outputs of the transaction:")
+	for _, output := range unsignedTransaction.Essence().Outputs() {
+		output.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
+			fmt.Printf("\t%d %s to %s\n", balance, color.String(), output.Address().Bech32())
+			return true
+		})
+	}
+
+	unlockBlocks, err := unsignedTransaction.Sign(seed)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+	if err = os.WriteFile(*outPtr, unlockBlocks.Bytes(), 0o644); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Printf("Unlock blocks written to %s\n", *outPtr)
+}
+
+func execSubmitCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	helpPtr := command.Bool("help", false, "show this help screen")
+	inPtr := command.String("in", "unsigned.tx", "(optional) file to read the unsigned transaction from")
+	unlockBlocksPtr := command.String("unlock-blocks", "unlockblocks.bin", "(optional) file to read the unlock blocks from")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		panic(err)
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	unsignedTransaction, err := readUnsignedTransaction(*inPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	unlockBlocksBytes, err := os.ReadFile(*unlockBlocksPtr)
+	if err != nil {
+		printUsage(command, fmt.Sprintf("failed to read %s: %s", *unlockBlocksPtr, err))
+	}
+	unlockBlocks, _, err := ledgerstate.UnlockBlocksFromBytes(unlockBlocksBytes)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println("Submitting transaction...")
+	tx, err := cliWallet.SubmitSignedTransaction(unsignedTransaction, unlockBlocks)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Printf("Submitting transaction %s... [DONE]\n", tx.ID().Base58())
+}
+
+// readUnsignedTransaction reads an UnsignedTransaction from the given file.
+func readUnsignedTransaction(fileName string) (unsignedTransaction *wallet.UnsignedTransaction, err error) {
+	unsignedTransactionBytes, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", fileName, err)
+	}
+
+	return wallet.UnsignedTransactionFromBytes(unsignedTransactionBytes)
+}
@@ -26,7 +26,7 @@ func execPendingMana(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	fmt.Println("-----------------------------------")
 	unspentOutputs := cliWallet.UnspentOutputs()
 	for addr, v := range unspentOutputs {
-		fmt.Printf("Address: %s\n", addr.Base58())
+		fmt.Printf("Address: %s\n", addr.Bech32())
 		for ID, output := range v {
 			var total float64
 			output.Object.Balances().ForEach(func(color ledgerstate.Color, balance uint64) bool {
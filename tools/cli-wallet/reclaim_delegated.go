@@ -35,7 +35,7 @@ func execReclaimDelegatedFundsCommand(command *flag.FlagSet, cliWallet *wallet.W
 
 	var toAddress ledgerstate.Address
 	if *toAddressPtr != "" {
-		toAddress, err = ledgerstate.AddressFromBase58EncodedString(*toAddressPtr)
+		toAddress, err = ledgerstate.AddressFromString(*toAddressPtr)
 		if err != nil {
 			printUsage(command, fmt.Sprintf("wrong optional toAddress provided: %s", err.Error()))
 		}
@@ -15,6 +15,21 @@ import (
 )
 
 func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	options := parseSendFundsOptions(command, cliWallet)
+
+	fmt.Println("Sending funds...")
+	_, err := cliWallet.SendFunds(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Sending funds ... [DONE]")
+}
+
+// parseSendFundsOptions defines the flags of a transfer on the given command, parses them and returns the corresponding
+// send options.
+func parseSendFundsOptions(command *flag.FlagSet, cliWallet *wallet.Wallet) []sendoptions.SendFundsOption {
 	helpPtr := command.Bool("help", false, "show this help screen")
 	addressPtr := command.String("dest-addr", "", "destination address for the transfer")
 	amountPtr := command.Int64("amount", 0, "the amount of tokens that are supposed to be sent")
@@ -22,6 +37,9 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	timelockPtr := command.Int64("lock-until", 0, "(optional) unix timestamp until which time the sent funds are locked from spending")
 	fallbackAddressPtr := command.String("fallb-addr", "", "(optional) fallback address that can claim back the (unspent) sent funds after fallback deadline")
 	fallbackDeadlinePtr := command.Int64("fallb-deadline", 0, "(optional) unix timestamp after which only the fallback address can claim the funds back")
+	hashLockPtr := command.String("hash-lock", "", "(optional) base58 encoded hash lock that the recipient needs to unlock by revealing its preimage")
+	htlcSecretPtr := command.String("htlc-secret", "", "(optional) secret to derive the hash lock from (instead of providing hash-lock)")
+	htlcTimeoutPtr := command.Int64("htlc-timeout", 0, "(optional) unix timestamp after which the hash time locked funds can only be refunded to the wallet")
 	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
 	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
 
@@ -44,10 +62,9 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printUsage(command, "color must be set")
 	}
 
-	destinationAddress, err := ledgerstate.AddressFromBase58EncodedString(*addressPtr)
+	destinationAddress, err := ledgerstate.AddressFromString(*addressPtr)
 	if err != nil {
 		printUsage(command, err.Error())
-		return
 	}
 
 	var color ledgerstate.Color
@@ -89,7 +106,7 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		if !(*fallbackAddressPtr != "" && *fallbackDeadlinePtr > 0) {
 			printUsage(command, "please provide both fallb-addr and fallb-deadline arguments for conditional sending")
 		}
-		fAddy, aErr := ledgerstate.AddressFromBase58EncodedString(*fallbackAddressPtr)
+		fAddy, aErr := ledgerstate.AddressFromString(*fallbackAddressPtr)
 		if aErr != nil {
 			printUsage(command, fmt.Sprintf("wrong fallback address: %s", aErr.Error()))
 		}
@@ -99,12 +116,25 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		}
 		options = append(options, sendoptions.Fallback(fAddy, fDeadline))
 	}
-	fmt.Println("Sending funds...")
-	_, err = cliWallet.SendFunds(options...)
-	if err != nil {
-		printUsage(command, err.Error())
+
+	if *hashLockPtr != "" || *htlcSecretPtr != "" || *htlcTimeoutPtr > 0 {
+		if !((*hashLockPtr != "") != (*htlcSecretPtr != "") && *htlcTimeoutPtr > 0) {
+			printUsage(command, "please provide either hash-lock or htlc-secret together with htlc-timeout for hash time locked sending")
+		}
+		hashLock := ledgerstate.NewHashLock([]byte(*htlcSecretPtr))
+		if *hashLockPtr != "" {
+			var hErr error
+			if hashLock, hErr = ledgerstate.HashLockFromBase58EncodedString(*hashLockPtr); hErr != nil {
+				printUsage(command, fmt.Sprintf("wrong hash lock: %s", hErr.Error()))
+			}
+		}
+		fmt.Printf("Hash lock: %s\n", hashLock.Base58())
+		timeout := time.Unix(*htlcTimeoutPtr, 0)
+		if timeout.Before(nowis) {
+			printUsage(command, fmt.Sprintf("htlc timeout %s is in the past", timeout.String()))
+		}
+		options = append(options, sendoptions.HashTimeLock(hashLock, timeout, cliWallet.ReceiveAddress().Address()))
 	}
 
-	fmt.Println()
-	fmt.Println("Sending funds ... [DONE]")
+	return options
 }
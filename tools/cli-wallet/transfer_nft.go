@@ -39,7 +39,7 @@ func execTransferNFTCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printUsage(command, "an nft (alias) ID must be given for transfer")
 	}
 
-	destinationAddress, err := ledgerstate.AddressFromBase58EncodedString(*addressPtr)
+	destinationAddress, err := ledgerstate.AddressFromString(*addressPtr)
 	if err != nil {
 		printUsage(command, err.Error())
 	}
@@ -81,7 +81,7 @@ func execWithdrawFromFTCommand(command *flag.FlagSet, cliWallet *wallet.Wallet)
 	}
 
 	if *addressPtr != "" {
-		address, aErr := ledgerstate.AddressFromBase58EncodedString(*addressPtr)
+		address, aErr := ledgerstate.AddressFromString(*addressPtr)
 		if aErr != nil {
 			printUsage(command, aErr.Error())
 		}
@@ -0,0 +1,114 @@
+// Package main converts the database of a GoShimmer node to a different storage engine (e.g. from RocksDB to Pebble).
+// Reading or writing a RocksDB database requires the tool to be built with the rocksdb build tag:
+//
+//	go run -tags rocksdb ./tools/db-convert --source mainnetdb --target mainnetdb-pebble
+package main
+
+import (
+	"log"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/iotaledger/hive.go/kvstore"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/iotaledger/goshimmer/packages/database"
+)
+
+const (
+	cfgSource       = "source"
+	cfgSourceEngine = "source-engine"
+	cfgTarget       = "target"
+	cfgTargetEngine = "target-engine"
+)
+
+func init() {
+	flag.String(cfgSource, "mainnetdb", "the directory of the database that is converted")
+	flag.String(cfgSourceEngine, "", "the engine of the source database (rocksdb, pebble), it is detected if empty")
+	flag.String(cfgTarget, "", "the directory of the converted database, which must not contain any data")
+	flag.String(cfgTargetEngine, database.EnginePebble.String(), "the engine of the converted database (rocksdb, pebble)")
+}
+
+func main() {
+	flag.Parse()
+	if err := viper.BindPFlags(flag.CommandLine); err != nil {
+		panic(err)
+	}
+
+	sourceDir, targetDir := viper.GetString(cfgSource), viper.GetString(cfgTarget)
+	if targetDir == "" || targetDir == sourceDir {
+		log.Fatalf("a target directory that differs from the source is required, enter it via --%s=...", cfgTarget)
+	}
+
+	sourceEngine := database.Engine(viper.GetString(cfgSourceEngine))
+	if sourceEngine == "" {
+		detectedEngine, exists, err := database.DetectEngine(sourceDir)
+		if err != nil {
+			log.Fatalf("failed to detect the engine of the source database: %s", err)
+		}
+		if !exists {
+			log.Fatalf("no database found in %s", sourceDir)
+		}
+		sourceEngine = detectedEngine
+	}
+	targetEngine := database.Engine(viper.GetString(cfgTargetEngine))
+
+	source, err := database.NewDBWithEngine(sourceEngine, sourceDir)
+	if err != nil {
+		log.Fatalf("failed to open the source database: %s", err)
+	}
+	target, err := database.NewDBWithEngine(targetEngine, targetDir)
+	if err != nil {
+		closeDB(source)
+		log.Fatalf("failed to open the target database: %s", err)
+	}
+
+	log.Printf("converting %s database %s to %s database %s...", sourceEngine, sourceDir, targetEngine, targetDir)
+	start := time.Now()
+	copiedObjects, err := convert(source, target)
+	closeDB(source)
+	closeDB(target)
+	// scripts rely on the exit code to detect a failed conversion
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("converting %s database %s to %s database %s... done, copied %d objects in %v", sourceEngine, sourceDir, targetEngine, targetDir, copiedObjects, time.Since(start))
+	log.Printf("start the node with --database.directory=%s --database.engine=%s to use the converted database", targetDir, targetEngine)
+}
+
+// convert copies all objects of the source to the target and verifies the result.
+func convert(source, target database.DB) (copiedObjects int, err error) {
+	if copiedObjects, err = database.CopyStore(source.NewStore(), target.NewStore()); err != nil {
+		return 0, errors.Errorf("failed to convert the database: %w", err)
+	}
+	if err = verify(source.NewStore(), target.NewStore(), copiedObjects); err != nil {
+		return 0, errors.Errorf("failed to verify the converted database: %w", err)
+	}
+
+	return copiedObjects, nil
+}
+
+// verify checks that the target contains the same number of objects as the source.
+func verify(source, target kvstore.KVStore, copiedObjects int) error {
+	for name, store := range map[string]kvstore.KVStore{"source": source, "target": target} {
+		objects := 0
+		if err := store.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+			objects++
+			return true
+		}); err != nil {
+			return err
+		}
+		if objects != copiedObjects {
+			return errors.Errorf("%s contains %d objects, but %d objects were copied", name, objects, copiedObjects)
+		}
+	}
+
+	return nil
+}
+
+func closeDB(db database.DB) {
+	if err := db.Close(); err != nil {
+		log.Printf("failed to close database: %s", err)
+	}
+}
@@ -0,0 +1,57 @@
+// Package main generates the BLS key shares of a dRNG committee whose beacons are produced by the nodes themselves.
+// The shares are dealt by this tool and are therefore only meant for private networks. Every share has to be passed to
+// exactly one committee member via "drng.producer.keyShare", while all members use the same "drng.producer.commits".
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/goshimmer/packages/drng"
+)
+
+func main() {
+	membersPtr := flag.Int("members", 5, "number of committee members")
+	thresholdPtr := flag.Int("threshold", 3, "number of committee members that are needed to produce a beacon")
+	flag.Parse()
+
+	keyShares, err := drng.NewKeyShares(*membersPtr, *thresholdPtr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	dpk, err := keyShares[0].DistributedPK()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	commitsBytes, err := keyShares[0].CommitsBytes()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	commits := make([]string, len(commitsBytes))
+	for i, commitBytes := range commitsBytes {
+		commits[i] = fmt.Sprintf("%q", hex.EncodeToString(commitBytes))
+	}
+
+	fmt.Println("Distributed public key (drng.custom.distributedPubKey):")
+	fmt.Println(hex.EncodeToString(dpk))
+	fmt.Println()
+	fmt.Println("Commitments of all members (drng.producer.commits):")
+	fmt.Printf("[%s]\n", strings.Join(commits, ", "))
+	for i, keyShare := range keyShares {
+		priShareBytes, err := keyShare.PriShareBytes()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println()
+		fmt.Printf("Key share of member %d (drng.producer.keyShare):\n", i+1)
+		fmt.Println(hex.EncodeToString(priShareBytes))
+	}
+}
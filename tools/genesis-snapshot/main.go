@@ -25,6 +25,7 @@ const (
 	cfgGenesisTokenAmount   = "token-amount"
 	cfgSnapshotFileName     = "snapshot-file"
 	cfgSnapshotGenesisSeed  = "seed"
+	cfgNetworkID            = "network-id"
 	defaultSnapshotFileName = "./snapshot.bin"
 
 	// In the docker network tokensToPledge is also pledged to the faucet
@@ -39,6 +40,7 @@ var nodesToPledge = []string{
 func init() {
 	flag.Uint64(cfgGenesisTokenAmount, 1000000000000000, "the amount of tokens to add to the genesis output") // we pledge this amount to peer master
 	flag.String(cfgSnapshotFileName, defaultSnapshotFileName, "the name of the generated snapshot file")
+	flag.Uint32(cfgNetworkID, 0, "the network (autopeering network version) the snapshot is created for, 0 allows every network")
 	// flag.String(cfgSnapshotGenesisSeed, "", "the genesis seed")
 	// Most recent seed when checking ../integration-tests/assets :
 	flag.String(cfgSnapshotGenesisSeed, "7R1itJx5hVuo9w9hjg5cwKFmek4HMSoBDgJZN8hKGxih", "the genesis seed")
@@ -80,6 +82,7 @@ func main() {
 	// define maps for snapshot
 	transactionsMap := make(map[ledgerstate.TransactionID]ledgerstate.Record)
 	accessManaMap := make(map[identity.ID]ledgerstate.AccessMana)
+	consensusManaMap := make(map[identity.ID]float64)
 
 	//////////////// prepare pledge to Peer master ////////////////////////////////////////////////////////////////
 	output := ledgerstate.NewSigLockedColoredOutput(
@@ -114,6 +117,7 @@ func main() {
 		Timestamp: time.Unix(tangle.DefaultGenesisTime, 0),
 	}
 	accessManaMap[nodeID] = accessManaRecord
+	consensusManaMap[nodeID] = float64(genesisTokenAmount)
 
 	//////////////// prepare pledge for nodesToPledge ////////////////////////////////////////////////////////////////
 	randomSeed := seed.NewSeed()
@@ -152,11 +156,19 @@ func main() {
 			Timestamp: time.Unix(tangle.DefaultGenesisTime, 0),
 		}
 		accessManaMap[nodeID] = accessManaRecord
+		consensusManaMap[nodeID] = float64(tokensToPledge)
 	}
 
 	newSnapshot := &ledgerstate.Snapshot{
-		AccessManaByNode: accessManaMap,
-		Transactions:     transactionsMap,
+		Header: &ledgerstate.SnapshotHeader{
+			NetworkID:                uint32(viper.GetUint(cfgNetworkID)),
+			GenesisTime:              time.Unix(tangle.DefaultGenesisTime, 0),
+			SnapshotTime:             time.Unix(tangle.DefaultGenesisTime, 0),
+			LastConfirmedMessageTime: time.Unix(tangle.DefaultGenesisTime, 0),
+		},
+		AccessManaByNode:    accessManaMap,
+		ConsensusManaByNode: consensusManaMap,
+		Transactions:        transactionsMap,
 	}
 
 	genesisWallet := wallet.New(wallet.Import(genesisSeed, 1, []bitmask.BitMask{}, wallet.NewAssetRegistry("test")), wallet.GenericConnector(mockedConnector))
@@ -168,7 +180,7 @@ func main() {
 	log.Printf("-> output id (base58): %s", ledgerstate.NewOutputID(ledgerstate.GenesisTransactionID, 0))
 	log.Printf("-> token amount: %d", genesisTokenAmount)
 
-	f, err := os.OpenFile(snapshotFileName, os.O_RDWR|os.O_CREATE, 0666)
+	f, err := os.OpenFile(snapshotFileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0666)
 	if err != nil {
 		log.Fatal("unable to create snapshot file", err)
 	}
@@ -196,6 +208,7 @@ func main() {
 	f.Close()
 
 	fmt.Println("\n================= read Snapshot ===============")
+	fmt.Println(readSnapshot.Header)
 	fmt.Printf("\n================= %d Snapshot Txs ===============\n", len(readSnapshot.Transactions))
 	for key, txRecord := range readSnapshot.Transactions {
 		fmt.Println("===== key =", key)
@@ -206,6 +219,10 @@ func main() {
 		fmt.Println("===== key =", key)
 		fmt.Println(accessManaNode)
 	}
+	fmt.Printf("\n================= %d Snapshot Consensus Manas ===============\n", len(readSnapshot.ConsensusManaByNode))
+	for key, consensusMana := range readSnapshot.ConsensusManaByNode {
+		fmt.Println("===== key =", key, consensusMana)
+	}
 }
 
 type mockConnector struct {
@@ -266,3 +283,7 @@ func (connector *mockConnector) GetTransactionInclusionState(txID ledgerstate.Tr
 func (connector *mockConnector) GetUnspentAliasOutput(addr *ledgerstate.AliasAddress) (output *ledgerstate.AliasOutput, err error) {
 	return
 }
+
+func (connector *mockConnector) AddressHistory(addr address.Address) (history wallet.HistoryEntries, err error) {
+	return
+}
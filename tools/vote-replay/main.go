@@ -0,0 +1,48 @@
+// Package main fetches the logged trace of a vote from a node and re-executes it to verify that the logged decision
+// follows from the queried opinions and random numbers.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client"
+)
+
+func main() {
+	nodePtr := flag.String("node", "http://127.0.0.1:8080", "web API of the node that logged the vote")
+	idPtr := flag.String("id", "", "transaction or message ID the node voted on")
+	verbosePtr := flag.Bool("verbose", false, "print every round of the vote")
+	flag.Parse()
+
+	if *idPtr == "" {
+		fmt.Fprintln(os.Stderr, "no ID given")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	api := client.NewGoShimmerAPI(*nodePtr)
+	trace, err := api.GetVoteTrace(*idPtr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("ID:              %s\n", trace.ID)
+	fmt.Printf("Initial opinion: %s\n", trace.InitialOpinion)
+	fmt.Printf("Rounds:          %d\n", len(trace.Rounds))
+	fmt.Printf("Outcome:         %s (%s)\n", trace.Outcome, trace.FinalOpinion)
+	if *verbosePtr {
+		for _, round := range trace.Rounds {
+			fmt.Printf("  round %3d: rand %.6f, eta %.6f, opinion %-7s, liked %.4f, %d queried opinions\n",
+				round.Number, round.RandUsed, round.Eta, round.Opinion, round.ProportionLiked, len(round.QueriedOpinions))
+		}
+	}
+
+	if err := client.ReplayVoteTrace(trace); err != nil {
+		fmt.Fprintf(os.Stderr, "replay failed: %s\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Replay:          OK")
+}
@@ -0,0 +1,54 @@
+// Package main simulates a network of voters in-process and reports how fast they finalize and how often they fail
+// to agree. It is used to evaluate voting protocols and their parameters before deploying them.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/simulation"
+)
+
+func main() {
+	config := simulation.DefaultConfig()
+	voterPtr := flag.String("voter", config.Voter, "voting protocol of the honest nodes ("+strings.Join(vote.RegisteredVoters(), ", ")+")")
+	nodesPtr := flag.Int("nodes", config.Nodes, "number of nodes, including the adversaries")
+	adversariesPtr := flag.Int("adversaries", config.Adversaries, "number of adversarial nodes")
+	strategyPtr := flag.String("strategy", string(config.AdversaryStrategy), "strategy of the adversarial nodes (minority, random or silent)")
+	likeRatioPtr := flag.Float64("likeRatio", config.InitialLikeRatio, "proportion of honest nodes that initially like the conflict")
+	zipfPtr := flag.Float64("zipf", config.ManaZipf, "exponent of the Zipf distribution of the mana (0 for equal mana)")
+	maxRoundsPtr := flag.Int("maxRounds", config.MaxRounds, "number of rounds after which a run is aborted")
+	runsPtr := flag.Int("runs", config.Runs, "number of simulated votes")
+	roundIntervalPtr := flag.Duration("roundInterval", config.RoundInterval, "duration of a round")
+	seedPtr := flag.Int64("seed", config.Seed, "seed of the random number generator")
+	flag.Parse()
+
+	config.Voter = *voterPtr
+	config.Nodes = *nodesPtr
+	config.Adversaries = *adversariesPtr
+	config.AdversaryStrategy = simulation.AdversaryStrategy(*strategyPtr)
+	config.InitialLikeRatio = *likeRatioPtr
+	config.ManaZipf = *zipfPtr
+	config.MaxRounds = *maxRoundsPtr
+	config.Runs = *runsPtr
+	config.RoundInterval = *roundIntervalPtr
+	config.Seed = *seedPtr
+
+	result, err := simulation.Run(config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Runs:                     %d\n", result.Runs)
+	fmt.Printf("Honest nodes:             %d\n", result.HonestNodes)
+	fmt.Printf("Finalized nodes:          %d\n", result.FinalizedNodes)
+	fmt.Printf("Liked runs:               %d\n", result.LikedRuns)
+	fmt.Printf("Agreement failure rate:   %.4f\n", result.AgreementFailureRate())
+	fmt.Printf("Termination failure rate: %.4f\n", result.TerminationFailureRate())
+	fmt.Printf("Mean finalization:        %.2f rounds (%v)\n", result.MeanFinalizationRounds, result.MeanFinalizationTime())
+	fmt.Printf("Max finalization:         %d rounds (%v)\n", result.MaxFinalizationRounds, result.MaxFinalizationTime())
+}